@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// GetActorInfo returns the actor info of the provider and id, only
+// Refresh and Merge of the options apply to actors.
+func (c *Client) GetActorInfo(ctx context.Context, provider, id string, opts *InfoOptions) (*model.ActorInfo, error) {
+	v := values{}
+	if opts != nil {
+		if opts.Refresh {
+			v["lazy"] = []string{"false"}
+		}
+		v.set("merge", opts.Merge)
+	}
+	info := &model.ActorInfo{}
+	if err := c.get(ctx, "/v1/actors"+escape(provider, id), url.Values(v), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// SearchActor searches actors with the keyword, the page info is
+// returned if the results are paginated.
+func (c *Client) SearchActor(ctx context.Context, keyword string, opts *SearchOptions) ([]*model.ActorSearchResult, *Page, error) {
+	var results []*model.ActorSearchResult
+	page, err := c.do(ctx, http.MethodGet, "/v1/actors/search", opts.values(keyword), nil, &results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, page, nil
+}
+
+// GetActorBirthdays returns actors with birthdays within days from
+// date `from`, the server defaults to today and 7 days if zero.
+func (c *Client) GetActorBirthdays(ctx context.Context, from time.Time, days int) ([]*model.ActorBirthday, error) {
+	v := values{}
+	if !from.IsZero() {
+		v.set("from", from.Format(time.DateOnly))
+	}
+	v.set("days", days)
+	var birthdays []*model.ActorBirthday
+	if err := c.get(ctx, "/v1/actors/birthdays", url.Values(v), &birthdays); err != nil {
+		return nil, err
+	}
+	return birthdays, nil
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// The admin methods require the admin token if the server has one.
+
+// DBStats is the statistics of cached metadata of the server.
+type DBStats struct {
+	Type             string           `json:"type"`
+	Movies           int64            `json:"movies"`
+	Actors           int64            `json:"actors"`
+	Reviews          int64            `json:"reviews"`
+	MoviesByProvider map[string]int64 `json:"movies_by_provider"`
+	ActorsByProvider map[string]int64 `json:"actors_by_provider"`
+}
+
+// GetDBStats returns the statistics of cached metadata.
+func (c *Client) GetDBStats(ctx context.Context) (*DBStats, error) {
+	stats := &DBStats{}
+	if err := c.get(ctx, "/v1/admin/cache/stats", nil, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// PurgeMovieInfo deletes the cached movie info of the provider and id.
+func (c *Client) PurgeMovieInfo(ctx context.Context, provider, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/v1/admin/cache/movies"+escape(provider, id), nil, nil, nil)
+	return err
+}
+
+// PurgeActorInfo deletes the cached actor info of the provider and id.
+func (c *Client) PurgeActorInfo(ctx context.Context, provider, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/v1/admin/cache/actors"+escape(provider, id), nil, nil, nil)
+	return err
+}
+
+// RefreshMovieInfo scrapes the movie info of the provider and id again.
+func (c *Client) RefreshMovieInfo(ctx context.Context, provider, id string) (*model.MovieInfo, error) {
+	info := &model.MovieInfo{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/admin/refresh/movies"+escape(provider, id), nil, nil, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// RefreshActorInfo scrapes the actor info of the provider and id again.
+func (c *Client) RefreshActorInfo(ctx context.Context, provider, id string) (*model.ActorInfo, error) {
+	info := &model.ActorInfo{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/admin/refresh/actors"+escape(provider, id), nil, nil, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetMovieOverride returns the manual override of the movie.
+func (c *Client) GetMovieOverride(ctx context.Context, provider, id string) (*model.MovieOverride, error) {
+	override := &model.MovieOverride{}
+	if err := c.get(ctx, "/v1/admin/overrides/movies"+escape(provider, id), nil, override); err != nil {
+		return nil, err
+	}
+	return override, nil
+}
+
+// PatchMovieOverride overrides the fields of the movie, and returns
+// the movie info with the override applied.
+func (c *Client) PatchMovieOverride(ctx context.Context, provider, id string, fields map[string]json.RawMessage) (*model.MovieInfo, error) {
+	info := &model.MovieInfo{}
+	if _, err := c.do(ctx, http.MethodPatch, "/v1/admin/overrides/movies"+escape(provider, id), nil, fields, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DeleteMovieOverride deletes the manual override of the movie.
+func (c *Client) DeleteMovieOverride(ctx context.Context, provider, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/v1/admin/overrides/movies"+escape(provider, id), nil, nil, nil)
+	return err
+}
+
+// VacuumDB vacuums the database of the server.
+func (c *Client) VacuumDB(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/v1/admin/db/vacuum", nil, nil, nil)
+	return err
+}
+
+// ExportTranslations returns the translation memory in the format,
+// tmx or csv, the caller must close it.
+func (c *Client) ExportTranslations(ctx context.Context, format string) (io.ReadCloser, error) {
+	v := values{}
+	v.set("format", format)
+	body, _, err := c.raw(ctx, http.MethodGet, "/v1/admin/translations/export", url.Values(v), nil)
+	return body, err
+}
+
+// ImportTranslations imports the translation memory in the format,
+// and returns the number of imported translations.
+func (c *Client) ImportTranslations(ctx context.Context, r io.Reader, format string) (int, error) {
+	v := values{}
+	v.set("format", format)
+	data := &struct {
+		Imported int `json:"imported"`
+	}{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/admin/translations/import", url.Values(v), r, data); err != nil {
+		return 0, err
+	}
+	return data.Imported, nil
+}
+
+// GetJobs returns the jobs of the status, all statuses if it's empty,
+// and at most limit jobs if it's positive.
+func (c *Client) GetJobs(ctx context.Context, status model.JobStatus, limit int) ([]*model.Job, error) {
+	v := values{}
+	v.set("status", string(status))
+	v.set("limit", limit)
+	var jobs []*model.Job
+	if err := c.get(ctx, "/v1/admin/jobs", url.Values(v), &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetJob returns the job of the id.
+func (c *Client) GetJob(ctx context.Context, id uint) (*model.Job, error) {
+	job := &model.Job{}
+	if err := c.get(ctx, "/v1/admin/jobs/"+strconv.FormatUint(uint64(id), 10), nil, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// SubmitJob submits a job of the type with params, params must be a
+// JSON object or empty.
+func (c *Client) SubmitJob(ctx context.Context, typ string, params json.RawMessage) (*model.Job, error) {
+	body := &struct {
+		Type   string          `json:"type"`
+		Params json.RawMessage `json:"params,omitempty"`
+	}{Type: typ, Params: params}
+	job := &model.Job{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/admin/jobs", nil, body, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetCookies returns the cookies of the provider by URL.
+func (c *Client) GetCookies(ctx context.Context, provider string) (map[string][]*http.Cookie, error) {
+	var cookies map[string][]*http.Cookie
+	if err := c.get(ctx, "/v1/admin/cookies"+escape(provider), nil, &cookies); err != nil {
+		return nil, err
+	}
+	return cookies, nil
+}
+
+// SetCookies sets the cookies of the provider for rawURL, the base
+// URL of the provider is used if it's empty.
+func (c *Client) SetCookies(ctx context.Context, provider, rawURL string, cookies []*http.Cookie) error {
+	body := &struct {
+		URL     string         `json:"url,omitempty"`
+		Cookies []*http.Cookie `json:"cookies"`
+	}{URL: rawURL, Cookies: cookies}
+	_, err := c.do(ctx, http.MethodPut, "/v1/admin/cookies"+escape(provider), nil, body, nil)
+	return err
+}
+
+// Reload reloads the config of the server.
+func (c *Client) Reload(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/v1/admin/reload", nil, nil, nil)
+	return err
+}
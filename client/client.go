@@ -0,0 +1,230 @@
+// Package client is the Go client of the HTTP API of a MetaTube server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+)
+
+// Defaults of the client.
+const (
+	DefaultRetries = 3
+	DefaultTimeout = 2 * time.Minute
+)
+
+// Client calls the /v1 API of a MetaTube server, requests of safe
+// methods, e.g. GET, failed with network errors, 429 or 5xx responses
+// are retried. Other requests are never retried, as they may have taken
+// effect on the server before failing.
+type Client struct {
+	baseURL *url.URL
+	token   string
+	client  *retryablehttp.Client
+}
+
+// New returns a client of the server at baseURL, the token is sent as
+// the bearer token, and can be empty if the server has no token.
+func New(baseURL, token string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url: %s", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	httpClient := cleanhttp.DefaultPooledClient()
+	httpClient.Timeout = DefaultTimeout
+	c := &Client{
+		baseURL: u,
+		token:   token,
+		client: &retryablehttp.Client{
+			HTTPClient:   httpClient,
+			RetryWaitMin: 500 * time.Millisecond,
+			RetryWaitMax: 5 * time.Second,
+			RetryMax:     DefaultRetries,
+			CheckRetry:   checkRetry,
+			Backoff:      retryablehttp.DefaultBackoff,
+			ErrorHandler: retryablehttp.PassthroughErrorHandler,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+type noRetryKey struct{}
+
+// checkRetry is the default retry policy, except that requests marked
+// by send as not retryable are never retried.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if noRetry, _ := ctx.Value(noRetryKey{}).(bool); noRetry {
+		return false, err
+	}
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// safeMethod reports whether requests of the method have no effects on
+// the server, and thus are safe to retry.
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// Page is the page info of paginated results.
+type Page struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+type response struct {
+	Data  json.RawMessage   `json:"data"`
+	Page  *Page             `json:"page"`
+	Error *errors.HTTPError `json:"error"`
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	u := *c.baseURL
+	u.RawPath = c.baseURL.EscapedPath() + path
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// send sends the request and returns the response if it succeeded,
+// otherwise the error of the server as *errors.HTTPError.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	var rawBody any
+	if b, ok := body.(io.Reader); ok {
+		rawBody = b
+	} else if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		rawBody = data
+	}
+	if !safeMethod(method) {
+		ctx = context.WithValue(ctx, noRetryKey{}, true)
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, c.url(path, query), rawBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		if _, ok := body.(io.Reader); !ok {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+	return resp, nil
+}
+
+func decodeError(resp *http.Response) error {
+	r := &response{}
+	if err := json.NewDecoder(resp.Body).Decode(r); err == nil && r.Error != nil {
+		return r.Error
+	}
+	return errors.FromCode(resp.StatusCode)
+}
+
+// do calls the API and decodes the data of the response into v.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, v any) (*Page, error) {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	r := &response{}
+	if err = json.NewDecoder(resp.Body).Decode(r); err != nil {
+		return nil, err
+	}
+	if v != nil && len(r.Data) > 0 {
+		if err = json.Unmarshal(r.Data, v); err != nil {
+			return nil, err
+		}
+	}
+	return r.Page, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	_, err := c.do(ctx, http.MethodGet, path, query, nil, v)
+	return err
+}
+
+// raw calls the API and returns the body of the response as is, the
+// caller must close it.
+func (c *Client) raw(ctx context.Context, method, path string, query url.Values, body any) (io.ReadCloser, string, error) {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// escape escapes the path segments.
+func escape(segments ...string) string {
+	var b bytes.Buffer
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
+
+// values is url.Values that ignores zero values.
+type values url.Values
+
+func (v values) set(key string, value any) {
+	switch value := value.(type) {
+	case string:
+		if value != "" {
+			url.Values(v).Set(key, value)
+		}
+	case bool:
+		if value {
+			url.Values(v).Set(key, "true")
+		}
+	case int:
+		if value != 0 {
+			url.Values(v).Set(key, fmt.Sprint(value))
+		}
+	case float64:
+		if value != 0 {
+			url.Values(v).Set(key, fmt.Sprint(value))
+		}
+	case []string:
+		for _, s := range value {
+			url.Values(v).Add(key, s)
+		}
+	}
+}
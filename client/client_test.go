@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	goerr "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/route"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := httptest.NewServer(route.New(engine.Default(), auth.Token("secret")))
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := New(srv.URL+"/", "secret")
+	require.NoError(t, err)
+
+	providers, err := c.GetProviders(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, providers.MovieProviders)
+
+	version, err := c.GetDBVersion(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, version)
+
+	_, err = c.GetMovieInfo(ctx, "unknown", "a/b", nil)
+	var httpErr *errors.HTTPError
+	require.True(t, goerr.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+
+	follow, err := c.AddFollow(ctx, model.FollowActor, "Name")
+	require.NoError(t, err)
+	follows, err := c.GetFollows(ctx)
+	require.NoError(t, err)
+	require.Len(t, follows, 1)
+	assert.Equal(t, follow.ID, follows[0].ID)
+	require.NoError(t, c.RemoveFollow(ctx, follow.ID))
+
+	unauthorized, err := New(srv.URL, "wrong")
+	require.NoError(t, err)
+	_, err = unauthorized.GetDBVersion(ctx)
+	require.True(t, goerr.As(err, &httpErr))
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+func TestClient_Retry(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		assert.Equal(t, "/v1/movies/search", r.URL.Path)
+		assert.Equal(t, "abc", r.URL.Query().Get("q"))
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`{"data":[{"id":"1"}],"page":{"page":2,"limit":1,"total":2}}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, "", WithRetryWait(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+	results, page, err := c.SearchMovie(context.Background(), "abc", &SearchOptions{Page: 2})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "1", results[0].ID)
+	assert.Equal(t, &Page{Page: 2, Limit: 1, Total: 2}, page)
+	assert.EqualValues(t, 3, calls.Load())
+
+	c, err = New(srv.URL, "", WithRetries(0))
+	require.NoError(t, err)
+	calls.Store(0)
+	_, _, err = c.SearchMovie(context.Background(), "abc", nil)
+	assert.Equal(t, errors.FromCode(http.StatusBadGateway), err)
+}
+
+func TestClient_NoRetry(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, "", WithRetryWait(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+	// requests of unsafe methods may have taken effect.
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		calls.Store(0)
+		_, err = c.do(context.Background(), method, "/v1/test", nil, nil, nil)
+		assert.Equal(t, errors.FromCode(http.StatusBadGateway), err, method)
+		assert.EqualValues(t, 1, calls.Load(), method)
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// GetFollows returns the follows of the user of the token, follows and
+// collections are scoped to the API key of the token on the server.
+func (c *Client) GetFollows(ctx context.Context) ([]*model.Follow, error) {
+	var follows []*model.Follow
+	if err := c.get(ctx, "/v1/follows", nil, &follows); err != nil {
+		return nil, err
+	}
+	return follows, nil
+}
+
+// AddFollow follows the actor or series of the name.
+func (c *Client) AddFollow(ctx context.Context, typ model.FollowType, name string) (*model.Follow, error) {
+	body := &struct {
+		Type model.FollowType `json:"type"`
+		Name string           `json:"name"`
+	}{Type: typ, Name: name}
+	follow := &model.Follow{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/follows", nil, body, follow); err != nil {
+		return nil, err
+	}
+	return follow, nil
+}
+
+// RemoveFollow removes the follow of the id.
+func (c *Client) RemoveFollow(ctx context.Context, id uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/v1/follows/"+strconv.FormatUint(uint64(id), 10), nil, nil, nil)
+	return err
+}
+
+// GetUnseenReleases returns the releases of the follows that are not
+// marked seen.
+func (c *Client) GetUnseenReleases(ctx context.Context) ([]*model.FollowRelease, error) {
+	var releases []*model.FollowRelease
+	if err := c.get(ctx, "/v1/follows/releases", nil, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// MarkReleasesSeen marks the releases of the follows seen.
+func (c *Client) MarkReleasesSeen(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/v1/follows/releases/seen", nil, nil, nil)
+	return err
+}
+
+// GetCollection returns the items of the collection, or items of all
+// collections if collection is empty.
+func (c *Client) GetCollection(ctx context.Context, collection model.CollectionType) ([]*model.CollectionItem, error) {
+	v := url.Values{}
+	if collection != "" {
+		v.Set("collection", string(collection))
+	}
+	var items []*model.CollectionItem
+	if err := c.get(ctx, "/v1/collections", v, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// AddCollectionItem adds the movie or actor of the provider to the
+// collection.
+func (c *Client) AddCollectionItem(ctx context.Context, collection model.CollectionType, provider, id, note string) (*model.CollectionItem, error) {
+	body := &struct {
+		Collection model.CollectionType `json:"collection"`
+		Provider   string               `json:"provider"`
+		ID         string               `json:"id"`
+		Note       string               `json:"note,omitempty"`
+	}{Collection: collection, Provider: provider, ID: id, Note: note}
+	item := &model.CollectionItem{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/collections", nil, body, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// RemoveCollectionItem removes the collection item of the id.
+func (c *Client) RemoveCollectionItem(ctx context.Context, id uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/v1/collections/"+strconv.FormatUint(uint64(id), 10), nil, nil, nil)
+	return err
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Image types of GetImage.
+const (
+	PrimaryImage  = "primary"
+	ThumbImage    = "thumb"
+	BackdropImage = "backdrop"
+)
+
+// ImageOptions are the options of GetImage.
+type ImageOptions struct {
+	// URL is the image to process instead of the default one.
+	URL string
+	// Ratio and Position crop the image, Auto detects the position.
+	Ratio    float64
+	Position float64
+	Auto     bool
+	// Badge is the badge drawn on the image.
+	Badge string
+	// Quality is the JPEG quality.
+	Quality int
+	// Fit is either crop or letterbox, letterbox pads primary images
+	// to the ratio rather than cropping them.
+	Fit string
+	// Pipeline chains transforms of the image in the compact syntax,
+	// e.g. resize:width=400|format:type=png.
+	Pipeline string
+}
+
+func (o *ImageOptions) values() url.Values {
+	v := values{}
+	if o != nil {
+		v.set("url", o.URL)
+		v.set("ratio", o.Ratio)
+		v.set("pos", o.Position)
+		v.set("auto", o.Auto)
+		v.set("badge", o.Badge)
+		v.set("quality", o.Quality)
+		v.set("fit", o.Fit)
+		v.set("pipeline", o.Pipeline)
+	}
+	return url.Values(v)
+}
+
+// GetImage returns the image of the type of the movie or actor of the
+// provider and id, and its content type, the caller must close it.
+func (c *Client) GetImage(ctx context.Context, typ, provider, id string, opts *ImageOptions) (io.ReadCloser, string, error) {
+	return c.raw(ctx, http.MethodGet, "/v1/images"+escape(typ, provider, id), opts.values(), nil)
+}
+
+// GetActorFaceImage returns the image of the actor of the provider and
+// id cropped to the ratio and centered on the face, e.g. 1 for a square,
+// the server defaults to 2:3 if ratio is zero. The caller must close it.
+func (c *Client) GetActorFaceImage(ctx context.Context, provider, id string, ratio float64, quality int) (io.ReadCloser, string, error) {
+	v := values{}
+	v.set("ratio", ratio)
+	v.set("quality", quality)
+	return c.raw(ctx, http.MethodGet, "/v1/actors"+escape(provider, id)+"/primary", url.Values(v), nil)
+}
+
+// GetMoviePreview returns the animated preview of the trailer of the
+// movie of the provider and id in the format, webp or gif, the server
+// defaults to webp if format is empty. The caller must close it.
+func (c *Client) GetMoviePreview(ctx context.Context, provider, id, format string) (io.ReadCloser, string, error) {
+	v := values{}
+	v.set("format", format)
+	return c.raw(ctx, http.MethodGet, "/v1/images/preview"+escape(provider, id), url.Values(v), nil)
+}
+
+// GetMovieTrailer returns the trailer video of the movie of the
+// provider and id, and its content type, the caller must close it.
+func (c *Client) GetMovieTrailer(ctx context.Context, provider, id string) (io.ReadCloser, string, error) {
+	return c.raw(ctx, http.MethodGet, "/v1/movies"+escape(provider, id)+"/trailer", nil, nil)
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// OrganizeRequest is the request of Organize.
+type OrganizeRequest struct {
+	Path          string `json:"path"`
+	Template      string `json:"template"`
+	Root          string `json:"root,omitempty"`
+	DryRun        bool   `json:"dry_run,omitempty"`
+	Collision     string `json:"collision,omitempty"`
+	MaxNameLength int    `json:"max_name_length,omitempty"`
+	// Number overrides the number parsed from the filename.
+	Number string `json:"number,omitempty"`
+}
+
+// Move is a file moved by Organize.
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// OrganizeResult is the result of a file of Organize.
+type OrganizeResult struct {
+	Path  string  `json:"path"`
+	Part  int     `json:"part,omitempty"`
+	Moves []*Move `json:"moves,omitempty"`
+	Error string  `json:"error,omitempty"`
+}
+
+// Organize renames and moves the media files of the request on the
+// server with the template, the client must use the admin token, and
+// the paths must be within the library roots of the server.
+func (c *Client) Organize(ctx context.Context, req *OrganizeRequest) ([]*OrganizeResult, error) {
+	var results []*OrganizeResult
+	if _, err := c.do(ctx, http.MethodPost, "/v1/admin/library/organize", nil, req, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FilmographyRequest is the request of GetFilmographyReport.
+type FilmographyRequest struct {
+	Actor string `json:"actor"`
+	// Numbers are movie numbers or video filenames of the library.
+	Numbers []string `json:"numbers,omitempty"`
+	// Path is a library directory on the server to scan for videos, it
+	// must be within the library roots of the server.
+	Path string `json:"path,omitempty"`
+}
+
+// FilmographyReport is the completeness of the library against the
+// filmography of the actor.
+type FilmographyReport struct {
+	Actor        string                     `json:"actor"`
+	Total        int                        `json:"total"`
+	Owned        int                        `json:"owned"`
+	Completeness float64                    `json:"completeness"`
+	Missing      []*model.MovieSearchResult `json:"missing"`
+	Upcoming     []*model.MovieSearchResult `json:"upcoming"`
+}
+
+// GetFilmographyReport returns released movies of the actor missing in
+// the library, and upcoming ones, the client must use the admin token.
+func (c *Client) GetFilmographyReport(ctx context.Context, req *FilmographyRequest) (*FilmographyReport, error) {
+	report := &FilmographyReport{}
+	if _, err := c.do(ctx, http.MethodPost, "/v1/admin/library/filmography", nil, req, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// NumberStatesRequest is the request of SetNumberStates, a nil flag
+// leaves the state unchanged.
+type NumberStatesRequest struct {
+	Numbers []string  `json:"numbers"`
+	Owned   *bool     `json:"owned,omitempty"`
+	Watched *bool     `json:"watched,omitempty"`
+	At      time.Time `json:"at,omitzero"`
+}
+
+// SetNumberStates marks the numbers of the request owned or watched,
+// states are scoped to the API key of the token on the server.
+func (c *Client) SetNumberStates(ctx context.Context, req *NumberStatesRequest) ([]*model.NumberState, error) {
+	var states []*model.NumberState
+	if _, err := c.do(ctx, http.MethodPost, "/v1/states", nil, req, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// GetNumberStates returns the states, filtered by owned or watched flags
+// if not nil.
+func (c *Client) GetNumberStates(ctx context.Context, owned, watched *bool) ([]*model.NumberState, error) {
+	v := url.Values{}
+	if owned != nil {
+		v.Set("owned", strconv.FormatBool(*owned))
+	}
+	if watched != nil {
+		v.Set("watched", strconv.FormatBool(*watched))
+	}
+	var states []*model.NumberState
+	if err := c.get(ctx, "/v1/states", v, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// InfoOptions are the options of GetMovieInfo and GetActorInfo.
+type InfoOptions struct {
+	// Refresh scrapes the info from the provider even if it's cached.
+	Refresh bool
+	// Merge fills in missing movie fields from other providers, or
+	// merges the same actor of other providers into one record.
+	Merge bool
+	// Lang translates movie titles and summaries to the language.
+	Lang string
+	// Duration is the actual duration of the movie file, the runtime
+	// closest to it is preferred among providers.
+	Duration time.Duration
+}
+
+func (o *InfoOptions) values() url.Values {
+	v := values{}
+	if o != nil {
+		if o.Refresh {
+			v["lazy"] = []string{"false"}
+		}
+		v.set("merge", o.Merge)
+		v.set("lang", o.Lang)
+		v.set("duration", int(o.Duration/time.Second))
+	}
+	return url.Values(v)
+}
+
+// SearchOptions are the options of SearchMovie and SearchActor.
+type SearchOptions struct {
+	// Provider searches only the provider.
+	Provider string
+	// Fallback searches the cached results as well.
+	Fallback bool
+	// By is actor or genre to list movies of them, movies only.
+	By string
+	// Dedup collapses results of the same movie from all providers.
+	Dedup bool
+	// Compilation is include, demote or exclude, movies only.
+	Compilation string
+	// Page, Limit, Sort and Order paginate and sort the results.
+	Page  int
+	Limit int
+	Sort  string
+	Order string
+}
+
+func (o *SearchOptions) values(q string) url.Values {
+	v := values{}
+	v.set("q", q)
+	if o != nil {
+		v.set("provider", o.Provider)
+		v.set("fallback", o.Fallback)
+		v.set("by", o.By)
+		v.set("dedup", o.Dedup)
+		v.set("compilation", o.Compilation)
+		v.set("page", o.Page)
+		v.set("limit", o.Limit)
+		v.set("sort", o.Sort)
+		v.set("order", o.Order)
+	}
+	return url.Values(v)
+}
+
+func lazyValues(refresh bool) url.Values {
+	if refresh {
+		return url.Values{"lazy": []string{"false"}}
+	}
+	return nil
+}
+
+// GetMovieInfo returns the movie info of the provider and id.
+func (c *Client) GetMovieInfo(ctx context.Context, provider, id string, opts *InfoOptions) (*model.MovieInfo, error) {
+	info := &model.MovieInfo{}
+	if err := c.get(ctx, "/v1/movies"+escape(provider, id), opts.values(), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// SearchMovie searches movies with the keyword, the page info is
+// returned if the results are paginated.
+func (c *Client) SearchMovie(ctx context.Context, keyword string, opts *SearchOptions) ([]*model.MovieSearchResult, *Page, error) {
+	var results []*model.MovieSearchResult
+	page, err := c.do(ctx, http.MethodGet, "/v1/movies/search", opts.values(keyword), nil, &results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, page, nil
+}
+
+// GetRelatedMovies returns movies related to the movie of the provider and id.
+func (c *Client) GetRelatedMovies(ctx context.Context, provider, id string, refresh bool) ([]*model.RelatedMovie, error) {
+	var related []*model.RelatedMovie
+	if err := c.get(ctx, "/v1/movies"+escape(provider, id)+"/related", lazyValues(refresh), &related); err != nil {
+		return nil, err
+	}
+	return related, nil
+}
+
+// LookupMovie returns the best matching movie info of the keyword,
+// e.g. a file name.
+func (c *Client) LookupMovie(ctx context.Context, keyword string) (*model.MovieInfo, error) {
+	info := &model.MovieInfo{}
+	if err := c.get(ctx, "/v1/movies/lookup", url.Values{"q": []string{keyword}}, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetMovieByNumber returns the movie info of the number from the
+// best provider of it.
+func (c *Client) GetMovieByNumber(ctx context.Context, number string, refresh bool) (*model.MovieInfo, error) {
+	info := &model.MovieInfo{}
+	if err := c.get(ctx, "/v1/movies/number"+escape(number), lazyValues(refresh), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetMovieReviews returns the reviews of the movie of the provider and id.
+func (c *Client) GetMovieReviews(ctx context.Context, provider, id string, refresh bool) ([]*model.MovieReviewDetail, error) {
+	var reviews []*model.MovieReviewDetail
+	if err := c.get(ctx, "/v1/reviews"+escape(provider, id), lazyValues(refresh), &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// CalendarDay is the movies released on a date.
+type CalendarDay struct {
+	Date   string                     `json:"date"`
+	Movies []*model.MovieSearchResult `json:"movies"`
+}
+
+// GetCalendar returns the movies released between from and to by day,
+// zero dates are defaulted by the server, and actors filter them.
+func (c *Client) GetCalendar(ctx context.Context, from, to time.Time, actors ...string) ([]*CalendarDay, error) {
+	v := values{}
+	if !from.IsZero() {
+		v.set("from", from.Format(time.DateOnly))
+	}
+	if !to.IsZero() {
+		v.set("to", to.Format(time.DateOnly))
+	}
+	v.set("actor", actors)
+	var days []*CalendarDay
+	if err := c.get(ctx, "/v1/calendar", url.Values(v), &days); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures the client.
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client.
+func WithHTTPClient(c *http.Client) Option {
+	return func(client *Client) {
+		client.client.HTTPClient = c
+	}
+}
+
+// WithRetries sets the maximum number of retries of a request.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.client.RetryMax = n
+	}
+}
+
+// WithRetryWait sets the minimum and maximum wait between retries.
+func WithRetryWait(min, max time.Duration) Option {
+	return func(c *Client) {
+		c.client.RetryWaitMin = min
+		c.client.RetryWaitMax = max
+	}
+}
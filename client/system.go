@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/metatube-community/metatube-sdk-go/subtitle"
+)
+
+// Providers is the Name:URL maps of the providers of the server.
+type Providers struct {
+	ActorProviders map[string]string `json:"actor_providers"`
+	MovieProviders map[string]string `json:"movie_providers"`
+}
+
+// GetProviders returns the providers of the server.
+func (c *Client) GetProviders(ctx context.Context) (*Providers, error) {
+	providers := &Providers{}
+	if err := c.get(ctx, "/v1/providers", nil, providers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// GetDBVersion returns the version of the database of the server.
+func (c *Client) GetDBVersion(ctx context.Context) (string, error) {
+	data := &struct {
+		Version string `json:"version"`
+	}{}
+	if err := c.get(ctx, "/v1/db/version", nil, data); err != nil {
+		return "", err
+	}
+	return data.Version, nil
+}
+
+// Translation is the result of Translate.
+type Translation struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Text string `json:"translated_text"`
+}
+
+// Translate translates q from the language to the other with the
+// translation engine, from can be empty to detect it.
+func (c *Client) Translate(ctx context.Context, q, from, to, engine string) (*Translation, error) {
+	v := values{}
+	v.set("q", q)
+	v.set("from", from)
+	v.set("to", to)
+	v.set("engine", engine)
+	t := &Translation{}
+	if err := c.get(ctx, "/v1/translate", url.Values(v), t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SearchSubtitles searches subtitles of the movie number, all sources
+// of the server are searched if source is empty.
+func (c *Client) SearchSubtitles(ctx context.Context, number, source string) ([]*subtitle.Subtitle, error) {
+	v := values{}
+	v.set("q", number)
+	v.set("source", source)
+	var results []*subtitle.Subtitle
+	if err := c.get(ctx, "/v1/subtitles/search", url.Values(v), &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetSubtitles returns subtitles of the movie of the provider and id.
+func (c *Client) GetSubtitles(ctx context.Context, provider, id, source string) ([]*subtitle.Subtitle, error) {
+	v := values{}
+	v.set("source", source)
+	var results []*subtitle.Subtitle
+	if err := c.get(ctx, "/v1/subtitles"+escape(provider, id), url.Values(v), &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
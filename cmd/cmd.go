@@ -1,67 +1,100 @@
 package cmd
 
 import (
+	"context"
 	goflag "flag"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peterbourgon/ff/v3"
 
+	"github.com/metatube-community/metatube-sdk-go/common/cloudflare"
+	"github.com/metatube-community/metatube-sdk-go/common/ffmpeg"
+	"github.com/metatube-community/metatube-sdk-go/common/headless"
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/common/tracing"
+	"github.com/metatube-community/metatube-sdk-go/config"
 	"github.com/metatube-community/metatube-sdk-go/database"
 	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face/remote"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/pigo"
+	"github.com/metatube-community/metatube-sdk-go/library"
 	"github.com/metatube-community/metatube-sdk-go/route"
 	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/store"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
 )
 
-var Config = &struct {
-	// main config
-	Bind  string
-	Port  string
-	Token string
-	DSN   string
+// Config is the startup settings, see config.Settings for the order
+// of precedence of their sources.
+var Config = &config.Settings{}
 
-	// engine config
-	RequestTimeout time.Duration
-
-	// database config
-	DBMaxIdleConns int
-	DBMaxOpenConns int
-	DBAutoMigrate  bool
-	DBPreparedStmt bool
-
-	// version flag
-	VersionFlag bool
-}{}
+// reloader reloads the config file, if any, of the engine.
+var reloader *config.Reloader
 
 func init() {
 	// gin init
 	gin.DisableConsoleColor()
+}
 
-	// flag init
-	flag := goflag.NewFlagSet("", goflag.ExitOnError)
-
-	// flag parse
-	flag.StringVar(&Config.Bind, "bind", "", "Bind address of server")
-	flag.StringVar(&Config.Port, "port", "8080", "Port number of server")
-	flag.StringVar(&Config.Token, "token", "", "Token to access server")
-	flag.StringVar(&Config.DSN, "dsn", "", "Database Service Name")
-	flag.DurationVar(&Config.RequestTimeout, "request-timeout", engine.DefaultRequestTimeout, "Timeout per request")
-	flag.IntVar(&Config.DBMaxIdleConns, "db-max-idle-conns", 0, "Database max idle connections")
-	flag.IntVar(&Config.DBMaxOpenConns, "db-max-open-conns", 0, "Database max open connections")
-	flag.BoolVar(&Config.DBAutoMigrate, "db-auto-migrate", false, "Database auto migration")
-	flag.BoolVar(&Config.DBPreparedStmt, "db-prepared-stmt", false, "Database prepared statement")
-	flag.BoolVar(&Config.VersionFlag, "version", false, "Show version")
-	ff.Parse(flag, os.Args[1:], ff.WithEnvVars())
+// NewFlagSet returns a flag set with all flags bound to Config.
+func NewFlagSet(name string) *goflag.FlagSet {
+	flag := goflag.NewFlagSet(name, goflag.ExitOnError)
+	Config.BindFlags(flag)
+	return flag
 }
 
-func Router(names ...string) *gin.Engine {
+// Parse parses args, environment variables and the settings file
+// into Config, and sets up the default logger.
+func Parse(args []string) error {
+	if err := ff.Parse(NewFlagSet(""), args, config.Options()...); err != nil {
+		return err
+	}
+	return SetupLogger()
+}
+
+// SetupLogger sets up the default structured logger from Config.
+func SetupLogger() error {
+	return logger.Setup(&logger.Config{
+		Level:  Config.LogLevel,
+		Format: Config.LogFormat,
+		Output: os.Stdout,
+	})
+}
+
+// SetupTracing sets up the global tracer provider from Config, the
+// returned function is a no-op if tracing is disabled.
+func SetupTracing(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if Config.OTLPEndpoint == "" /* tracing disabled */ {
+		return func(context.Context) error { return nil }, nil
+	}
+	return tracing.Setup(ctx, &tracing.Config{
+		Endpoint:    Config.OTLPEndpoint,
+		Insecure:    Config.OTLPInsecure,
+		SampleRatio: Config.TraceSampleRatio,
+	})
+}
+
+// Engine opens the database and returns a configured engine.
+func Engine(names ...string) *engine.Engine {
+	var replicas []string
+	if Config.DBReadReplicas != "" {
+		replicas = strings.Split(Config.DBReadReplicas, ",")
+	}
 	db, err := database.Open(&database.Config{
 		DSN:                  Config.DSN,
 		PreparedStmt:         Config.DBPreparedStmt,
 		MaxIdleConns:         Config.DBMaxIdleConns,
 		MaxOpenConns:         Config.DBMaxOpenConns,
+		BusyTimeout:          Config.DBBusyTimeout,
+		ReadReplicas:         replicas,
 		DisableAutomaticPing: true,
 	})
 	if err != nil {
@@ -69,19 +102,124 @@ func Router(names ...string) *gin.Engine {
 	}
 
 	// engine options
-	var opts []engine.Option
+	opts := []engine.Option{engine.WithDB(db)}
 
 	// timeout must >= 1 second
 	if Config.RequestTimeout >= time.Second {
-		opts = append(opts, engine.WithRequestTimeout(Config.RequestTimeout))
+		opts = append(opts, engine.WithTimeout(Config.RequestTimeout))
 	}
 
+	// shared cache store
+	if Config.CacheStore != "" {
+		cache, err := store.Open(Config.CacheStore, db)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, engine.WithCache(cache, Config.CacheTTL))
+		translate.DefaultMemory.SetStore(cache, 0)
+	}
+
+	// in-memory LRU of hottest records
+	opts = append(opts, engine.WithRecordCache(Config.RecordCacheSize))
+
+	// raw provider payloads for re-parsing
+	if Config.DBRawPayloads {
+		opts = append(opts, engine.WithRawPayloads())
+	}
+
+	// background job workers
+	opts = append(opts, engine.WithJobWorkers(Config.JobWorkers))
+	opts = append(opts, engine.WithScrapeWorkers(Config.ScrapeWorkers))
+
 	// specify engine name
 	for _, name := range names {
 		opts = append(opts, engine.WithEngineName(name))
 	}
 
-	app := engine.New(db, opts...)
+	// startup provider settings
+	for name, c := range Config.Providers {
+		opts = append(opts, engine.WithProviderConfig(name, c))
+	}
+
+	// persistent provider cookies
+	if Config.CookieFile != "" {
+		opts = append(opts, engine.WithCookieFile(Config.CookieFile))
+	}
+
+	// webhook notifications
+	if Config.WebhookURLs != "" {
+		opts = append(opts, engine.WithWebhook(
+			webhook.New(strings.Split(Config.WebhookURLs, ","), webhook.DefaultTimeout)))
+	}
+
+	// external face detection service
+	if Config.FaceDetectorURL != "" {
+		opts = append(opts, engine.WithFaceDetector(
+			face.Fallback(remote.New(Config.FaceDetectorURL), pigo.Detector{})))
+	}
+
+	// animated previews of trailers
+	if Config.PreviewDir != "" {
+		ff, err := ffmpeg.New(Config.FFmpegPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, engine.WithPreview(Config.PreviewDir, ff),
+			engine.WithPreviewLimits(Config.PreviewMaxSize, Config.PreviewMaxAge))
+	}
+
+	// Cloudflare challenge solver
+	if Config.FlareSolverr != "" {
+		cloudflare.SetSolver(cloudflare.NewSolver(Config.FlareSolverr, Config.RequestTimeout))
+	}
+
+	// headless browser for JS-rendered pages
+	switch Config.HeadlessBrowser {
+	case "":
+	case "chrome":
+		headless.SetRenderer(headless.NewChrome("", Config.RequestTimeout))
+	default:
+		headless.SetRenderer(headless.NewChrome(Config.HeadlessBrowser, Config.RequestTimeout))
+	}
+
+	// default translator parameters, e.g. API keys
+	translate.SetDefaults(Config.Translators)
+
+	app := engine.New(opts...)
+
+	// batch scans as background jobs, within the library roots only.
+	var libraryRoots []string
+	if Config.LibraryRoots != "" {
+		libraryRoots = strings.Split(Config.LibraryRoots, ",")
+	}
+	app.RegisterJobHandler(library.ScanJob, library.ScanJobHandler(app, libraryRoots))
+
+	// hot-reloadable config
+	if Config.ConfigFile != "" {
+		reloader = config.NewReloader(Config.ConfigFile, app)
+		reloader.Translators = Config.Translators
+		if err = reloader.Reload(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// periodic checks of follows for new releases
+	if Config.FollowCheckInterval > 0 {
+		app.Go(func(ctx context.Context) {
+			ticker := time.NewTicker(Config.FollowCheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				if err := app.WithContext(ctx).CheckFollows(); err != nil {
+					app.Logger().Error("check follows", slog.Any("error", err))
+				}
+			}
+		})
+	}
 
 	// always enable auto migrate for sqlite DB
 	if app.DBType() == database.Sqlite {
@@ -90,11 +228,74 @@ func Router(names ...string) *gin.Engine {
 	if err = app.DBAutoMigrate(Config.DBAutoMigrate); err != nil {
 		log.Fatal(err)
 	}
+	return app
+}
 
-	var token auth.Validator
+// Validator returns the token validator, or nil if auth is disabled.
+// API keys share the same quotas among HTTP and gRPC servers.
+var Validator = sync.OnceValue(func() auth.Validator {
+	if len(Config.APIKeys) > 0 {
+		keys, err := Config.APIKeys.Keys()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if Config.Token != "" {
+			keys = append(keys, &auth.Key{Name: "default", Token: Config.Token})
+		}
+		return auth.NewKeyStore(keys...)
+	}
 	if Config.Token != "" {
-		token = auth.Token(Config.Token)
+		return auth.Token(Config.Token)
+	}
+	return nil
+})
+
+func Router(names ...string) *gin.Engine {
+	return NewRouter(Engine(names...))
+}
+
+// NewRouter returns the HTTP router of the given engine, the options
+// are applied after the ones of the config.
+func NewRouter(app *engine.Engine, opts ...route.Option) *gin.Engine {
+	// route options
+	var routeOpts []route.Option
+	if Config.EnableStashBox {
+		routeOpts = append(routeOpts, route.WithStashBox())
+	}
+	if Config.EnableWebUI {
+		routeOpts = append(routeOpts, route.WithWebUI())
+	}
+	switch Config.SubtitleSources {
+	case "":
+	case "all":
+		routeOpts = append(routeOpts, route.WithSubtitleSources())
+	default:
+		routeOpts = append(routeOpts, route.WithSubtitleSources(strings.Split(Config.SubtitleSources, ",")...))
+	}
+
+	if reloader != nil {
+		routeOpts = append(routeOpts, route.WithReload(reloader.Reload))
+	}
+	if Config.CORSOrigins != "" {
+		routeOpts = append(routeOpts, route.WithCORS(strings.Split(Config.CORSOrigins, ",")...))
+	}
+	if len(Config.IPAllowlist) > 0 || len(Config.IPDenylist) > 0 {
+		routeOpts = append(routeOpts, route.WithIPFilter(Config.IPAllowlist, Config.IPDenylist))
+	}
+	if len(Config.TrustedProxies) > 0 {
+		routeOpts = append(routeOpts, route.WithTrustedProxies(Config.TrustedProxies...))
+	}
+	if Config.LibraryRoots != "" {
+		routeOpts = append(routeOpts, route.WithLibraryRoots(strings.Split(Config.LibraryRoots, ",")...))
+	}
+	// admin token takes precedence over admin keys.
+	if Config.AdminToken != "" {
+		routeOpts = append(routeOpts, route.WithAdminValidator(auth.Token(Config.AdminToken)))
+	} else if keys, ok := Validator().(*auth.KeyStore); ok {
+		if admins := keys.Admins(); admins != nil {
+			routeOpts = append(routeOpts, route.WithAdminValidator(admins))
+		}
 	}
 
-	return route.New(app, token)
+	return route.New(app, Validator(), append(routeOpts, opts...)...)
 }
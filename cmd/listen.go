@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+
+	"github.com/metatube-community/metatube-sdk-go/route"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd.
+const listenFDsStart = 3
+
+// unixPeerAddr is the remote address of the peers of Unix sockets,
+// which have no IPs, so that they are seen as local clients.
+var unixPeerAddr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+
+// Listen returns the listener of the HTTP server: the socket activated
+// by systemd if any, the Unix socket if configured, or the TCP address.
+func Listen() (net.Listener, error) {
+	lis, err := systemdListener()
+	if lis == nil && err == nil {
+		if Config.UnixSocket != "" {
+			lis, err = listenUnix(Config.UnixSocket, fs.FileMode(Config.UnixSocketMode))
+		} else {
+			lis, err = net.Listen("tcp", net.JoinHostPort(Config.Bind, Config.Port))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lis.Addr().Network() == "unix" {
+		lis = &unixListener{lis}
+	}
+	return lis, nil
+}
+
+// ListenerOptions returns the route options of the listener, the peers
+// of Unix sockets are reverse proxies in front of the server, so their
+// forwarded client IPs and hosts are trusted.
+func ListenerOptions(lis net.Listener) []route.Option {
+	if _, ok := lis.(*unixListener); !ok {
+		return nil
+	}
+	addr, _ := netip.AddrFromSlice(unixPeerAddr.IP.To4())
+	return []route.Option{route.WithTrustedProxies(netip.PrefixFrom(addr, addr.BitLen()))}
+}
+
+// unixListener reports the peers of the Unix socket as unixPeerAddr.
+type unixListener struct {
+	net.Listener
+}
+
+func (l *unixListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &unixConn{conn}, nil
+}
+
+type unixConn struct {
+	net.Conn
+}
+
+func (*unixConn) RemoteAddr() net.Addr { return unixPeerAddr }
+
+// systemdListener returns the first socket passed by systemd socket
+// activation, or nil if the process is not socket activated.
+func systemdListener() (net.Listener, error) {
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return nil, nil
+	}
+	// not passed to child processes.
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	f := os.NewFile(listenFDsStart, "LISTEN_FD_"+strconv.Itoa(listenFDsStart))
+	defer f.Close()
+	lis, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("systemd socket: %w", err)
+	}
+	return lis, nil
+}
+
+// listenUnix listens on the Unix socket of the path, a stale socket
+// file left by a previous run is removed.
+func listenUnix(path string, mode fs.FileMode) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("unix socket: %s exists and is not a socket", path)
+		}
+		if err = os.Remove(path); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, mode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+// socketDir returns a short temp dir, since the paths of Unix sockets
+// are limited to about 100 bytes.
+func socketDir(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "mt")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(socketDir(t), "mt.sock")
+
+	lis, err := listenUnix(path, 0o600)
+	require.NoError(t, err)
+	fi, err := os.Lstat(path)
+	require.NoError(t, err)
+	assert.NotZero(t, fi.Mode()&fs.ModeSocket)
+	assert.Equal(t, fs.FileMode(0o600), fi.Mode().Perm())
+
+	// leave a stale socket file as a crashed run.
+	lis.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, lis.Close())
+	_, err = os.Lstat(path)
+	require.NoError(t, err)
+
+	lis, err = listenUnix(path, 0o660)
+	require.NoError(t, err)
+	defer lis.Close()
+	fi, err = os.Lstat(path)
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o660), fi.Mode().Perm())
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestListenUnix_NotSocket(t *testing.T) {
+	path := filepath.Join(socketDir(t), "mt.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	_, err := listenUnix(path, 0o660)
+	assert.Error(t, err)
+	// never removes files which are not sockets.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+}
+
+func TestSystemdListener_NotActivated(t *testing.T) {
+	for _, unit := range []struct {
+		pid, fds string
+	}{
+		{"", ""},
+		{strconv.Itoa(os.Getpid() + 1), "1"},
+		{strconv.Itoa(os.Getpid()), "0"},
+		{strconv.Itoa(os.Getpid()), "x"},
+	} {
+		t.Setenv("LISTEN_PID", unit.pid)
+		t.Setenv("LISTEN_FDS", unit.fds)
+		lis, err := systemdListener()
+		assert.NoError(t, err)
+		assert.Nil(t, lis)
+	}
+}
+
+func TestListen(t *testing.T) {
+	withConfig(t)
+	t.Setenv("LISTEN_PID", "")
+
+	Config.UnixSocket = ""
+	Config.Bind = "127.0.0.1"
+	Config.Port = "0"
+	lis, err := Listen()
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", lis.Addr().Network())
+	require.NoError(t, lis.Close())
+
+	// Unix socket takes precedence over TCP.
+	Config.UnixSocket = filepath.Join(socketDir(t), "mt.sock")
+	Config.UnixSocketMode = 0o600
+	lis, err = Listen()
+	require.NoError(t, err)
+	assert.Equal(t, "unix", lis.Addr().Network())
+	assert.Equal(t, Config.UnixSocket, lis.Addr().String())
+	require.NoError(t, lis.Close())
+}
+
+func TestListen_UnixIPFilter(t *testing.T) {
+	withConfig(t)
+	t.Setenv("LISTEN_PID", "")
+
+	Config.UnixSocket = filepath.Join(socketDir(t), "mt.sock")
+	Config.UnixSocketMode = 0o600
+	Config.IPAllowlist = []netip.Prefix{netip.MustParsePrefix("192.168.1.0/24")}
+	lis, err := Listen()
+	require.NoError(t, err)
+	srv := &http.Server{Handler: NewRouter(engine.New(), ListenerOptions(lis)...)}
+	go srv.Serve(lis)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", Config.UnixSocket)
+		},
+	}}
+	for _, unit := range []struct {
+		forwarded string
+		code      int
+	}{
+		// clients forwarded by the proxy on the socket.
+		{"192.168.1.2", http.StatusOK},
+		{"10.0.0.1", http.StatusForbidden},
+		// the proxy itself is a local client.
+		{"", http.StatusForbidden},
+	} {
+		req, err := http.NewRequest(http.MethodGet, "http://metatube/healthz", nil)
+		require.NoError(t, err)
+		if unit.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", unit.forwarded)
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, unit.code, resp.StatusCode, unit.forwarded)
+	}
+}
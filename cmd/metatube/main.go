@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	goflag "flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/gorilla/schema"
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/metatube-community/metatube-sdk-go/cmd"
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/config"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	V "github.com/metatube-community/metatube-sdk-go/internal/version"
+	"github.com/metatube-community/metatube-sdk-go/library"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+	_ "github.com/metatube-community/metatube-sdk-go/translate/baidu"
+	_ "github.com/metatube-community/metatube-sdk-go/translate/deepl"
+	_ "github.com/metatube-community/metatube-sdk-go/translate/google"
+	_ "github.com/metatube-community/metatube-sdk-go/translate/googlefree"
+	_ "github.com/metatube-community/metatube-sdk-go/translate/openai"
+)
+
+func main() {
+	root := &ffcli.Command{
+		Name:       "metatube",
+		ShortUsage: "metatube [flags] <subcommand> [flags] [args...]",
+		FlagSet:    cmd.NewFlagSet("metatube"),
+		Options:    config.Options(),
+		Subcommands: []*ffcli.Command{
+			searchCommand(),
+			getCommand(),
+			scanCommand(),
+			watchCommand(),
+			organizeCommand(),
+			translateCommand(),
+			parseCommand(),
+			reparseCommand(),
+			serveCommand(),
+		},
+		Exec: func(context.Context, []string) error {
+			if cmd.Config.VersionFlag {
+				fmt.Println(V.BuildString())
+				return nil
+			}
+			return goflag.ErrHelp
+		},
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := root.Parse(os.Args[1:])
+	if err == nil {
+		err = cmd.SetupLogger()
+	}
+	if err == nil {
+		err = root.Run(ctx)
+	}
+	if err != nil {
+		if errors.Is(err, goflag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
+	}
+}
+
+func newEngine() *engine.Engine {
+	return cmd.Engine(engine.DefaultEngineName)
+}
+
+func searchCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube search", goflag.ExitOnError)
+	actor := fs.Bool("actor", false, "Search actors instead of movies")
+	provider := fs.String("provider", "", "Search the specified provider only")
+	fallback := fs.Bool("fallback", true, "Fallback to search results in DB")
+	return &ffcli.Command{
+		Name:       "search",
+		ShortUsage: "metatube search [-actor] [-provider name] <keyword>",
+		ShortHelp:  "Search movies or actors by keyword",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) (err error) {
+			if len(args) != 1 {
+				return goflag.ErrHelp
+			}
+			app := newEngine()
+			var results any
+			switch {
+			case *actor && *provider != "":
+				results, err = app.SearchActor(args[0], *provider, *fallback)
+			case *actor:
+				results, err = app.SearchActorAll(args[0], *fallback)
+			case *provider != "":
+				results, err = app.SearchMovie(args[0], *provider, *fallback)
+			default:
+				results, err = app.SearchMovieAll(args[0], *fallback)
+			}
+			if err != nil {
+				return err
+			}
+			return printJSON(results)
+		},
+	}
+}
+
+func getCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube get", goflag.ExitOnError)
+	actor := fs.Bool("actor", false, "Get actor info instead of movie info")
+	lazy := fs.Bool("lazy", true, "Use info cached in DB if available")
+	return &ffcli.Command{
+		Name:       "get",
+		ShortUsage: "metatube get [-actor] <provider> <id> | <url>",
+		ShortHelp:  "Get movie or actor info by provider id or URL",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) (err error) {
+			app := newEngine()
+			var info any
+			switch {
+			case len(args) == 1 && *actor:
+				info, err = app.GetActorInfoByURL(args[0], *lazy)
+			case len(args) == 1:
+				info, err = app.GetMovieInfoByURL(args[0], *lazy)
+			case len(args) == 2 && *actor:
+				info, err = app.GetActorInfoByProviderID(args[0], args[1], *lazy)
+			case len(args) == 2:
+				info, err = app.GetMovieInfoByProviderID(args[0], args[1], *lazy)
+			default:
+				return goflag.ErrHelp
+			}
+			if err != nil {
+				return err
+			}
+			return printJSON(info)
+		},
+	}
+}
+
+func scanCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube scan", goflag.ExitOnError)
+	overwrite := fs.Bool("overwrite", false, "Overwrite existing NFO and artworks")
+	noArtwork := fs.Bool("no-artwork", false, "Do not download artworks")
+	quality := fs.Int("quality", 90, "JPEG quality of artworks")
+	rename := fs.String("rename", "", "Rename videos with template, e.g. \"{{.Number}} {{.Title}}\"")
+	romaji := fs.Bool("romaji", false, "Write actor names in romaji into NFO")
+	return &ffcli.Command{
+		Name:       "scan",
+		ShortUsage: "metatube scan [-overwrite] [-no-artwork] [-rename template] <dir>",
+		ShortHelp:  "Scan a directory and write NFO and artworks next to videos",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) != 1 {
+				return goflag.ErrHelp
+			}
+			scanner, err := newScanner(*overwrite, *noArtwork, *romaji, *quality, *rename)
+			if err != nil {
+				return err
+			}
+			results, err := scanner.ScanDir(args[0])
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				printResult(result)
+			}
+			return nil
+		},
+	}
+}
+
+func watchCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube watch", goflag.ExitOnError)
+	overwrite := fs.Bool("overwrite", false, "Overwrite existing NFO and artworks")
+	noArtwork := fs.Bool("no-artwork", false, "Do not download artworks")
+	quality := fs.Int("quality", 90, "JPEG quality of artworks")
+	rename := fs.String("rename", "", "Rename videos with template, e.g. \"{{.Number}} {{.Title}}\"")
+	romaji := fs.Bool("romaji", false, "Write actor names in romaji into NFO")
+	delay := fs.Duration("delay", library.DefaultWatchDelay, "Time a new file must stay unchanged before scanning")
+	return &ffcli.Command{
+		Name:       "watch",
+		ShortUsage: "metatube watch [-delay duration] [-rename template] <dir>...",
+		ShortHelp:  "Watch directories and scrape new videos automatically",
+		FlagSet:    fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return goflag.ErrHelp
+			}
+			scanner, err := newScanner(*overwrite, *noArtwork, *romaji, *quality, *rename)
+			if err != nil {
+				return err
+			}
+			watcher := library.NewWatcher(scanner, args...)
+			watcher.Delay = *delay
+			watcher.OnResult = printResult
+			if err = watcher.Run(ctx); errors.Is(err, context.Canceled) {
+				return nil // interrupted.
+			}
+			return err
+		},
+	}
+}
+
+func organizeCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube organize", goflag.ExitOnError)
+	pattern := fs.String("template", "{{.Number}} {{.Title}}", "Target path template without extension")
+	root := fs.String("root", "", "Destination root directory, defaults to the source directory")
+	dryRun := fs.Bool("dry-run", false, "Print planned moves without touching files")
+	collision := fs.String("collision", string(library.CollisionSkip), "Collision policy: skip, rename or overwrite")
+	maxLength := fs.Int("max-name-length", library.DefaultMaxNameLength, "Max length in bytes of each path element")
+	return &ffcli.Command{
+		Name:       "organize",
+		ShortUsage: "metatube organize [-template text] [-root dir] [-dry-run] [-collision policy] <path>...",
+		ShortHelp:  "Rename and move videos according to a template",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) == 0 {
+				return goflag.ErrHelp
+			}
+			tmpl, err := library.ParseNameTemplate(*pattern)
+			if err != nil {
+				return err
+			}
+			organizer := library.NewOrganizer(tmpl)
+			organizer.Root = *root
+			organizer.DryRun = *dryRun
+			organizer.MaxNameLength = *maxLength
+			if organizer.Collision, err = library.ParseCollisionPolicy(*collision); err != nil {
+				return err
+			}
+
+			var videos []string
+			for _, arg := range args {
+				found, err := library.FindVideos(arg)
+				if err != nil {
+					return err
+				}
+				videos = append(videos, found...)
+			}
+
+			scanner := library.NewScanner(newEngine())
+			for _, video := range videos {
+				info, err := scanner.Lookup(number.Trim(filepath.Base(video)))
+				if err != nil {
+					fmt.Printf("FAIL\t%s\t%v\n", video, err)
+					continue
+				}
+				moves, err := organizer.Organize(video, info)
+				for _, move := range moves {
+					fmt.Printf("MOVE\t%s\t%s\n", move.From, move.To)
+				}
+				if err != nil {
+					fmt.Printf("FAIL\t%s\t%v\n", video, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newScanner(overwrite, noArtwork, romaji bool, quality int, rename string) (*library.Scanner, error) {
+	scanner := library.NewScanner(newEngine())
+	scanner.Overwrite = overwrite
+	scanner.NoArtwork = noArtwork
+	scanner.Romaji = romaji
+	scanner.Quality = quality
+	if rename != "" {
+		tmpl, err := library.ParseNameTemplate(rename)
+		if err != nil {
+			return nil, err
+		}
+		scanner.Organizer = library.NewOrganizer(tmpl)
+	}
+	return scanner, nil
+}
+
+func printResult(result *library.Result) {
+	switch {
+	case result.Error != nil:
+		fmt.Printf("FAIL\t%s\t%s\t%v\n", result.Number, result.Path, result.Error)
+	case result.Info == nil:
+		fmt.Printf("SKIP\t%s\t%s\n", result.Number, result.Path)
+	default:
+		fmt.Printf("OK\t%s\t%s\t%s:%s\n", result.Number, result.Path, result.Info.Provider, result.Info.ID)
+	}
+}
+
+func parseCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube parse", goflag.ExitOnError)
+	actor := fs.Bool("actor", false, "Parse actor info instead of movie info")
+	file := fs.String("file", "", "Path of the saved HTML page to parse instead of the live page")
+	trace := fs.Bool("trace", false, "Print the pages and XPath matches as well")
+	return &ffcli.Command{
+		Name:       "parse",
+		ShortUsage: "metatube parse [-actor] [-file page.html] [-trace] <provider> <id> | <provider> <url>",
+		ShortHelp:  "Dry-run a provider parser against a live or saved page without caching",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) != 2 {
+				return goflag.ErrHelp
+			}
+			r := &engine.DryRun{Provider: args[0], Actor: *actor}
+			if strings.Contains(args[1], "://") {
+				r.URL = args[1]
+			} else {
+				r.ID = args[1]
+			}
+			if *file != "" {
+				html, err := os.ReadFile(*file)
+				if err != nil {
+					return err
+				}
+				r.HTML = html
+			}
+			result, err := newEngine().DryRunParse(r)
+			if err != nil {
+				return err
+			}
+			if !*trace {
+				result.Trace = nil
+			}
+			if err = printJSON(result); err != nil {
+				return err
+			}
+			if result.Error != "" {
+				return errors.New(result.Error)
+			}
+			return nil
+		},
+	}
+}
+
+func reparseCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube reparse", goflag.ExitOnError)
+	provider := fs.String("provider", "", "Re-parse movies of the specified provider only")
+	limit := fs.Int("limit", 0, "Maximum number of movies to re-parse, unlimited if zero")
+	dryRun := fs.Bool("dry-run", false, "Report the changes without saving them")
+	return &ffcli.Command{
+		Name:       "reparse",
+		ShortUsage: "metatube [flags] reparse [-provider name] [-limit n] [-dry-run]",
+		ShortHelp:  "Rebuild movie metadata from stored raw payloads with the current parsers",
+		FlagSet:    fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 0 {
+				return goflag.ErrHelp
+			}
+			report, err := newEngine().WithContext(ctx).ReparseMovies(*provider, *limit, *dryRun)
+			if err != nil {
+				return err
+			}
+			return printJSON(report)
+		},
+	}
+}
+
+func translateCommand() *ffcli.Command {
+	fs := goflag.NewFlagSet("metatube translate", goflag.ExitOnError)
+	from := fs.String("from", "auto", "Source language")
+	to := fs.String("to", "zh-CN", "Target language")
+	name := fs.String("engine", "googlefree", "Translation engine")
+	var config configFlag
+	fs.Var(&config, "config", "Engine config in key=value form, can be repeated")
+	return &ffcli.Command{
+		Name:       "translate",
+		ShortUsage: "metatube translate [-engine name] [-from lang] [-to lang] [-config key=value] <text>",
+		ShortHelp:  "Translate text with the specified engine",
+		FlagSet:    fs,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) == 0 {
+				return goflag.ErrHelp
+			}
+			decoder := schema.NewDecoder()
+			decoder.SetAliasTag("json")
+			decoder.IgnoreUnknownKeys(true)
+			result, err := translate.
+				New(*name, func(v any) error { return decoder.Decode(v, config.values()) }).
+				Translate(strings.Join(args, " "), *from, *to)
+			if err != nil {
+				return err
+			}
+			fmt.Println(result)
+			return nil
+		},
+	}
+}
+
+func serveCommand() *ffcli.Command {
+	return &ffcli.Command{
+		Name:       "serve",
+		ShortUsage: "metatube [flags] serve",
+		ShortHelp:  "Start the HTTP server",
+		Exec: func(ctx context.Context, _ []string) error {
+			return cmd.Serve(ctx, newEngine())
+		},
+	}
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// configFlag is a repeatable key=value flag.
+type configFlag []string
+
+func (f *configFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *configFlag) Set(s string) error {
+	if !strings.Contains(s, "=") {
+		return fmt.Errorf("invalid config %q, want key=value", s)
+	}
+	*f = append(*f, s)
+	return nil
+}
+
+func (f *configFlag) values() map[string][]string {
+	values := make(map[string][]string, len(*f))
+	for _, s := range *f {
+		k, v, _ := strings.Cut(s, "=")
+		values[k] = append(values[k], v)
+	}
+	return values
+}
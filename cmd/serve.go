@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cron"
+	"github.com/metatube-community/metatube-sdk-go/config"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/rpc"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+// DefaultShutdownTimeout is the default grace period of shutdown.
+const DefaultShutdownTimeout = config.DefaultShutdownTimeout
+
+// Serve starts the HTTP server, and the gRPC server if enabled, it
+// blocks until ctx is done and then shuts the servers down gracefully:
+// new requests are refused, in-flight requests are drained within the
+// shutdown timeout and canceled afterwards, and the engine is closed,
+// i.e. its background workers are stopped and waited for before its
+// stores are closed.
+func Serve(ctx context.Context, app *engine.Engine) (err error) {
+	// the engine is closed on all returns, as background workers may
+	// have been started before any of the failures.
+	defer func() {
+		if closeErr := app.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	shutdown, err := SetupTracing(ctx)
+	if err != nil {
+		return err
+	}
+	defer shutdown(context.Background())
+
+	// persistent translation memory.
+	if Config.TranslationFile != "" {
+		if err = translate.DefaultMemory.Load(Config.TranslationFile); err != nil {
+			return err
+		}
+		defer func() {
+			if err := translate.DefaultMemory.Save(Config.TranslationFile); err != nil {
+				slog.Error("save translation memory", slog.Any("error", err))
+			}
+		}()
+	}
+
+	// baseCtx is the parent of all request contexts, it's
+	// canceled when the shutdown grace period is over.
+	baseCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if reloader != nil {
+		go func() {
+			if err := reloader.Watch(ctx); err != nil {
+				slog.Error("watch config", slog.Any("error", err))
+			}
+		}()
+	}
+
+	// background jobs stop with the engine, and are resumed on restart.
+	app.Go(func(ctx context.Context) {
+		if err := app.RunJobs(ctx); err != nil {
+			slog.Error("run jobs", slog.Any("error", err))
+		}
+	})
+
+	// periodic provider health checks.
+	if Config.HealthCheckInterval > 0 {
+		app.Go(func(ctx context.Context) {
+			app.WatchProviderHealth(ctx, Config.HealthCheckInterval)
+		})
+	}
+
+	// scheduled refresh of stale metadata.
+	if Config.RefreshSchedule != "" && Config.JobWorkers > 0 {
+		schedule, err := cron.Parse(Config.RefreshSchedule)
+		if err != nil {
+			return err
+		}
+		params, _ := json.Marshal(map[string]any{"max_age": datatypes.Duration(Config.RefreshMaxAge)})
+		app.Go(func(ctx context.Context) {
+			app.RunSchedule(ctx, schedule, engine.JobRefreshStale, params)
+		})
+	}
+
+	// scheduled validation of stored artwork.
+	if Config.ArtworkSchedule != "" && Config.JobWorkers > 0 {
+		schedule, err := cron.Parse(Config.ArtworkSchedule)
+		if err != nil {
+			return err
+		}
+		app.Go(func(ctx context.Context) {
+			app.RunSchedule(ctx, schedule, engine.JobValidateArtwork, nil)
+		})
+	}
+
+	tlsConfig, challenge, err := TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	// listen before starting any servers to fail fast.
+	httpLis, err := Listen()
+	if err != nil {
+		return err
+	}
+
+	var grpcServer *grpc.Server
+	if Config.GRPCPort != "" /* gRPC enabled */ {
+		lis, err := net.Listen("tcp", net.JoinHostPort(Config.Bind, Config.GRPCPort))
+		if err != nil {
+			httpLis.Close()
+			return err
+		}
+		var opts []grpc.ServerOption
+		if tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		grpcServer = rpc.New(app, Validator(), opts...)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				slog.Error("gRPC server", slog.Any("error", err))
+			}
+		}()
+	}
+
+	srv := &http.Server{
+		Handler:     NewRouter(app, ListenerOptions(httpLis)...),
+		TLSConfig:   tlsConfig,
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			// certificates are provided by the TLS config.
+			errCh <- srv.ServeTLS(httpLis, "", "")
+			return
+		}
+		errCh <- srv.Serve(httpLis)
+	}()
+
+	// ACME HTTP challenges and redirection to HTTPS.
+	if challenge != nil {
+		challengeSrv := &http.Server{
+			Addr:    net.JoinHostPort(Config.Bind, Config.ACMEHTTPPort),
+			Handler: challenge,
+		}
+		defer challengeSrv.Close()
+		go func() {
+			if err := challengeSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("ACME challenge server", slog.Any("error", err))
+			}
+		}()
+	}
+
+	select {
+	case err = <-errCh:
+		// server failed to start or exited unexpectedly.
+	case <-ctx.Done():
+		slog.Info("shutting down", slog.Duration("timeout", Config.ShutdownTimeout))
+		err = shutdownServers(srv, grpcServer, cancel)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+	// requests are drained, the background workers are stopped and the
+	// stores of the engine are closed by the deferred close.
+	return err
+}
+
+func shutdownServers(srv *http.Server, grpcServer *grpc.Server, cancel context.CancelFunc) error {
+	ctx, stop := context.WithTimeout(context.Background(), Config.ShutdownTimeout)
+	defer stop()
+
+	if grpcServer != nil {
+		done := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(done)
+		}()
+		defer func() {
+			select {
+			case <-done:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+		}()
+	}
+
+	err := srv.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		// grace period is over, cancel outstanding requests.
+		cancel()
+		slog.Warn("shutdown timed out, canceling in-flight requests")
+		err = srv.Close()
+	}
+	return err
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+func TestServe_ClosesEngineOnError(t *testing.T) {
+	withConfig(t)
+	Config.JobWorkers = 1
+	Config.RefreshSchedule = "invalid"
+
+	db, err := database.Open(&database.Config{DSN: "file:serve_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	app := engine.New(engine.WithDB(db))
+	require.NoError(t, app.DBAutoMigrate(true))
+
+	// fails after the job workers are started.
+	assert.Error(t, Serve(context.Background(), app))
+	_, err = app.DBVersion()
+	assert.Error(t, err, "engine is not closed")
+}
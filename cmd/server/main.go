@@ -1,11 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net"
-	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/metatube-community/metatube-sdk-go/cmd"
 	"github.com/metatube-community/metatube-sdk-go/engine"
@@ -18,18 +19,19 @@ func showVersionAndExit() {
 }
 
 func main() {
+	if err := cmd.Parse(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+
 	if _, isSet := os.LookupEnv("VERSION"); cmd.Config.VersionFlag &&
 		!isSet /* NOTE: ignore this flag if ENV contains VERSION variable. */ {
 		showVersionAndExit()
 	}
 
-	var (
-		addr = net.JoinHostPort(
-			cmd.Config.Bind,
-			cmd.Config.Port)
-		router = cmd.Router(engine.DefaultEngineName)
-	)
-	if err := http.ListenAndServe(addr, router); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.Serve(ctx, cmd.Engine(engine.DefaultEngineName)); err != nil {
 		log.Fatal(err)
 	}
 }
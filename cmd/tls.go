@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig returns the TLS config of the servers, or nil if TLS is
+// disabled. The challenge handler is not nil if ACME certificates are
+// enabled with HTTP challenges.
+func TLSConfig() (config *tls.Config, challenge http.Handler, err error) {
+	hasCert := Config.TLSCertFile != "" || Config.TLSKeyFile != ""
+	hasACME := Config.ACMEDomains != ""
+	switch {
+	case hasCert && hasACME:
+		return nil, nil, errors.New("TLS certificate and ACME are mutually exclusive")
+	case hasCert:
+		if Config.TLSCertFile == "" || Config.TLSKeyFile == "" {
+			return nil, nil, errors.New("both TLS certificate and key files are required")
+		}
+		cert, err := tls.LoadX509KeyPair(Config.TLSCertFile, Config.TLSKeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}, nil, nil
+	case hasACME:
+		var domains []string
+		for _, domain := range strings.Split(Config.ACMEDomains, ",") {
+			if domain = strings.TrimSpace(domain); domain != "" {
+				domains = append(domains, domain)
+			}
+		}
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Cache:      autocert.DirCache(Config.ACMECacheDir),
+			Email:      Config.ACMEEmail,
+		}
+		config = m.TLSConfig()
+		config.MinVersion = tls.VersionTLS12
+		if Config.ACMEHTTPPort != "" {
+			// redirects other HTTP requests to HTTPS.
+			challenge = m.HTTPHandler(http.HandlerFunc(redirectHTTPS))
+		}
+		return config, challenge, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// redirectHTTPS redirects the request to the HTTPS port of the server.
+func redirectHTTPS(w http.ResponseWriter, r *http.Request) {
+	host := (&url.URL{Host: r.Host}).Hostname()
+	if Config.Port != "443" {
+		host = net.JoinHostPort(host, Config.Port)
+	} else if strings.Contains(host, ":") /* IPv6 */ {
+		host = "[" + host + "]"
+	}
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
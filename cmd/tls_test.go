@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withConfig restores Config after the test.
+func withConfig(t *testing.T) {
+	saved := *Config
+	t.Cleanup(func() { *Config = saved })
+}
+
+// writeCert writes a self-signed certificate and its key to dir.
+func writeCert(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return
+}
+
+func TestTLSConfig(t *testing.T) {
+	withConfig(t)
+	certFile, keyFile := writeCert(t, t.TempDir())
+
+	for _, unit := range []struct {
+		name      string
+		cert, key string
+		domains   string
+		httpPort  string
+		enabled   bool
+		challenge bool
+		err       bool
+	}{
+		{name: "disabled"},
+		{name: "cert", cert: certFile, key: keyFile, enabled: true},
+		{name: "cert without key", cert: certFile, err: true},
+		{name: "key without cert", key: keyFile, err: true},
+		{name: "missing cert", cert: certFile + ".missing", key: keyFile, err: true},
+		{name: "cert and acme", cert: certFile, key: keyFile, domains: "example.com", err: true},
+		{name: "acme", domains: "example.com, www.example.com", enabled: true},
+		{name: "acme with http", domains: "example.com", httpPort: "80", enabled: true, challenge: true},
+	} {
+		t.Run(unit.name, func(t *testing.T) {
+			Config.TLSCertFile = unit.cert
+			Config.TLSKeyFile = unit.key
+			Config.ACMEDomains = unit.domains
+			Config.ACMECacheDir = t.TempDir()
+			Config.ACMEHTTPPort = unit.httpPort
+
+			config, challenge, err := TLSConfig()
+			if unit.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if !unit.enabled {
+				assert.Nil(t, config)
+				assert.Nil(t, challenge)
+				return
+			}
+			require.NotNil(t, config)
+			assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+			if unit.cert != "" {
+				assert.Len(t, config.Certificates, 1)
+			} else {
+				assert.NotNil(t, config.GetCertificate)
+			}
+			assert.Equal(t, unit.challenge, challenge != nil)
+		})
+	}
+}
+
+func TestRedirectHTTPS(t *testing.T) {
+	withConfig(t)
+
+	for _, unit := range []struct {
+		port, target, location string
+	}{
+		{"443", "http://example.com/v1/movies?q=1", "https://example.com/v1/movies?q=1"},
+		{"443", "http://example.com:80/", "https://example.com/"},
+		{"8443", "http://example.com:8080/v1/actors", "https://example.com:8443/v1/actors"},
+		{"8443", "http://[::1]/", "https://[::1]:8443/"},
+		{"443", "http://[::1]:80/", "https://[::1]/"},
+	} {
+		Config.Port = unit.port
+		w := httptest.NewRecorder()
+		redirectHTTPS(w, httptest.NewRequest(http.MethodGet, unit.target, nil))
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, unit.location, w.Header().Get("Location"), unit.target)
+	}
+}
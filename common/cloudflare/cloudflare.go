@@ -0,0 +1,140 @@
+package cloudflare
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Clearance is the cookies and User-Agent that passed a challenge,
+// Cloudflare binds the cf_clearance cookie to the User-Agent.
+type Clearance struct {
+	Cookies   []*http.Cookie
+	UserAgent string
+}
+
+var (
+	mu         sync.RWMutex
+	solver     *Solver
+	clearances = make(map[string]*Clearance)
+)
+
+// SetSolver sets the solver used for all challenged requests,
+// nil disables solving.
+func SetSolver(s *Solver) {
+	mu.Lock()
+	solver = s
+	mu.Unlock()
+}
+
+// SetClearance sets the clearance of the host, it's either
+// supplied by users or obtained by the solver.
+func SetClearance(host string, c *Clearance) {
+	mu.Lock()
+	defer mu.Unlock()
+	if c == nil {
+		delete(clearances, strings.ToLower(host))
+		return
+	}
+	clearances[strings.ToLower(host)] = c
+}
+
+func getSolver() *Solver {
+	mu.RLock()
+	defer mu.RUnlock()
+	return solver
+}
+
+func getClearance(host string) *Clearance {
+	mu.RLock()
+	defer mu.RUnlock()
+	return clearances[strings.ToLower(host)]
+}
+
+// IsChallenge reports whether the response is a Cloudflare challenge.
+func IsChallenge(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden &&
+		resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	if resp.Header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+	return strings.EqualFold(resp.Header.Get("Server"), "cloudflare") &&
+		strings.Contains(resp.Header.Get("Content-Type"), "text/html")
+}
+
+// Transport applies clearances to requests, and retries challenged
+// GET requests through the solver, if any.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport returns a Transport wrapping base.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if c := getClearance(req.URL.Host); c != nil {
+		req = req.Clone(req.Context())
+		applyClearance(req, c)
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || !IsChallenge(resp) {
+		return resp, err
+	}
+	s := getSolver()
+	if s == nil {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	solution, err := s.Solve(req.Context(), req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	SetClearance(req.URL.Host, &Clearance{
+		Cookies:   solution.httpCookies(),
+		UserAgent: solution.UserAgent,
+	})
+	return solution.response(req), nil
+}
+
+func applyClearance(req *http.Request, c *Clearance) {
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	for _, cookie := range c.Cookies {
+		// replace cookies of the same name.
+		var kept []string
+		for _, v := range req.Header.Values("Cookie") {
+			for _, pair := range strings.Split(v, ";") {
+				if name, _, _ := strings.Cut(strings.TrimSpace(pair), "="); name != cookie.Name && name != "" {
+					kept = append(kept, strings.TrimSpace(pair))
+				}
+			}
+		}
+		req.Header.Set("Cookie", strings.Join(append(kept, cookie.Name+"="+cookie.Value), "; "))
+	}
+}
+
+func newResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
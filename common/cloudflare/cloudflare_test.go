@@ -0,0 +1,73 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	const ua = "Mozilla/5.0 Solver"
+
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("cf_clearance"); err == nil && c.Value == "ok" && r.UserAgent() == ua {
+			_, _ = io.WriteString(w, "content")
+			return
+		}
+		w.Header().Set("Cf-Mitigated", "challenge")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer site.Close()
+
+	solver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1", r.URL.Path)
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "request.get", req["cmd"])
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "ok",
+			"solution": map[string]any{
+				"url":       req["url"],
+				"status":    200,
+				"response":  "solved",
+				"userAgent": ua,
+				"cookies":   []map[string]any{{"name": "cf_clearance", "value": "ok"}},
+			},
+		})
+	}))
+	defer solver.Close()
+
+	c := &http.Client{Transport: NewTransport(nil)}
+	get := func() (int, string) {
+		resp, err := c.Get(site.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	// no solver, challenge is returned as is.
+	code, _ := get()
+	assert.Equal(t, http.StatusForbidden, code)
+
+	SetSolver(NewSolver(solver.URL, time.Second))
+	defer SetSolver(nil)
+	code, body := get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "solved", body)
+
+	// clearance is reused for later requests.
+	code, body = get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "content", body)
+
+	u, _ := url.Parse(site.URL)
+	SetClearance(u.Host, nil)
+}
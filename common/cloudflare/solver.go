@@ -0,0 +1,119 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// DefaultSolveTimeout is the default timeout of solving a challenge.
+const DefaultSolveTimeout = time.Minute
+
+// Solver solves challenges with a FlareSolverr instance.
+type Solver struct {
+	endpoint string
+	timeout  time.Duration
+	client   *http.Client
+}
+
+// NewSolver returns a Solver using the FlareSolverr instance at
+// endpoint, e.g. http://localhost:8191.
+func NewSolver(endpoint string, timeout time.Duration) *Solver {
+	if timeout <= 0 {
+		timeout = DefaultSolveTimeout
+	}
+	client := cleanhttp.DefaultPooledClient()
+	// leave some time for FlareSolverr to respond.
+	client.Timeout = timeout + 10*time.Second
+	return &Solver{
+		endpoint: strings.TrimRight(endpoint, "/") + "/v1",
+		timeout:  timeout,
+		client:   client,
+	}
+}
+
+// Solution is the result of a solved request.
+type Solution struct {
+	URL       string            `json:"url"`
+	Status    int               `json:"status"`
+	Headers   map[string]string `json:"headers"`
+	Response  string            `json:"response"`
+	UserAgent string            `json:"userAgent"`
+	Cookies   []struct {
+		Name   string `json:"name"`
+		Value  string `json:"value"`
+		Domain string `json:"domain"`
+		Path   string `json:"path"`
+	} `json:"cookies"`
+}
+
+func (s *Solution) httpCookies() []*http.Cookie {
+	cookies := make([]*http.Cookie, 0, len(s.Cookies))
+	for _, c := range s.Cookies {
+		cookies = append(cookies, &http.Cookie{
+			Name:   c.Name,
+			Value:  c.Value,
+			Domain: c.Domain,
+			Path:   c.Path,
+		})
+	}
+	return cookies
+}
+
+func (s *Solution) response(req *http.Request) *http.Response {
+	header := make(http.Header, len(s.Headers))
+	for k, v := range s.Headers {
+		switch strings.ToLower(k) {
+		// the body is already decoded.
+		case "content-encoding", "content-length", "transfer-encoding":
+			continue
+		}
+		header.Set(k, v)
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	status := s.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return newResponse(req, status, header, s.Response)
+}
+
+// Solve requests the URL through FlareSolverr.
+func (s *Solver) Solve(ctx context.Context, url string) (*Solution, error) {
+	body, _ := json.Marshal(map[string]any{
+		"cmd":        "request.get",
+		"url":        url,
+		"maxTimeout": s.timeout.Milliseconds(),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("flaresolverr: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result := &struct {
+		Status   string    `json:"status"`
+		Message  string    `json:"message"`
+		Solution *Solution `json:"solution"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("flaresolverr: %w", err)
+	}
+	if result.Status != "ok" || result.Solution == nil {
+		return nil, fmt.Errorf("flaresolverr: %s", result.Message)
+	}
+	return result.Solution, nil
+}
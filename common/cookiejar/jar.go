@@ -0,0 +1,101 @@
+package cookiejar
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var _ http.CookieJar = (*Jar)(nil)
+
+// Jar is an in-memory cookie jar that remembers what has been set,
+// so that its cookies can be exported and persisted.
+type Jar struct {
+	jar *cookiejar.Jar
+
+	mu sync.Mutex
+	// scheme://host -> name -> cookie
+	entries map[string]map[string]*http.Cookie
+}
+
+// New returns an empty Jar.
+func New() *Jar {
+	jar, _ := cookiejar.New(nil) // never fails.
+	return &Jar{
+		jar:     jar,
+		entries: make(map[string]map[string]*http.Cookie),
+	}
+}
+
+func (j *Jar) Cookies(u *url.URL) []*http.Cookie { return j.jar.Cookies(u) }
+
+func (j *Jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	key := (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, ok := j.entries[key]
+	if !ok {
+		entry = make(map[string]*http.Cookie)
+		j.entries[key] = entry
+	}
+	now := time.Now()
+	for _, c := range cookies {
+		if expired(c, now) {
+			delete(entry, c.Name)
+			continue
+		}
+		c := *c // copy
+		if c.MaxAge > 0 {
+			// persist as absolute time.
+			c.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+			c.MaxAge = 0
+		}
+		if c.Path == "" {
+			c.Path = "/"
+		}
+		c.Raw, c.Unparsed = "", nil
+		entry[c.Name] = &c
+	}
+	if len(entry) == 0 {
+		delete(j.entries, key)
+	}
+}
+
+// Export returns all unexpired cookies keyed by the URLs they were
+// set for.
+func (j *Jar) Export() map[string][]*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	out := make(map[string][]*http.Cookie, len(j.entries))
+	for key, entry := range j.entries {
+		for _, c := range entry {
+			if expired(c, now) {
+				continue
+			}
+			c := *c
+			out[key] = append(out[key], &c)
+		}
+	}
+	return out
+}
+
+// Import sets cookies previously returned by Export.
+func (j *Jar) Import(cookies map[string][]*http.Cookie) error {
+	for rawURL, cs := range cookies {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return err
+		}
+		j.SetCookies(u, cs)
+	}
+	return nil
+}
+
+func expired(c *http.Cookie, now time.Time) bool {
+	return c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(now))
+}
@@ -0,0 +1,43 @@
+package cookiejar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJar(t *testing.T) {
+	u, _ := url.Parse("https://www.example.com/path/page")
+
+	j := New()
+	j.SetCookies(u, []*http.Cookie{
+		{Name: "age_check_done", Value: "1"},
+		{Name: "session", Value: "abc", MaxAge: 3600},
+		{Name: "stale", Value: "x", Expires: time.Now().Add(-time.Hour)},
+	})
+	exported := j.Export()
+	require.Len(t, exported["https://www.example.com"], 2)
+
+	// round trip through JSON, as it's persisted.
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	cookies := map[string][]*http.Cookie{}
+	require.NoError(t, json.Unmarshal(data, &cookies))
+
+	k := New()
+	require.NoError(t, k.Import(cookies))
+	names := map[string]string{}
+	for _, c := range k.Cookies(u) {
+		names[c.Name] = c.Value
+	}
+	assert.Equal(t, map[string]string{"age_check_done": "1", "session": "abc"}, names)
+
+	// deleted cookies are dropped.
+	k.SetCookies(u, []*http.Cookie{{Name: "session", MaxAge: -1}})
+	assert.Len(t, k.Export()["https://www.example.com"], 1)
+}
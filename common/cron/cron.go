@@ -0,0 +1,177 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the next activation time after the given time.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// Every is a fixed interval schedule, e.g. "@every 6h".
+type Every time.Duration
+
+func (d Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// spec is a schedule of the standard five cron fields, each field
+// is a bit set of the allowed values.
+type spec struct {
+	minute, hour, dom, month, dow uint64
+	// day-of-month and day-of-week are ORed if both are restricted.
+	domStar, dowStar bool
+}
+
+type bounds struct{ min, max int }
+
+var fieldBounds = [5]bounds{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a schedule of five cron fields (minute, hour, day of
+// month, month and day of week), a descriptor like "@daily", or
+// "@every <duration>".
+func Parse(s string) (Schedule, error) {
+	s = strings.TrimSpace(s)
+	if d, ok := strings.CutPrefix(s, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, err
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid interval: %s", d)
+		}
+		return Every(interval), nil
+	}
+	if v, ok := descriptors[s]; ok {
+		s = v
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("expected %d fields: %q", len(fieldBounds), s)
+	}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseField(field, fieldBounds[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", field, err)
+		}
+		sets[i] = set
+	}
+	// 7 is also Sunday.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+	return &spec{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+// parseField parses a comma-separated list of "*", "n" or "n-m",
+// each optionally followed by "/step".
+func parseField(field string, b bounds) (set uint64, err error) {
+	for _, expr := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(expr, "/")
+		lo, hi := b.min, b.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			l, h, _ := strings.Cut(rng, "-")
+			if lo, err = strconv.Atoi(l); err != nil {
+				return 0, err
+			}
+			if hi, err = strconv.Atoi(h); err != nil {
+				return 0, err
+			}
+		default:
+			if lo, err = strconv.Atoi(rng); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				hi = b.max
+			}
+		}
+		n := 1
+		if hasStep {
+			if n, err = strconv.Atoi(step); err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step: %s", step)
+			}
+		}
+		// day of week also accepts 7 as Sunday.
+		max := b.max
+		if b == fieldBounds[4] {
+			max = 7
+		}
+		if lo < b.min || hi > max || lo > hi {
+			return 0, fmt.Errorf("out of range: %s", rng)
+		}
+		for i := lo; i <= hi; i += n {
+			set |= 1 << i
+		}
+	}
+	return
+}
+
+func has(set uint64, v int) bool { return set&(1<<v) != 0 }
+
+// Next returns the first matched minute after t, or the zero time if
+// there is none within five years, e.g. for "0 0 30 2 *".
+func (s *spec) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *spec) matchDay(t time.Time) bool {
+	dom, dow := has(s.dom, t.Day()), has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
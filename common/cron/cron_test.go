@@ -0,0 +1,37 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	from := time.Date(2024, 2, 28, 12, 30, 15, 0, time.UTC)
+	for _, unit := range []struct {
+		spec string
+		want time.Time
+	}{
+		{"@daily", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 2, 28, 13, 0, 0, 0, time.UTC)},
+		{"@every 6h", from.Add(6 * time.Hour)},
+		{"*/15 * * * *", time.Date(2024, 2, 28, 12, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 2, 29, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 1-5", time.Date(2024, 2, 29, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", time.Date(2024, 3, 3, 3, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		s, err := Parse(unit.spec)
+		require.NoError(t, err, unit.spec)
+		assert.Equal(t, unit.want, s.Next(from), unit.spec)
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@every -1h"} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,76 @@
+// Package edition detects edition indicators of movies, e.g. 4K, VR,
+// remastered and compilation releases, from titles and genres.
+package edition
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Edition flags, in the order they are reported.
+const (
+	UHD         = "4k"
+	VR          = "vr"
+	Remaster    = "remaster"
+	Compilation = "compilation"
+)
+
+var patterns = []struct {
+	flag string
+	re   *regexp.Regexp
+}{
+	{UHD, regexp.MustCompile(`(?i)(^|[^a-z0-9])([48]k|uhd|2160p)([^a-z0-9]|$)`)},
+	{VR, regexp.MustCompile(`(?i)(^|[^a-z0-9])vr([^a-z0-9]|$)|VR専用`)},
+	{Remaster, regexp.MustCompile(`(?i)リマスター|remaster|復刻`)},
+	{Compilation, regexp.MustCompile(`(?i)総集編|ベスト|コンピレーション|(^|[^a-z])best([^a-z]|$)`)},
+}
+
+// Detect returns the edition flags found in any of the texts,
+// e.g. the title and genres of a movie, nil if none is found.
+func Detect(texts ...string) (flags []string) {
+	for _, p := range patterns {
+		for _, text := range texts {
+			if p.re.MatchString(text) {
+				flags = append(flags, p.flag)
+				break
+			}
+		}
+	}
+	return
+}
+
+var (
+	// e.g. 8時間, 4時間SP, 10 hours.
+	hoursRe = regexp.MustCompile(`(?i)(\d+)\s*(時間|hours?)`)
+	// e.g. 30人, 50名.
+	castRe = regexp.MustCompile(`(\d+)\s*[人名]`)
+	// e.g. 傑作選, 完全保存版.
+	compilationRe = regexp.MustCompile(`傑作選|厳選|完全保存版|全作品|全タイトル|全\d+作品`)
+)
+
+// Compilation thresholds of the heuristic.
+const (
+	compilationHours   = 4
+	compilationCast    = 10
+	compilationRuntime = 240
+	compilationActors  = 5
+)
+
+// LooksLikeCompilation reports whether the movie looks like a compilation
+// without explicit tags, i.e. very long titles with many actresses, or
+// titles announcing many hours or cast members. Zero runtime or actors
+// means unknown.
+func LooksLikeCompilation(title string, runtime, actors int) bool {
+	if ss := hoursRe.FindStringSubmatch(title); len(ss) > 0 {
+		if n, _ := strconv.Atoi(ss[1]); n >= compilationHours {
+			return true
+		}
+	}
+	if ss := castRe.FindStringSubmatch(title); len(ss) > 0 {
+		if n, _ := strconv.Atoi(ss[1]); n >= compilationCast {
+			return true
+		}
+	}
+	return compilationRe.MatchString(title) ||
+		runtime >= compilationRuntime && actors >= compilationActors
+}
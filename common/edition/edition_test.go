@@ -0,0 +1,45 @@
+package edition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	for _, unit := range []struct {
+		texts []string
+		want  []string
+	}{
+		{[]string{"新人 NO.1 STYLE"}, nil},
+		{[]string{"【4K】新人 NO.1 STYLE"}, []string{UHD}},
+		{[]string{"新人", "4K", "ハイビジョン"}, []string{UHD}},
+		{[]string{"【VR】長尺 4K映像"}, []string{UHD, VR}},
+		{[]string{"SIVR-123"}, nil},
+		{[]string{"高画質リマスター版"}, []string{Remaster}},
+		{[]string{"三上悠亜 BEST 8時間"}, []string{Compilation}},
+		{[]string{"タイトル", "ベスト・総集編"}, []string{Compilation}},
+		{[]string{"The Bestseller"}, nil},
+	} {
+		assert.Equal(t, unit.want, Detect(unit.texts...), unit.texts)
+	}
+}
+
+func TestLooksLikeCompilation(t *testing.T) {
+	for _, unit := range []struct {
+		title           string
+		runtime, actors int
+		want            bool
+	}{
+		{"新人デビュー", 120, 1, false},
+		{"人気女優8時間スペシャル", 0, 0, true},
+		{"2時間ドラマ", 120, 2, false},
+		{"美少女30人連続", 0, 0, true},
+		{"3人の秘密", 0, 0, false},
+		{"S1 傑作選", 0, 0, true},
+		{"スペシャル", 480, 12, true},
+		{"スペシャル", 480, 1, false},
+	} {
+		assert.Equal(t, unit.want, LooksLikeCompilation(unit.title, unit.runtime, unit.actors), unit.title)
+	}
+}
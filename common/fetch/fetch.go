@@ -133,7 +133,7 @@ func (f *Fetcher) Request(method, url string, body io.Reader, opts ...Option) (r
 		option.apply(c)
 	}
 	// make HTTP request.
-	if resp, err = f.client.Do(req); err != nil {
+	if resp, err = f.client.Do(c.req); err != nil {
 		return
 	}
 	if c.RaiseForStatus && resp.StatusCode != http.StatusOK {
@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/metatube-community/metatube-sdk-go/common/random"
@@ -20,6 +21,10 @@ func WithRaiseForStatus(v bool) Option {
 	return func(c *Context) { c.RaiseForStatus = v }
 }
 
+func WithContext(ctx context.Context) Option {
+	return func(c *Context) { c.req = c.req.WithContext(ctx) }
+}
+
 func WithRequest(fn func(req *http.Request)) Option {
 	return func(c *Context) { fn(c.req) }
 }
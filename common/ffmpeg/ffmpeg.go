@@ -0,0 +1,109 @@
+// Package ffmpeg runs the ffmpeg executable to process videos.
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of animated previews.
+const (
+	GIF  = "gif"
+	WebP = "webp"
+)
+
+// PreviewOptions are options of animated previews.
+type PreviewOptions struct {
+	// Format is either GIF or WebP.
+	Format string
+	// Frames is the max number of sampled frames.
+	Frames int
+	// Interval is the interval between sampled frames.
+	Interval time.Duration
+	// Width is the width of frames, the ratio is kept.
+	Width int
+	// FPS is the frame rate of the preview.
+	FPS int
+}
+
+// DefaultPreviewOptions samples a frame every two seconds of the first
+// 24 seconds, and plays them at 4 frames per second.
+var DefaultPreviewOptions = PreviewOptions{
+	Format:   WebP,
+	Frames:   12,
+	Interval: 2 * time.Second,
+	Width:    320,
+	FPS:      4,
+}
+
+// FFmpeg runs an ffmpeg executable.
+type FFmpeg struct {
+	path string
+}
+
+// New looks up the ffmpeg executable of the path, empty to find
+// it in PATH.
+func New(path string) (*FFmpeg, error) {
+	if path == "" {
+		path = "ffmpeg"
+	}
+	path, err := exec.LookPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FFmpeg{path: path}, nil
+}
+
+// Preview samples frames of the input video, a file path or URL, and
+// writes them as an animated image to the output path.
+func (f *FFmpeg) Preview(ctx context.Context, input, output string, opts PreviewOptions) error {
+	args, err := previewArgs(input, output, opts)
+	if err != nil {
+		return err
+	}
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, f.path, args...)
+	cmd.Stderr = stderr
+	if err = cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("ffmpeg: %w: %s", err, msg)
+		}
+		return fmt.Errorf("ffmpeg: %w", err)
+	}
+	return nil
+}
+
+func previewArgs(input, output string, opts PreviewOptions) ([]string, error) {
+	if opts.Frames <= 0 || opts.Interval <= 0 || opts.Width <= 0 || opts.FPS <= 0 {
+		return nil, fmt.Errorf("ffmpeg: invalid preview options: %+v", opts)
+	}
+	filter := fmt.Sprintf("fps=1/%s,scale=%d:-2:flags=lanczos,setpts=N/%d/TB",
+		strconv.FormatFloat(opts.Interval.Seconds(), 'f', -1, 64), opts.Width, opts.FPS)
+
+	var codec []string
+	switch opts.Format {
+	case GIF:
+		// generate the palette from the frames for better colors.
+		filter += ",split[a][b];[a]palettegen[p];[b][p]paletteuse"
+	case WebP:
+		codec = []string{"-c:v", "libwebp", "-quality", "75"}
+	default:
+		return nil, fmt.Errorf("ffmpeg: unsupported preview format: %s", opts.Format)
+	}
+
+	args := []string{
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", input,
+		"-an",
+		"-vf", filter,
+		"-frames:v", strconv.Itoa(opts.Frames),
+		"-r", strconv.Itoa(opts.FPS),
+	}
+	args = append(args, codec...)
+	return append(args, "-loop", "0", "-f", opts.Format, output), nil
+}
@@ -0,0 +1,40 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewArgs(t *testing.T) {
+	args, err := previewArgs("in.mp4", "out.webp", DefaultPreviewOptions)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", "in.mp4",
+		"-an",
+		"-vf", "fps=1/2,scale=320:-2:flags=lanczos,setpts=N/4/TB",
+		"-frames:v", "12",
+		"-r", "4",
+		"-c:v", "libwebp", "-quality", "75",
+		"-loop", "0", "-f", "webp", "out.webp",
+	}, args)
+
+	args, err = previewArgs("in.mp4", "out.gif", PreviewOptions{
+		Format:   GIF,
+		Frames:   6,
+		Interval: 1500 * time.Millisecond,
+		Width:    240,
+		FPS:      2,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, args, "fps=1/1.5,scale=240:-2:flags=lanczos,setpts=N/2/TB,split[a][b];[a]palettegen[p];[b][p]paletteuse")
+	assert.NotContains(t, args, "libwebp")
+
+	_, err = previewArgs("in.mp4", "out.png", PreviewOptions{Format: "png", Frames: 1, Interval: time.Second, Width: 1, FPS: 1})
+	assert.Error(t, err)
+	_, err = previewArgs("in.mp4", "out.gif", PreviewOptions{Format: GIF})
+	assert.Error(t, err)
+}
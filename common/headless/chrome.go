@@ -0,0 +1,70 @@
+package headless
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// DefaultRenderTimeout is the default timeout of rendering a page.
+const DefaultRenderTimeout = 30 * time.Second
+
+var _ Renderer = (*Chrome)(nil)
+
+// Chrome renders pages with a local or remote Chrome browser.
+type Chrome struct {
+	timeout time.Duration
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// NewChrome returns a Chrome renderer. The browser is either a DevTools
+// websocket URL of a remote browser, e.g. ws://127.0.0.1:9222, or the
+// path of a local Chrome executable, empty to find it in PATH.
+func NewChrome(browser string, timeout time.Duration) *Chrome {
+	if timeout <= 0 {
+		timeout = DefaultRenderTimeout
+	}
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if strings.HasPrefix(browser, "ws://") || strings.HasPrefix(browser, "wss://") {
+		ctx, cancel = chromedp.NewRemoteAllocator(context.Background(), browser)
+	} else {
+		opts := append(chromedp.DefaultExecAllocatorOptions[:],
+			chromedp.Flag("blink-settings", "imagesEnabled=false"))
+		if browser != "" {
+			opts = append(opts, chromedp.ExecPath(browser))
+		}
+		ctx, cancel = chromedp.NewExecAllocator(context.Background(), opts...)
+	}
+	return &Chrome{
+		timeout: timeout,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Render opens the URL in a new tab and returns the rendered HTML.
+func (c *Chrome) Render(ctx context.Context, url string) (content string, err error) {
+	tab, closeTab := chromedp.NewContext(c.ctx)
+	defer closeTab()
+	tab, cancel := context.WithTimeout(tab, c.timeout)
+	defer cancel()
+	// cancel rendering along with the request.
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	err = chromedp.Run(tab,
+		chromedp.Navigate(url),
+		chromedp.WaitReady("body"),
+		chromedp.OuterHTML("html", &content),
+	)
+	return
+}
+
+// Close closes the browser.
+func (c *Chrome) Close() { c.cancel() }
@@ -0,0 +1,85 @@
+package headless
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+// Renderer renders JS-rendered pages into HTML.
+type Renderer interface {
+	Render(ctx context.Context, url string) (string, error)
+}
+
+var (
+	mu       sync.RWMutex
+	renderer Renderer
+)
+
+// SetRenderer sets the renderer for all opted-in requests,
+// nil disables headless rendering.
+func SetRenderer(r Renderer) {
+	mu.Lock()
+	renderer = r
+	mu.Unlock()
+}
+
+// Enabled reports whether a renderer is set.
+func Enabled() bool { return getRenderer() != nil }
+
+func getRenderer() Renderer {
+	mu.RLock()
+	defer mu.RUnlock()
+	return renderer
+}
+
+// Transport renders GET requests matching any of the patterns with
+// the renderer, other requests, or all requests if no renderer is
+// set, go through the base transport.
+type Transport struct {
+	Base     http.RoundTripper
+	Patterns []*regexp.Regexp
+}
+
+// NewTransport returns a Transport wrapping base.
+func NewTransport(base http.RoundTripper, patterns ...*regexp.Regexp) *Transport {
+	return &Transport{Base: base, Patterns: patterns}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	r := getRenderer()
+	if r == nil || req.Method != http.MethodGet || !t.match(req.URL.String()) {
+		return base.RoundTrip(req)
+	}
+	content, err := r.Render(req.Context(), req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        http.StatusText(http.StatusOK),
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewBufferString(content)),
+		ContentLength: int64(len(content)),
+		Request:       req,
+	}, nil
+}
+
+func (t *Transport) match(url string) bool {
+	for _, p := range t.Patterns {
+		if p.MatchString(url) {
+			return true
+		}
+	}
+	return false
+}
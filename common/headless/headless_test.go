@@ -0,0 +1,44 @@
+package headless
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type renderFunc func(ctx context.Context, url string) (string, error)
+
+func (f renderFunc) Render(ctx context.Context, url string) (string, error) { return f(ctx, url) }
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "static")
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: NewTransport(nil, regexp.MustCompile(`/app/`))}
+	get := func(path string) string {
+		resp, err := c.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return string(data)
+	}
+
+	// disabled by default.
+	assert.False(t, Enabled())
+	assert.Equal(t, "static", get("/app/1"))
+
+	SetRenderer(renderFunc(func(_ context.Context, url string) (string, error) {
+		return "rendered " + url, nil
+	}))
+	defer SetRenderer(nil)
+	assert.Equal(t, "rendered "+srv.URL+"/app/1", get("/app/1"))
+	assert.Equal(t, "static", get("/legacy/1"))
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// RequestIDKey is the attribute key of request ID.
+const RequestIDKey = "request_id"
+
+// RequestIDHeader is the HTTP header of request ID.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// Config is the logger configuration.
+type Config struct {
+	// Minimum level, one of debug, info, warn and error.
+	Level string
+
+	// Output format, either text or json.
+	Format string
+
+	// Output writer, os.Stderr is used if nil.
+	Output io.Writer
+}
+
+// New returns a structured logger with the given config.
+func New(cfg *Config) (*slog.Logger, error) {
+	if cfg == nil /* init if nil */ {
+		cfg = new(Config)
+	}
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	w := cfg.Output
+	if w == nil {
+		w = os.Stderr
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format: %s", cfg.Format)
+	}
+}
+
+// Setup creates a logger with the given config and sets it as default.
+func Setup(cfg *Config) error {
+	l, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(l)
+	return nil
+}
+
+// ParseLevel parses level from string, empty string means info.
+func ParseLevel(s string) (level slog.Level, err error) {
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err = level.UnmarshalText([]byte(s)); err != nil {
+		return level, fmt.Errorf("invalid log level: %s", s)
+	}
+	return
+}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, if any.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns l annotated with the request ID carried by ctx,
+// slog.Default() is used if l is nil.
+func FromContext(ctx context.Context, l *slog.Logger) *slog.Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	if id := RequestID(ctx); id != "" {
+		return l.With(RequestIDKey, id)
+	}
+	return l
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, unit := range []struct {
+		s     string
+		level slog.Level
+		ok    bool
+	}{
+		{"", slog.LevelInfo, true},
+		{"debug", slog.LevelDebug, true},
+		{"WARN", slog.LevelWarn, true},
+		{"error", slog.LevelError, true},
+		{"verbose", 0, false},
+	} {
+		level, err := ParseLevel(unit.s)
+		if !unit.ok {
+			assert.Error(t, err, unit.s)
+			continue
+		}
+		if assert.NoError(t, err, unit.s) {
+			assert.Equal(t, unit.level, level, unit.s)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, RequestID(ctx))
+
+	id := NewRequestID()
+	assert.Len(t, id, 16)
+	assert.NotEqual(t, id, NewRequestID())
+	assert.Equal(t, id, RequestID(WithRequestID(ctx, id)))
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc", r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	l, err := New(&Config{Level: "debug", Format: "json", Output: buf})
+	require.NoError(t, err)
+
+	req, _ := http.NewRequestWithContext(WithRequestID(context.Background(), "abc"), http.MethodGet, srv.URL, nil)
+	resp, err := (&Transport{Logger: l}).RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	out := buf.String()
+	assert.Contains(t, out, `"request_id":"abc"`)
+	assert.Contains(t, out, `"status":418`)
+	assert.Contains(t, out, `"url":"`+srv.URL)
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Transport wraps an http.RoundTripper and logs every upstream
+// request with its URL, status and duration, the request ID carried
+// by the request context, if any, is sent along.
+type Transport struct {
+	// Base transport, http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// Logger to use, slog.Default() is used if nil.
+	Logger *slog.Logger
+}
+
+// NewTransport returns a logging transport wrapping base.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if id := RequestID(req.Context()); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(RequestIDHeader, id)
+	}
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+
+	l := FromContext(req.Context(), t.Logger)
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		l.Warn("upstream request failed", append(attrs, slog.Any("error", err))...)
+		return resp, err
+	}
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		l.Warn("upstream request", attrs...)
+	} else {
+		l.Debug("upstream request", attrs...)
+	}
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package number
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Series is a known amateur series, its numbers are often written
+// with or without the numeric label prefix, e.g. GANA-1350 and
+// 200GANA-1350 are the same movie.
+type Series struct {
+	// Label is the numeric label prefix, e.g. 200.
+	Label string
+	// Code is the series code, e.g. GANA.
+	Code string
+	// Maker is the maker of the series.
+	Maker string
+	// Provider is the name of the preferred provider.
+	Provider string
+}
+
+// AmateurSeries is the catalog of well-known amateur series.
+var AmateurSeries = []*Series{
+	{Label: "200", Code: "GANA", Maker: "ナンパTV", Provider: "MGS"},
+	{Label: "259", Code: "LUXU", Maker: "ラグジュTV", Provider: "MGS"},
+	{Label: "300", Code: "MIUM", Maker: "プレステージプレミアム(PRESTIGE PREMIUM)", Provider: "MGS"},
+	{Label: "300", Code: "MAAN", Maker: "プレステージプレミアム(PRESTIGE PREMIUM)", Provider: "MGS"},
+	{Label: "300", Code: "NTK", Maker: "プレステージプレミアム(PRESTIGE PREMIUM)", Provider: "MGS"},
+	{Label: "261", Code: "ARA", Maker: "ARA", Provider: "MGS"},
+	{Label: "277", Code: "DCV", Maker: "ドキュメンTV", Provider: "MGS"},
+	{Label: "230", Code: "OREC", Maker: "俺の素人", Provider: "MGS"},
+	{Label: "229", Code: "SCUTE", Maker: "S-Cute", Provider: "MGS"},
+	{Label: "345", Code: "SIMM", Maker: "しろうとまんまん", Provider: "MGS"},
+	{Label: "332", Code: "NAMA", Maker: "ナマナマネット", Provider: "MGS"},
+	{Label: "348", Code: "NTR", Maker: "NTR.net", Provider: "MGS"},
+	{Label: "", Code: "SIRO", Maker: "シロウトTV", Provider: "MGS"},
+}
+
+var amateurRe = regexp.MustCompile(`^(?i)(\d*)([a-z]+)[-_]?(\d+)$`)
+
+// LookupSeries returns the amateur series of the number, if known.
+func LookupSeries(s string) (*Series, bool) {
+	m := amateurRe.FindStringSubmatch(strings.TrimSpace(s))
+	if len(m) != 4 {
+		return nil, false
+	}
+	for _, series := range AmateurSeries {
+		if strings.EqualFold(series.Code, m[2]) &&
+			(m[1] == "" || m[1] == series.Label) {
+			return series, true
+		}
+	}
+	return nil, false
+}
+
+// NormalizeAmateur returns the full form, i.e. with the label prefix, of
+// an amateur number, e.g. GANA-1350 -> 200GANA-1350. Other numbers are
+// returned as is.
+func NormalizeAmateur(s string) string {
+	series, ok := LookupSeries(s)
+	if !ok {
+		return s
+	}
+	m := amateurRe.FindStringSubmatch(strings.TrimSpace(s))
+	return series.Label + series.Code + "-" + m[3]
+}
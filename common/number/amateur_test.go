@@ -0,0 +1,30 @@
+package number
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupSeries(t *testing.T) {
+	for _, unit := range []struct {
+		orig     string
+		full     string
+		provider string
+	}{
+		{"GANA-1350", "200GANA-1350", "MGS"},
+		{"200gana1350", "200GANA-1350", "MGS"},
+		{"259LUXU-1234", "259LUXU-1234", "MGS"},
+		{"siro-4000", "SIRO-4000", "MGS"},
+		{"100GANA-1350", "100GANA-1350", ""},
+		{"ABP-030", "ABP-030", ""},
+	} {
+		series, ok := LookupSeries(unit.orig)
+		if unit.provider == "" {
+			assert.False(t, ok, unit.orig)
+		} else if assert.True(t, ok, unit.orig) {
+			assert.Equal(t, unit.provider, series.Provider)
+		}
+		assert.Equal(t, unit.full, NormalizeAmateur(unit.orig), unit.orig)
+	}
+}
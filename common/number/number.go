@@ -72,3 +72,14 @@ func RequireFaceDetection(s string) bool {
 	}
 	return false
 }
+
+// Normalize returns the comparable form of a number, it ignores case,
+// separators and zero paddings, e.g. abp00030 and ABP-030 -> ABP30.
+// Amateur numbers are expanded to the full form with label prefixes.
+func Normalize(s string) string {
+	s = strings.ToUpper(NormalizeAmateur(strings.TrimSpace(s)))
+	if ss := regexp.MustCompile(`^(\d*[A-Z]+)[-_]?0*(\d+)(.*)$`).FindStringSubmatch(s); len(ss) == 4 {
+		s = ss[1] + ss[2] + ss[3]
+	}
+	return strings.NewReplacer("-", "", "_", "").Replace(s)
+}
@@ -294,3 +294,20 @@ func TestRequireFaceDetection(t *testing.T) {
 		assert.Equal(t, unit.want, RequireFaceDetection(unit.orig), unit.orig)
 	}
 }
+
+func TestNormalize(t *testing.T) {
+	for _, unit := range []struct {
+		orig, want string
+	}{
+		{"ABP-030", "ABP30"},
+		{"abp00030", "ABP30"},
+		{"abp030", "ABP30"},
+		{"GANA-1350", "200GANA1350"},
+		{"200gana-1350", "200GANA1350"},
+		{"FC2-738573", "FC2738573"},
+		{"123456_789", "123456789"},
+		{"heyzo-1342", "HEYZO1342"},
+	} {
+		assert.Equal(t, unit.want, Normalize(unit.orig), unit.orig)
+	}
+}
@@ -0,0 +1,45 @@
+package number
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// trailing subtitle tags, e.g. -C, -UC and -ch.
+	subtitleTagRe = regexp.MustCompile(`(?i)([-_](?:c|uc|ch))+$`)
+	// explicit part suffixes, e.g. -cd1, _part2 and .disc3.
+	partSuffixRe = regexp.MustCompile(`(?i)[-_.\s](?:cd|dis[ck]|part|pt)[-_.\s]?(\d{1,2})$`)
+	// numeric part suffixes, e.g. ABP-030_1 and FC2-123456-2.
+	partDigitRe = regexp.MustCompile(`\d{3,}[-_]([1-9])$`)
+	// letter part suffixes, e.g. ABP-030-A and ABP-030B. C is not
+	// included as it's mostly a Chinese subtitle tag.
+	partLetterRe = regexp.MustCompile(`(?i)\d[-_]?([AB])$`)
+)
+
+// ParsePart returns the trimmed number and the 1-based part index of a
+// multi-part video filename, e.g. ABP-030-cd2.mp4 returns ABP-030 and 2.
+// The part is 0 if the filename has no part suffix.
+func ParsePart(s string) (num string, part int) {
+	orig := s
+
+	const maxExtLength = 7
+	if ext := path.Ext(s); len(ext) < maxExtLength {
+		s = s[:len(s)-len(ext)] // trim extension
+	}
+	s = subtitleTagRe.ReplaceAllString(strings.TrimSpace(s), "")
+
+	if m := partSuffixRe.FindStringSubmatchIndex(s); m != nil {
+		part, _ = strconv.Atoi(s[m[2]:m[3]])
+		// trim the suffix, as it's not always a tag to Trim.
+		return Trim(s[:m[0]]), part
+	}
+	if m := partDigitRe.FindStringSubmatch(s); len(m) == 2 {
+		part, _ = strconv.Atoi(m[1])
+	} else if m = partLetterRe.FindStringSubmatch(s); len(m) == 2 {
+		part = int(strings.ToUpper(m[1])[0]-'A') + 1
+	}
+	return Trim(orig), part
+}
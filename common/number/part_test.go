@@ -0,0 +1,37 @@
+package number
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePart(t *testing.T) {
+	for _, unit := range []struct {
+		orig string
+		num  string
+		part int
+	}{
+		{"ABP-030.mp4", "ABP-030", 0},
+		{"ABP-030-C.mp4", "ABP-030", 0},
+		{"ABP-030C.mp4", "ABP-030", 0},
+		{"ABP-030-cd1.mp4", "ABP-030", 1},
+		{"ABP-030-CD2-C.mp4", "ABP-030", 2},
+		{"ABP-030_part3.mkv", "ABP-030", 3},
+		{"ABP-030.pt2.mkv", "ABP-030", 2},
+		{"ABP-030 disc 2.mkv", "ABP-030", 2},
+		{"ABP-030_1.mp4", "ABP-030", 1},
+		{"ABP-030-2.mp4", "ABP-030", 2},
+		{"ABP-030A.mp4", "ABP-030", 1},
+		{"ABP-030-B.mp4", "ABP-030", 2},
+		{"ABP-030-B-C.mp4", "ABP-030", 2},
+		{"FC2-PPV-123456-2.mp4", "FC2-123456", 2},
+		{"rctd-461_Cd39-C.mp4", "rctd-461", 39},
+		{"093021_539-FHD.mkv", "093021_539", 0},
+		{"200gana-1350.mp4", "200gana-1350", 0},
+	} {
+		num, part := ParsePart(unit.orig)
+		assert.Equal(t, unit.num, num, unit.orig)
+		assert.Equal(t, unit.part, part, unit.orig)
+	}
+}
@@ -10,7 +10,8 @@ import (
 
 	"github.com/araddon/dateparse"
 	"golang.org/x/net/html"
-	dt "gorm.io/datatypes"
+
+	dt "github.com/metatube-community/metatube-sdk-go/datatypes"
 )
 
 // ParseInt parses string to int regardless.
@@ -39,26 +40,53 @@ func ParseDate(s string) dt.Date {
 	return dt.Date(ParseTime(s))
 }
 
-// ParseDuration parses a string with valid duration format into time.Duration.
+var (
+	clockDurationRe = regexp.MustCompile(`(\d+):(\d{1,2})(?::(\d{1,2}))?`)
+	unitDurationRe  = regexp.MustCompile(`(\d+(?:\.\d+)?)([hms]?)`)
+)
+
+// ParseDuration parses a string with valid duration format into time.Duration,
+// e.g. "120min", "2時間5分", "1.5h", "01:59:30" or "PT1H2M3S". Clock formats
+// with two parts are read as minutes and seconds. A number without unit is
+// read as the unit next to the previous one, or as minutes if it is the first.
 func ParseDuration(s string) time.Duration {
 	s = ReplaceSpaceAll(s)
 	s = strings.ToLower(s)
 	s = strings.ReplaceAll(s, "秒", "s")
 	s = strings.ReplaceAll(s, "分", "m")
 	s = strings.ReplaceAll(s, "時", "h")
+	s = strings.ReplaceAll(s, "小时", "h")
 	s = strings.ReplaceAll(s, "时", "h")
 	s = strings.ReplaceAll(s, "sec", "s")
 	s = strings.ReplaceAll(s, "min", "m")
-	if ss := regexp.MustCompile(`(?i)(\d+):(\d+):(\d+)`).FindStringSubmatch(s); len(ss) > 0 {
-		s = fmt.Sprintf("%02sh%02sm%02ss", ss[1], ss[2], ss[3])
-	} else if ss := regexp.MustCompile(`(?i)(\d+[mhs]?)`).FindAllStringSubmatch(s, -1); len(ss) > 0 {
-		ds := make([]string, 0, 3)
-		for _, d := range ss {
-			ds = append(ds, d[1])
+	if ss := clockDurationRe.FindStringSubmatch(s); len(ss) > 0 {
+		if ss[3] == "" {
+			return time.Duration(ParseInt(ss[1]))*time.Minute +
+				time.Duration(ParseInt(ss[2]))*time.Second
 		}
-		s = strings.Join(ds, "")
+		return time.Duration(ParseInt(ss[1]))*time.Hour +
+			time.Duration(ParseInt(ss[2]))*time.Minute +
+			time.Duration(ParseInt(ss[3]))*time.Second
+	}
+	var d, unit time.Duration
+	for _, ss := range unitDurationRe.FindAllStringSubmatch(s, -1) {
+		switch ss[2] {
+		case "h":
+			unit = time.Hour
+		case "m":
+			unit = time.Minute
+		case "s":
+			unit = time.Second
+		default:
+			if unit == 0 {
+				unit = time.Minute
+			} else if unit /= 60; unit < time.Second {
+				continue // nothing smaller than seconds.
+			}
+		}
+		n, _ := strconv.ParseFloat(ss[1], 64)
+		d += time.Duration(n * float64(unit))
 	}
-	d, _ := time.ParseDuration(s)
 	return d
 }
 
@@ -115,6 +143,59 @@ func ParseActorNames(s string) (names []string) {
 	return
 }
 
+// placeholderActors are names used by sites in place of unknown or
+// uncredited actors, compared case-insensitively.
+var placeholderActors = map[string]struct{}{
+	"素人":      {},
+	"素人女性":    {},
+	"企画":      {},
+	"企画女優":    {},
+	"不明":      {},
+	"不詳":      {},
+	"なし":      {},
+	"名無し":     {},
+	"n/a":     {},
+	"none":    {},
+	"unknown": {},
+}
+
+// IsPlaceholderActor reports whether the name is a placeholder of
+// unknown actors, e.g. 素人, 企画女優 or ---.
+func IsPlaceholderActor(name string) bool {
+	name = strings.TrimSpace(name)
+	if _, ok := placeholderActors[strings.ToLower(name)]; ok {
+		return true
+	}
+	// names of punctuation only, e.g. ---, ？.
+	return !strings.ContainsFunc(name, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	})
+}
+
+// SanitizeActorNames splits multi-name strings, e.g. A、B、C, into
+// individual names, and drops placeholders and duplicates.
+func SanitizeActorNames(names []string) (sanitized []string) {
+	seen := make(map[string]struct{}, len(names))
+	for _, s := range names {
+		if IsPlaceholderActor(s) {
+			continue // e.g. N/A.
+		}
+		for _, name := range strings.FieldsFunc(s, func(r rune) bool {
+			return strings.ContainsRune("、,，/／;；", r)
+		}) {
+			if name = strings.TrimSpace(name); IsPlaceholderActor(name) {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			sanitized = append(sanitized, name)
+		}
+	}
+	return
+}
+
 // ReplaceSpaceAll removes all spaces in string.
 func ReplaceSpaceAll(s string) string {
 	var b strings.Builder
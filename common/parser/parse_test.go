@@ -29,6 +29,19 @@ func TestParseDuration(t *testing.T) {
 		{"01:02:03", time.Hour + time.Minute*2 + time.Second*3},
 		{"PT1:2:03", time.Hour + time.Minute*2 + time.Second*3},
 		{"PT01:02:03", time.Hour + time.Minute*2 + time.Second*3},
+		{"120", time.Minute * 120},
+		{"120min", time.Minute * 120},
+		{"120分钟", time.Minute * 120},
+		{"収録時間: 120分", time.Minute * 120},
+		{"2時間5分", time.Hour*2 + time.Minute*5},
+		{"2小时5分钟", time.Hour*2 + time.Minute*5},
+		{"2h30", time.Hour*2 + time.Minute*30},
+		{"1.5h", time.Hour + time.Minute*30},
+		{"2 hours 5 minutes", time.Hour*2 + time.Minute*5},
+		{"01:59:30", time.Hour + time.Minute*59 + time.Second*30},
+		{"119:30", time.Minute*119 + time.Second*30},
+		{"", 0},
+		{"N/A", 0},
 	} {
 		assert.Equal(t, unit.want, ParseDuration(unit.orig), fmt.Sprintf("Arg: %s", unit.orig))
 	}
@@ -50,6 +63,23 @@ func TestParseActorNames(t *testing.T) {
 	}
 }
 
+func TestSanitizeActorNames(t *testing.T) {
+	for _, unit := range []struct {
+		orig []string
+		want []string
+	}{
+		{nil, nil},
+		{[]string{"素人", "---", "企画女優", "N/A", " ？ "}, nil},
+		{[]string{"川上ゆう"}, []string{"川上ゆう"}},
+		{[]string{"A、B、C"}, []string{"A", "B", "C"}},
+		{[]string{"川上ゆう / 森野雫", "川上ゆう", "素人"}, []string{"川上ゆう", "森野雫"}},
+		{[]string{"Sola Aoi, Maria Ozawa"}, []string{"Sola Aoi", "Maria Ozawa"}},
+		{[]string{"マリア・オザワ"}, []string{"マリア・オザワ"}},
+	} {
+		assert.Equal(t, unit.want, SanitizeActorNames(unit.orig), fmt.Sprintf("Arg: %v", unit.orig))
+	}
+}
+
 func TestParseIDToNumber(t *testing.T) {
 	for _, unit := range []struct {
 		id, want string
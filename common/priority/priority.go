@@ -0,0 +1,41 @@
+// Package priority defines the scheduling classes of provider calls,
+// carried by contexts from the engine down to the HTTP requests.
+package priority
+
+import "context"
+
+// Priority is the scheduling class of provider calls.
+type Priority int
+
+const (
+	// Interactive is for lookups a user is waiting for, e.g. the
+	// identify dialogs of media servers, it is the default.
+	Interactive Priority = iota
+	// Background is for bulk work, e.g. jobs and library scans.
+	Background
+)
+
+func (p Priority) String() string {
+	if p == Background {
+		return "background"
+	}
+	return "interactive"
+}
+
+type priorityKey struct{}
+
+// WithContext returns a copy of ctx with the priority.
+func WithContext(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// FromContext returns the priority of ctx, calls without one are
+// interactive.
+func FromContext(ctx context.Context) Priority {
+	if ctx != nil {
+		if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+			return p
+		}
+	}
+	return Interactive
+}
@@ -0,0 +1,191 @@
+package profile
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Profile is a set of consistent headers sent by a real browser.
+type Profile struct {
+	Name      string
+	UserAgent string
+	Headers   map[string]string
+}
+
+// Profiles are the built-in browser profiles, Sec-CH headers are only
+// sent by Chromium based browsers, just like the real ones.
+var Profiles = []*Profile{
+	{
+		Name:      "chrome-windows",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Safari/537.36",
+		Headers: map[string]string{
+			"Sec-CH-UA":          `"Chromium";v="134", "Not:A-Brand";v="24", "Google Chrome";v="134"`,
+			"Sec-CH-UA-Mobile":   "?0",
+			"Sec-CH-UA-Platform": `"Windows"`,
+		},
+	},
+	{
+		Name:      "chrome-macos",
+		UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Safari/537.36",
+		Headers: map[string]string{
+			"Sec-CH-UA":          `"Chromium";v="134", "Not:A-Brand";v="24", "Google Chrome";v="134"`,
+			"Sec-CH-UA-Mobile":   "?0",
+			"Sec-CH-UA-Platform": `"macOS"`,
+		},
+	},
+	{
+		Name:      "edge-windows",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Safari/537.36 Edg/134.0.0.0",
+		Headers: map[string]string{
+			"Sec-CH-UA":          `"Chromium";v="134", "Not:A-Brand";v="24", "Microsoft Edge";v="134"`,
+			"Sec-CH-UA-Mobile":   "?0",
+			"Sec-CH-UA-Platform": `"Windows"`,
+		},
+	},
+	{
+		Name:      "firefox-windows",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:136.0) Gecko/20100101 Firefox/136.0",
+	},
+	{
+		Name:      "safari-macos",
+		UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.3 Safari/605.1.15",
+	},
+}
+
+// commonHeaders are sent by all browsers on navigation.
+var commonHeaders = map[string]string{
+	"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+	"Sec-Fetch-Dest":            "document",
+	"Sec-Fetch-Mode":            "navigate",
+	"Sec-Fetch-Site":            "none",
+	"Upgrade-Insecure-Requests": "1",
+}
+
+// DefaultLanguage is the default Accept-Language header value.
+const DefaultLanguage = "ja-JP,ja;q=0.9,en-US;q=0.8,en;q=0.7"
+
+// DefaultRotateInterval is the default interval of profile rotation.
+const DefaultRotateInterval = 30 * time.Minute
+
+type languageKey struct{}
+
+// WithLanguage returns a copy of ctx carrying the Accept-Language header
+// value of the client, which is passed through to providers of rotators
+// without a language of their own.
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// Language returns the Accept-Language header value carried by ctx, if any.
+func Language(ctx context.Context) string {
+	language, _ := ctx.Value(languageKey{}).(string)
+	return language
+}
+
+// Get returns the built-in profile by name, or nil if not found.
+func Get(name string) *Profile {
+	for _, p := range Profiles {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Random returns a random built-in profile.
+func Random() *Profile {
+	return Profiles[rand.IntN(len(Profiles))]
+}
+
+// Apply sets the profile headers to the request. The User-Agent and
+// Sec-CH headers are always overwritten to keep them consistent, other
+// headers are only set if absent so that providers can override them.
+func (p *Profile) Apply(req *http.Request, language string) {
+	req.Header.Set("User-Agent", p.UserAgent)
+	for _, key := range []string{"Sec-CH-UA", "Sec-CH-UA-Mobile", "Sec-CH-UA-Platform"} {
+		req.Header.Del(key)
+	}
+	for k, v := range p.Headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range commonHeaders {
+		if req.Header.Get(k) == "" ||
+			// Accept */* is the placeholder set by colly.
+			(k == "Accept" && req.Header.Get(k) == "*/*") {
+			req.Header.Set(k, v)
+		}
+	}
+	if language == "" {
+		language = DefaultLanguage
+	}
+	if req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", language)
+	}
+}
+
+// Rotator rotates browser profiles periodically, so that requests in
+// the same period look like coming from the same browser session.
+type Rotator struct {
+	// Accept-Language header value, the one carried by the request
+	// context is used if empty.
+	Language string
+
+	// Interval of rotation.
+	Interval time.Duration
+
+	mu      sync.Mutex
+	current *Profile
+	expires time.Time
+}
+
+// NewRotator returns a Rotator with the given Accept-Language.
+func NewRotator(language string) *Rotator {
+	return &Rotator{
+		Language: language,
+		Interval: DefaultRotateInterval,
+	}
+}
+
+// Profile returns the profile of the current period.
+func (r *Rotator) Profile() *Profile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now := time.Now(); r.current == nil || now.After(r.expires) {
+		r.current = Random()
+		r.expires = now.Add(r.Interval)
+	}
+	return r.current
+}
+
+// Apply sets the headers of the current profile to the request.
+func (r *Rotator) Apply(req *http.Request) {
+	language := r.Language
+	if language == "" {
+		language = Language(req.Context())
+	}
+	r.Profile().Apply(req, language)
+}
+
+// Transport returns a transport applying the rotated profiles to
+// all requests before sending them with base.
+func (r *Rotator) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, rotator: r}
+}
+
+type transport struct {
+	base    http.RoundTripper
+	rotator *Rotator
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context()) // RoundTrippers must not modify requests.
+	t.rotator.Apply(req)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	for _, p := range Profiles {
+		assert.NotEmpty(t, p.UserAgent, p.Name)
+		isChromium := strings.Contains(p.UserAgent, "Chrome/")
+		assert.Equal(t, isChromium, p.Headers["Sec-CH-UA"] != "", p.Name)
+		assert.Equal(t, p, Get(strings.ToUpper(p.Name)))
+	}
+	assert.Nil(t, Get("unknown"))
+	assert.Contains(t, Profiles, Random())
+}
+
+func TestRotator(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	r := NewRotator("en-US")
+	c := &http.Client{Transport: r.Transport(nil)}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Go-http-client/1.1")
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	p := r.Profile()
+	assert.Equal(t, p.UserAgent, got.Get("User-Agent"))
+	assert.Equal(t, "application/json", got.Get("Accept"))
+	assert.Equal(t, "en-US", got.Get("Accept-Language"))
+	assert.Equal(t, p.Headers["Sec-CH-UA"], got.Get("Sec-CH-UA"))
+	// request should not be modified.
+	assert.Equal(t, "Go-http-client/1.1", req.Header.Get("User-Agent"))
+	// profile is kept within the interval.
+	assert.Same(t, p, r.Profile())
+}
+
+func TestRotator_ContextLanguage(t *testing.T) {
+	ctx := WithLanguage(context.Background(), "zh-TW")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+
+	NewRotator("").Apply(req)
+	assert.Equal(t, "zh-TW", req.Header.Get("Accept-Language"))
+
+	// the language of the rotator takes precedence.
+	req.Header.Del("Accept-Language")
+	NewRotator("en-US").Apply(req)
+	assert.Equal(t, "en-US", req.Header.Get("Accept-Language"))
+
+	req = req.WithContext(context.Background())
+	req.Header.Del("Accept-Language")
+	NewRotator("").Apply(req)
+	assert.Equal(t, DefaultLanguage, req.Header.Get("Accept-Language"))
+}
@@ -0,0 +1,151 @@
+package romaji
+
+import (
+	"strings"
+	"unicode"
+)
+
+// exceptions are curated romanizations of stage names, which are either
+// written in kanji or read irregularly, in given-family name order.
+var exceptions = map[string]string{
+	"三上悠亜":   "Yua Mikami",
+	"河北彩花":   "Saika Kawakita",
+	"深田えいみ":  "Eimi Fukada",
+	"明日花キララ": "Kirara Asuka",
+	"葵つかさ":   "Tsukasa Aoi",
+	"橋本ありな":  "Arina Hashimoto",
+	"天使もえ":   "Moe Amatsuka",
+	"波多野結衣":  "Yui Hatano",
+	"吉沢明歩":   "Akiho Yoshizawa",
+	"桃乃木かな":  "Kana Momonogi",
+	"相沢みなみ":  "Minami Aizawa",
+	"七沢みあ":   "Mia Nanasawa",
+	"楓カレン":   "Karen Kaede",
+	"紗倉まな":   "Mana Sakura",
+	"羽咲みはる":  "Miharu Usa",
+}
+
+var kana = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ゔ": "vu",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o",
+	"ゃ": "ya", "ゅ": "yu", "ょ": "yo", "ゎ": "wa",
+}
+
+// digraphs are kana followed by small ya/yu/yo or small vowels.
+var digraphs = map[string]string{
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+}
+
+// Romanize converts the Japanese name to Hepburn romaji, the curated
+// exceptions are looked up first, and then the name and its readings,
+// e.g. kana aliases, are tried in order. It returns false if none of
+// them is written in kana only.
+func Romanize(name string, readings ...string) (string, bool) {
+	if s, ok := exceptions[strings.TrimSpace(name)]; ok {
+		return s, true
+	}
+	for _, s := range append([]string{name}, readings...) {
+		if r, ok := fromKana(s); ok {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+// fromKana converts kana to romaji in passport style, long vowels are
+// not marked, and each word is capitalized.
+func fromKana(s string) (string, bool) {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '・' || r == '･'
+	})
+	if len(words) == 0 {
+		return "", false
+	}
+	for i, word := range words {
+		w, ok := romanizeWord([]rune(toHiragana(word)))
+		if !ok || w == "" {
+			return "", false
+		}
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " "), true
+}
+
+func toHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 'ァ' + 'ぁ'
+		}
+		return r
+	}, s)
+}
+
+func romanizeWord(rs []rune) (string, bool) {
+	var (
+		b        strings.Builder
+		geminate bool
+	)
+	for i := 0; i < len(rs); i++ {
+		if rs[i] == 'っ' {
+			geminate = true
+			continue
+		}
+		if rs[i] == 'ー' {
+			continue // long vowels are not marked.
+		}
+		var syllable string
+		if i+1 < len(rs) {
+			if d, ok := digraphs[string(rs[i:i+2])]; ok {
+				syllable = d
+				i++
+			}
+		}
+		if syllable == "" {
+			k, ok := kana[string(rs[i])]
+			if !ok {
+				return "", false // kanji or unknown.
+			}
+			syllable = k
+		}
+		if geminate {
+			if strings.HasPrefix(syllable, "ch") {
+				b.WriteByte('t')
+			} else if c := syllable[0]; !strings.ContainsRune("aeioun", rune(c)) {
+				b.WriteByte(c)
+			}
+			geminate = false
+		}
+		b.WriteString(syllable)
+	}
+	// ou, oo and uu are long vowels in names.
+	return strings.NewReplacer("ou", "o", "oo", "o", "uu", "u").Replace(b.String()), true
+}
@@ -0,0 +1,31 @@
+package romaji
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRomanize(t *testing.T) {
+	for _, unit := range []struct {
+		name     string
+		readings []string
+		want     string
+		ok       bool
+	}{
+		{"三上悠亜", nil, "Yua Mikami", true},
+		{"あいだ ゆあ", nil, "Aida Yua", true},
+		{"さとう しょうこ", nil, "Sato Shoko", true},
+		{"はっとり", nil, "Hattori", true},
+		{"まっちゃ", nil, "Matcha", true},
+		{"ユウキ・ミナ", nil, "Yuki Mina", true},
+		{"ティナ", nil, "Tina", true},
+		{"本田瞳", []string{"Hitomi", "ほんだ ひとみ"}, "Honda Hitomi", true},
+		{"本田瞳", nil, "", false},
+		{"", nil, "", false},
+	} {
+		got, ok := Romanize(unit.name, unit.readings...)
+		assert.Equal(t, unit.ok, ok, unit.name)
+		assert.Equal(t, unit.want, got, unit.name)
+	}
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	ctx, span := Start(context.Background(), "parent", attribute.String("provider", "TEST"))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	End(span, errors.New("failed"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, parent := spans[0], spans[1]
+	assert.Equal(t, "parent", parent.Name())
+	assert.Equal(t, codes.Error, parent.Status().Code)
+	assert.Contains(t, parent.Attributes(), attribute.String("provider", "TEST"))
+	assert.Equal(t, parent.SpanContext().SpanID(), child.Parent().SpanID())
+}
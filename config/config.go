@@ -0,0 +1,236 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xpath"
+	"gopkg.in/yaml.v3"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+// Config is the runtime configuration that can be reloaded
+// without restarting the server.
+type Config struct {
+	// Default proxy URL of all providers.
+	Proxy string `yaml:"proxy"`
+
+	// Default minimum interval between requests of each provider.
+	RateLimit time.Duration `yaml:"rate_limit"`
+
+	// Per-provider settings, keyed by provider name.
+	Providers map[string]*Provider `yaml:"providers"`
+
+	// FlareSolverr endpoint to solve Cloudflare challenges,
+	// e.g. http://localhost:8191.
+	FlareSolverr string `yaml:"flaresolverr"`
+
+	// Externally obtained Cloudflare clearances, keyed by host.
+	Clearances map[string]*Clearance `yaml:"clearances"`
+
+	// Default translator parameters, e.g. API keys,
+	// keyed by translator name and then parameter name.
+	Translators map[string]map[string]string `yaml:"translators"`
+
+	// Blocklist of movies to filter out, disabled if empty.
+	Blocklist *Blocklist `yaml:"blocklist"`
+
+	// Cleanup rules of titles, the built-in ones if empty.
+	TitleCleanup *TitleCleanup `yaml:"title_cleanup"`
+
+	// Curated translation files, later ones take precedence.
+	// They are reloaded whenever they change, too.
+	TranslationFiles []string `yaml:"translation_files"`
+
+	// JavaScript files of scrape hooks of all providers, called
+	// before the ones of each provider. They are reloaded whenever
+	// they change, too.
+	Scripts []string `yaml:"scripts"`
+}
+
+// Blocklist is the content filtering settings of the engine.
+type Blocklist struct {
+	// Numbers are compared in normalized form, e.g. ABP-030.
+	Numbers []string `yaml:"numbers"`
+	// Keywords are regular expressions of numbers and titles.
+	Keywords []string `yaml:"keywords"`
+	// Genres are compared case-insensitively.
+	Genres []string `yaml:"genres"`
+}
+
+// TitleCleanup is the title cleanup settings of the engine.
+type TitleCleanup struct {
+	// Rules are regular expressions of noise to strip from titles,
+	// in addition to the built-in ones.
+	Rules []string `yaml:"rules"`
+	// NoDefaults disables the built-in rules.
+	NoDefaults bool `yaml:"no_defaults"`
+}
+
+// rules returns all the cleanup rules in order.
+func (t *TitleCleanup) rules() []string {
+	if t == nil {
+		return engine.DefaultTitleRules
+	}
+	if t.NoDefaults {
+		return t.Rules
+	}
+	return append(slices.Clone(engine.DefaultTitleRules), t.Rules...)
+}
+
+// Provider is the runtime configuration of a provider.
+type Provider struct {
+	// Priorities override the built-in ones if set.
+	ActorPriority *float64 `yaml:"actor_priority"`
+	MoviePriority *float64 `yaml:"movie_priority"`
+
+	// Proxy overrides the default proxy if set.
+	Proxy string `yaml:"proxy"`
+
+	// RateLimit overrides the default rate limit if set.
+	RateLimit *time.Duration `yaml:"rate_limit"`
+
+	// Cookies are set for the provider's base URL, e.g. sessions.
+	Cookies map[string]string `yaml:"cookies"`
+
+	// XPaths replace the built-in XPath selectors of the keys with
+	// the values, e.g. to hotfix extraction after layout changes.
+	XPaths map[string]string `yaml:"xpaths"`
+
+	// Scripts are JavaScript files of scrape hooks of the provider.
+	Scripts []string `yaml:"scripts"`
+}
+
+// Clearance is a cf_clearance cookie obtained in a browser, it only
+// works with the same User-Agent and IP address.
+type Clearance struct {
+	Cookie    string `yaml:"cookie"`
+	UserAgent string `yaml:"user_agent"`
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses the YAML encoded config.
+func Parse(data []byte) (*Config, error) {
+	c := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	// Provider names are case-insensitive.
+	providers := make(map[string]*Provider, len(c.Providers))
+	for name, p := range c.Providers {
+		if p == nil {
+			p = &Provider{}
+		}
+		providers[strings.ToUpper(name)] = p
+	}
+	c.Providers = providers
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate checks the config for invalid values.
+func (c *Config) Validate() error {
+	if _, err := parseProxy(c.Proxy); err != nil {
+		return err
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("invalid rate limit: %s", c.RateLimit)
+	}
+	if c.FlareSolverr != "" {
+		if u, err := url.Parse(c.FlareSolverr); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid flaresolverr: %s", c.FlareSolverr)
+		}
+	}
+	if c.Blocklist != nil {
+		for _, keyword := range c.Blocklist.Keywords {
+			if _, err := regexp.Compile(keyword); err != nil {
+				return fmt.Errorf("invalid blocklist keyword: %w", err)
+			}
+		}
+	}
+	if c.TitleCleanup != nil {
+		for _, rule := range c.TitleCleanup.Rules {
+			if _, err := regexp.Compile(rule); err != nil {
+				return fmt.Errorf("invalid title cleanup rule: %w", err)
+			}
+		}
+	}
+	for host, cl := range c.Clearances {
+		if cl == nil || cl.Cookie == "" {
+			return fmt.Errorf("clearance %s: empty cookie", host)
+		}
+	}
+	for name, p := range c.Providers {
+		if _, err := parseProxy(p.Proxy); err != nil {
+			return fmt.Errorf("provider %s: %w", name, err)
+		}
+		if p.RateLimit != nil && *p.RateLimit < 0 {
+			return fmt.Errorf("provider %s: invalid rate limit: %s", name, *p.RateLimit)
+		}
+		for query, override := range p.XPaths {
+			if _, err := xpath.Compile(override); err != nil {
+				return fmt.Errorf("provider %s: invalid xpath override of %s: %w", name, query, err)
+			}
+		}
+	}
+	return nil
+}
+
+// scriptFiles returns all script files of the config.
+func (c *Config) scriptFiles() []string {
+	files := slices.Clone(c.Scripts)
+	for _, p := range c.Providers {
+		files = append(files, p.Scripts...)
+	}
+	return files
+}
+
+// ProxyOf returns the proxy of the named provider, nil if not set.
+func (c *Config) ProxyOf(name string) *url.URL {
+	raw := c.Proxy
+	if p, ok := c.Providers[strings.ToUpper(name)]; ok && p.Proxy != "" {
+		raw = p.Proxy
+	}
+	u, _ := parseProxy(raw) // validated.
+	return u
+}
+
+// RateLimitOf returns the rate limit of the named provider.
+func (c *Config) RateLimitOf(name string) time.Duration {
+	if p, ok := c.Providers[strings.ToUpper(name)]; ok && p.RateLimit != nil {
+		return *p.RateLimit
+	}
+	return c.RateLimit
+}
+
+func parseProxy(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy: %s", raw)
+	}
+	return u, nil
+}
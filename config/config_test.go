@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`
+proxy: http://127.0.0.1:1080
+rate_limit: 500ms
+providers:
+  fanza:
+    movie_priority: 2.5
+    proxy: socks5://127.0.0.1:1081
+  javbus:
+    rate_limit: 2s
+    xpaths:
+      //h3: //h2[@class="title"]
+flaresolverr: http://localhost:8191
+clearances:
+  www.javbus.com:
+    cookie: abc
+    user_agent: Mozilla/5.0
+translators:
+  deepl:
+    deepl-api-key: secret
+blocklist:
+  numbers: [ABP-030]
+  keywords: ["(?i)^fc2"]
+title_cleanup:
+  rules: ['^FHD\s*']
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ABP-030"}, c.Blocklist.Numbers)
+	assert.Equal(t, "abc", c.Clearances["www.javbus.com"].Cookie)
+	require.Contains(t, c.Providers, "FANZA")
+	assert.Equal(t, 2.5, *c.Providers["FANZA"].MoviePriority)
+	assert.Nil(t, c.Providers["FANZA"].ActorPriority)
+	assert.Equal(t, "socks5://127.0.0.1:1081", c.ProxyOf("fanza").String())
+	assert.Equal(t, "http://127.0.0.1:1080", c.ProxyOf("JAVBUS").String())
+	assert.Equal(t, 500*time.Millisecond, c.RateLimitOf("FANZA"))
+	assert.Equal(t, 2*time.Second, c.RateLimitOf("JAVBUS"))
+	assert.Equal(t, "secret", c.Translators["deepl"]["deepl-api-key"])
+	assert.Equal(t, `//h2[@class="title"]`, c.Providers["JAVBUS"].XPaths["//h3"])
+	assert.Equal(t, append(slices.Clone(engine.DefaultTitleRules), `^FHD\s*`), c.TitleCleanup.rules())
+
+	c, err = Parse(nil)
+	require.NoError(t, err)
+	assert.Nil(t, c.ProxyOf("FANZA"))
+	assert.Equal(t, engine.DefaultTitleRules, c.TitleCleanup.rules())
+
+	c, err = Parse([]byte("title_cleanup: {no_defaults: true}"))
+	require.NoError(t, err)
+	assert.Empty(t, c.TitleCleanup.rules())
+
+	for _, data := range []string{
+		"proxy: 127.0.0.1",
+		"unknown: true",
+		"providers: {fanza: {rate_limit: -1s}}",
+		"flaresolverr: localhost",
+		"clearances: {www.javbus.com: {user_agent: Mozilla/5.0}}",
+		"blocklist: {keywords: ['(']}",
+		"providers: {javbus: {xpaths: {'//h3': '//h2['}}}",
+		"title_cleanup: {rules: ['(']}",
+	} {
+		_, err = Parse([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	app := engine.Default()
+	provider, err := app.GetMovieProviderByName("FANZA")
+	require.NoError(t, err)
+	builtin := provider.Priority()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+providers:
+  fanza:
+    movie_priority: 42
+    xpaths:
+      //h1: //h2
+translators:
+  deepl:
+    deepl-api-key: secret
+`), 0o644))
+
+	r := NewReloader(path, app)
+	require.NoError(t, r.Reload())
+	assert.Equal(t, 42.0, provider.Priority())
+	assert.Equal(t, map[string]string{"//h1": "//h2"}, provider.(mt.XPathOverrider).XPathOverrides())
+	assert.Equal(t, "secret", translate.Defaults("DeepL")["deepl-api-key"])
+
+	// invalid file keeps current settings.
+	require.NoError(t, os.WriteFile(path, []byte("proxy: invalid"), 0o644))
+	assert.Error(t, r.Reload())
+	assert.Equal(t, 42.0, provider.Priority())
+
+	// removed settings are restored.
+	require.NoError(t, os.WriteFile(path, []byte(""), 0o644))
+	require.NoError(t, r.Reload())
+	assert.Equal(t, builtin, provider.Priority())
+	assert.Empty(t, provider.(mt.XPathOverrider).XPathOverrides())
+	assert.Empty(t, translate.Defaults("deepl"))
+}
+
+func TestReloader_TranslationFiles(t *testing.T) {
+	app := engine.Default()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "zh.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+lang: zh-CN
+movies:
+  ABP-030:
+    title: 精选标题
+`), 0o644))
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("translation_files: ["+file+"]"), 0o644))
+
+	r := NewReloader(path, app)
+	require.NoError(t, r.Reload())
+	assert.True(t, r.isWatched(file))
+
+	info := &model.MovieInfo{Number: "abp00030", Title: "タイトル", Summary: "概要"}
+	require.NoError(t, app.TranslateMovieInfo(info, "zh-cn"))
+	assert.Equal(t, "精选标题", info.Title)
+	assert.Equal(t, "概要", info.Summary) // no translator set.
+
+	// missing files keep current translations.
+	require.NoError(t, os.Remove(file))
+	assert.Error(t, r.Reload())
+}
+
+func TestReloader_Scripts(t *testing.T) {
+	app := engine.Default()
+	dir := t.TempDir()
+	all := filepath.Join(dir, "all.js")
+	fanza := filepath.Join(dir, "fanza.js")
+	require.NoError(t, os.WriteFile(all, []byte(`function postScrape(info) {}`), 0o644))
+	require.NoError(t, os.WriteFile(fanza, []byte(`function preScrape(provider, id) {}`), 0o644))
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+scripts: [`+all+`]
+providers:
+  fanza:
+    scripts: [`+fanza+`]
+`), 0o644))
+
+	r := NewReloader(path, app)
+	require.NoError(t, r.Reload())
+	assert.True(t, r.isWatched(all))
+	assert.True(t, r.isWatched(fanza))
+
+	c, err := Load(path)
+	require.NoError(t, err)
+	hooks, err := loadHooks(c)
+	require.NoError(t, err)
+	assert.Len(t, hooks.Of("FANZA"), 2)
+	assert.Len(t, hooks.Of("JAVBUS"), 1)
+
+	// invalid scripts keep current hooks.
+	require.NoError(t, os.WriteFile(fanza, []byte(`function (`), 0o644))
+	assert.Error(t, r.Reload())
+	require.NoError(t, os.Remove(all))
+	assert.Error(t, r.Reload())
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cloudflare"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+// DefaultReloadDelay is the default delay before reloading a changed
+// config file, so that bursts of writes trigger only one reload.
+const DefaultReloadDelay = time.Second
+
+// Reloader applies the config file to an engine, and reloads it
+// whenever the file changes.
+type Reloader struct {
+	path   string
+	app    *engine.Engine
+	logger *slog.Logger
+
+	// Delay before reloading a changed file.
+	Delay time.Duration
+
+	// Translators are the startup translator parameters, the
+	// config file overrides them parameter by parameter.
+	Translators map[string]map[string]string
+
+	mu sync.Mutex
+	// built-in priorities to restore when
+	// they are removed from the config file.
+	actorPriorities map[string]float64
+	moviePriorities map[string]float64
+	// hosts of clearances applied by the last reload.
+	clearanceHosts []string
+	// translation and script files loaded by the last reload.
+	files []string
+}
+
+func NewReloader(path string, app *engine.Engine) *Reloader {
+	r := &Reloader{
+		path:            path,
+		app:             app,
+		logger:          slog.Default().With(slog.String("component", "config")),
+		Delay:           DefaultReloadDelay,
+		actorPriorities: make(map[string]float64),
+		moviePriorities: make(map[string]float64),
+	}
+	for name, provider := range app.GetActorProviders() {
+		r.actorPriorities[name] = provider.Priority()
+	}
+	for name, provider := range app.GetMovieProviders() {
+		r.moviePriorities[name] = provider.Priority()
+	}
+	return r
+}
+
+// Reload loads the config file and applies it to the engine, the
+// current settings are kept if the file is invalid.
+func (r *Reloader) Reload() error {
+	c, err := Load(r.path)
+	if err != nil {
+		return err
+	}
+	// load files first, so that nothing is applied on errors.
+	curated, err := translate.LoadCurated(c.TranslationFiles...)
+	if err != nil {
+		return err
+	}
+	hooks, err := loadHooks(c)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, provider := range r.app.GetActorProviders() {
+		priority := r.actorPriorities[name]
+		if p, ok := c.Providers[name]; ok && p.ActorPriority != nil {
+			priority = *p.ActorPriority
+		}
+		provider.SetPriority(priority)
+		apply(c, name, provider)
+	}
+	for name, provider := range r.app.GetMovieProviders() {
+		priority := r.moviePriorities[name]
+		if p, ok := c.Providers[name]; ok && p.MoviePriority != nil {
+			priority = *p.MoviePriority
+		}
+		provider.SetPriority(priority)
+		apply(c, name, provider)
+	}
+	for name := range c.Providers {
+		if !r.app.IsActorProvider(name) && !r.app.IsMovieProvider(name) {
+			r.logger.Warn("unknown provider in config", slog.String("provider", name))
+		}
+	}
+	translate.SetDefaults(mergeTranslators(r.Translators, c.Translators))
+	r.app.SetCuratedTranslations(curated)
+	r.app.SetHooks(hooks)
+	r.files = r.files[:0]
+	for _, path := range append(c.TranslationFiles, c.scriptFiles()...) {
+		r.files = append(r.files, filepath.Clean(path))
+	}
+
+	var blocklist *engine.Blocklist
+	if b := c.Blocklist; b != nil {
+		blocklist, _ = engine.NewBlocklist(b.Numbers, b.Keywords, b.Genres) // validated.
+	}
+	r.app.SetBlocklist(blocklist)
+
+	cleaner, _ := engine.NewTitleCleaner(c.TitleCleanup.rules()...) // validated.
+	r.app.SetTitleCleaner(cleaner)
+
+	// keep the solver set by flags, if any.
+	if c.FlareSolverr != "" {
+		cloudflare.SetSolver(cloudflare.NewSolver(c.FlareSolverr, cloudflare.DefaultSolveTimeout))
+	}
+	for _, host := range r.clearanceHosts {
+		cloudflare.SetClearance(host, nil)
+	}
+	r.clearanceHosts = r.clearanceHosts[:0]
+	for host, cl := range c.Clearances {
+		cloudflare.SetClearance(host, &cloudflare.Clearance{
+			Cookies:   []*http.Cookie{{Name: "cf_clearance", Value: cl.Cookie}},
+			UserAgent: cl.UserAgent,
+		})
+		r.clearanceHosts = append(r.clearanceHosts, host)
+	}
+
+	r.logger.Info("config reloaded", slog.String("path", r.path))
+	return nil
+}
+
+func mergeTranslators(base, override map[string]map[string]string) map[string]map[string]string {
+	merged := make(map[string]map[string]string, len(base)+len(override))
+	for _, m := range []map[string]map[string]string{base, override} {
+		for name, params := range m {
+			name = strings.ToLower(name)
+			if merged[name] == nil {
+				merged[name] = make(map[string]string, len(params))
+			}
+			for k, v := range params {
+				merged[name][k] = v
+			}
+		}
+	}
+	return merged
+}
+
+// loadHooks loads the scripts of the config as hooks.
+func loadHooks(c *Config) (engine.Hooks, error) {
+	hooks := make(engine.Hooks)
+	load := func(name string, paths []string) error {
+		for _, path := range paths {
+			h, err := engine.LoadScriptHook(path)
+			if err != nil {
+				return err
+			}
+			hooks[name] = append(hooks[name], h)
+		}
+		return nil
+	}
+	if err := load(engine.AllProviders, c.Scripts); err != nil {
+		return nil, err
+	}
+	for name, p := range c.Providers {
+		if err := load(name, p.Scripts); err != nil {
+			return nil, err
+		}
+	}
+	return hooks, nil
+}
+
+func apply(c *Config, name string, provider mt.Provider) {
+	if s, ok := provider.(mt.ProxySetter); ok {
+		s.SetProxy(c.ProxyOf(name))
+	}
+	if s, ok := provider.(mt.RateLimitSetter); ok {
+		s.SetRateLimit(c.RateLimitOf(name))
+	}
+	if s, ok := provider.(mt.XPathOverrider); ok {
+		var xpaths map[string]string
+		if p := c.Providers[name]; p != nil {
+			xpaths = p.XPaths
+		}
+		s.SetXPathOverrides(xpaths)
+	}
+	if m, ok := provider.(mt.CookieManager); ok && c.Providers[name] != nil {
+		cookies := make([]*http.Cookie, 0, len(c.Providers[name].Cookies))
+		for k, v := range c.Providers[name].Cookies {
+			cookies = append(cookies, &http.Cookie{Name: k, Value: v})
+		}
+		if len(cookies) > 0 {
+			_ = m.SetCookies("", cookies) // ignore error.
+		}
+	}
+}
+
+// Watch reloads the config file whenever it changes, it
+// blocks until ctx is done.
+func (r *Reloader) Watch(ctx context.Context) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	// Watch the directory instead of the file itself, so that
+	// files replaced by editors or k8s config maps still work.
+	// Directories of files added later are not watched.
+	for _, dir := range r.watchDirs() {
+		if err = w.Add(dir); err != nil {
+			return err
+		}
+	}
+
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-w.Errors:
+			r.logger.Error("watch config", slog.Any("error", err))
+		case event := <-w.Events:
+			if !r.isWatched(filepath.Clean(event.Name)) ||
+				event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(r.Delay, func() {
+				if err := r.Reload(); err != nil {
+					r.logger.Error("reload config", slog.Any("error", err))
+				}
+			})
+		}
+	}
+}
+
+// watchDirs returns the directories of the config file, the
+// translation files and the script files.
+func (r *Reloader) watchDirs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dirs := []string{filepath.Dir(r.path)}
+	for _, path := range r.files {
+		if dir := filepath.Dir(path); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// isWatched reports whether changes of the file trigger reloads.
+func (r *Reloader) isWatched(name string) bool {
+	if name == filepath.Clean(r.path) {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Contains(r.files, name)
+}
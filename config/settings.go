@@ -0,0 +1,503 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+// DefaultShutdownTimeout is the default grace period of shutdown.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// DefaultCacheTTL is the default time to live of cached entries.
+const DefaultCacheTTL = 24 * time.Hour
+
+// DefaultRecordCacheSize is the default number of hottest movie and
+// actor infos kept in memory.
+const DefaultRecordCacheSize = 1000
+
+// SettingsFileFlag is the flag of the settings file.
+const SettingsFileFlag = "settings-file"
+
+// Settings are the startup settings of the server. Each one is bound
+// from the first source that sets it, in this order of precedence:
+//
+//  1. command-line flags, e.g. -port 8080
+//  2. environment variables, i.e. flag names in upper snake case,
+//     e.g. PORT=8080
+//  3. the YAML settings file given by -settings-file, keyed by flag
+//     names, e.g. port: 8080
+//  4. built-in defaults
+//
+// Settings that can be changed without restarting, e.g. proxies and
+// the blocklist, belong to the hot-reloadable config file instead.
+type Settings struct {
+	// main config
+	Bind string
+	Port string
+	// Unix socket to listen on instead of Bind and Port.
+	UnixSocket     string
+	UnixSocketMode uint
+	Token          string
+	// admin token, falls back to Token if empty.
+	AdminToken string
+	// API keys with quotas, accepted besides Token.
+	APIKeys APIKeySettings
+	DSN     string
+
+	// TLS config
+	TLSCertFile  string
+	TLSKeyFile   string
+	ACMEDomains  string
+	ACMEEmail    string
+	ACMECacheDir string
+	ACMEHTTPPort string
+
+	// server config
+	ShutdownTimeout time.Duration
+	SettingsFile    string
+	ConfigFile      string
+	CookieFile      string
+	TranslationFile string
+
+	// gRPC config
+	GRPCPort string
+
+	// route config
+	EnableStashBox  bool
+	EnableWebUI     bool
+	SubtitleSources string
+	CORSOrigins     string
+	IPAllowlist     IPPrefixes
+	IPDenylist      IPPrefixes
+	TrustedProxies  IPPrefixes
+	LibraryRoots    string
+
+	// engine config
+	RequestTimeout  time.Duration
+	FlareSolverr    string
+	HeadlessBrowser string
+
+	// cache config
+	CacheStore      string
+	CacheTTL        time.Duration
+	RecordCacheSize int
+
+	// provider config
+	Providers ProviderSettings
+
+	// translator config
+	Translators TranslatorSettings
+
+	// image config
+	FaceDetectorURL string
+	PreviewDir      string
+	PreviewMaxSize  int64
+	PreviewMaxAge   time.Duration
+	FFmpegPath      string
+
+	// webhook config
+	WebhookURLs         string
+	HealthCheckInterval time.Duration
+	FollowCheckInterval time.Duration
+
+	// job config
+	JobWorkers      int
+	ScrapeWorkers   int
+	RefreshSchedule string
+	RefreshMaxAge   time.Duration
+	ArtworkSchedule string
+
+	// database config
+	DBMaxIdleConns int
+	DBMaxOpenConns int
+	DBAutoMigrate  bool
+	DBPreparedStmt bool
+	DBRawPayloads  bool
+	DBBusyTimeout  time.Duration
+	DBReadReplicas string
+
+	// tracing config
+	OTLPEndpoint     string
+	OTLPInsecure     bool
+	TraceSampleRatio float64
+
+	// log config
+	LogLevel  string
+	LogFormat string
+
+	// version flag
+	VersionFlag bool
+}
+
+// BindFlags binds all settings to the flag set.
+func (s *Settings) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&s.Bind, "bind", "", "Bind address of server")
+	fs.StringVar(&s.Port, "port", "8080", "Port number of server")
+	fs.StringVar(&s.UnixSocket, "unix-socket", "", "Path of the Unix socket to listen on instead of the bind address and port, its peers are trusted proxies; disabled if empty")
+	fs.UintVar(&s.UnixSocketMode, "unix-socket-mode", 0o660, "File mode of the Unix socket")
+	fs.StringVar(&s.Token, "token", "", "Token to access server")
+	fs.StringVar(&s.AdminToken, "admin-token", "", "Token to access admin endpoints, which are disabled if neither it nor API keys of the admin role are set")
+	fs.Var(&s.APIKeys, "api-key", "API key setting as name.key=value, keys: token, role (user or admin), rate_limit (requests per minute), quota (requests per day); repeatable or separated by semicolons")
+	fs.StringVar(&s.DSN, "dsn", "", "Database Service Name")
+	fs.StringVar(&s.SettingsFile, SettingsFileFlag, "", "Path of the YAML file of startup settings, overridden by flags and environment variables")
+	fs.StringVar(&s.ConfigFile, "config-file", "", "Path of the hot-reloadable config file")
+	fs.StringVar(&s.CookieFile, "cookie-file", "", "Path of the file to persist provider cookies, disabled if empty")
+	fs.StringVar(&s.TranslationFile, "translation-memory-file", "", "Path of the .tmx or .csv file to persist the translation memory, disabled if empty")
+	fs.StringVar(&s.TLSCertFile, "tls-cert-file", "", "Path of the TLS certificate file to serve HTTPS, disabled if empty")
+	fs.StringVar(&s.TLSKeyFile, "tls-key-file", "", "Path of the TLS private key file to serve HTTPS")
+	fs.StringVar(&s.ACMEDomains, "acme-domains", "", "Comma-separated domains to obtain certificates from Let's Encrypt automatically, disabled if empty")
+	fs.StringVar(&s.ACMEEmail, "acme-email", "", "Contact email of the ACME account")
+	fs.StringVar(&s.ACMECacheDir, "acme-cache-dir", "certs", "Directory to cache ACME certificates")
+	fs.StringVar(&s.ACMEHTTPPort, "acme-http-port", "", "Port number to answer ACME HTTP challenges and redirect HTTP to HTTPS, e.g. 80, disabled if empty")
+	fs.DurationVar(&s.ShutdownTimeout, "shutdown-timeout", DefaultShutdownTimeout, "Grace period to drain in-flight requests on shutdown")
+	fs.StringVar(&s.GRPCPort, "grpc-port", "", "Port number of gRPC server, disabled if empty")
+	fs.BoolVar(&s.EnableStashBox, "enable-stash-box", false, "Enable stash-box compatible GraphQL endpoint")
+	fs.BoolVar(&s.EnableWebUI, "enable-web-ui", false, "Enable embedded web UI at /ui/ to search and inspect metadata")
+	fs.StringVar(&s.SubtitleSources, "subtitle-sources", "", "Comma-separated subtitle sources, or \"all\" for all sources")
+	fs.StringVar(&s.LibraryRoots, "library-roots", "", "Comma-separated library directories the organize endpoint and scan jobs may access, disabled if empty")
+	fs.StringVar(&s.CORSOrigins, "cors-origins", "", "Comma-separated origins allowed to access the server from browsers, or \"*\" for any origins, disabled if empty")
+	fs.Var(&s.IPAllowlist, "ip-allowlist", "Comma-separated IPs or CIDRs of clients allowed to access the server, all allowed if empty; repeatable")
+	fs.Var(&s.IPDenylist, "ip-denylist", "Comma-separated IPs or CIDRs of clients denied to access the server, takes precedence over the allowlist; repeatable")
+	fs.Var(&s.TrustedProxies, "trusted-proxies", "Comma-separated IPs or CIDRs of reverse proxies trusted to forward client IPs; repeatable")
+	fs.DurationVar(&s.RequestTimeout, "request-timeout", engine.DefaultRequestTimeout, "Timeout per request")
+	fs.StringVar(&s.FlareSolverr, "flaresolverr-url", "", "FlareSolverr endpoint to solve Cloudflare challenges, disabled if empty")
+	fs.StringVar(&s.HeadlessBrowser, "headless-browser", "", "Chrome path or DevTools websocket URL to render JS pages, \"chrome\" to find in PATH, disabled if empty")
+	fs.StringVar(&s.CacheStore, "cache-store", "", "Cache store of HTTP responses, images and translations: memory, db, redis://host:port (rediss:// for TLS) of a single Redis node to share caches and scrape locks among replicas, file:///path of a directory, or bolt:///path of a BoltDB file, disabled if empty")
+	fs.DurationVar(&s.CacheTTL, "cache-ttl", DefaultCacheTTL, "Time to live of cached HTTP responses and images")
+	fs.IntVar(&s.RecordCacheSize, "record-cache-size", DefaultRecordCacheSize, "Number of hottest movie and actor infos kept in memory above the database, disabled if zero")
+	fs.Var(&s.Providers, "provider", "Provider setting as name.key=value, keys: priority, proxy, rate_limit, base_url, crop (position, face, cover or letterbox); repeatable or separated by semicolons")
+	fs.Var(&s.Translators, "translator", "Translator parameter as name.key=value, e.g. deepl.deepl-api-key=xxx; repeatable or separated by semicolons")
+	fs.StringVar(&s.FaceDetectorURL, "face-detector-url", "", "URL of an external face detection service for image crops, falls back to the built-in detector on errors")
+	fs.StringVar(&s.PreviewDir, "preview-dir", "", "Directory to cache animated previews of trailers, disabled if empty")
+	fs.Int64Var(&s.PreviewMaxSize, "preview-max-size", engine.DefaultPreviewMaxSize, "Size limit in bytes of cached previews, the least recently served ones are evicted first, unlimited if zero")
+	fs.DurationVar(&s.PreviewMaxAge, "preview-max-age", engine.DefaultPreviewMaxAge, "Age of cached previews since last served to be evicted, unlimited if zero")
+	fs.StringVar(&s.FFmpegPath, "ffmpeg-path", "", "Path of the ffmpeg executable for previews, found in PATH if empty")
+	fs.StringVar(&s.WebhookURLs, "webhook-urls", "", "Comma-separated webhook URLs for metadata events")
+	fs.DurationVar(&s.HealthCheckInterval, "health-check-interval", 0, "Interval of provider health checks")
+	fs.DurationVar(&s.FollowCheckInterval, "follow-check-interval", 0, "Interval of checking follows for new releases, disabled if zero")
+	fs.IntVar(&s.JobWorkers, "job-workers", engine.DefaultJobWorkers, "Number of background jobs run concurrently, disabled if zero")
+	fs.IntVar(&s.ScrapeWorkers, "scrape-workers", engine.DefaultScrapeWorkers, "Number of concurrent provider calls, interactive ones are served first, unlimited if zero")
+	fs.StringVar(&s.RefreshSchedule, "refresh-schedule", "0 3 * * *", "Cron schedule of refreshing stale metadata, disabled if empty")
+	fs.DurationVar(&s.RefreshMaxAge, "refresh-max-age", engine.DefaultRefreshMaxAge, "Age of metadata to be refreshed by the schedule")
+	fs.StringVar(&s.ArtworkSchedule, "artwork-schedule", "", "Cron schedule of validating and repairing stored artwork URLs, disabled if empty")
+	fs.IntVar(&s.DBMaxIdleConns, "db-max-idle-conns", 0, "Database max idle connections")
+	fs.IntVar(&s.DBMaxOpenConns, "db-max-open-conns", 0, "Database max open connections")
+	fs.BoolVar(&s.DBAutoMigrate, "db-auto-migrate", false, "Database auto migration")
+	fs.BoolVar(&s.DBPreparedStmt, "db-prepared-stmt", false, "Database prepared statement")
+	fs.BoolVar(&s.DBRawPayloads, "db-raw-payloads", false, "Store raw provider payloads of scraped movies for re-parsing")
+	fs.DurationVar(&s.DBBusyTimeout, "db-busy-timeout", database.DefaultBusyTimeout, "Time SQLite waits for locks of other connections before failing")
+	fs.StringVar(&s.DBReadReplicas, "db-read-replicas", "", "Comma-separated DSNs of read-only database replicas serving queries, a read-only pool of the same file for SQLite if empty")
+	fs.StringVar(&s.OTLPEndpoint, "otlp-endpoint", "", "OTLP/HTTP endpoint to export traces, disabled if empty")
+	fs.BoolVar(&s.OTLPInsecure, "otlp-insecure", false, "Export traces via HTTP instead of HTTPS")
+	fs.Float64Var(&s.TraceSampleRatio, "trace-sample-ratio", 1, "Fraction of traces to sample")
+	fs.StringVar(&s.LogLevel, "log-level", "info", "Log level: debug, info, warn or error")
+	fs.StringVar(&s.LogFormat, "log-format", "text", "Log format: text or json")
+	fs.BoolVar(&s.VersionFlag, "version", false, "Show version")
+}
+
+// Options returns the options to parse settings in the documented
+// order of precedence with ff.
+func Options() []ff.Option {
+	return []ff.Option{
+		ff.WithEnvVars(),
+		ff.WithConfigFileFlag(SettingsFileFlag),
+		ff.WithConfigFileParser(SettingsFileParser),
+	}
+}
+
+// SettingsFileParser is the ff.ConfigFileParser of YAML settings files.
+// Top-level keys are flag names, lists set repeatable flags, and maps of
+// the provider and translator flags are flattened into name.key=value.
+func SettingsFileParser(r io.Reader, set func(name, value string) error) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var m map[string]any
+	if err = yaml.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil && err != io.EOF {
+		return fmt.Errorf("parse settings: %w", err)
+	}
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names) // deterministic order.
+	for _, name := range names {
+		values, err := flattenSetting(m[name])
+		if err != nil {
+			return fmt.Errorf("setting %s: %w", name, err)
+		}
+		for _, value := range values {
+			if err = set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func flattenSetting(v any) ([]string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		var values []string
+		for _, e := range v {
+			inner, err := flattenSetting(e)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, inner...)
+		}
+		return values, nil
+	case map[string]any:
+		var values []string
+		for name, params := range v {
+			pm, ok := params.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid value of %s", name)
+			}
+			for key, param := range pm {
+				inner, err := flattenSetting(param)
+				if err != nil {
+					return nil, err
+				}
+				for _, value := range inner {
+					values = append(values, name+"."+key+"="+value)
+				}
+			}
+		}
+		sort.Strings(values)
+		return values, nil
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
+
+// parseKeyValues parses name.key=value pairs separated by semicolons.
+func parseKeyValues(s string, fn func(name, key, value string) error) error {
+	for _, pair := range strings.Split(s, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		k, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid setting: %s", pair)
+		}
+		name, key, ok := strings.Cut(strings.TrimSpace(k), ".")
+		if !ok || name == "" || key == "" {
+			return fmt.Errorf("invalid setting: %s", pair)
+		}
+		if err := fn(name, key, strings.TrimSpace(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProviderSettings are the startup settings of providers, keyed by
+// upper-case provider name.
+type ProviderSettings map[string]*engine.ProviderConfig
+
+func (p *ProviderSettings) String() string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d providers", len(*p))
+}
+
+func (p *ProviderSettings) Set(s string) error {
+	return parseKeyValues(s, func(name, key, value string) error {
+		if *p == nil {
+			*p = make(ProviderSettings)
+		}
+		name = strings.ToUpper(name)
+		c, ok := (*p)[name]
+		if !ok {
+			c = &engine.ProviderConfig{}
+			(*p)[name] = c
+		}
+		switch key {
+		case "priority":
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("provider %s: invalid priority: %s", name, value)
+			}
+			c.Priority = &v
+		case "proxy":
+			u, err := parseProxy(value)
+			if err != nil {
+				return fmt.Errorf("provider %s: %w", name, err)
+			}
+			c.Proxy = u
+		case "rate_limit":
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return fmt.Errorf("provider %s: invalid rate limit: %s", name, value)
+			}
+			c.RateLimit = d
+		case "base_url":
+			u, err := url.Parse(value)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("provider %s: invalid base url: %s", name, value)
+			}
+			c.BaseURLs = append(c.BaseURLs, u)
+		case "crop":
+			mode, err := engine.ParseCropMode(value)
+			if err != nil {
+				return fmt.Errorf("provider %s: %w", name, err)
+			}
+			c.CropMode = mode
+		default:
+			return fmt.Errorf("provider %s: unknown setting: %s", name, key)
+		}
+		return nil
+	})
+}
+
+// IPPrefixes are IPs or CIDRs separated by commas, a single IP is
+// parsed as the prefix of the full length.
+type IPPrefixes []netip.Prefix
+
+func (p *IPPrefixes) String() string {
+	if p == nil {
+		return ""
+	}
+	ss := make([]string, 0, len(*p))
+	for _, prefix := range *p {
+		ss = append(ss, prefix.String())
+	}
+	return strings.Join(ss, ",")
+}
+
+func (p *IPPrefixes) Set(s string) error {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return fmt.Errorf("invalid IP or CIDR: %s", v)
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		*p = append(*p, prefix.Masked())
+	}
+	return nil
+}
+
+// APIKeySettings are the API keys of tenants, keyed by key name.
+type APIKeySettings map[string]*auth.Key
+
+func (a *APIKeySettings) String() string {
+	if a == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d keys", len(*a))
+}
+
+func (a *APIKeySettings) Set(s string) error {
+	return parseKeyValues(s, func(name, key, value string) error {
+		if *a == nil {
+			*a = make(APIKeySettings)
+		}
+		k, ok := (*a)[name]
+		if !ok {
+			k = &auth.Key{Name: name}
+			(*a)[name] = k
+		}
+		switch key {
+		case "token":
+			if value == "" {
+				return fmt.Errorf("api key %s: empty token", name)
+			}
+			k.Token = value
+		case "role":
+			switch role := auth.Role(value); role {
+			case auth.RoleUser, auth.RoleAdmin:
+				k.Role = role
+			default:
+				return fmt.Errorf("api key %s: invalid role: %s", name, value)
+			}
+		case "rate_limit", "quota":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("api key %s: invalid %s: %s", name, key, value)
+			}
+			if key == "rate_limit" {
+				k.RateLimit = n
+			} else {
+				k.Quota = n
+			}
+		default:
+			return fmt.Errorf("api key %s: unknown setting: %s", name, key)
+		}
+		return nil
+	})
+}
+
+// Keys returns the keys of the settings ordered by name, it returns an
+// error if any key has no token or tokens are duplicated.
+func (a APIKeySettings) Keys() ([]*auth.Key, error) {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	keys := make([]*auth.Key, 0, len(a))
+	tokens := make(map[string]struct{}, len(a))
+	for _, name := range names {
+		k := a[name]
+		if k.Token == "" {
+			return nil, fmt.Errorf("api key %s: missing token", name)
+		}
+		if _, ok := tokens[k.Token]; ok {
+			return nil, fmt.Errorf("api key %s: duplicate token", name)
+		}
+		tokens[k.Token] = struct{}{}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// TranslatorSettings are the default parameters of translators, e.g.
+// API keys, keyed by translator name and then parameter name.
+type TranslatorSettings map[string]map[string]string
+
+func (t *TranslatorSettings) String() string {
+	if t == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d translators", len(*t))
+}
+
+func (t *TranslatorSettings) Set(s string) error {
+	return parseKeyValues(s, func(name, key, value string) error {
+		if *t == nil {
+			*t = make(TranslatorSettings)
+		}
+		name = strings.ToLower(name)
+		if (*t)[name] == nil {
+			(*t)[name] = make(map[string]string)
+		}
+		(*t)[name][key] = value
+		return nil
+	})
+}
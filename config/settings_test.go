@@ -0,0 +1,135 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+port: 9090
+token: file
+dsn: file.db
+request-timeout: 30s
+provider:
+  fanza:
+    priority: 2
+    base_url: [https://www.dmm.com/, https://www.dmm.co.jp/]
+translator:
+  deepl:
+    deepl-api-key: secret
+`), 0o644))
+	t.Setenv("TOKEN", "env")
+	t.Setenv("DSN", "env.db")
+
+	s := &Settings{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	s.BindFlags(fs)
+	require.NoError(t, ff.Parse(fs, []string{
+		"-" + SettingsFileFlag, path,
+		"-dsn", "flag.db",
+		"-provider", "javbus.proxy=socks5://127.0.0.1:1080;javbus.rate_limit=1s",
+	}, Options()...))
+
+	assert.Equal(t, "9090", s.Port)
+	assert.Equal(t, "env", s.Token)
+	assert.Equal(t, "flag.db", s.DSN)
+	assert.Equal(t, 30*time.Second, s.RequestTimeout)
+	assert.Equal(t, "info", s.LogLevel)
+	assert.Equal(t, "secret", s.Translators["deepl"]["deepl-api-key"])
+
+	// flags take precedence over the file for the whole setting.
+	assert.NotContains(t, s.Providers, "FANZA")
+	require.Contains(t, s.Providers, "JAVBUS")
+	assert.Equal(t, "socks5://127.0.0.1:1080", s.Providers["JAVBUS"].Proxy.String())
+	assert.Equal(t, time.Second, s.Providers["JAVBUS"].RateLimit)
+
+	var p ProviderSettings
+	require.NoError(t, p.Set("fanza.priority=2;fanza.base_url=https://www.dmm.com/"))
+	assert.Equal(t, 2.0, *p["FANZA"].Priority)
+	assert.Len(t, p["FANZA"].BaseURLs, 1)
+	require.NoError(t, p.Set("javdb.crop=Face"))
+	assert.Equal(t, engine.CropModeFace, p["JAVDB"].CropMode)
+	for _, s := range []string{
+		"fanza",
+		"fanza=1",
+		"fanza.priority=high",
+		"fanza.proxy=127.0.0.1",
+		"fanza.unknown=1",
+		"fanza.crop=center",
+	} {
+		assert.Error(t, p.Set(s), s)
+	}
+}
+
+func TestIPPrefixes(t *testing.T) {
+	var p IPPrefixes
+	require.NoError(t, p.Set("192.168.1.0/24, 10.0.0.1"))
+	require.NoError(t, p.Set("::1,192.168.2.1/24"))
+	assert.Equal(t, "192.168.1.0/24,10.0.0.1/32,::1/128,192.168.2.0/24", p.String())
+	for _, s := range []string{
+		"localhost",
+		"10.0.0.1/33",
+	} {
+		assert.Error(t, p.Set(s), s)
+	}
+}
+
+func TestAPIKeySettings(t *testing.T) {
+	var a APIKeySettings
+	require.NoError(t, a.Set("alice.token=a;alice.rate_limit=60;alice.role=admin;bob.token=b;bob.quota=1000"))
+	keys, err := a.Keys()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, auth.Key{Name: "alice", Token: "a", Role: auth.RoleAdmin, RateLimit: 60}, *keys[0])
+	assert.Equal(t, auth.Key{Name: "bob", Token: "b", Quota: 1000}, *keys[1])
+	for _, s := range []string{
+		"alice.token=",
+		"alice.quota=-1",
+		"alice.rate_limit=fast",
+		"alice.role=root",
+		"alice.unknown=1",
+	} {
+		assert.Error(t, a.Set(s), s)
+	}
+
+	require.NoError(t, a.Set("carol.quota=1"))
+	_, err = a.Keys()
+	assert.Error(t, err, "missing token")
+	require.NoError(t, a.Set("carol.token=a"))
+	_, err = a.Keys()
+	assert.Error(t, err, "duplicate token")
+}
+
+func TestSettingsFileParser(t *testing.T) {
+	s := &Settings{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	s.BindFlags(fs)
+	path := filepath.Join(t.TempDir(), "settings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+provider:
+  fanza:
+    priority: 2
+    base_url: [https://www.dmm.com/, https://www.dmm.co.jp/]
+`), 0o644))
+	require.NoError(t, ff.Parse(fs, []string{"-" + SettingsFileFlag, path}, Options()...))
+	require.Contains(t, s.Providers, "FANZA")
+	assert.Equal(t, 2.0, *s.Providers["FANZA"].Priority)
+	assert.Len(t, s.Providers["FANZA"].BaseURLs, 2)
+
+	require.NoError(t, os.WriteFile(path, []byte("unknown: 1"), 0o644))
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	(&Settings{}).BindFlags(fs)
+	assert.Error(t, ff.Parse(fs, []string{"-" + SettingsFileFlag, path}, Options()...))
+}
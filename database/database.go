@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"log"
 	"os"
 	"regexp"
@@ -33,6 +34,15 @@ type Config struct {
 
 	// Max DB idle connections.
 	MaxIdleConns int
+
+	// BusyTimeout of SQLite, DefaultBusyTimeout if zero.
+	BusyTimeout time.Duration
+
+	// ReadReplicas are DSNs of read-only databases, queries outside
+	// transactions are balanced among them, except for ReplicaLagWindow
+	// after writes of the process. A read-only pool of the same file is
+	// used by file-based SQLite if empty.
+	ReadReplicas []string
 }
 
 func Open(cfg *Config) (*gorm.DB, error) {
@@ -46,18 +56,21 @@ func Open(cfg *Config) (*gorm.DB, error) {
 		cfg.MaxIdleConns = 2
 	}
 
-	var dialector gorm.Dialector
-	// We try to parse it as postgresql, otherwise
-	// fallback to sqlite.
-	if regexp.MustCompile(`^postgres(ql)?://`).MatchString(cfg.DSN) ||
-		len(strings.Fields(cfg.DSN)) >= 3 {
-		dialector = postgres.New(postgres.Config{
-			DSN: cfg.DSN,
-			// set true to disable implicit prepared statement usage.
-			PreferSimpleProtocol: !cfg.PreparedStmt,
-		})
-	} else {
-		dialector = sqlite.Open(cfg.DSN)
+	if cfg.BusyTimeout <= 0 {
+		cfg.BusyTimeout = DefaultBusyTimeout
+	}
+
+	maxOpenConns := cfg.MaxOpenConns
+	replicas := cfg.ReadReplicas
+	dialector := openDialector(cfg, cfg.DSN, false)
+	if dialector.Name() == Sqlite && !isMemoryDSN(cfg.DSN) {
+		// SQLite allows one writer at a time, so writes queue up in
+		// the pool of one connection instead of failing with "database
+		// is locked", and reads are served by a pool of their own.
+		maxOpenConns = 1
+		if len(replicas) == 0 {
+			replicas = []string{cfg.DSN}
+		}
 	}
 
 	db, err := gorm.Open(dialector, &gorm.Config{
@@ -77,7 +90,49 @@ func Open(cfg *Config) (*gorm.DB, error) {
 
 	if sqlDB, err := db.DB(); err == nil /* ignore error */ {
 		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
-		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+		sqlDB.SetMaxOpenConns(maxOpenConns)
+	}
+
+	if len(replicas) > 0 {
+		r := &resolver{}
+		if dialector.Name() != Sqlite {
+			r.lagWindow = ReplicaLagWindow
+		}
+		for _, dsn := range replicas {
+			replica, err := gorm.Open(openDialector(cfg, dsn, true), &gorm.Config{
+				DisableAutomaticPing: cfg.DisableAutomaticPing,
+			})
+			if err == nil {
+				var reader *sql.DB
+				if reader, err = replica.DB(); err == nil {
+					reader.SetMaxIdleConns(cfg.MaxIdleConns)
+					reader.SetMaxOpenConns(cfg.MaxOpenConns)
+					r.readers = append(r.readers, reader)
+				}
+			}
+			if err != nil {
+				_ = Close(db)
+				_ = r.close()
+				return nil, err
+			}
+		}
+		if err = db.Use(r); err != nil {
+			return nil, err
+		}
 	}
 	return db, nil
 }
+
+// openDialector returns the dialector of the DSN. We try to parse it
+// as postgresql, otherwise fallback to sqlite.
+func openDialector(cfg *Config, dsn string, readOnly bool) gorm.Dialector {
+	if regexp.MustCompile(`^postgres(ql)?://`).MatchString(dsn) ||
+		len(strings.Fields(dsn)) >= 3 {
+		return postgres.New(postgres.Config{
+			DSN: dsn,
+			// set true to disable implicit prepared statement usage.
+			PreferSimpleProtocol: !cfg.PreparedStmt,
+		})
+	}
+	return sqlite.Open(sqliteDSN(dsn, cfg.BusyTimeout, readOnly))
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteDSN(t *testing.T) {
+	dsn := sqliteDSN("metatube.db", 5*time.Second, false)
+	assert.Contains(t, dsn, "metatube.db?")
+	assert.Contains(t, dsn, "busy_timeout%285000%29")
+	assert.Contains(t, dsn, "journal_mode%28WAL%29")
+	assert.Contains(t, dsn, "_txlock=immediate")
+
+	dsn = sqliteDSN("file:metatube.db?_pragma=journal_mode(DELETE)", time.Second, true)
+	assert.Contains(t, dsn, "journal_mode(DELETE)&")
+	assert.NotContains(t, dsn, "WAL")
+	assert.Contains(t, dsn, "query_only%281%29")
+	assert.NotContains(t, dsn, "_txlock")
+
+	dsn = sqliteDSN("file::memory:?cache=shared", time.Second, false)
+	assert.NotContains(t, dsn, "journal_mode")
+	assert.Contains(t, dsn, "busy_timeout")
+}
+
+func TestOpen_Sqlite(t *testing.T) {
+	db, err := Open(&Config{DSN: filepath.Join(t.TempDir(), "metatube.db")})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, Close(db)) }()
+
+	var mode string
+	require.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&mode).Error)
+	assert.Equal(t, "wal", mode)
+
+	type item struct {
+		ID   uint
+		Name string
+	}
+	require.NoError(t, db.AutoMigrate(&item{}))
+
+	// concurrent writes queue up for the single writer.
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- db.Create(&item{Name: "item"}).Error
+			var items []*item
+			errs <- db.Limit(10).Find(&items).Error
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	var count int64
+	require.NoError(t, db.Model(&item{}).Count(&count).Error)
+	assert.EqualValues(t, 50, count)
+
+	// queries are served by the read-only pool.
+	assert.Error(t, db.Raw("DELETE FROM items").Scan(&[]*item{}).Error)
+	require.NoError(t, db.Exec("DELETE FROM items").Error)
+
+	// unless they are of the primary.
+	primary := Primary(db)
+	assert.NoError(t, primary.Raw("DELETE FROM items").Scan(&[]*item{}).Error)
+	assert.NoError(t, primary.Raw("DELETE FROM items").Scan(&[]*item{}).Error)
+
+	// or written recently, if the readers may lag.
+	r := db.Config.Plugins[resolverName].(*resolver)
+	r.lagWindow = time.Minute
+	require.NoError(t, db.Create(&item{Name: "item"}).Error)
+	assert.NoError(t, db.Raw("DELETE FROM items").Scan(&[]*item{}).Error)
+	r.lastWrite.Store(time.Now().Add(-time.Minute).UnixNano())
+	assert.Error(t, db.Raw("DELETE FROM items").Scan(&[]*item{}).Error)
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrationsTableName is the table of applied schema migrations.
+const MigrationsTableName = "schema_migrations"
+
+// migrationLockID is the Postgres advisory lock of migrations, so that
+// replicas starting at the same time migrate one by one.
+const migrationLockID = 0x6d74_6d69_6772
+
+// Migration is a versioned schema migration, it is applied only once.
+type Migration struct {
+	// ID is the unique version of the migration, e.g. 202610160001.
+	// Migrations are applied in the order they are given, and IDs
+	// must never change once released.
+	ID string
+	// Migrate applies the migration in the transaction.
+	Migrate func(tx *gorm.DB) error
+}
+
+type schemaMigration struct {
+	ID        string `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
+
+func (*schemaMigration) TableName() string {
+	return MigrationsTableName
+}
+
+// Migrate applies the pending migrations in order, each in its own
+// transaction, and returns the IDs of the applied ones. It stops at the
+// first failed migration, keeping the ones applied before it. It
+// refuses to migrate if the database was migrated by a newer release,
+// i.e. some applied migrations are unknown.
+func Migrate(db *gorm.DB, migrations []*Migration) (applied []string, err error) {
+	for _, m := range migrations {
+		var ok bool
+		if ok, err = migrate(db, migrations, m); err != nil {
+			return
+		}
+		if ok {
+			applied = append(applied, m.ID)
+		}
+	}
+	return
+}
+
+// migrate applies m unless it is applied already, e.g. by another
+// replica, and reports whether it is applied now.
+func migrate(db *gorm.DB, migrations []*Migration, m *Migration) (ok bool, err error) {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == Postgres {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.AutoMigrate(&schemaMigration{}); err != nil {
+			return err
+		}
+		pending, err := pendingMigrations(tx, migrations)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(pending, m) {
+			return nil
+		}
+		if err := m.Migrate(tx); err != nil {
+			return fmt.Errorf("migration %s: %w", m.ID, err)
+		}
+		if err := tx.Create(&schemaMigration{ID: m.ID, AppliedAt: time.Now()}).Error; err != nil {
+			return err
+		}
+		ok = true
+		return nil
+	})
+	return
+}
+
+// PendingMigrations returns the IDs of the migrations not applied yet.
+func PendingMigrations(db *gorm.DB, migrations []*Migration) (ids []string, err error) {
+	pending, err := pendingMigrations(db, migrations)
+	for _, m := range pending {
+		ids = append(ids, m.ID)
+	}
+	return
+}
+
+func pendingMigrations(db *gorm.DB, migrations []*Migration) (pending []*Migration, err error) {
+	known := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		if slices.Contains(known, m.ID) {
+			return nil, fmt.Errorf("duplicate migration: %s", m.ID)
+		}
+		known = append(known, m.ID)
+	}
+	var applied []string
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		if err = db.Model(&schemaMigration{}).Pluck("id", &applied).Error; err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range applied {
+		if !slices.Contains(known, id) {
+			return nil, fmt.Errorf("unknown migration %s: database is migrated by a newer release", id)
+		}
+	}
+	for _, m := range migrations {
+		if !slices.Contains(applied, m.ID) {
+			pending = append(pending, m)
+		}
+	}
+	return
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMigrate(t *testing.T) {
+	db, err := Open(&Config{DSN: "file:migrate_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+
+	type item struct {
+		ID   uint
+		Name string
+	}
+	var calls int
+	migrations := []*Migration{
+		{ID: "1", Migrate: func(tx *gorm.DB) error {
+			calls++
+			return tx.AutoMigrate(&item{})
+		}},
+	}
+	pending, err := PendingMigrations(db, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, pending)
+
+	applied, err := Migrate(db, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, applied)
+	require.NoError(t, db.Create(&item{Name: "kept"}).Error)
+
+	// applied migrations are skipped.
+	applied, err = Migrate(db, migrations)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Equal(t, 1, calls)
+
+	// failed migrations are rolled back, the ones before them are kept.
+	migrations = append(migrations,
+		&Migration{ID: "2", Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE items ADD COLUMN note TEXT").Error
+		}},
+		&Migration{ID: "3", Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec("CREATE TABLE others (id INTEGER)").Error; err != nil {
+				return err
+			}
+			return errors.New("failed")
+		}},
+	)
+	applied, err = Migrate(db, migrations)
+	assert.ErrorContains(t, err, "migration 3: failed")
+	assert.Equal(t, []string{"2"}, applied)
+	assert.True(t, db.Migrator().HasColumn(&item{}, "note"))
+	assert.False(t, db.Migrator().HasTable("others"))
+	pending, err = PendingMigrations(db, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, pending)
+
+	migrations[2].Migrate = func(*gorm.DB) error { return nil }
+	applied, err = Migrate(db, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, applied)
+	var count int64
+	require.NoError(t, db.Model(&item{}).Where("name = ?", "kept").Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	// databases of newer releases are refused.
+	_, err = Migrate(db, migrations[:1])
+	assert.ErrorContains(t, err, "newer release")
+
+	_, err = Migrate(db, append(migrations, &Migration{ID: "1"}))
+	assert.ErrorContains(t, err, "duplicate migration")
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/atomic"
+	"gorm.io/gorm"
+)
+
+const (
+	resolverName = "metatube:resolver"
+	primaryKey   = "metatube:primary"
+)
+
+// ReplicaLagWindow is how long queries are served by the primary after
+// writes of the process, if replicas may lag behind, so that the writes
+// are read back.
+const ReplicaLagWindow = 5 * time.Second
+
+// resolver routes queries outside transactions to the read pools, so
+// that they never wait for the writer. Writes and transactions stay
+// in the pool of the DB.
+type resolver struct {
+	readers []*sql.DB
+	next    atomic.Uint32
+	// lagWindow is zero if the readers never lag, e.g. of the same
+	// SQLite file.
+	lagWindow time.Duration
+	lastWrite atomic.Int64
+}
+
+func (r *resolver) Name() string { return resolverName }
+
+func (r *resolver) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register(resolverName, r.route); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register(resolverName, r.route); err != nil {
+		return err
+	}
+	for _, err := range []error{
+		db.Callback().Create().After("gorm:create").Register(resolverName, r.written),
+		db.Callback().Update().After("gorm:update").Register(resolverName, r.written),
+		db.Callback().Delete().After("gorm:delete").Register(resolverName, r.written),
+		db.Callback().Raw().After("gorm:raw").Register(resolverName, r.written),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *resolver) route(db *gorm.DB) {
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return // in a transaction.
+	}
+	if primary, _ := db.Get(primaryKey); primary == true {
+		return
+	}
+	if r.lagWindow > 0 && time.Since(time.Unix(0, r.lastWrite.Load())) < r.lagWindow {
+		return // written recently.
+	}
+	n := r.next.Inc()
+	db.Statement.ConnPool = r.readers[int(n)%len(r.readers)]
+}
+
+func (r *resolver) written(*gorm.DB) {
+	r.lastWrite.Store(time.Now().UnixNano())
+}
+
+// Primary returns db whose queries are always served by the primary,
+// e.g. reads of states that replicas share, which must see the writes
+// of other replicas right away.
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Set(primaryKey, true).Session(&gorm.Session{})
+}
+
+func (r *resolver) close() error {
+	var errs []error
+	for _, reader := range r.readers {
+		errs = append(errs, reader.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the DB along with its read pools, if any.
+func Close(db *gorm.DB) error {
+	var errs []error
+	if r, ok := db.Config.Plugins[resolverName].(*resolver); ok {
+		errs = append(errs, r.close())
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	errs = append(errs, sqlDB.Close())
+	return errors.Join(errs...)
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBusyTimeout is the default time SQLite waits for the locks
+// of other connections before it fails with "database is locked".
+const DefaultBusyTimeout = 10 * time.Second
+
+// isMemoryDSN reports whether the SQLite DSN is of an in-memory DB,
+// which supports neither WAL mode nor connections of other pools.
+func isMemoryDSN(dsn string) bool {
+	return strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory")
+}
+
+// sqliteDSN returns the DSN with the pragmas of production use added,
+// pragmas given by the DSN take precedence:
+//
+//   - WAL mode, so that readers never block the writer and vice versa.
+//   - busy timeout, so that locked connections wait instead of failing.
+//   - immediate transactions, so that a transaction never fails in the
+//     middle of it upgrading its read lock to a write lock.
+//
+// Connections of read-only DSNs refuse all writes.
+func sqliteDSN(dsn string, busyTimeout time.Duration, readOnly bool) string {
+	params := url.Values{}
+	pragma := func(name, value string) {
+		if !strings.Contains(dsn, name) {
+			params.Add("_pragma", fmt.Sprintf("%s(%s)", name, value))
+		}
+	}
+	pragma("busy_timeout", fmt.Sprint(busyTimeout.Milliseconds()))
+	if !isMemoryDSN(dsn) {
+		pragma("journal_mode", "WAL")
+		pragma("synchronous", "NORMAL")
+	}
+	if readOnly {
+		pragma("query_only", "1")
+	} else if !strings.Contains(dsn, "_txlock") {
+		params.Set("_txlock", "immediate")
+	}
+	if len(params) == 0 {
+		return dsn
+	}
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + params.Encode()
+}
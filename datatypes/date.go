@@ -0,0 +1,92 @@
+// Package datatypes provides the Date and Duration types of models, they
+// serialize consistently in both the database and the API.
+//
+// Duration is meant for durations exchanged in whole seconds, e.g. the
+// max age of refreshes and lookups. Movie runtimes remain minutes in
+// the database and the /v1 API, and durations of the config remain
+// time.Duration, as they are parsed by flags and may be sub-second.
+package datatypes
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without time of day, it is stored as a date
+// column in the database, and as an RFC 3339 timestamp at midnight in
+// JSON, the same as the /v1 API has always served. ISO 8601 dates, e.g.
+// 2006-01-02, are accepted as well. The zero date is 0001-01-01.
+type Date time.Time
+
+// NewDate returns the date of the year, month and day in UTC.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// ParseDate parses the ISO 8601 date, RFC 3339 timestamps are accepted
+// as well for compatibility, empty means the zero date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Date(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date: %q", s)
+}
+
+// Time returns the date as time.Time.
+func (date Date) Time() time.Time { return time.Time(date) }
+
+// IsZero reports whether the date is the zero date.
+func (date Date) IsZero() bool { return time.Time(date).IsZero() }
+
+// String returns the date in ISO 8601 form.
+func (date Date) String() string { return time.Time(date).Format(time.DateOnly) }
+
+func (date *Date) Scan(value any) (err error) {
+	nullTime := &sql.NullTime{}
+	err = nullTime.Scan(value)
+	*date = Date(nullTime.Time)
+	return
+}
+
+func (date Date) Value() (driver.Value, error) {
+	y, m, d := time.Time(date).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Time(date).Location()), nil
+}
+
+// GormDataType returns the common data type of gorm.
+func (Date) GormDataType() string {
+	return "date"
+}
+
+func (date Date) GobEncode() ([]byte, error) {
+	return time.Time(date).GobEncode()
+}
+
+func (date *Date) GobDecode(b []byte) error {
+	return (*time.Time)(date).GobDecode(b)
+}
+
+func (date Date) MarshalJSON() ([]byte, error) {
+	return time.Time(date).MarshalJSON()
+}
+
+func (date *Date) UnmarshalJSON(b []byte) (err error) {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err = json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*date, err = ParseDate(s)
+	return
+}
@@ -0,0 +1,51 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate_JSON(t *testing.T) {
+	date := NewDate(2021, 2, 3)
+	data, err := json.Marshal(date)
+	require.NoError(t, err)
+	assert.Equal(t, `"2021-02-03T00:00:00Z"`, string(data))
+
+	data, err = json.Marshal(Date{})
+	require.NoError(t, err)
+	assert.Equal(t, `"0001-01-01T00:00:00Z"`, string(data))
+
+	for _, unit := range []struct {
+		data string
+		want Date
+	}{
+		{`"2021-02-03"`, date},
+		{`"2021-02-03T00:00:00Z"`, date},
+		{`""`, Date{}},
+		{`null`, Date{}},
+	} {
+		var d Date
+		require.NoError(t, json.Unmarshal([]byte(unit.data), &d), unit.data)
+		assert.True(t, unit.want.Time().Equal(d.Time()), unit.data)
+	}
+
+	var d Date
+	assert.Error(t, json.Unmarshal([]byte(`"03/02/2021"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`20210203`), &d))
+}
+
+func TestDate_SQL(t *testing.T) {
+	value, err := NewDate(2021, 2, 3).Value()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC), value)
+
+	var d Date
+	require.NoError(t, d.Scan(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2021-02-03", d.String())
+	require.NoError(t, d.Scan(nil))
+	assert.True(t, d.IsZero())
+}
@@ -0,0 +1,105 @@
+package datatypes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Duration is a time.Duration of whole seconds, it is stored as an
+// integer column of seconds in the database, and as integer seconds
+// in JSON and query parameters.
+type Duration time.Duration
+
+// Seconds returns the duration of n seconds.
+func Seconds(n int64) Duration {
+	return Duration(time.Duration(n) * time.Second)
+}
+
+// ParseDuration parses integer seconds, or a duration string such as
+// 1h30m, empty means zero.
+func ParseDuration(s string) (Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Seconds(n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+	return Duration(d), nil
+}
+
+// Std returns the duration as time.Duration.
+func (d Duration) Std() time.Duration { return time.Duration(d) }
+
+// Seconds returns the duration in whole seconds, rounded.
+func (d Duration) Seconds() int64 {
+	return int64(time.Duration(d).Round(time.Second) / time.Second)
+}
+
+// String returns the duration in the form of time.Duration.
+func (d Duration) String() string { return time.Duration(d).String() }
+
+func (d *Duration) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = 0
+	case int64:
+		*d = Seconds(v)
+	case float64:
+		*d = Duration(v * float64(time.Second))
+	case []byte:
+		return d.Scan(string(v))
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("scan duration: %w", err)
+		}
+		*d = Seconds(n)
+	default:
+		return fmt.Errorf("scan duration: unsupported type %T", value)
+	}
+	return nil
+}
+
+func (d Duration) Value() (driver.Value, error) {
+	return d.Seconds(), nil
+}
+
+// GormDataType returns the common data type of gorm.
+func (Duration) GormDataType() string {
+	return "int"
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, d.Seconds(), 10), nil
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) (err error) {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	var s string
+	if b[0] == '"' {
+		if err = json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(b)
+	}
+	*d, err = ParseDuration(s)
+	return
+}
+
+// UnmarshalParam decodes query and form parameters, it implements the
+// binding.BindUnmarshaler interface of gin.
+func (d *Duration) UnmarshalParam(param string) (err error) {
+	*d, err = ParseDuration(param)
+	return
+}
@@ -0,0 +1,52 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration_JSON(t *testing.T) {
+	data, err := json.Marshal(Duration(90*time.Minute + 400*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, `5400`, string(data))
+
+	for _, unit := range []struct {
+		data string
+		want Duration
+	}{
+		{`5400`, Seconds(5400)},
+		{`"5400"`, Seconds(5400)},
+		{`"1h30m"`, Seconds(5400)},
+		{`""`, 0},
+		{`null`, 0},
+	} {
+		var d Duration
+		require.NoError(t, json.Unmarshal([]byte(unit.data), &d), unit.data)
+		assert.Equal(t, unit.want, d, unit.data)
+	}
+
+	var d Duration
+	assert.Error(t, json.Unmarshal([]byte(`"90 minutes"`), &d))
+	assert.Error(t, d.UnmarshalParam("-"))
+	require.NoError(t, d.UnmarshalParam("7200"))
+	assert.Equal(t, 2*time.Hour, d.Std())
+}
+
+func TestDuration_SQL(t *testing.T) {
+	value, err := Seconds(5400).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(5400), value)
+
+	var d Duration
+	for _, v := range []any{int64(5400), float64(5400), []byte("5400"), "5400"} {
+		require.NoError(t, d.Scan(v))
+		assert.Equal(t, 90*time.Minute, d.Std())
+	}
+	require.NoError(t, d.Scan(nil))
+	assert.Zero(t, d)
+	assert.Error(t, d.Scan(true))
+}
@@ -1,19 +1,24 @@
 package engine
 
 import (
+	"context"
 	goerr "errors"
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"gorm.io/gorm/clause"
 
 	"github.com/metatube-community/metatube-sdk-go/collections"
 	"github.com/metatube-community/metatube-sdk-go/common/comparer"
 	"github.com/metatube-community/metatube-sdk-go/common/parser"
+	"github.com/metatube-community/metatube-sdk-go/common/romaji"
+	"github.com/metatube-community/metatube-sdk-go/metrics"
 	"github.com/metatube-community/metatube-sdk-go/model"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 	"github.com/metatube-community/metatube-sdk-go/provider/gfriends"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
 )
 
 func (e *Engine) searchActorFromDB(keyword string, provider mt.Provider) (results []*model.ActorSearchResult, err error) {
@@ -35,7 +40,7 @@ func (e *Engine) searchActorFromDB(keyword string, provider mt.Provider) (result
 func (e *Engine) searchActor(keyword string, provider mt.Provider, fallback bool) ([]*model.ActorSearchResult, error) {
 	innerSearch := func(keyword string) (results []*model.ActorSearchResult, err error) {
 		if provider.Name() == gfriends.Name {
-			return provider.(mt.ActorSearcher).SearchActor(keyword)
+			return mt.WithContext(provider.(mt.ActorSearcher), e.ctx).SearchActor(keyword)
 		}
 		if searcher, ok := provider.(mt.ActorSearcher); ok {
 			defer func() {
@@ -68,7 +73,15 @@ func (e *Engine) searchActor(keyword string, provider mt.Provider, fallback bool
 					}
 				}()
 			}
-			return searcher.SearchActor(keyword)
+			var (
+				ctx  context.Context
+				done func(*error)
+			)
+			if ctx, done, err = e.observe(provider, opSearchActor, keyword); err != nil {
+				return nil, err
+			}
+			defer done(&err)
+			return mt.WithContext(searcher, ctx).SearchActor(keyword)
 		}
 		// All providers should implement ActorSearcher interface.
 		return nil, mt.ErrInfoNotFound
@@ -147,7 +160,15 @@ func (e *Engine) getActorInfoFromDB(provider mt.ActorProvider, id string) (*mode
 	return info, err
 }
 
-func (e *Engine) getActorInfoWithCallback(provider mt.ActorProvider, id string, lazy bool, callback func() (*model.ActorInfo, error)) (info *model.ActorInfo, err error) {
+func (e *Engine) getActorInfoWithCallback(provider mt.ActorProvider, id string, lazy bool, callback func(ctx context.Context) (*model.ActorInfo, error)) (info *model.ActorInfo, err error) {
+	// forced refreshes bypass the HTTP cache.
+	e = e.withRefresh(lazy)
+	defer func() {
+		// romanized name for international users.
+		if err == nil && info != nil && info.Romaji == "" {
+			info.Romaji, _ = romaji.Romanize(info.Name, info.Aliases...)
+		}
+	}()
 	defer func() {
 		// metadata validation check.
 		if err == nil && (info == nil || !info.Valid()) {
@@ -155,40 +176,76 @@ func (e *Engine) getActorInfoWithCallback(provider mt.ActorProvider, id string,
 		}
 	}()
 	if provider.Name() == gfriends.Name {
-		return provider.GetActorInfoByID(id)
+		return mt.WithContext(provider, e.ctx).GetActorInfoByID(id)
 	}
 	defer func() {
 		// actor image injection.
 		if err == nil && info != nil {
-			if gInfo, gErr := e.MustGetActorProviderByName(gfriends.Name).GetActorInfoByID(info.Name); gErr == nil && len(gInfo.Images) > 0 {
+			if gInfo, gErr := mt.WithContext(e.MustGetActorProviderByName(gfriends.Name), e.ctx).GetActorInfoByID(info.Name); gErr == nil && len(gInfo.Images) > 0 {
 				info.Images = append(gInfo.Images, info.Images...)
 			}
 		}
 	}()
 	// Query DB first (by id).
 	if lazy {
-		if info, err = e.getActorInfoFromDB(provider, id); err == nil && info.Valid() {
+		if info, err = e.getCachedActorInfo(provider, id); err == nil && info.Valid() {
+			metrics.ObserveCache(opActorInfo, true)
 			return
 		}
+		metrics.ObserveCache(opActorInfo, false)
+	}
+	// Concurrent scrapes of the same actor are collapsed into one.
+	key := sharedKey(opActorInfo, provider, id)
+	v, err, shared := e.group.Do(e.flightKey(key), func() (any, error) {
+		return lockedScrape(e, key, func(since time.Time) (*model.ActorInfo, bool) {
+			info, err := e.withPrimary().getActorInfoFromDB(provider, id)
+			return info, err == nil && info.Valid() && !info.UpdatedAt.Before(since)
+		}, func() (*model.ActorInfo, error) {
+			return e.scrapeActorInfo(provider, id, callback)
+		})
+	})
+	if info, _ = v.(*model.ActorInfo); shared && info != nil {
+		info = cloneActorInfo(info)
 	}
+	return
+}
+
+func (e *Engine) scrapeActorInfo(provider mt.ActorProvider, id string, callback func(ctx context.Context) (*model.ActorInfo, error)) (info *model.ActorInfo, err error) {
 	// Delayed info auto-save.
 	defer func() {
 		if err == nil && info.Valid() {
+			event := webhook.ActorScraped
+			if e.notifier != nil && e.existsInDB(&model.ActorInfo{}, info.Provider, info.ID) {
+				event = webhook.ActorRefreshed
+			}
 			// Make sure we save the original info here.
 			e.db.Clauses(clause.OnConflict{
 				UpdateAll: true,
 			}).Create(info) // ignore error
+			e.evictActorInfo(info.Provider, info.ID)
+			e.notify(event, info.Provider, info.ID, info)
 		}
 	}()
-	return callback()
+	defer func() {
+		// field stats are of the info as parsed by the provider.
+		if err == nil && info != nil {
+			e.observeFields(provider, actorInfoType, info)
+		}
+	}()
+	ctx, done, err := e.observe(provider, opActorInfo, id)
+	if err != nil {
+		return nil, err
+	}
+	defer done(&err)
+	return callback(ctx)
 }
 
 func (e *Engine) getActorInfoByProviderID(provider mt.ActorProvider, id string, lazy bool) (*model.ActorInfo, error) {
 	if id = provider.NormalizeActorID(id); id == "" {
 		return nil, mt.ErrInvalidID
 	}
-	return e.getActorInfoWithCallback(provider, id, lazy, func() (*model.ActorInfo, error) {
-		return provider.GetActorInfoByID(id)
+	return e.getActorInfoWithCallback(provider, id, lazy, func(ctx context.Context) (*model.ActorInfo, error) {
+		return mt.WithContext(provider, ctx).GetActorInfoByID(id)
 	})
 }
 
@@ -208,15 +265,21 @@ func (e *Engine) getActorInfoByProviderURL(provider mt.ActorProvider, rawURL str
 	case id == "":
 		return nil, mt.ErrInvalidURL
 	}
-	return e.getActorInfoWithCallback(provider, id, lazy, func() (*model.ActorInfo, error) {
-		return provider.GetActorInfoByURL(rawURL)
+	return e.getActorInfoWithCallback(provider, id, lazy, func(ctx context.Context) (*model.ActorInfo, error) {
+		return mt.WithContext(provider, ctx).GetActorInfoByURL(rawURL)
 	})
 }
 
+// GetActorInfoByURL gets the actor info of the link, the provider is
+// picked automatically like GetMovieInfoByURL.
 func (e *Engine) GetActorInfoByURL(rawURL string, lazy bool) (*model.ActorInfo, error) {
-	provider, err := e.GetActorProviderByURL(rawURL)
+	u, err := parseLink(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := resolveProvider(e.actorHostProviders, u)
 	if err != nil {
 		return nil, err
 	}
-	return e.getActorInfoByProviderURL(provider, rawURL, lazy)
+	return e.getActorInfoByProviderURL(provider, u.String(), lazy)
 }
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// actorField is a mergeable field of the actor info.
+type actorField struct {
+	name  string
+	empty func(*model.ActorInfo) bool
+	copy  func(dst, src *model.ActorInfo)
+}
+
+func actorValueField[T comparable](name string, ptr func(*model.ActorInfo) *T) actorField {
+	return actorField{
+		name: name,
+		empty: func(a *model.ActorInfo) bool {
+			var zero T
+			return *ptr(a) == zero
+		},
+		copy: func(dst, src *model.ActorInfo) { *ptr(dst) = *ptr(src) },
+	}
+}
+
+// actorFields are named after the JSON keys of the actor info, aliases
+// and images are merged separately.
+var actorFields = []actorField{
+	actorValueField("summary", func(a *model.ActorInfo) *string { return &a.Summary }),
+	actorValueField("hobby", func(a *model.ActorInfo) *string { return &a.Hobby }),
+	actorValueField("skill", func(a *model.ActorInfo) *string { return &a.Skill }),
+	actorValueField("blood_type", func(a *model.ActorInfo) *string { return &a.BloodType }),
+	actorValueField("cup_size", func(a *model.ActorInfo) *string { return &a.CupSize }),
+	actorValueField("measurements", func(a *model.ActorInfo) *string { return &a.Measurements }),
+	actorValueField("nationality", func(a *model.ActorInfo) *string { return &a.Nationality }),
+	actorValueField("height", func(a *model.ActorInfo) *int { return &a.Height }),
+	actorValueField("birthday", func(a *model.ActorInfo) *datatypes.Date { return &a.Birthday }),
+	actorValueField("debut_date", func(a *model.ActorInfo) *datatypes.Date { return &a.DebutDate }),
+}
+
+// actorFieldPrecedence lists providers preferred for the fields over
+// the requested one, e.g. gfriends has the best curated images, and
+// xslist the most complete profiles.
+var actorFieldPrecedence = map[string][]string{
+	"images":       {"Gfriends"},
+	"birthday":     {"XsList"},
+	"height":       {"XsList"},
+	"measurements": {"XsList"},
+	"cup_size":     {"XsList"},
+}
+
+// mergeActorInfos merges the infos of the same person into the first
+// one, the canonical record. Fields are taken from the first info with
+// them in the precedence order of the field, i.e. the providers of
+// actorFieldPrecedence and then the order of infos. Aliases are the
+// union of all names, and images are concatenated in the same order.
+func mergeActorInfos(infos []*model.ActorInfo) *model.ActorInfo {
+	merged := *infos[0] // shallow copy, no changes to the saved info.
+	merged.Sources = make(map[string]string)
+	merged.Aliases = nil
+	merged.Images = nil
+
+	for _, field := range actorFields {
+		for _, info := range orderActorInfos(infos, field.name) {
+			if !field.empty(info) {
+				field.copy(&merged, info)
+				merged.Sources[field.name] = info.Provider
+				break
+			}
+		}
+	}
+
+	for _, info := range orderActorInfos(infos, "images") {
+		for _, image := range info.Images {
+			if !slices.Contains(merged.Images, image) {
+				merged.Images = append(merged.Images, image)
+			}
+		}
+		if _, ok := merged.Sources["images"]; !ok && len(info.Images) > 0 {
+			merged.Sources["images"] = info.Provider
+		}
+	}
+
+	seen := map[string]bool{normalizeActorName(merged.Name): true}
+	for _, info := range infos {
+		for _, name := range append([]string{info.Name}, info.Aliases...) {
+			if key := normalizeActorName(name); key != "" && !seen[key] {
+				seen[key] = true
+				merged.Aliases = append(merged.Aliases, name)
+			}
+		}
+	}
+	if len(merged.Aliases) == 0 {
+		merged.Aliases = pq.StringArray{}
+	}
+	return &merged
+}
+
+// orderActorInfos returns infos in the precedence order of the field.
+func orderActorInfos(infos []*model.ActorInfo, field string) []*model.ActorInfo {
+	preferred := actorFieldPrecedence[field]
+	if len(preferred) == 0 {
+		return infos
+	}
+	ordered := slices.Clone(infos)
+	rank := func(info *model.ActorInfo) int {
+		if i := slices.IndexFunc(preferred, func(name string) bool {
+			return strings.EqualFold(name, info.Provider)
+		}); i >= 0 {
+			return i
+		}
+		return len(preferred)
+	}
+	slices.SortStableFunc(ordered, func(a, b *model.ActorInfo) int {
+		return rank(a) - rank(b)
+	})
+	return ordered
+}
+
+// normalizeActorName returns the comparable form of actor names, it
+// ignores case and spaces, e.g. between family and given names.
+func normalizeActorName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), ""))
+}
+
+// isSameActor reports whether the actors share any name or alias.
+func isSameActor(names map[string]bool, name string, aliases []string) bool {
+	for _, n := range append([]string{name}, aliases...) {
+		if names[normalizeActorName(n)] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMergedActorInfo gets the actor info from the provider, and merges
+// the same person, i.e. sharing any name or alias, of other providers
+// into one canonical record. Sources of fields are recorded.
+func (e *Engine) GetMergedActorInfo(name, id string, lazy bool) (*model.ActorInfo, error) {
+	info, err := e.GetActorInfoByProviderID(name, id, lazy)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, n := range append([]string{info.Name}, info.Aliases...) {
+		if key := normalizeActorName(n); key != "" {
+			names[key] = true
+		}
+	}
+
+	infos := []*model.ActorInfo{info}
+	results, _ := e.SearchActorAll(info.Name, true) // ignore search errors.
+	for _, result := range results {
+		if slices.ContainsFunc(infos, func(i *model.ActorInfo) bool {
+			return i.Provider == result.Provider
+		}) || !isSameActor(names, result.Name, result.Aliases) {
+			continue // one record per provider.
+		}
+		other, err := e.GetActorInfoByProviderID(result.Provider, result.ID, true)
+		if err != nil {
+			e.logger.Warn("get same actor info",
+				slog.String("provider", result.Provider),
+				slog.String("id", result.ID),
+				slog.Any("error", err))
+			continue
+		}
+		infos = append(infos, other)
+	}
+	return mergeActorInfos(infos), nil
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestMergeActorInfos(t *testing.T) {
+	birthday := datatypes.Date(time.Date(1995, 1, 2, 0, 0, 0, 0, time.UTC))
+	merged := mergeActorInfos([]*model.ActorInfo{
+		{
+			Provider: "AVBASE",
+			Name:     "三上悠亜",
+			Summary:  "Summary",
+			Height:   160,
+			Aliases:  []string{"鬼頭桃菜"},
+			Images:   []string{"https://avbase/1.jpg"},
+		},
+		{
+			Provider: "XsList",
+			Name:     "三上 悠亜",
+			Height:   159,
+			Birthday: birthday,
+			Aliases:  []string{"Yua Mikami", "鬼頭 桃菜"},
+			Images:   []string{"https://xslist/1.jpg"},
+		},
+		{
+			Provider: "Gfriends",
+			Name:     "三上悠亜",
+			Images:   []string{"https://gfriends/1.jpg", "https://avbase/1.jpg"},
+		},
+	})
+	assert.Equal(t, "AVBASE", merged.Provider)
+	assert.Equal(t, "三上悠亜", merged.Name)
+	assert.Equal(t, "Summary", merged.Summary)
+	assert.Equal(t, 159, merged.Height)
+	assert.Equal(t, birthday, merged.Birthday)
+	assert.Equal(t, []string{"鬼頭桃菜", "Yua Mikami"}, []string(merged.Aliases))
+	assert.Equal(t, []string{"https://gfriends/1.jpg", "https://avbase/1.jpg", "https://xslist/1.jpg"}, []string(merged.Images))
+	assert.Equal(t, map[string]string{
+		"summary":  "AVBASE",
+		"height":   "XsList",
+		"birthday": "XsList",
+		"images":   "Gfriends",
+	}, merged.Sources)
+}
+
+func TestIsSameActor(t *testing.T) {
+	names := map[string]bool{"三上悠亜": true, "yuamikami": true}
+	assert.True(t, isSameActor(names, "三上 悠亜", nil))
+	assert.True(t, isSameActor(names, "Other", []string{"Yua Mikami"}))
+	assert.False(t, isSameActor(names, "Other", []string{"Another"}))
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// DBStats is the statistics of cached metadata in the database.
+type DBStats struct {
+	Type             string           `json:"type"`
+	Movies           int64            `json:"movies"`
+	Actors           int64            `json:"actors"`
+	Reviews          int64            `json:"reviews"`
+	MoviesByProvider map[string]int64 `json:"movies_by_provider"`
+	ActorsByProvider map[string]int64 `json:"actors_by_provider"`
+}
+
+// PurgeMovieInfo deletes the cached movie info and its reviews, so
+// that the next lookup will fetch them from the provider again.
+func (e *Engine) PurgeMovieInfo(name, id string) error {
+	provider, err := e.GetMovieProviderByName(name)
+	if err != nil {
+		return err
+	}
+	if id = provider.NormalizeMovieID(id); id == "" {
+		return mt.ErrInvalidID
+	}
+	tx := e.db.
+		Where("provider = ?", provider.Name()).
+		Where("id = ? COLLATE NOCASE", id).
+		Delete(&model.MovieInfo{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	e.evictMovieInfo(provider.Name(), id)
+	if err = e.db.
+		Where("provider = ?", provider.Name()).
+		Where("id = ? COLLATE NOCASE", id).
+		Delete(&model.MovieReviewInfo{}).Error; err != nil {
+		return err
+	}
+	if tx.RowsAffected == 0 {
+		return mt.ErrInfoNotFound
+	}
+	return nil
+}
+
+// PurgeActorInfo deletes the cached actor info.
+func (e *Engine) PurgeActorInfo(name, id string) error {
+	provider, err := e.GetActorProviderByName(name)
+	if err != nil {
+		return err
+	}
+	if id = provider.NormalizeActorID(id); id == "" {
+		return mt.ErrInvalidID
+	}
+	tx := e.db.
+		Where("provider = ?", provider.Name()).
+		Where("id = ? COLLATE NOCASE", id).
+		Delete(&model.ActorInfo{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	e.evictActorInfo(provider.Name(), id)
+	if tx.RowsAffected == 0 {
+		return mt.ErrInfoNotFound
+	}
+	return nil
+}
+
+// DBStats returns the statistics of cached metadata.
+func (e *Engine) DBStats() (*DBStats, error) {
+	stats := &DBStats{Type: e.DBType()}
+	var err error
+	if stats.MoviesByProvider, stats.Movies, err = e.countByProvider(&model.MovieInfo{}); err != nil {
+		return nil, err
+	}
+	if stats.ActorsByProvider, stats.Actors, err = e.countByProvider(&model.ActorInfo{}); err != nil {
+		return nil, err
+	}
+	if err = e.db.Model(&model.MovieReviewInfo{}).Count(&stats.Reviews).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (e *Engine) countByProvider(v any) (counts map[string]int64, total int64, err error) {
+	var rows []struct {
+		Provider string
+		Count    int64
+	}
+	if err = e.db.Model(v).
+		Select("provider, COUNT(*) AS count").
+		Group("provider").
+		Scan(&rows).Error; err != nil {
+		return
+	}
+	counts = make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Provider] = row.Count
+		total += row.Count
+	}
+	return
+}
+
+// DBVacuum reclaims unused space of the database.
+func (e *Engine) DBVacuum() error {
+	switch dbType := e.DBType(); dbType {
+	case database.Postgres:
+		for _, table := range []string{
+			model.MovieMetadataTableName,
+			model.ActorMetadataTableName,
+			model.MovieReviewsTableName,
+		} {
+			if err := e.db.Exec(fmt.Sprintf("VACUUM ANALYZE %s;", table)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	case database.Sqlite:
+		return e.db.Exec("VACUUM;").Error
+	default:
+		return fmt.Errorf("unsupported DB type: %s", dbType)
+	}
+}
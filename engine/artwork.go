@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// artworkStatus is the result of checking an artwork URL.
+type artworkStatus int
+
+const (
+	artworkOK artworkStatus = iota
+	artworkDead
+	artworkPlaceholder
+)
+
+// ArtworkReport is the summary of an artwork validation run.
+type ArtworkReport struct {
+	Checked     int       `json:"checked"`
+	Dead        int       `json:"dead"`
+	Placeholder int       `json:"placeholder"`
+	Repaired    int       `json:"repaired"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+}
+
+type validateArtworkJobParams struct {
+	Limit int `json:"limit"`
+}
+
+// checkArtwork fetches the image, and reports whether it is dead, i.e.
+// not found or not an image, or a placeholder.
+func (e *Engine) checkArtwork(provider mt.Provider, url string) artworkStatus {
+	resp, err := e.Fetch(url, provider)
+	if err != nil {
+		return artworkDead
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return artworkDead
+	}
+	img, _, err := imageutil.Decode(resp.Body)
+	if err != nil {
+		return artworkDead
+	}
+	if imageutil.IsPlaceholder(img) {
+		return artworkPlaceholder
+	}
+	return artworkOK
+}
+
+// ValidateArtwork checks the covers of stored movies, least recently
+// updated first and at most limit of them if limit > 0. Dead links and
+// placeholder images are repaired with the cover of the same movie from
+// another provider.
+func (e *Engine) ValidateArtwork(limit int) (*ArtworkReport, error) {
+	report := &ArtworkReport{StartedAt: time.Now()}
+
+	var infos []*model.MovieInfo
+	tx := e.db.Where("cover_url <> ''").Order("updated_at")
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	if err := tx.Find(&infos).Error; err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if err := e.ctx.Err(); err != nil {
+			return nil, err
+		}
+		provider, err := e.GetMovieProviderByName(info.Provider)
+		if err != nil {
+			continue // provider removed.
+		}
+		report.Checked++
+		switch e.checkArtwork(provider, info.CoverURL) {
+		case artworkOK:
+			continue
+		case artworkDead:
+			report.Dead++
+		case artworkPlaceholder:
+			report.Placeholder++
+		}
+		if e.repairArtwork(info) {
+			report.Repaired++
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	e.logger.Info("validate artwork",
+		slog.Int("checked", report.Checked),
+		slog.Int("dead", report.Dead),
+		slog.Int("placeholder", report.Placeholder),
+		slog.Int("repaired", report.Repaired),
+		slog.Duration("duration", report.FinishedAt.Sub(report.StartedAt)))
+	return report, nil
+}
+
+// repairArtwork replaces the cover images of the stored movie with the
+// first valid ones of the same movie from other providers.
+func (e *Engine) repairArtwork(info *model.MovieInfo) (repaired bool) {
+	e.forEachSameMovie(info, func(other *model.MovieInfo) bool {
+		provider, err := e.GetMovieProviderByName(other.Provider)
+		if err != nil || other.CoverURL == "" ||
+			e.checkArtwork(provider, other.CoverURL) != artworkOK {
+			return true // try next.
+		}
+		repaired = e.db.Model(&model.MovieInfo{}).
+			Where("provider = ? AND id = ?", info.Provider, info.ID).
+			Updates(map[string]any{
+				"cover_url":     other.CoverURL,
+				"big_cover_url": other.BigCoverURL,
+				"thumb_url":     other.ThumbURL,
+				"big_thumb_url": other.BigThumbURL,
+			}).Error == nil
+		e.evictMovieInfo(info.Provider, info.ID)
+		e.logger.Info("repair artwork",
+			slog.String("provider", info.Provider),
+			slog.String("id", info.ID),
+			slog.String("from", other.Provider))
+		return false
+	})
+	return
+}
+
+func (e *Engine) validateArtworkJob(ctx context.Context, params json.RawMessage) (any, error) {
+	p := &validateArtworkJobParams{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+	}
+	return e.WithContext(ctx).ValidateArtwork(p.Limit)
+}
+
+// fillMissingArtwork fills in the cover images of the scraped movie,
+// e.g. a placeholder one dropped by the provider, from the same movie
+// of other providers in priority order. Only searchers are queried,
+// so that it never scrapes the same movie info recursively.
+func (e *Engine) fillMissingArtwork(info *model.MovieInfo) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []*model.MovieSearchResult
+	)
+	if dbResults, err := e.searchMovieFromDB(info.Number, nil, true); err == nil {
+		results = append(results, dbResults...)
+	}
+	for _, provider := range e.movieProviders {
+		if _, ok := provider.(mt.MovieSearcher); !ok || provider.Name() == info.Provider {
+			continue
+		}
+		wg.Add(1)
+		go func(provider mt.MovieProvider) {
+			defer wg.Done()
+			innerResults, innerErr := e.searchMovie(info.Number, provider, false)
+			if innerErr != nil {
+				return // ignore search errors.
+			}
+			mu.Lock()
+			results = append(results, innerResults...)
+			mu.Unlock()
+		}(provider)
+	}
+	wg.Wait()
+
+	var best *model.MovieSearchResult
+	for _, result := range results {
+		if result.Provider == info.Provider || result.CoverURL == "" ||
+			number.Normalize(result.Number) != number.Normalize(info.Number) {
+			continue
+		}
+		provider, err := e.GetMovieProviderByName(result.Provider)
+		if err != nil {
+			continue
+		}
+		if best == nil || provider.Priority() > e.MustGetMovieProviderByName(best.Provider).Priority() {
+			best = result
+		}
+	}
+	if best == nil {
+		return
+	}
+	info.CoverURL = best.CoverURL
+	if info.ThumbURL == "" {
+		info.ThumbURL = best.ThumbURL
+	}
+	e.logger.Info("fill missing artwork",
+		slog.String("provider", info.Provider),
+		slog.String("id", info.ID),
+		slog.String("from", best.Provider))
+}
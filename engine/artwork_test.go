@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine_CheckArtwork(t *testing.T) {
+	encode := func(w, h int) []byte {
+		img := image.NewGray(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				img.SetGray(x, y, color.Gray{Y: uint8((x + y) % 256)})
+			}
+		}
+		buf := &bytes.Buffer{}
+		_ = png.Encode(buf, img)
+		return buf.Bytes()
+	}
+	cover, pixel := encode(200, 300), encode(1, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cover.png":
+			w.Write(cover)
+		case "/pixel.png":
+			w.Write(pixel)
+		case "/text":
+			w.Write([]byte("now printing"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	e := Default()
+	for _, unit := range []struct {
+		path string
+		want artworkStatus
+	}{
+		{"/cover.png", artworkOK},
+		{"/pixel.png", artworkPlaceholder},
+		{"/text", artworkDead},
+		{"/missing.png", artworkDead},
+	} {
+		assert.Equal(t, unit.want, e.checkArtwork(nil, srv.URL+unit.path), unit.path)
+	}
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// MaxBirthdayDays is the max date range of a birthday query.
+const MaxBirthdayDays = 366
+
+// GetActorBirthdays returns actors of the database whose birthdays are
+// within days from date `from`, sorted by the upcoming date. The same
+// person of multiple providers, i.e. the same name, is listed once.
+func (e *Engine) GetActorBirthdays(from time.Time, days int) ([]*model.ActorBirthday, error) {
+	if days <= 0 || days > MaxBirthdayDays {
+		return nil, ErrInvalidDateRange
+	}
+	from = truncateDate(from)
+	to := from.AddDate(0, 0, days)
+
+	var actors []*model.ActorInfo
+	if err := e.db.
+		Select("id", "name", "provider", "homepage", "aliases", "images", "birthday").
+		Where("birthday IS NOT NULL").
+		Find(&actors).Error; err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]*model.ActorBirthday)
+	for _, actor := range actors {
+		birthday := time.Time(actor.Birthday)
+		if birthday.Year() <= 1 /* unknown */ {
+			continue
+		}
+		date := time.Date(from.Year(), birthday.Month(), birthday.Day(), 0, 0, 0, 0, time.UTC)
+		if date.Before(from) {
+			date = date.AddDate(1, 0, 0)
+		}
+		if !date.Before(to) {
+			continue
+		}
+		key := normalizeActorName(actor.Name)
+		if other, ok := seen[key]; ok && e.actorPriority(other.Provider) >= e.actorPriority(actor.Provider) {
+			continue
+		}
+		seen[key] = &model.ActorBirthday{
+			ActorSearchResult: actor.ToSearchResult(),
+			Birthday:          actor.Birthday,
+			Date:              datatypes.Date(date),
+			Age:               actor.AgeAt(date),
+		}
+	}
+
+	birthdays := make([]*model.ActorBirthday, 0, len(seen))
+	for _, birthday := range seen {
+		birthdays = append(birthdays, birthday)
+	}
+	sort.Slice(birthdays, func(i, j int) bool {
+		a, b := time.Time(birthdays[i].Date), time.Time(birthdays[j].Date)
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return birthdays[i].Name < birthdays[j].Name
+	})
+	return birthdays, nil
+}
+
+// actorPriority returns the priority of the actor provider, 0 if the
+// provider is no longer available.
+func (e *Engine) actorPriority(name string) float64 {
+	if provider, err := e.GetActorProviderByName(name); err == nil {
+		return provider.Priority()
+	}
+	return 0
+}
+
+// FillActorAges sets ages of the movie actors at the release date,
+// from birthdays of actors of the same names in the database.
+func (e *Engine) FillActorAges(info *model.MovieInfo) error {
+	release := time.Time(info.ReleaseDate)
+	if release.Year() <= 1 || len(info.Actors) == 0 {
+		return nil
+	}
+	var actors []*model.ActorInfo
+	if err := e.db.
+		Select("name", "birthday").
+		Where("name IN ? AND birthday IS NOT NULL", []string(info.Actors)).
+		Find(&actors).Error; err != nil {
+		return err
+	}
+	for _, actor := range actors {
+		age := actor.AgeAt(release)
+		if age == 0 {
+			continue
+		}
+		if info.ActorAges == nil {
+			info.ActorAges = make(map[string]int)
+		}
+		// birthdays may differ among providers, the first one wins.
+		if _, ok := info.ActorAges[actor.Name]; !ok {
+			info.ActorAges[actor.Name] = age
+		}
+	}
+	return nil
+}
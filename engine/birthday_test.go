@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_GetActorBirthdays(t *testing.T) {
+	e := Default()
+	date := func(year int, month time.Month, day int) datatypes.Date {
+		return datatypes.Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	actor := func(provider, id, name string, birthday datatypes.Date) *model.ActorInfo {
+		return &model.ActorInfo{ID: id, Provider: provider, Name: name, Homepage: id, Birthday: birthday}
+	}
+	require.NoError(t, e.db.Create([]*model.ActorInfo{
+		actor("XsList", "birthday-1", "Birthday One", date(1995, time.December, 30)),
+		actor("Gfriends", "birthday-1", "Birthday One", date(1995, time.December, 30)),
+		actor("XsList", "birthday-2", "Birthday Two", date(2000, time.January, 2)),
+		actor("XsList", "birthday-3", "Birthday Three", date(2000, time.February, 1)),
+	}).Error)
+
+	birthdays, err := e.GetActorBirthdays(time.Date(2024, time.December, 28, 0, 0, 0, 0, time.UTC), 7)
+	require.NoError(t, err)
+	var names []string
+	for _, b := range birthdays {
+		if b.ID == "birthday-1" || b.ID == "birthday-2" || b.ID == "birthday-3" {
+			names = append(names, b.Name)
+		}
+	}
+	assert.Equal(t, []string{"Birthday One", "Birthday Two"}, names)
+	for _, b := range birthdays {
+		switch b.ID {
+		case "birthday-1":
+			assert.Equal(t, 29, b.Age)
+			assert.Equal(t, date(2024, time.December, 30), b.Date)
+		case "birthday-2":
+			assert.Equal(t, 25, b.Age)
+			assert.Equal(t, date(2025, time.January, 2), b.Date)
+		}
+	}
+
+	_, err = e.GetActorBirthdays(time.Now(), 0)
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+
+	info := &model.MovieInfo{
+		ReleaseDate: date(2020, time.January, 1),
+		Actors:      []string{"Birthday One", "Birthday Two", "Unknown"},
+	}
+	require.NoError(t, e.FillActorAges(info))
+	assert.Equal(t, map[string]int{"Birthday One": 24, "Birthday Two": 19}, info.ActorAges)
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+var ErrBlocked = errors.New(http.StatusForbidden, "blocked")
+
+// Blocklist filters movies out of search results, and refuses to
+// scrape them, by numbers, keyword patterns or genres.
+type Blocklist struct {
+	numbers  map[string]struct{}
+	keywords []*regexp.Regexp
+	genres   map[string]struct{}
+}
+
+// NewBlocklist returns a *Blocklist, numbers are compared in normalized
+// form, keywords are regular expressions matched against numbers and
+// titles, and genres are compared case-insensitively.
+func NewBlocklist(numbers, keywords, genres []string) (*Blocklist, error) {
+	b := &Blocklist{
+		numbers: make(map[string]struct{}, len(numbers)),
+		genres:  make(map[string]struct{}, len(genres)),
+	}
+	for _, num := range numbers {
+		b.numbers[number.Normalize(num)] = struct{}{}
+	}
+	for _, keyword := range keywords {
+		re, err := regexp.Compile(keyword)
+		if err != nil {
+			return nil, err
+		}
+		b.keywords = append(b.keywords, re)
+	}
+	for _, genre := range genres {
+		b.genres[strings.ToLower(strings.TrimSpace(genre))] = struct{}{}
+	}
+	return b, nil
+}
+
+// BlocksNumber reports whether the number, or ID, is blocked.
+func (b *Blocklist) BlocksNumber(s string) bool {
+	if b == nil || s == "" {
+		return false
+	}
+	if _, ok := b.numbers[number.Normalize(s)]; ok {
+		return true
+	}
+	return b.matchKeyword(s)
+}
+
+// BlocksSearchResult reports whether the search result is blocked.
+func (b *Blocklist) BlocksSearchResult(result *model.MovieSearchResult) bool {
+	if b == nil {
+		return false
+	}
+	return b.BlocksNumber(result.Number) || b.matchKeyword(result.Title)
+}
+
+// BlocksMovieInfo reports whether the movie info is blocked.
+func (b *Blocklist) BlocksMovieInfo(info *model.MovieInfo) bool {
+	if b == nil {
+		return false
+	}
+	if b.BlocksNumber(info.Number) || b.matchKeyword(info.Title) {
+		return true
+	}
+	for _, genre := range info.Genres {
+		if _, ok := b.genres[strings.ToLower(strings.TrimSpace(genre))]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Blocklist) matchKeyword(s string) bool {
+	for _, re := range b.keywords {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBlocklist replaces the blocklist at runtime, nil disables it.
+func (e *Engine) SetBlocklist(b *Blocklist) { e.blocklist.Store(b) }
+
+// filterBlocked removes blocked results in place.
+func (e *Engine) filterBlocked(results []*model.MovieSearchResult) []*model.MovieSearchResult {
+	b := e.blocklist.Load()
+	if b == nil {
+		return results
+	}
+	filtered := results[:0]
+	for _, result := range results {
+		if !b.BlocksSearchResult(result) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
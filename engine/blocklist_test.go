@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestBlocklist(t *testing.T) {
+	b, err := NewBlocklist([]string{"ABP-030"}, []string{`(?i)^fc2`, `禁止`}, []string{"Blocked"})
+	require.NoError(t, err)
+
+	assert.True(t, b.BlocksNumber("abp00030"))
+	assert.True(t, b.BlocksNumber("FC2-738573"))
+	assert.False(t, b.BlocksNumber("ABP-031"))
+	assert.True(t, b.BlocksSearchResult(&model.MovieSearchResult{Number: "ABP-031", Title: "禁止タイトル"}))
+	assert.True(t, b.BlocksMovieInfo(&model.MovieInfo{Number: "ABP-031", Genres: []string{"blocked"}}))
+	assert.False(t, b.BlocksMovieInfo(&model.MovieInfo{Number: "ABP-031", Genres: []string{"Other"}}))
+
+	var nilList *Blocklist
+	assert.False(t, nilList.BlocksNumber("ABP-030"))
+
+	_, err = NewBlocklist(nil, []string{"("}, nil)
+	assert.Error(t, err)
+}
+
+func TestEngine_Blocklist(t *testing.T) {
+	b, err := NewBlocklist([]string{"ABP-040"}, nil, nil)
+	require.NoError(t, err)
+	e := Default()
+	e.SetBlocklist(b)
+
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:       "abp00040",
+		Number:   "ABP-040",
+		Title:    "Title",
+		Provider: "FANZA",
+		Homepage: "https://www.dmm.co.jp/",
+		CoverURL: "https://www.dmm.co.jp/cover.jpg",
+	}).Error)
+	_, err = e.GetMovieInfoByProviderID("fanza", "abp00040", true)
+	assert.ErrorIs(t, err, ErrBlocked)
+
+	results := e.filterBlocked([]*model.MovieSearchResult{{Number: "ABP-040"}, {Number: "ABP-031"}})
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "ABP-031", results[0].Number)
+	}
+}
+
+func TestEngine_SearchMovieAllBlocked(t *testing.T) {
+	db, err := database.Open(&database.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+	// no providers to search, results come from the database only.
+	e.movieProviders = map[string]mt.MovieProvider{}
+
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:       "abp00060",
+		Number:   "ABP-060",
+		Title:    "Title",
+		Provider: "FANZA",
+		Homepage: "https://www.dmm.co.jp/",
+		CoverURL: "https://www.dmm.co.jp/cover.jpg",
+	}).Error)
+	b, err := NewBlocklist([]string{"ABP-060"}, nil, nil)
+	require.NoError(t, err)
+	e.SetBlocklist(b)
+
+	results, err := e.SearchMovieAll("ABP-060", true)
+	assert.ErrorIs(t, err, mt.ErrInfoNotFound)
+	assert.Empty(t, results)
+}
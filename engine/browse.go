@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// browseMovie queries all movie providers that the search function
+// supports concurrently, and merges their results by number.
+func (e *Engine) browseMovie(operation, key string, search func(mt.MovieProvider) ([]*model.MovieSearchResult, bool, error)) []*model.MovieSearchResult {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		ds      []any
+		results []*model.MovieSearchResult
+	)
+	for _, provider := range e.movieProviders {
+		wg.Add(1)
+		go func(provider mt.MovieProvider) {
+			defer wg.Done()
+			startTime := time.Now()
+			innerResults, ok, innerErr := search(provider)
+			if !ok {
+				return // unsupported.
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			d := time.Since(startTime).String()
+			if innerErr != nil {
+				d += " " + innerErr.Error()
+			} else {
+				results = append(results, innerResults...)
+			}
+			ds = append(ds, slog.String(provider.Name(), d))
+		}(provider)
+	}
+	wg.Wait()
+
+	results = e.filterBlocked(e.mergeFilmography(results))
+	sanitizeActors(results)
+	detectEditions(results)
+	e.logger.Info(strings.ReplaceAll(operation, "_", " "),
+		slog.String("key", key),
+		slog.Int("results", len(results)),
+		slog.Group("providers", ds...))
+	return results
+}
+
+// SearchMovieByGenre lists movies of the genre from all providers that
+// expose genre pages, page starts from 1 and is passed to providers.
+func (e *Engine) SearchMovieByGenre(genre string, page int) ([]*model.MovieSearchResult, error) {
+	if genre = strings.TrimSpace(genre); genre == "" {
+		return nil, mt.ErrInvalidKeyword
+	}
+	if page < 1 {
+		page = 1
+	}
+	results := e.browseMovie(opSearchMovieByGenre, genre, func(provider mt.MovieProvider) (results []*model.MovieSearchResult, ok bool, err error) {
+		searcher, ok := provider.(mt.MovieGenreSearcher)
+		if !ok {
+			return nil, false, nil
+		}
+		ctx, done, err := e.observe(provider, opSearchMovieByGenre, genre)
+		if err != nil {
+			return nil, true, err
+		}
+		defer done(&err)
+		results, err = mt.WithContext(searcher, ctx).SearchMovieByGenre(genre, page)
+		return
+	})
+	if len(results) == 0 {
+		return nil, mt.ErrInfoNotFound
+	}
+	return results, nil
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// MaxCalendarDays is the max date range of a release calendar query.
+const MaxCalendarDays = 93
+
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+// GetMovieCalendar aggregates release calendars of the providers from
+// date `from` to `to`, both inclusive. Results are filtered by actors
+// if given, and sorted by release date in ascending order.
+func (e *Engine) GetMovieCalendar(from, to time.Time, actors ...string) ([]*model.MovieSearchResult, error) {
+	from, to = truncateDate(from), truncateDate(to)
+	if to.Before(from) || to.Sub(from) > MaxCalendarDays*24*time.Hour {
+		return nil, ErrInvalidDateRange
+	}
+
+	key := from.Format(time.DateOnly) + "/" + to.Format(time.DateOnly)
+	results := e.browseMovie(opMovieCalendar, key, func(provider mt.MovieProvider) (results []*model.MovieSearchResult, ok bool, err error) {
+		getter, ok := provider.(mt.MovieCalendarGetter)
+		if !ok {
+			return nil, false, nil
+		}
+		ctx, done, err := e.observe(provider, opMovieCalendar, key)
+		if err != nil {
+			return nil, true, err
+		}
+		defer done(&err)
+		for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+			var monthResults []*model.MovieSearchResult
+			if monthResults, err = mt.WithContext(getter, ctx).GetMovieCalendar(month.Year(), month.Month()); err != nil {
+				return
+			}
+			results = append(results, monthResults...)
+		}
+		return
+	})
+
+	filtered := results[:0]
+	for _, result := range results {
+		date := truncateDate(time.Time(result.ReleaseDate))
+		if date.Before(from) || date.After(to) || !hasAnyActor(result, actors) {
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return time.Time(filtered[i].ReleaseDate).Before(time.Time(filtered[j].ReleaseDate))
+	})
+	return filtered, nil
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// hasAnyActor reports whether any of the actors is in the result,
+// empty actors match all results.
+func hasAnyActor(result *model.MovieSearchResult, actors []string) bool {
+	if len(actors) == 0 {
+		return true
+	}
+	for _, actor := range actors {
+		for _, name := range result.Actors {
+			if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(actor)) {
+				return true
+			}
+		}
+	}
+	return false
+}
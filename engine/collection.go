@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+var (
+	ErrInvalidCollectionItem  = errors.New(http.StatusBadRequest, "invalid collection item")
+	ErrCollectionItemNotFound = errors.New(http.StatusNotFound, "collection item not found")
+)
+
+// AddCollectionItem adds the movie or actor of the provider to the
+// collection of the user, it returns the existing item if it's already
+// in the collection, the note is updated if not empty.
+func (e *Engine) AddCollectionItem(user string, collection model.CollectionType, provider, id, note string) (*model.CollectionItem, error) {
+	if id = strings.TrimSpace(id); id == "" || !collection.Valid() {
+		return nil, ErrInvalidCollectionItem
+	}
+	if collection.IsActor() {
+		p, err := e.GetActorProviderByName(provider)
+		if err != nil {
+			return nil, err
+		}
+		provider = p.Name()
+	} else {
+		p, err := e.GetMovieProviderByName(provider)
+		if err != nil {
+			return nil, err
+		}
+		provider = p.Name()
+	}
+	item := &model.CollectionItem{
+		User:       user,
+		Collection: collection,
+		Provider:   provider,
+		ItemID:     id,
+	}
+	if err := e.db.
+		Where(item).
+		FirstOrCreate(item).Error; err != nil {
+		return nil, err
+	}
+	if note = strings.TrimSpace(note); note != "" && note != item.Note {
+		item.Note = note
+		if err := e.db.Model(item).Update("note", note).Error; err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+// RemoveCollectionItem removes the item from the collections of the user.
+func (e *Engine) RemoveCollectionItem(user string, id uint) error {
+	result := e.db.
+		Where("username = ?", user).
+		Delete(&model.CollectionItem{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCollectionItemNotFound
+	}
+	return nil
+}
+
+// GetCollection returns the items of the collection of the user, most
+// recently added first, or items of all collections if empty.
+func (e *Engine) GetCollection(user string, collection model.CollectionType) (items []*model.CollectionItem, err error) {
+	if collection != "" && !collection.Valid() {
+		return nil, ErrInvalidCollectionItem
+	}
+	tx := e.primaryDB().Where("username = ?", user)
+	if collection != "" {
+		tx = tx.Where("collection = ?", collection)
+	}
+	err = tx.
+		Order("id DESC").
+		Find(&items).Error
+	return
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestEngine_Collections(t *testing.T) {
+	e := Default()
+
+	movie, err := e.AddCollectionItem("carol", model.CollectionFavoriteMovies, "fanza", "abc001", "")
+	require.NoError(t, err)
+	assert.Equal(t, "FANZA", movie.Provider)
+	again, err := e.AddCollectionItem("carol", model.CollectionFavoriteMovies, "FANZA", " abc001 ", "great")
+	require.NoError(t, err)
+	assert.Equal(t, movie.ID, again.ID)
+	assert.Equal(t, "great", again.Note)
+
+	later, err := e.AddCollectionItem("carol", model.CollectionWatchLater, "FANZA", "abc002", "")
+	require.NoError(t, err)
+
+	for _, unit := range []struct {
+		collection model.CollectionType
+		provider   string
+		id         string
+		err        error
+	}{
+		{"unknown", "FANZA", "abc001", ErrInvalidCollectionItem},
+		{model.CollectionFavoriteMovies, "FANZA", " ", ErrInvalidCollectionItem},
+		{model.CollectionFavoriteMovies, "unknown", "abc001", mt.ErrProviderNotFound},
+		{model.CollectionFavoriteActors, "FANZA", "1", mt.ErrProviderNotFound},
+	} {
+		_, err := e.AddCollectionItem("carol", unit.collection, unit.provider, unit.id, "")
+		assert.ErrorIs(t, err, unit.err)
+	}
+
+	items, err := e.GetCollection("carol", model.CollectionFavoriteMovies)
+	require.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "great", items[0].Note)
+	}
+	items, err = e.GetCollection("carol", "")
+	require.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, later.ID, items[0].ID)
+	}
+	_, err = e.GetCollection("carol", "unknown")
+	assert.ErrorIs(t, err, ErrInvalidCollectionItem)
+
+	assert.ErrorIs(t, e.RemoveCollectionItem("dave", movie.ID), ErrCollectionItemNotFound)
+	require.NoError(t, e.RemoveCollectionItem("carol", movie.ID))
+	items, err = e.GetCollection("carol", model.CollectionFavoriteMovies)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// cookieManagers returns the cookie managers of the named provider,
+// the actor and movie providers of the same name are different
+// instances.
+func (e *Engine) cookieManagers(name string) (managers []mt.CookieManager) {
+	name = strings.ToUpper(name)
+	if p, ok := e.actorProviders[name].(mt.CookieManager); ok {
+		managers = append(managers, p)
+	}
+	if p, ok := e.movieProviders[name].(mt.CookieManager); ok {
+		managers = append(managers, p)
+	}
+	return
+}
+
+func (e *Engine) cookieProviderNames() []string {
+	names := make(map[string]struct{})
+	for name := range e.actorProviders {
+		names[name] = struct{}{}
+	}
+	for name := range e.movieProviders {
+		names[name] = struct{}{}
+	}
+	out := make([]string, 0, len(names))
+	for name := range names {
+		out = append(out, name)
+	}
+	return out
+}
+
+// GetProviderCookies returns the cookies of the named provider keyed
+// by the URLs they were set for.
+func (e *Engine) GetProviderCookies(name string) (map[string][]*http.Cookie, error) {
+	if !e.IsActorProvider(name) && !e.IsMovieProvider(name) {
+		return nil, mt.ErrProviderNotFound
+	}
+	cookies := make(map[string][]*http.Cookie)
+	for _, m := range e.cookieManagers(name) {
+		for u, cs := range m.Cookies() {
+			seen := make(map[string]struct{}, len(cookies[u]))
+			for _, c := range cookies[u] {
+				seen[c.Name] = struct{}{}
+			}
+			for _, c := range cs {
+				if _, ok := seen[c.Name]; !ok {
+					cookies[u] = append(cookies[u], c)
+				}
+			}
+		}
+	}
+	return cookies, nil
+}
+
+// SetProviderCookies sets cookies of the named provider for the URL,
+// empty means the provider's base URL, and persists all cookies.
+func (e *Engine) SetProviderCookies(name, rawURL string, cookies []*http.Cookie) error {
+	managers := e.cookieManagers(name)
+	if len(managers) == 0 {
+		if !e.IsActorProvider(name) && !e.IsMovieProvider(name) {
+			return mt.ErrProviderNotFound
+		}
+		return errors.New("cookies not supported")
+	}
+	for _, m := range managers {
+		if err := m.SetCookies(rawURL, cookies); err != nil {
+			return err
+		}
+	}
+	return e.SaveCookies()
+}
+
+// loadCookies loads persisted cookies of all providers, if any.
+func (e *Engine) loadCookies() error {
+	if e.cookieFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(e.cookieFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// provider -> url -> cookies
+	all := make(map[string]map[string][]*http.Cookie)
+	if err = json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for name, byURL := range all {
+		for _, m := range e.cookieManagers(name) {
+			for u, cookies := range byURL {
+				if err = m.SetCookies(u, cookies); err != nil {
+					e.logger.Warn("load cookies",
+						slog.String("provider", name),
+						slog.Any("error", err))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SaveCookies persists cookies of all providers to the cookie
+// file, it's a no-op if no cookie file is set.
+func (e *Engine) SaveCookies() error {
+	if e.cookieFile == "" {
+		return nil
+	}
+	all := make(map[string]map[string][]*http.Cookie)
+	for _, name := range e.cookieProviderNames() {
+		if cookies, _ := e.GetProviderCookies(name); len(cookies) > 0 {
+			all[name] = cookies
+		}
+	}
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write to a temp file first, so that a crash never
+	// leaves a truncated cookie file.
+	tmp, err := os.CreateTemp(filepath.Dir(e.cookieFile), ".cookies-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), e.cookieFile)
+}
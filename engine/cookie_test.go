@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+)
+
+func TestEngine_ProviderCookies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	open := func() *Engine {
+		db, _ := database.Open(&database.Config{DisableAutomaticPing: true})
+		return New(WithDB(db), WithCookieFile(path))
+	}
+
+	e := open()
+	require.NoError(t, e.SetProviderCookies("fanza", "", []*http.Cookie{
+		{Name: "session", Value: "abc"},
+	}))
+	_, err := e.GetProviderCookies("unknown")
+	assert.Error(t, err)
+
+	// cookies survive restarts.
+	e = open()
+	cookies, err := e.GetProviderCookies("FANZA")
+	require.NoError(t, err)
+	var found bool
+	for _, cs := range cookies {
+		for _, c := range cs {
+			found = found || (c.Name == "session" && c.Value == "abc")
+		}
+	}
+	assert.True(t, found)
+}
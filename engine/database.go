@@ -2,13 +2,24 @@ package engine
 
 import (
 	"fmt"
+	"log/slog"
+
+	"gorm.io/gorm"
 
 	"github.com/metatube-community/metatube-sdk-go/database"
-	"github.com/metatube-community/metatube-sdk-go/model"
 )
 
+// DBAutoMigrate applies pending schema migrations if v is true,
+// otherwise it only warns about them.
 func (e *Engine) DBAutoMigrate(v bool) error {
 	if !v {
+		pending, err := database.PendingMigrations(e.db, migrations)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			e.logger.Warn("database has pending migrations", slog.Any("migrations", pending))
+		}
 		return nil
 	}
 	// Create Case-Insensitive Collation for Postgres.
@@ -18,11 +29,27 @@ func (e *Engine) DBAutoMigrate(v bool) error {
 		locale = 'und-u-ks-level2',
 		deterministic = FALSE)`)
 	}
-	return e.db.AutoMigrate(
-		&model.MovieInfo{},
-		&model.ActorInfo{},
-		&model.MovieReviewInfo{},
-	)
+	applied, err := database.Migrate(e.db, migrations)
+	if len(applied) > 0 {
+		e.logger.Info("database migrated", slog.Any("migrations", applied))
+	}
+	return err
+}
+
+// primaryDB returns the DB whose queries are always served by the
+// primary, for states shared by replicas, e.g. jobs and follows, see
+// database.Primary.
+func (e *Engine) primaryDB() *gorm.DB {
+	return database.Primary(e.db)
+}
+
+// withPrimary returns a shallow copy of the Engine whose queries are
+// served by the primary, e.g. reloads of records saved by other
+// replicas right before.
+func (e *Engine) withPrimary() *Engine {
+	c := *e
+	c.db = e.primaryDB()
+	return &c
 }
 
 func (e *Engine) DBType() string {
@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+var (
+	ErrTraceNotSupported = errors.New(http.StatusNotImplemented, "provider does not support tracing")
+	ErrInvalidDryRun     = errors.New(http.StatusBadRequest, "invalid dry run")
+	ErrMultiDocument     = errors.New(http.StatusUnprocessableEntity, "provider fetches documents other than the given one")
+)
+
+// ScrapeDebug is the result of a traced scrape of a movie or actor.
+type ScrapeDebug struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	// Info is the parsed movie or actor info, nil if failed.
+	Info any `json:"info"`
+	// Empty are the JSON names of the fields of the info that are
+	// not extracted.
+	Empty []string  `json:"empty"`
+	Error string    `json:"error,omitempty"`
+	Trace *mt.Trace `json:"trace"`
+}
+
+// DryRun runs the parser of a provider with a fresh provider instance,
+// bypassing all caches, either against live pages or the given HTML.
+type DryRun struct {
+	Provider string
+	// ID or URL of the movie or actor, the URL takes precedence and
+	// must be of the site of the provider.
+	ID  string
+	URL string
+	// HTML is served for all requests of the provider instead of the
+	// live pages if not empty.
+	HTML []byte
+	// SingleDocument serves the HTML for requests of the URL only, and
+	// fails the run if the provider fetches any other document, e.g.
+	// an API, since the HTML is not all the provider parses then.
+	SingleDocument bool
+	Actor          bool
+}
+
+// DebugMovieScrape scrapes the movie of the provider with a fresh
+// provider instance, bypassing all caches, and traces the extraction.
+func (e *Engine) DebugMovieScrape(name, id string) (*ScrapeDebug, error) {
+	return e.DryRunParse(&DryRun{Provider: name, ID: id})
+}
+
+// DebugActorScrape is the same as DebugMovieScrape, but for actors.
+func (e *Engine) DebugActorScrape(name, id string) (*ScrapeDebug, error) {
+	return e.DryRunParse(&DryRun{Provider: name, ID: id, Actor: true})
+}
+
+// DryRunParse runs the dry run and traces the extraction, errors of
+// the provider are reported in the result.
+func (e *Engine) DryRunParse(r *DryRun) (*ScrapeDebug, error) {
+	if r.ID == "" && r.URL == "" {
+		return nil, ErrInvalidDryRun
+	}
+	var (
+		provider mt.Provider
+		scrape   func() (any, error)
+	)
+	if r.Actor {
+		p, err := newActorProvider(r.Provider)
+		if err != nil {
+			return nil, err
+		}
+		provider, scrape = p, func() (any, error) {
+			if r.URL != "" {
+				return p.GetActorInfoByURL(r.URL)
+			}
+			return p.GetActorInfoByID(p.NormalizeActorID(r.ID))
+		}
+	} else {
+		p, err := newMovieProvider(r.Provider)
+		if err != nil {
+			return nil, err
+		}
+		provider, scrape = p, func() (any, error) {
+			if r.URL != "" {
+				return p.GetMovieInfoByURL(r.URL)
+			}
+			return p.GetMovieInfoByID(p.NormalizeMovieID(r.ID))
+		}
+	}
+
+	if r.URL != "" {
+		// never fetches arbitrary URLs on behalf of the caller.
+		if u, err := url.Parse(r.URL); err != nil || !sameSite(u, provider) {
+			return nil, mt.ErrInvalidURL
+		}
+	}
+
+	tracer, ok := provider.(mt.Tracer)
+	if !ok {
+		return nil, ErrTraceNotSupported
+	}
+	if s, ok := provider.(mt.RequestTimeoutSetter); ok {
+		s.SetRequestTimeout(e.timeout)
+	}
+	transport := e.transport
+	if len(r.HTML) > 0 {
+		t := &htmlTransport{html: r.HTML}
+		if r.SingleDocument {
+			t.url = r.URL
+		}
+		transport = t
+	}
+	if s, ok := provider.(mt.TransportSetter); ok && transport != nil {
+		s.SetTransport(transport)
+	} else if len(r.HTML) > 0 {
+		return nil, ErrTraceNotSupported
+	}
+	e.applyProviderConfig(strings.ToUpper(r.Provider), provider)
+	// XPath overrides may have been reloaded into the running provider.
+	var running mt.Provider
+	if r.Actor {
+		running, _ = e.GetActorProviderByName(r.Provider)
+	} else {
+		running, _ = e.GetMovieProviderByName(r.Provider)
+	}
+	if s, ok := provider.(mt.XPathOverrider); ok {
+		if o, ok := running.(mt.XPathOverrider); ok {
+			s.SetXPathOverrides(o.XPathOverrides())
+		}
+	}
+
+	result := &ScrapeDebug{
+		Provider: provider.Name(),
+		ID:       r.ID,
+		Trace:    &mt.Trace{},
+	}
+	tracer.SetTrace(result.Trace)
+	info, err := scrape()
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Info = info
+	result.Empty = emptyFields(info)
+	return result, nil
+}
+
+func newMovieProvider(name string) (mt.MovieProvider, error) {
+	for n, factory := range mt.RangeMovieFactory {
+		if strings.EqualFold(n, name) {
+			return factory(), nil
+		}
+	}
+	return nil, mt.ErrProviderNotFound
+}
+
+func newActorProvider(name string) (mt.ActorProvider, error) {
+	for n, factory := range mt.RangeActorFactory {
+		if strings.EqualFold(n, name) {
+			return factory(), nil
+		}
+	}
+	return nil, mt.ErrProviderNotFound
+}
+
+// htmlTransport responds all requests with the HTML, or only those of
+// the URL if not empty.
+type htmlTransport struct {
+	html []byte
+	url  string
+}
+
+func (t *htmlTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.url != "" && !sameURL(req.URL.String(), t.url) {
+		return nil, ErrMultiDocument
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader(t.html)),
+		ContentLength: int64(len(t.html)),
+		Request:       req,
+	}, nil
+}
+
+// emptyFields returns the sorted JSON names of the zero-valued fields
+// of the info, empty lists and zero dates included.
+func emptyFields(info any) []string {
+	empty := []string{}
+	for name, isEmpty := range jsonFields(info) {
+		if isEmpty {
+			empty = append(empty, name)
+		}
+	}
+	sort.Strings(empty)
+	return empty
+}
+
+// jsonFields returns the JSON names of the fields of the info, mapped
+// to whether they are zero-valued.
+func jsonFields(info any) map[string]bool {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	m := make(map[string]bool, len(fields))
+	for name, v := range fields {
+		switch v := v.(type) {
+		case string:
+			m[name] = v == "" || strings.HasPrefix(v, "0001-01-01")
+		case float64:
+			m[name] = v == 0
+		case bool:
+			m[name] = !v
+		case []any:
+			m[name] = len(v) == 0
+		case map[string]any:
+			m[name] = len(v) == 0
+		default:
+			m[name] = true
+		}
+	}
+	return m
+}
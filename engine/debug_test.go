@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestEmptyFields(t *testing.T) {
+	info := &model.MovieInfo{
+		ID:          "abc001",
+		Number:      "ABC-001",
+		Provider:    "FANZA",
+		Actors:      []string{"Actor"},
+		Genres:      []string{},
+		ReleaseDate: datatypes.Date{},
+	}
+	empty := emptyFields(info)
+	for _, name := range []string{"title", "genres", "release_date", "runtime", "score"} {
+		assert.Contains(t, empty, name)
+	}
+	for _, name := range []string{"id", "number", "provider", "actors"} {
+		assert.NotContains(t, empty, name)
+	}
+}
+
+func TestEngine_DebugMovieScrape(t *testing.T) {
+	e := Default()
+	_, err := e.DebugMovieScrape("unknown", "abc001")
+	assert.ErrorIs(t, err, mt.ErrProviderNotFound)
+	_, err = e.DebugActorScrape("unknown", "1")
+	require.ErrorIs(t, err, mt.ErrProviderNotFound)
+}
+
+func TestEngine_DryRunParse(t *testing.T) {
+	e := Default()
+	_, err := e.DryRunParse(&DryRun{Provider: "JAVBUS"})
+	assert.ErrorIs(t, err, ErrInvalidDryRun)
+	for _, link := range []string{
+		"http://169.254.169.254/latest/meta-data/",
+		"https://javbus.com.example.com/ABC-001",
+		"file:///etc/passwd",
+		"://",
+	} {
+		_, err = e.DryRunParse(&DryRun{Provider: "JAVBUS", URL: link})
+		assert.ErrorIs(t, err, mt.ErrInvalidURL, link)
+	}
+
+	html := []byte(`<html><body>
+<a class="bigImage" href="/pics/cover/abc_b.jpg"><img title="Dry Run Title" src="/pics/cover/abc_b.jpg"></a>
+<div class="col-md-3 info"><p><span>品番:</span> <span>ABC-001</span></p></div>
+</body></html>`)
+	result, err := e.DryRunParse(&DryRun{Provider: "javbus", ID: "abc-001", HTML: html})
+	require.NoError(t, err)
+	require.Empty(t, result.Error)
+	info := result.Info.(*model.MovieInfo)
+	assert.Equal(t, "Dry Run Title", info.Title)
+	assert.Equal(t, "ABC-001", info.Number)
+	assert.Contains(t, result.Empty, "actors")
+	assert.NotEmpty(t, result.Trace.Pages)
+	assert.NotEmpty(t, result.Trace.Misses)
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/comparer"
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// dedupTitleSimilarity is the min title similarity of the same movie,
+// titles may differ slightly between providers, e.g. with or without
+// actor names or edition tags.
+const dedupTitleSimilarity = 0.4
+
+// DedupMovieSearchResults collapses results of the same movie, i.e. the
+// same normalized number and similar titles, into the first one of them,
+// and lists all providers in its sources. Order of results is kept.
+func DedupMovieSearchResults(results []*model.MovieSearchResult) []*model.MovieSearchResult {
+	var (
+		deduped = make([]*model.MovieSearchResult, 0, len(results))
+		groups  = make(map[string][]*model.MovieSearchResult)
+	)
+	for _, result := range results {
+		key := number.Normalize(result.Number)
+		if first := findSameMovie(groups[key], result); first != nil {
+			first.Sources = append(first.Sources, &model.MovieSource{
+				ID:       result.ID,
+				Provider: result.Provider,
+				Homepage: result.Homepage,
+			})
+			continue
+		}
+		// copy to avoid modifying cached results.
+		r := *result
+		r.Sources = []*model.MovieSource{{
+			ID:       result.ID,
+			Provider: result.Provider,
+			Homepage: result.Homepage,
+		}}
+		groups[key] = append(groups[key], &r)
+		deduped = append(deduped, &r)
+	}
+	return deduped
+}
+
+func findSameMovie(candidates []*model.MovieSearchResult, result *model.MovieSearchResult) *model.MovieSearchResult {
+	for _, candidate := range candidates {
+		if isSimilarTitle(candidate.Title, result.Title) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func isSimilarTitle(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if a == "" || b == "" || strings.Contains(a, b) || strings.Contains(b, a) {
+		return true
+	}
+	return comparer.Compare(a, b) >= dedupTitleSimilarity
+}
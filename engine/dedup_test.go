@@ -0,0 +1,25 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestDedupMovieSearchResults(t *testing.T) {
+	results := DedupMovieSearchResults([]*model.MovieSearchResult{
+		{ID: "abp00030", Number: "ABP-030", Title: "タイトル 松下紗栄子", Provider: "FANZA"},
+		{ID: "ABP-030", Number: "ABP-030", Title: "タイトル", Provider: "JavBus"},
+		{ID: "ABP-031", Number: "ABP-031", Title: "タイトル", Provider: "JavBus"},
+		{ID: "abp030", Number: "abp030", Title: "Completely Different", Provider: "Other"},
+	})
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "FANZA", results[0].Provider)
+		assert.Len(t, results[0].Sources, 2)
+		assert.Equal(t, "JavBus", results[0].Sources[1].Provider)
+		assert.Equal(t, "ABP-031", results[1].ID)
+		assert.Equal(t, "Other", results[2].Provider)
+	}
+}
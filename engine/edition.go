@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"slices"
+
+	"github.com/metatube-community/metatube-sdk-go/common/edition"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// detectEditions fills in edition flags of the search results from
+// their titles in place.
+func detectEditions(results []*model.MovieSearchResult) {
+	for _, result := range results {
+		result.Editions = edition.Detect(result.Title)
+		if !slices.Contains(result.Editions, edition.Compilation) &&
+			edition.LooksLikeCompilation(result.Title, 0, len(result.Actors)) {
+			result.Editions = append(result.Editions, edition.Compilation)
+		}
+	}
+}
+
+// detectMovieEditions fills in edition flags of the movie info from the
+// title and genres, compilations are also guessed by the heuristic.
+func detectMovieEditions(info *model.MovieInfo) {
+	info.Editions = edition.Detect(append([]string{info.Title}, info.Genres...)...)
+	if !slices.Contains(info.Editions, edition.Compilation) &&
+		edition.LooksLikeCompilation(info.Title, info.Runtime, len(info.Actors)) {
+		info.Editions = append(info.Editions, edition.Compilation)
+	}
+}
+
+// CompilationPolicy is how compilations in search results are treated.
+type CompilationPolicy string
+
+const (
+	// CompilationInclude keeps compilations as they are.
+	CompilationInclude CompilationPolicy = "include"
+	// CompilationDemote moves compilations after other results.
+	CompilationDemote CompilationPolicy = "demote"
+	// CompilationExclude removes compilations.
+	CompilationExclude CompilationPolicy = "exclude"
+)
+
+// ApplyCompilationPolicy excludes or down-ranks compilations of the
+// results by the policy, order of the rest is kept.
+func ApplyCompilationPolicy(results []*model.MovieSearchResult, policy CompilationPolicy) []*model.MovieSearchResult {
+	isCompilation := func(v *model.MovieSearchResult) bool {
+		return slices.Contains(v.Editions, edition.Compilation)
+	}
+	switch policy {
+	case CompilationExclude:
+		return slices.DeleteFunc(results, isCompilation)
+	case CompilationDemote:
+		slices.SortStableFunc(results, func(a, b *model.MovieSearchResult) int {
+			switch ca, cb := isCompilation(a), isCompilation(b); {
+			case ca == cb:
+				return 0
+			case cb:
+				return -1
+			default:
+				return 1
+			}
+		})
+	}
+	return results
+}
+
+// extraEditions reports whether the result has edition flags that
+// the keyword does not ask for, e.g. a compilation of the original.
+func extraEditions(keyword string, result *model.MovieSearchResult) bool {
+	wanted := edition.Detect(keyword)
+	for _, flag := range result.Editions {
+		if !slices.Contains(wanted, flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// bestResult returns the first exact match without extra editions,
+// or the first result if there is none, or nil if results are empty.
+func bestResult(keyword string, results []*model.MovieSearchResult) *model.MovieSearchResult {
+	if len(results) == 0 {
+		return nil
+	}
+	for _, result := range results {
+		if matchMovie(keyword, result).Confidence == 1 && !extraEditions(keyword, result) {
+			return result
+		}
+	}
+	return results[0]
+}
@@ -0,0 +1,28 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestApplyCompilationPolicy(t *testing.T) {
+	results := func() []*model.MovieSearchResult {
+		return []*model.MovieSearchResult{
+			{ID: "a", Editions: []string{"compilation"}},
+			{ID: "b"},
+			{ID: "c", Editions: []string{"4k"}},
+		}
+	}
+	ids := func(results []*model.MovieSearchResult) (ids []string) {
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		return
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids(ApplyCompilationPolicy(results(), CompilationInclude)))
+	assert.Equal(t, []string{"b", "c", "a"}, ids(ApplyCompilationPolicy(results(), CompilationDemote)))
+	assert.Equal(t, []string{"b", "c"}, ids(ApplyCompilationPolicy(results(), CompilationExclude)))
+}
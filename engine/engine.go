@@ -1,17 +1,31 @@
 package engine
 
 import (
-	"log"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
-	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 
 	"github.com/metatube-community/metatube-sdk-go/common/fetch"
+	"github.com/metatube-community/metatube-sdk-go/common/ffmpeg"
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/common/tracing"
 	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/pigo"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/store"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
 )
 
 const (
@@ -24,8 +38,58 @@ type Engine struct {
 	name    string
 	timeout time.Duration
 	fetcher *fetch.Fetcher
+	// Fetcher of Streams without Timeout
+	streamer *fetch.Fetcher
+	// Request Context
+	ctx context.Context
+	// Background Workers, stopped by Close
+	cancel  context.CancelFunc
+	workers *sync.WaitGroup
 	// Engine Logger
-	logger *log.Logger
+	logger *slog.Logger
+	// Webhook Notifier
+	notifier *webhook.Notifier
+	// Cookie File
+	cookieFile string
+	// In-flight Scrapes
+	group *singleflight.Group
+	// Background Jobs
+	jobs *jobQueue
+	// Provider Call Slots
+	sched *scheduler
+	// Content Blocklist
+	blocklist *atomic.Pointer[Blocklist]
+	// Scrape Hooks
+	hooks *atomic.Pointer[Hooks]
+	// Title Cleanup Rules
+	titleCleaner *atomic.Pointer[TitleCleaner]
+	// Field Extraction Stats of Providers
+	fieldStats *fieldStats
+	// Last Known Health of Providers
+	health *providerHealth
+	// Hottest Records above DB, nil if disabled
+	records *recordCache
+	// Name:Recent Documents Map, nil if raw payloads are not stored
+	payloads map[string]*payloadRing
+	// Default Translator
+	translator translate.Translator
+	// Curated Translations
+	curated *atomic.Pointer[translate.Curated]
+	// Custom HTTP Transport
+	transport http.RoundTripper
+	// Face Detector of Image Crops
+	faceDetector face.Detector
+	// Animated Previews of Trailers
+	previewDir     string
+	ffmpeg         *ffmpeg.FFmpeg
+	previewSem     chan struct{}
+	previewMaxSize int64
+	previewMaxAge  time.Duration
+	// Cache Store of HTTP Responses and Images
+	cache    store.Store
+	cacheTTL time.Duration
+	// Name:Config Map of Providers
+	providers map[string]*ProviderConfig
 	// Name:Provider Map
 	actorProviders map[string]mt.ActorProvider
 	movieProviders map[string]mt.MovieProvider
@@ -34,25 +98,43 @@ type Engine struct {
 	movieHostProviders map[string][]mt.MovieProvider
 }
 
-func New(db *gorm.DB, opts ...Option) *Engine {
+// New returns a configured *Engine, an in-memory database is used if
+// no database is given by WithDB.
+func New(opts ...Option) *Engine {
+	ctx, cancel := context.WithCancel(context.Background())
 	engine := &Engine{
-		db:      db,
-		name:    DefaultEngineName,
-		timeout: DefaultRequestTimeout,
+		ctx:          ctx,
+		cancel:       cancel,
+		workers:      new(sync.WaitGroup),
+		name:         DefaultEngineName,
+		timeout:      DefaultRequestTimeout,
+		group:        new(singleflight.Group),
+		jobs:         newJobQueue(),
+		sched:        newScheduler(DefaultScrapeWorkers),
+		blocklist:    atomic.NewPointer[Blocklist](nil),
+		hooks:        atomic.NewPointer[Hooks](nil),
+		titleCleaner: atomic.NewPointer(defaultTitleCleaner),
+		fieldStats:   newFieldStats(),
+		health:       newProviderHealth(),
+		faceDetector: pigo.Detector{},
+		curated:      atomic.NewPointer[translate.Curated](nil),
+		providers:    make(map[string]*ProviderConfig),
 	}
 	// apply options
 	for _, opt := range opts {
 		opt(engine)
 	}
+	if engine.db == nil {
+		engine.db, _ = database.Open(&database.Config{
+			DSN:                  "",
+			DisableAutomaticPing: true,
+		})
+	}
 	return engine.init()
 }
 
 func Default() *Engine {
-	db, _ := database.Open(&database.Config{
-		DSN:                  "",
-		DisableAutomaticPing: true,
-	})
-	engine := New(db)
+	engine := New()
 	defer engine.DBAutoMigrate(true)
 	return engine
 }
@@ -66,17 +148,14 @@ func (e *Engine) GetActorProviders() map[string]mt.ActorProvider {
 	return e.actorProviders
 }
 
+// GetActorProviderByURL resolves the provider of the link by its host
+// and path, links without scheme and subdomains of the site are allowed.
 func (e *Engine) GetActorProviderByURL(rawURL string) (mt.ActorProvider, error) {
-	u, err := url.Parse(rawURL)
+	u, err := parseLink(rawURL)
 	if err != nil {
 		return nil, err
 	}
-	for _, p := range e.actorHostProviders[u.Hostname()] {
-		if strings.HasPrefix(u.Path, p.URL().Path) {
-			return p, nil
-		}
-	}
-	return nil, mt.ErrProviderNotFound
+	return resolveProvider(e.actorHostProviders, u)
 }
 
 func (e *Engine) GetActorProviderByName(name string) (mt.ActorProvider, error) {
@@ -104,17 +183,14 @@ func (e *Engine) GetMovieProviders() map[string]mt.MovieProvider {
 	return e.movieProviders
 }
 
+// GetMovieProviderByURL resolves the provider of the link by its host
+// and path, links without scheme and subdomains of the site are allowed.
 func (e *Engine) GetMovieProviderByURL(rawURL string) (mt.MovieProvider, error) {
-	u, err := url.Parse(rawURL)
+	u, err := parseLink(rawURL)
 	if err != nil {
 		return nil, err
 	}
-	for _, p := range e.movieHostProviders[u.Hostname()] {
-		if strings.HasPrefix(u.Path, p.URL().Path) {
-			return p, nil
-		}
-	}
-	return nil, mt.ErrProviderNotFound
+	return resolveProvider(e.movieHostProviders, u)
 }
 
 func (e *Engine) GetMovieProviderByName(name string) (mt.MovieProvider, error) {
@@ -135,7 +211,9 @@ func (e *Engine) MustGetMovieProviderByName(name string) mt.MovieProvider {
 
 // Fetch fetches content from url. If provider is nil, the
 // default fetcher will be used.
-func (e *Engine) Fetch(url string, provider mt.Provider) (*http.Response, error) {
+func (e *Engine) Fetch(url string, provider mt.Provider) (resp *http.Response, err error) {
+	_, span := tracing.Start(e.ctx, "engine.fetch", attribute.String("url", url))
+	defer func() { tracing.End(span, err) }()
 	// Provider which implements Fetcher interface should be
 	// used to fetch all its corresponding resources.
 	if fetcher, ok := provider.(mt.Fetcher); ok {
@@ -144,5 +222,58 @@ func (e *Engine) Fetch(url string, provider mt.Provider) (*http.Response, error)
 	return e.fetcher.Fetch(url)
 }
 
+// Go runs f in the background with the engine context, which is
+// canceled by Close, e.g. job workers and periodic checks.
+func (e *Engine) Go(f func(ctx context.Context)) {
+	e.workers.Add(1)
+	go func() {
+		defer e.workers.Done()
+		f(e.ctx)
+	}()
+}
+
+// Close cancels the engine context and waits for the background workers
+// started by Go, then waits for pending webhook deliveries, persists
+// provider cookies, and closes the cache store and the underlying
+// database connections.
+func (e *Engine) Close() error {
+	e.cancel()
+	e.workers.Wait()
+	e.notifier.Wait()
+	if err := e.SaveCookies(); err != nil {
+		e.logger.Error("save cookies", slog.Any("error", err))
+	}
+	var errs []error
+	if c, ok := e.cache.(io.Closer); ok {
+		errs = append(errs, c.Close())
+	}
+	errs = append(errs, database.Close(e.db))
+	return errors.Join(errs...)
+}
+
+// WithContext returns a shallow copy of the Engine bound to ctx, its
+// logger is annotated with the request ID carried by ctx, if any, and
+// its trace spans are children of the span carried by ctx.
+func (e *Engine) WithContext(ctx context.Context) *Engine {
+	c := *e
+	c.ctx = ctx
+	c.logger = logger.FromContext(ctx, e.logger)
+	return &c
+}
+
+// withRefresh returns a shallow copy of the Engine whose requests skip
+// the cached responses of the HTTP cache store, unless lazy.
+func (e *Engine) withRefresh(lazy bool) *Engine {
+	if lazy {
+		return e
+	}
+	c := *e
+	c.ctx = store.WithoutCache(e.ctx)
+	return &c
+}
+
+// Logger returns the structured logger of the Engine.
+func (e *Engine) Logger() *slog.Logger { return e.logger }
+
 // String returns the name of the Engine instance.
 func (e *Engine) String() string { return e.name }
@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+type closingStore struct {
+	store.Store
+	closed bool
+}
+
+func (s *closingStore) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestEngine_Close(t *testing.T) {
+	db, err := database.Open(&database.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	cache := &closingStore{Store: store.NewMemory(store.DefaultMemoryCapacity)}
+	e := New(WithDB(db), WithCache(cache, time.Minute))
+
+	var stopped []string
+	e.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		// the stores must still be open when workers stop.
+		assert.False(t, cache.closed)
+		stopped = append(stopped, "worker")
+	})
+
+	require.NoError(t, e.Close())
+	assert.Equal(t, []string{"worker"}, stopped)
+	assert.True(t, cache.closed)
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/metatube-community/metatube-sdk-go/metrics"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// fieldStatsWindow is the number of recent scrapes per provider that
+// recent empty rates are computed of.
+const fieldStatsWindow = 100
+
+// Info types of field stats.
+const (
+	movieInfoType = "movie"
+	actorInfoType = "actor"
+)
+
+// FieldStat is how often a field of the infos scraped by a provider
+// is empty, i.e. its selectors match nothing.
+type FieldStat struct {
+	Field string `json:"field"`
+	Total int64  `json:"total"`
+	Empty int64  `json:"empty"`
+	// EmptyRate is of all scrapes since start, and RecentEmptyRate of
+	// the recent ones, a spike of the latter is the earliest signal of
+	// a site redesign breaking the selectors.
+	EmptyRate       float64 `json:"empty_rate"`
+	RecentEmptyRate float64 `json:"recent_empty_rate"`
+}
+
+// ProviderFieldStats are the field stats of a provider, sorted by the
+// recent empty rates in descending order.
+type ProviderFieldStats struct {
+	Provider string       `json:"provider"`
+	Type     string       `json:"type"`
+	Scrapes  int64        `json:"scrapes"`
+	Recent   int          `json:"recent"`
+	Fields   []*FieldStat `json:"fields"`
+}
+
+type fieldCounter struct {
+	total int64
+	empty int64
+}
+
+type providerFields struct {
+	name, typ string
+	scrapes   int64
+	fields    map[string]*fieldCounter
+	// recent scrapes as maps of fields to whether they are empty.
+	recent [fieldStatsWindow]map[string]bool
+	next   int
+}
+
+// fieldStats keeps the field stats of providers in memory.
+type fieldStats struct {
+	mu        sync.Mutex
+	providers map[string]*providerFields
+}
+
+func newFieldStats() *fieldStats {
+	return &fieldStats{providers: make(map[string]*providerFields)}
+}
+
+func (s *fieldStats) observe(name, typ string, fields map[string]bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := typ + ":" + name
+	p, ok := s.providers[key]
+	if !ok {
+		p = &providerFields{name: name, typ: typ, fields: make(map[string]*fieldCounter)}
+		s.providers[key] = p
+	}
+	p.scrapes++
+	for field, empty := range fields {
+		c, ok := p.fields[field]
+		if !ok {
+			c = &fieldCounter{}
+			p.fields[field] = c
+		}
+		c.total++
+		if empty {
+			c.empty++
+		}
+	}
+	p.recent[p.next] = fields
+	p.next = (p.next + 1) % fieldStatsWindow
+}
+
+func (s *fieldStats) stats() []*ProviderFieldStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make([]*ProviderFieldStats, 0, len(s.providers))
+	for _, p := range s.providers {
+		recent := make(map[string]*fieldCounter)
+		n := 0
+		for _, fields := range p.recent {
+			if fields == nil {
+				continue
+			}
+			n++
+			for field, empty := range fields {
+				c, ok := recent[field]
+				if !ok {
+					c = &fieldCounter{}
+					recent[field] = c
+				}
+				c.total++
+				if empty {
+					c.empty++
+				}
+			}
+		}
+		ps := &ProviderFieldStats{Provider: p.name, Type: p.typ, Scrapes: p.scrapes, Recent: n}
+		for field, c := range p.fields {
+			fs := &FieldStat{
+				Field:     field,
+				Total:     c.total,
+				Empty:     c.empty,
+				EmptyRate: float64(c.empty) / float64(c.total),
+			}
+			if r, ok := recent[field]; ok {
+				fs.RecentEmptyRate = float64(r.empty) / float64(r.total)
+			}
+			ps.Fields = append(ps.Fields, fs)
+		}
+		sort.Slice(ps.Fields, func(i, j int) bool {
+			if ps.Fields[i].RecentEmptyRate != ps.Fields[j].RecentEmptyRate {
+				return ps.Fields[i].RecentEmptyRate > ps.Fields[j].RecentEmptyRate
+			}
+			return ps.Fields[i].Field < ps.Fields[j].Field
+		})
+		stats = append(stats, ps)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Type != stats[j].Type {
+			return stats[i].Type < stats[j].Type
+		}
+		return stats[i].Provider < stats[j].Provider
+	})
+	return stats
+}
+
+// GetFieldStats returns the field stats of the providers since start,
+// of the provider only if name is not empty.
+func (e *Engine) GetFieldStats(name string) []*ProviderFieldStats {
+	stats := e.fieldStats.stats()
+	if name == "" {
+		return stats
+	}
+	filtered := stats[:0]
+	for _, s := range stats {
+		if strings.EqualFold(s.Provider, name) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// observeFields records the fields of the info scraped by the provider
+// to metrics and the field stats, the info must be as parsed by the
+// provider, before any processing of the engine.
+func (e *Engine) observeFields(provider mt.Provider, typ string, info any) {
+	fields := jsonFields(info)
+	if len(fields) == 0 {
+		return
+	}
+	metrics.ObserveFields(provider.Name(), typ, fields)
+	e.fieldStats.observe(provider.Name(), typ, fields)
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestFieldStats(t *testing.T) {
+	s := newFieldStats()
+	for range fieldStatsWindow {
+		s.observe("TEST", movieInfoType, map[string]bool{"title": false, "actors": false})
+	}
+	// a redesign breaks the actor selectors.
+	for range fieldStatsWindow / 2 {
+		s.observe("TEST", movieInfoType, map[string]bool{"title": false, "actors": true})
+	}
+	s.observe("TEST", actorInfoType, map[string]bool{"name": false})
+
+	stats := s.stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, actorInfoType, stats[0].Type)
+	movie := stats[1]
+	assert.EqualValues(t, fieldStatsWindow*3/2, movie.Scrapes)
+	assert.Equal(t, fieldStatsWindow, movie.Recent)
+	require.Len(t, movie.Fields, 2)
+	actors := movie.Fields[0]
+	assert.Equal(t, "actors", actors.Field)
+	assert.EqualValues(t, fieldStatsWindow/2, actors.Empty)
+	assert.InDelta(t, 1.0/3, actors.EmptyRate, 1e-9)
+	assert.InDelta(t, 0.5, actors.RecentEmptyRate, 1e-9)
+	assert.Zero(t, movie.Fields[1].EmptyRate)
+}
+
+func TestEngine_FieldStats(t *testing.T) {
+	e := Default()
+	provider, err := e.GetMovieProviderByName("FANZA")
+	require.NoError(t, err)
+
+	_, err = e.scrapeMovieInfo(provider, "field00001", func(context.Context) (*model.MovieInfo, error) {
+		return &model.MovieInfo{
+			ID: "field00001", Number: "FIELD-001", Title: "Title", Provider: provider.Name(),
+			Homepage: "https://example.com/field00001", CoverURL: "https://example.com/field00001.jpg",
+		}, nil
+	})
+	require.NoError(t, err)
+
+	stats := e.GetFieldStats("fanza")
+	require.Len(t, stats, 1)
+	assert.EqualValues(t, 1, stats[0].Scrapes)
+	fields := make(map[string]*FieldStat)
+	for _, f := range stats[0].Fields {
+		fields[f.Field] = f
+	}
+	assert.Zero(t, fields["title"].Empty)
+	assert.EqualValues(t, 1, fields["actors"].Empty)
+	assert.Empty(t, e.GetFieldStats("JAVBUS"))
+}
@@ -0,0 +1,241 @@
+package engine
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func (e *Engine) searchMovieByActor(name string, provider mt.MovieProvider) (results []*model.MovieSearchResult, err error) {
+	searcher, ok := provider.(mt.MovieActorSearcher)
+	if !ok {
+		return nil, mt.ErrInfoNotFound
+	}
+	ctx, done, err := e.observe(provider, opSearchMovieByActor, name)
+	if err != nil {
+		return nil, err
+	}
+	defer done(&err)
+	return mt.WithContext(searcher, ctx).SearchMovieByActor(name)
+}
+
+// SearchMovieByActor searches the filmography of the actor from all
+// providers that support actor pages, and merges them by number.
+func (e *Engine) SearchMovieByActor(name string) (results []*model.MovieSearchResult, err error) {
+	return e.searchMovieListing("actor", name, nil, func(provider mt.MovieProvider) bool {
+		_, ok := provider.(mt.MovieActorSearcher)
+		return ok
+	}, e.searchMovieByActor)
+}
+
+func (e *Engine) searchMovieBySeries(name string, provider mt.MovieProvider) (results []*model.MovieSearchResult, err error) {
+	searcher, ok := provider.(mt.MovieSeriesSearcher)
+	if !ok {
+		return nil, mt.ErrInfoNotFound
+	}
+	ctx, done, err := e.observe(provider, opSearchMovieBySeries, name)
+	if err != nil {
+		return nil, err
+	}
+	defer done(&err)
+	return mt.WithContext(searcher, ctx).SearchMovieBySeries(name)
+}
+
+// SearchMovieBySeries lists movies of the series from all providers
+// that support series pages, and movies of the series in the database,
+// and merges them by number.
+func (e *Engine) SearchMovieBySeries(name string) (results []*model.MovieSearchResult, err error) {
+	return e.searchMovieListing("series", name, e.db.Where("series = ?", name), func(provider mt.MovieProvider) bool {
+		_, ok := provider.(mt.MovieSeriesSearcher)
+		return ok
+	}, e.searchMovieBySeries)
+}
+
+func (e *Engine) searchMovieByMaker(name string, provider mt.MovieProvider) (results []*model.MovieSearchResult, err error) {
+	searcher, ok := provider.(mt.MovieMakerSearcher)
+	if !ok {
+		return nil, mt.ErrInfoNotFound
+	}
+	ctx, done, err := e.observe(provider, opSearchMovieByMaker, name)
+	if err != nil {
+		return nil, err
+	}
+	defer done(&err)
+	return mt.WithContext(searcher, ctx).SearchMovieByMaker(name)
+}
+
+// SearchMovieByMaker lists movies of the maker from all providers that
+// support maker pages, and movies of the maker in the database, and
+// merges them by number.
+func (e *Engine) SearchMovieByMaker(name string) (results []*model.MovieSearchResult, err error) {
+	return e.searchMovieListing("maker", name, e.db.Where("maker = ?", name), func(provider mt.MovieProvider) bool {
+		_, ok := provider.(mt.MovieMakerSearcher)
+		return ok
+	}, e.searchMovieByMaker)
+}
+
+// searchMovieListing lists movies of the name from all providers that
+// support the listing, and from the movies of the local query if not
+// nil, the results are merged by number.
+func (e *Engine) searchMovieListing(kind, name string, local *gorm.DB,
+	supports func(mt.MovieProvider) bool,
+	search func(string, mt.MovieProvider) ([]*model.MovieSearchResult, error),
+) (results []*model.MovieSearchResult, err error) {
+	if name = strings.TrimSpace(name); name == "" {
+		return nil, mt.ErrInvalidKeyword
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+		ds []any
+	)
+	for _, provider := range e.movieProviders {
+		if !supports(provider) {
+			continue
+		}
+		wg.Add(1)
+		go func(provider mt.MovieProvider) {
+			defer wg.Done()
+			startTime := time.Now()
+			innerResults, innerErr := search(name, provider)
+
+			mu.Lock()
+			defer mu.Unlock()
+			d := time.Since(startTime).String()
+			if innerErr != nil {
+				d += " " + innerErr.Error()
+			} else {
+				results = append(results, innerResults...)
+			}
+			ds = append(ds, slog.String(provider.Name(), d))
+		}(provider)
+	}
+	wg.Wait()
+
+	if local != nil {
+		// movies already scraped, e.g. from providers without listings.
+		var infos []*model.MovieInfo
+		if err = local.Find(&infos).Error; err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			results = append(results, info.ToSearchResult())
+		}
+	}
+
+	results = e.filterBlocked(e.mergeFilmography(results))
+	sanitizeActors(results)
+	detectEditions(results)
+	e.logger.Info("search movie by "+kind,
+		slog.String(kind, name),
+		slog.Int("results", len(results)),
+		slog.Group("providers", ds...))
+
+	if len(results) == 0 {
+		return nil, mt.ErrInfoNotFound
+	}
+	return
+}
+
+// mergeFilmography removes duplicate movies of the same number, results
+// from providers of higher priority are kept, and sorts the rest by
+// release date, newest first.
+func (e *Engine) mergeFilmography(results []*model.MovieSearchResult) []*model.MovieSearchResult {
+	priority := func(v *model.MovieSearchResult) float64 {
+		if provider, err := e.GetMovieProviderByName(v.Provider); err == nil {
+			return provider.Priority()
+		}
+		return 0
+	}
+
+	merged := make(map[string]*model.MovieSearchResult)
+	for _, result := range results {
+		if !result.Valid() {
+			continue
+		}
+		key := strings.ToUpper(result.Number)
+		if prev, ok := merged[key]; !ok || priority(result) > priority(prev) {
+			merged[key] = result
+		}
+	}
+
+	results = make([]*model.MovieSearchResult, 0, len(merged))
+	for _, result := range merged {
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := time.Time(results[i].ReleaseDate), time.Time(results[j].ReleaseDate)
+		if a.Equal(b) {
+			return results[i].Number < results[j].Number
+		}
+		return a.After(b)
+	})
+	return results
+}
+
+// FilmographyReport is the completeness of a local library against the
+// aggregated filmography of an actor.
+type FilmographyReport struct {
+	Actor string `json:"actor"`
+	// Total is the number of released movies.
+	Total int `json:"total"`
+	// Owned is the number of released movies in the library.
+	Owned int `json:"owned"`
+	// Completeness is the ratio of owned released movies.
+	Completeness float64 `json:"completeness"`
+	// Missing are released movies not in the library, newest first.
+	Missing []*model.MovieSearchResult `json:"missing"`
+	// Upcoming are movies to be released, not counted in Total.
+	Upcoming []*model.MovieSearchResult `json:"upcoming"`
+}
+
+// GetFilmographyReport diffs the movie numbers of the library against
+// the filmography of the actor, see SearchMovieByActor. Numbers are
+// compared in the normalized form.
+func (e *Engine) GetFilmographyReport(actor string, numbers []string) (*FilmographyReport, error) {
+	results, err := e.SearchMovieByActor(actor)
+	if err != nil {
+		return nil, err
+	}
+	return diffFilmography(actor, results, numbers, truncateDate(time.Now())), nil
+}
+
+// diffFilmography splits the filmography into owned, missing and
+// upcoming movies by the numbers and release dates.
+func diffFilmography(actor string, results []*model.MovieSearchResult, numbers []string, today time.Time) *FilmographyReport {
+	owned := make(map[string]bool, len(numbers))
+	for _, n := range numbers {
+		owned[number.Normalize(n)] = true
+	}
+
+	report := &FilmographyReport{
+		Actor:    actor,
+		Missing:  make([]*model.MovieSearchResult, 0),
+		Upcoming: make([]*model.MovieSearchResult, 0),
+	}
+	for _, result := range results {
+		if truncateDate(time.Time(result.ReleaseDate)).After(today) {
+			report.Upcoming = append(report.Upcoming, result)
+			continue
+		}
+		report.Total++
+		if owned[number.Normalize(result.Number)] {
+			report.Owned++
+		} else {
+			report.Missing = append(report.Missing, result)
+		}
+	}
+	if report.Total > 0 {
+		report.Completeness = float64(report.Owned) / float64(report.Total)
+	}
+	return report
+}
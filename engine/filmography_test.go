@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_MergeFilmography(t *testing.T) {
+	db, _ := database.Open(&database.Config{DisableAutomaticPing: true})
+	e := New(WithDB(db))
+
+	result := func(provider, number string, date string) *model.MovieSearchResult {
+		d, _ := time.Parse(time.DateOnly, date)
+		return &model.MovieSearchResult{
+			ID:          number,
+			Number:      number,
+			Title:       number,
+			Provider:    provider,
+			Homepage:    "https://example.com/" + number,
+			ReleaseDate: datatypes.Date(d),
+		}
+	}
+
+	results := e.mergeFilmography([]*model.MovieSearchResult{
+		result("JavBus", "ABP-001", "2020-01-01"),
+		result("FANZA", "abp-001", "2020-01-01"),
+		result("JavBus", "ABP-002", "2021-01-01"),
+		{ID: "invalid"},
+	})
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "ABP-002", results[0].Number)
+		assert.Equal(t, "FANZA", results[1].Provider)
+	}
+}
+
+func TestDiffFilmography(t *testing.T) {
+	result := func(number, date string) *model.MovieSearchResult {
+		d, _ := time.Parse(time.DateOnly, date)
+		return &model.MovieSearchResult{Number: number, ReleaseDate: datatypes.Date(d)}
+	}
+	today, _ := time.Parse(time.DateOnly, "2024-06-01")
+
+	report := diffFilmography("Actor", []*model.MovieSearchResult{
+		result("ABP-004", "2024-07-01"),
+		result("ABP-003", "2024-06-01"),
+		result("ABP-002", "2024-01-01"),
+		result("ABP-001", "2023-01-01"),
+	}, []string{"abp001", "ABP-003", "XYZ-001"}, today)
+
+	assert.Equal(t, "Actor", report.Actor)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Owned)
+	assert.InDelta(t, 2.0/3.0, report.Completeness, 1e-9)
+	if assert.Len(t, report.Missing, 1) {
+		assert.Equal(t, "ABP-002", report.Missing[0].Number)
+	}
+	if assert.Len(t, report.Upcoming, 1) {
+		assert.Equal(t, "ABP-004", report.Upcoming[0].Number)
+	}
+
+	empty := diffFilmography("Actor", nil, nil, today)
+	assert.Zero(t, empty.Completeness)
+	assert.NotNil(t, empty.Missing)
+}
@@ -0,0 +1,157 @@
+package engine
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
+)
+
+var (
+	ErrInvalidFollow  = errors.New(http.StatusBadRequest, "invalid follow")
+	ErrFollowNotFound = errors.New(http.StatusNotFound, "follow not found")
+)
+
+// AddFollow follows the actor, series or maker for the user, it
+// returns the existing follow if it's already followed.
+func (e *Engine) AddFollow(user string, typ model.FollowType, name string) (*model.Follow, error) {
+	if name = strings.TrimSpace(name); name == "" || !typ.Valid() {
+		return nil, ErrInvalidFollow
+	}
+	follow := &model.Follow{User: user, Type: typ, Name: name}
+	if err := e.db.
+		Where(follow).
+		FirstOrCreate(follow).Error; err != nil {
+		return nil, err
+	}
+	return follow, nil
+}
+
+// RemoveFollow unfollows and removes all releases of the follow.
+func (e *Engine) RemoveFollow(user string, id uint) error {
+	return e.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Where("username = ?", user).
+			Delete(&model.Follow{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrFollowNotFound
+		}
+		return tx.
+			Where("follow_id = ?", id).
+			Delete(&model.FollowRelease{}).Error
+	})
+}
+
+// GetFollows returns all follows of the user.
+func (e *Engine) GetFollows(user string) (follows []*model.Follow, err error) {
+	err = e.primaryDB().
+		Where("username = ?", user).
+		Order("id").
+		Find(&follows).Error
+	return
+}
+
+// GetUnseenReleases returns releases of the user's follows that are
+// not marked as seen yet, newest first.
+func (e *Engine) GetUnseenReleases(user string) (releases []*model.FollowRelease, err error) {
+	err = e.primaryDB().
+		Where("seen = ?", false).
+		Where("follow_id IN (?)", e.db.Model(&model.Follow{}).Select("id").Where("username = ?", user)).
+		Order("release_date DESC").
+		Find(&releases).Error
+	return
+}
+
+// MarkReleasesSeen marks all releases of the user's follows as seen.
+func (e *Engine) MarkReleasesSeen(user string) error {
+	return e.db.Model(&model.FollowRelease{}).
+		Where("seen = ?", false).
+		Where("follow_id IN (?)", e.db.Model(&model.Follow{}).Select("id").Where("username = ?", user)).
+		Update("seen", true).Error
+}
+
+// CheckFollows checks all follows for new releases, new releases are
+// recorded as unseen and a webhook event is sent for each of them.
+func (e *Engine) CheckFollows() error {
+	var follows []*model.Follow
+	if err := e.primaryDB().Find(&follows).Error; err != nil {
+		return err
+	}
+	for _, follow := range follows {
+		results, err := e.searchFollow(follow)
+		if err != nil && err != mt.ErrInfoNotFound {
+			e.logger.Warn("check follow",
+				slog.String("type", string(follow.Type)),
+				slog.String("name", follow.Name),
+				slog.Any("error", err))
+			continue
+		}
+		n, err := e.recordReleases(follow, results)
+		if err != nil {
+			return err
+		}
+		e.logger.Info("check follow",
+			slog.String("type", string(follow.Type)),
+			slog.String("name", follow.Name),
+			slog.Int("new", n))
+	}
+	return nil
+}
+
+func (e *Engine) searchFollow(follow *model.Follow) ([]*model.MovieSearchResult, error) {
+	switch follow.Type {
+	case model.FollowSeries:
+		return e.SearchMovieBySeries(follow.Name)
+	case model.FollowMaker:
+		return e.SearchMovieByMaker(follow.Name)
+	default:
+		return e.SearchMovieByActor(follow.Name)
+	}
+}
+
+// recordReleases records new releases of the follow, and returns the
+// number of them. Releases found by the first check are recorded as
+// seen, so that old titles are not reported as new releases.
+func (e *Engine) recordReleases(follow *model.Follow, results []*model.MovieSearchResult) (n int, err error) {
+	baseline := follow.CheckedAt.IsZero()
+	for _, result := range results {
+		release := &model.FollowRelease{
+			FollowID:    follow.ID,
+			Provider:    result.Provider,
+			ID:          result.ID,
+			Number:      result.Number,
+			Title:       result.Title,
+			Homepage:    result.Homepage,
+			ThumbURL:    result.ThumbURL,
+			ReleaseDate: result.ReleaseDate,
+			Seen:        baseline,
+		}
+		tx := e.db.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(release)
+		if tx.Error != nil {
+			return n, tx.Error
+		}
+		if tx.RowsAffected == 0 || baseline {
+			continue
+		}
+		n++
+		e.notify(webhook.FollowNewRelease, release.Provider, release.ID, &struct {
+			Follow  *model.Follow        `json:"follow"`
+			Release *model.FollowRelease `json:"release"`
+		}{follow, release})
+	}
+	follow.CheckedAt = time.Now()
+	return n, e.db.Model(follow).Update("checked_at", follow.CheckedAt).Error
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestEngine_Follows(t *testing.T) {
+	e := Default()
+
+	follow, err := e.AddFollow("alice", model.FollowActor, "Actor")
+	require.NoError(t, err)
+	again, err := e.AddFollow("alice", model.FollowActor, " Actor ")
+	require.NoError(t, err)
+	assert.Equal(t, follow.ID, again.ID)
+	_, err = e.AddFollow("alice", "unknown", "Actor")
+	assert.ErrorIs(t, err, ErrInvalidFollow)
+
+	result := func(id string) *model.MovieSearchResult {
+		return &model.MovieSearchResult{ID: id, Number: id, Title: id, Provider: "FANZA", Homepage: id}
+	}
+
+	// first check is the baseline.
+	n, err := e.recordReleases(follow, []*model.MovieSearchResult{result("abc001")})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = e.recordReleases(follow, []*model.MovieSearchResult{result("abc001"), result("abc002")})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	releases, err := e.GetUnseenReleases("alice")
+	require.NoError(t, err)
+	if assert.Len(t, releases, 1) {
+		assert.Equal(t, "abc002", releases[0].ID)
+	}
+	releases, err = e.GetUnseenReleases("bob")
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+
+	require.NoError(t, e.MarkReleasesSeen("alice"))
+	releases, err = e.GetUnseenReleases("alice")
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+
+	assert.ErrorIs(t, e.RemoveFollow("bob", follow.ID), ErrFollowNotFound)
+	require.NoError(t, e.RemoveFollow("alice", follow.ID))
+	follows, err := e.GetFollows("alice")
+	require.NoError(t, err)
+	assert.Empty(t, follows)
+}
+
+// seriesProvider lists the movies of its series page.
+type seriesProvider struct {
+	mt.MovieProvider
+	series  string
+	results []*model.MovieSearchResult
+}
+
+func (*seriesProvider) Name() string      { return "STUB" }
+func (*seriesProvider) Priority() float64 { return 1 }
+
+func (p *seriesProvider) SearchMovieBySeries(name string) ([]*model.MovieSearchResult, error) {
+	if name != p.series {
+		return nil, mt.ErrInfoNotFound
+	}
+	return p.results, nil
+}
+
+func TestEngine_CheckFollowsSeries(t *testing.T) {
+	db, err := database.Open(&database.Config{DSN: "file:follow_series_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+
+	result := func(number string) *model.MovieSearchResult {
+		return &model.MovieSearchResult{ID: number, Number: number, Title: number, Provider: "STUB", Homepage: number}
+	}
+	provider := &seriesProvider{series: "Series", results: []*model.MovieSearchResult{result("ABC-001")}}
+	e.movieProviders = map[string]mt.MovieProvider{"STUB": provider}
+
+	_, err = e.AddFollow("alice", model.FollowSeries, "Series")
+	require.NoError(t, err)
+
+	// first check is the baseline.
+	require.NoError(t, e.CheckFollows())
+	releases, err := e.GetUnseenReleases("alice")
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+
+	// new release on the series page of the provider.
+	provider.results = append(provider.results, result("ABC-002"))
+	require.NoError(t, e.CheckFollows())
+	releases, err = e.GetUnseenReleases("alice")
+	require.NoError(t, err)
+	if assert.Len(t, releases, 1) {
+		assert.Equal(t, "ABC-002", releases[0].ID)
+	}
+
+	// new release of the series in the database.
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:       "ABC-003",
+		Number:   "ABC-003",
+		Title:    "ABC-003",
+		Provider: "STUB",
+		Homepage: "ABC-003",
+		Series:   "Series",
+	}).Error)
+	require.NoError(t, e.MarkReleasesSeen("alice"))
+	require.NoError(t, e.CheckFollows())
+	releases, err = e.GetUnseenReleases("alice")
+	require.NoError(t, err)
+	if assert.Len(t, releases, 1) {
+		assert.Equal(t, "ABC-003", releases[0].ID)
+	}
+}
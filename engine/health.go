@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/store"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
+)
+
+// ReadinessTimeout is the timeout of each readiness check.
+const ReadinessTimeout = 5 * time.Second
+
+// readinessProbeKey is the cache key probed by readiness checks.
+const readinessProbeKey = "readiness-probe"
+
+// ReadinessCheck is the result of a readiness check.
+type ReadinessCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// CheckReadiness checks whether the engine is ready to serve requests:
+// the database is reachable, providers are registered, and the cache
+// store, if any, is reachable.
+func (e *Engine) CheckReadiness() (checks []*ReadinessCheck, ready bool) {
+	ctx, cancel := context.WithTimeout(e.ctx, ReadinessTimeout)
+	defer cancel()
+
+	check := func(name string, err error) {
+		c := &ReadinessCheck{Name: name, OK: err == nil}
+		if err != nil {
+			c.Error = err.Error()
+		}
+		checks = append(checks, c)
+	}
+	check("database", e.pingDB(ctx))
+	check("providers", e.checkProviders())
+	if e.cache != nil {
+		check("cache", e.pingCache(ctx))
+	}
+
+	ready = true
+	for _, c := range checks {
+		ready = ready && c.OK
+	}
+	return
+}
+
+func (e *Engine) pingDB(ctx context.Context) error {
+	db, err := e.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.PingContext(ctx)
+}
+
+func (e *Engine) checkProviders() error {
+	if len(e.movieProviders) == 0 || len(e.actorProviders) == 0 {
+		return errors.New("no providers registered")
+	}
+	return nil
+}
+
+func (e *Engine) pingCache(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := e.cache.Get(readinessProbeKey)
+		if errors.Is(err, store.ErrNotFound) {
+			err = nil
+		}
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// providerHealth is the last known health of providers, so that only
+// the transitions are alerted instead of every failed check.
+type providerHealth struct {
+	mu        sync.Mutex
+	unhealthy map[string]bool
+}
+
+func newProviderHealth() *providerHealth {
+	return &providerHealth{unhealthy: make(map[string]bool)}
+}
+
+// update records the health of the provider, and reports whether it
+// differs from the last one, providers are healthy until checked.
+func (h *providerHealth) update(name string, healthy bool) (changed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.unhealthy[name] == !healthy {
+		return false
+	}
+	if healthy {
+		delete(h.unhealthy, name)
+	} else {
+		h.unhealthy[name] = true
+	}
+	return true
+}
+
+// CheckProviderHealth checks the reachability of all providers
+// concurrently, and returns the errors of unhealthy providers.
+// A webhook event is sent for every provider that becomes unhealthy,
+// or recovers, since the last check.
+func (e *Engine) CheckProviderHealth() map[string]error {
+	providers := make(map[string]mt.Provider)
+	for name, provider := range e.actorProviders {
+		providers[name] = provider
+	}
+	for name, provider := range e.movieProviders {
+		providers[name] = provider
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+	)
+	for name, provider := range providers {
+		wg.Add(1)
+		go func(name string, provider mt.Provider) {
+			defer wg.Done()
+			resp, err := e.Fetch(provider.URL().String(), provider)
+			if err == nil {
+				resp.Body.Close()
+			} else {
+				mu.Lock()
+				failed[name] = err
+				mu.Unlock()
+			}
+			if !e.health.update(provider.Name(), err == nil) {
+				return // state unchanged.
+			}
+			event := &webhook.Event{
+				Type:     webhook.ProviderRecovered,
+				Source:   e.name,
+				Provider: provider.Name(),
+			}
+			if err != nil {
+				event.Type, event.Error = webhook.ProviderHealthCheckFailed, err.Error()
+			}
+			e.notifier.Notify(event)
+		}(name, provider)
+	}
+	wg.Wait()
+	return failed
+}
+
+// WatchProviderHealth checks the health of providers every interval
+// until ctx is done.
+func (e *Engine) WatchProviderHealth(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.WithContext(ctx).CheckProviderHealth()
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine_CheckReadiness(t *testing.T) {
+	e := Default()
+	checks, ready := e.CheckReadiness()
+	assert.True(t, ready)
+	for _, check := range checks {
+		assert.True(t, check.OK, check.Name)
+	}
+
+	e = &Engine{db: e.db, ctx: e.ctx}
+	checks, ready = e.CheckReadiness()
+	assert.False(t, ready)
+	if assert.Len(t, checks, 2) {
+		assert.True(t, checks[0].OK)
+		assert.False(t, checks[1].OK)
+		assert.Equal(t, "providers", checks[1].Name)
+	}
+}
+
+func TestProviderHealth(t *testing.T) {
+	h := newProviderHealth()
+	for _, unit := range []struct {
+		name    string
+		healthy bool
+		changed bool
+	}{
+		{"A", true, false},
+		{"A", false, true},
+		{"A", false, false},
+		{"B", false, true},
+		{"A", true, true},
+		{"A", true, false},
+		{"B", false, false},
+	} {
+		assert.Equal(t, unit.changed, h.update(unit.name, unit.healthy), unit)
+	}
+}
+
+func TestEngine_WatchProviderHealth(t *testing.T) {
+	e := &Engine{health: newProviderHealth()}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.WatchProviderHealth(ctx, time.Millisecond)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher not stopped")
+	}
+}
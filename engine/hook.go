@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// AllProviders is the key of hooks that apply to all providers.
+const AllProviders = "*"
+
+// Hook is called around scrapes of movie infos, e.g. to clean up
+// titles or fix studio-specific quirks.
+type Hook interface {
+	// PreScrape is called before scraping the movie of the provider,
+	// an error aborts the scrape and is returned as is.
+	PreScrape(provider, id string) error
+	// PostScrape mutates the scraped movie info before it is saved,
+	// an error keeps the info as it was before the hook.
+	PostScrape(info *model.MovieInfo) error
+}
+
+// Hooks are hooks keyed by provider names, the ones of AllProviders
+// are called first.
+type Hooks map[string][]Hook
+
+// Of returns the hooks of the provider in call order.
+func (h Hooks) Of(name string) []Hook {
+	if len(h) == 0 {
+		return nil
+	}
+	all := h[AllProviders]
+	for k, hooks := range h {
+		if k != AllProviders && strings.EqualFold(k, name) {
+			return append(all[:len(all):len(all)], hooks...)
+		}
+	}
+	return all
+}
+
+// SetHooks replaces the scrape hooks at runtime, nil disables them.
+func (e *Engine) SetHooks(h Hooks) { e.hooks.Store(&h) }
+
+func (e *Engine) hooksOf(name string) []Hook {
+	if h := e.hooks.Load(); h != nil {
+		return h.Of(name)
+	}
+	return nil
+}
+
+// preScrapeMovie calls the pre-scrape hooks of the provider.
+func (e *Engine) preScrapeMovie(provider mt.MovieProvider, id string) error {
+	for _, hook := range e.hooksOf(provider.Name()) {
+		if err := hook.PreScrape(provider.Name(), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postScrapeMovie calls the post-scrape hooks of the provider, each
+// one is skipped if it fails.
+func (e *Engine) postScrapeMovie(provider mt.MovieProvider, info *model.MovieInfo) {
+	for _, hook := range e.hooksOf(provider.Name()) {
+		orig := cloneMovieInfo(info)
+		if err := hook.PostScrape(info); err != nil {
+			e.logger.Warn("post-scrape hook",
+				slog.String("provider", provider.Name()),
+				slog.String("id", info.ID),
+				slog.Any("error", err))
+			*info = *orig
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestScriptHook(t *testing.T) {
+	h, err := NewScriptHook("test.js", `
+function preScrape(provider, id) {
+  if (id.indexOf("BLOCKED") === 0) throw new Error("no " + provider);
+}
+function postScrape(info) {
+  info.title = info.title.replace(/^【.*?】\s*/, "");
+  info.genres = (info.genres || []).concat(["Hooked"]);
+}
+`)
+	require.NoError(t, err)
+
+	assert.NoError(t, h.PreScrape("FANZA", "abp00030"))
+	assert.ErrorContains(t, h.PreScrape("FANZA", "BLOCKED-1"), "no FANZA")
+
+	info := &model.MovieInfo{ID: "abp00030", Number: "ABP-030", Title: "【配信専用】 Title", Provider: "FANZA"}
+	info.ReleaseDate = datatypes.Date(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, h.PostScrape(info))
+	assert.Equal(t, "Title", info.Title)
+	assert.Equal(t, []string{"Hooked"}, []string(info.Genres))
+	assert.Equal(t, "ABP-030", info.Number)
+	assert.Equal(t, "2021-02-03", time.Time(info.ReleaseDate).Format(time.DateOnly))
+
+	// returned objects replace the info.
+	h, err = NewScriptHook("replace.js", `function postScrape(info) { return {id: info.id, title: "New"}; }`)
+	require.NoError(t, err)
+	require.NoError(t, h.PostScrape(info))
+	assert.Equal(t, "New", info.Title)
+	assert.Empty(t, info.Number)
+
+	// keys of the info are never changed.
+	h, err = NewScriptHook("move.js", `function postScrape(info) { info.id = "other"; info.provider = "JavBus"; }`)
+	require.NoError(t, err)
+	require.NoError(t, h.PostScrape(info))
+	assert.Equal(t, "abp00030", info.ID)
+	assert.Equal(t, "FANZA", info.Provider)
+	// no interrupts are left for the following calls.
+	assert.Nil(t, h.vm.Interrupt)
+
+	// undefined functions are no-ops.
+	h, err = NewScriptHook("empty.js", ``)
+	require.NoError(t, err)
+	assert.NoError(t, h.PreScrape("FANZA", "abp00030"))
+	assert.NoError(t, h.PostScrape(info))
+	assert.Equal(t, "New", info.Title)
+
+	h, err = NewScriptHook("loop.js", `function postScrape(info) { for (;;) {} }`)
+	require.NoError(t, err)
+	h.timeout = 10 * time.Millisecond
+	assert.ErrorIs(t, h.PostScrape(info), errScriptTimeout)
+
+	_, err = NewScriptHook("invalid.js", `function (`)
+	assert.Error(t, err)
+}
+
+func TestHooks_Of(t *testing.T) {
+	a, b := &ScriptHook{name: "a"}, &ScriptHook{name: "b"}
+	h := Hooks{AllProviders: {a}, "fanza": {b}}
+	assert.Equal(t, []Hook{a, b}, h.Of("FANZA"))
+	assert.Equal(t, []Hook{a}, h.Of("JAVBUS"))
+	assert.Equal(t, []Hook{a}, h[AllProviders])
+	assert.Nil(t, Hooks(nil).Of("FANZA"))
+}
+
+func TestEngine_ScrapeHooks(t *testing.T) {
+	e := Default()
+	provider, err := e.GetMovieProviderByName("FANZA")
+	require.NoError(t, err)
+
+	clean, err := NewScriptHook("clean.js", `function postScrape(info) { info.title = info.title.trim(); }`)
+	require.NoError(t, err)
+	broken, err := NewScriptHook("broken.js", `
+function preScrape(provider, id) { if (id === "hook00002") throw new Error("aborted"); }
+function postScrape(info) { info.title = "Broken"; throw new Error("broken"); }
+`)
+	require.NoError(t, err)
+	e.SetHooks(Hooks{"FANZA": {clean, broken}})
+
+	info, err := e.scrapeMovieInfo(provider, "hook00001", func(context.Context) (*model.MovieInfo, error) {
+		return &model.MovieInfo{
+			ID: "hook00001", Number: "HOOK-001", Title: " Title ", Provider: "FANZA",
+			Homepage: "https://example.com/hook00001", CoverURL: "https://example.com/hook00001.jpg",
+		}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Title", info.Title)
+
+	saved, err := e.getMovieInfoFromDB(provider, "hook00001")
+	require.NoError(t, err)
+	assert.Equal(t, "Title", saved.Title)
+
+	_, err = e.scrapeMovieInfo(provider, "hook00002", func(context.Context) (*model.MovieInfo, error) {
+		t.Fatal("scraped after aborted")
+		return nil, nil
+	})
+	assert.ErrorContains(t, err, "aborted")
+
+	e.SetHooks(nil)
+	info, err = e.scrapeMovieInfo(provider, "hook00003", func(context.Context) (*model.MovieInfo, error) {
+		return &model.MovieInfo{
+			ID: "hook00003", Number: "HOOK-003", Title: " Title ", Provider: "FANZA",
+			Homepage: "https://example.com/hook00003", CoverURL: "https://example.com/hook00003.jpg",
+		}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, " Title ", info.Title)
+}
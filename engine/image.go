@@ -1,12 +1,18 @@
 package engine
 
 import (
+	"bytes"
 	"image"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/metatube-community/metatube-sdk-go/common/number"
 	R "github.com/metatube-community/metatube-sdk-go/constant"
 	"github.com/metatube-community/metatube-sdk-go/imageutil"
-	"github.com/metatube-community/metatube-sdk-go/imageutil/pigo"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face"
+	"github.com/metatube-community/metatube-sdk-go/metrics"
 	"github.com/metatube-community/metatube-sdk-go/model"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 )
@@ -30,6 +36,23 @@ func (e *Engine) GetActorPrimaryImage(name, id string) (image.Image, error) {
 	return e.GetImageByURL(e.MustGetActorProviderByName(name), info.Images[0], R.PrimaryImageRatio, defaultActorPrimaryImagePosition, false)
 }
 
+// GetActorFaceImage returns the primary image of the actor cropped to
+// the ratio and centered on the detected face, e.g. 1 for a square, the
+// default primary ratio is used if ratio isn't positive.
+func (e *Engine) GetActorFaceImage(name, id string, ratio float64) (image.Image, error) {
+	info, err := e.GetActorInfoByProviderID(name, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Images) == 0 {
+		return nil, mt.ErrImageNotFound
+	}
+	if ratio <= 0 {
+		ratio = R.PrimaryImageRatio
+	}
+	return e.GetImageByURL(e.MustGetActorProviderByName(name), info.Images[0], ratio, defaultActorPrimaryImagePosition, true)
+}
+
 func (e *Engine) GetMoviePrimaryImage(name, id string, ratio, pos float64) (image.Image, error) {
 	url, info, err := e.getPreferredMovieImageURLAndInfo(name, id, true)
 	if err != nil {
@@ -41,11 +64,59 @@ func (e *Engine) GetMoviePrimaryImage(name, id string, ratio, pos float64) (imag
 	var auto bool
 	if pos < 0 /* manual position disabled */ {
 		pos = defaultMoviePrimaryImagePosition
-		auto = number.RequireFaceDetection(info.Number)
+		switch mode := e.movieCropMode(name, info); {
+		case mode == CropModeLetterbox:
+			return e.GetLetterboxImageByURL(e.MustGetMovieProviderByName(name), url, ratio)
+		case mode == CropModeFace, number.RequireFaceDetection(info.Number):
+			auto = true
+		case mode == CropModeCover:
+			return e.GetCoverImageByURL(e.MustGetMovieProviderByName(name), url, ratio)
+		}
 	}
 	return e.GetImageByURL(e.MustGetMovieProviderByName(name), url, ratio, pos, auto)
 }
 
+// GetMovieLetterboxImage returns the primary image of the movie padded
+// to the ratio rather than cropped, the default primary ratio is used
+// if ratio is negative.
+func (e *Engine) GetMovieLetterboxImage(name, id string, ratio float64) (image.Image, error) {
+	url, _, err := e.getPreferredMovieImageURLAndInfo(name, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if ratio < 0 /* default primary ratio */ {
+		ratio = R.PrimaryImageRatio
+	}
+	return e.GetLetterboxImageByURL(e.MustGetMovieProviderByName(name), url, ratio)
+}
+
+// movieCropMode returns the crop mode of the movie primary image, anime
+// covers are letterboxed unless the provider has its own mode.
+func (e *Engine) movieCropMode(name string, info *model.MovieInfo) CropMode {
+	if mode, ok := e.cropModeOf(name); ok {
+		return mode
+	}
+	if isAnime(info) {
+		return CropModeLetterbox
+	}
+	return CropModePosition
+}
+
+// animeGenres are genres of anime in the languages of providers.
+var animeGenres = []string{"アニメ", "anime", "动画", "動畫"}
+
+func isAnime(info *model.MovieInfo) bool {
+	for _, genre := range info.Genres {
+		genre = strings.ToLower(genre)
+		for _, anime := range animeGenres {
+			if strings.Contains(genre, anime) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (e *Engine) GetMovieThumbImage(name, id string) (image.Image, error) {
 	url, _, err := e.getPreferredMovieImageURLAndInfo(name, id, false)
 	if err != nil {
@@ -54,25 +125,105 @@ func (e *Engine) GetMovieThumbImage(name, id string) (image.Image, error) {
 	return e.GetImageByURL(e.MustGetMovieProviderByName(name), url, R.ThumbImageRatio, defaultMovieThumbImagePosition, false)
 }
 
+// GetMovieBackdropImage returns the cover of the movie as backdrop, or
+// a backdrop generated from preview images if the cover is missing or
+// not landscape, i.e. the provider has no proper fanart.
 func (e *Engine) GetMovieBackdropImage(name, id string) (image.Image, error) {
-	url, _, err := e.getPreferredMovieImageURLAndInfo(name, id, false)
+	url, info, err := e.getPreferredMovieImageURLAndInfo(name, id, false)
 	if err != nil {
 		return nil, err
 	}
-	return e.GetImageByURL(e.MustGetMovieProviderByName(name), url, R.BackdropImageRatio, defaultMovieBackdropImagePosition, false)
+	provider := e.MustGetMovieProviderByName(name)
+	if url != "" {
+		img, err := e.GetImageByURL(provider, url, R.BackdropImageRatio, defaultMovieBackdropImagePosition, false)
+		if (err == nil && isLandscape(img)) || len(info.PreviewImages) == 0 {
+			return img, err
+		}
+	}
+	return e.getPreviewBackdropImage(provider, info.PreviewImages)
+}
+
+// maxBackdropStills is the max number of preview images fetched to
+// generate a backdrop.
+const maxBackdropStills = 4
+
+// minBackdropRatio is the min width to height ratio of proper fanart.
+const minBackdropRatio = 1.2
+
+func isLandscape(img image.Image) bool {
+	return float64(img.Bounds().Dx()) >= float64(img.Bounds().Dy())*minBackdropRatio
+}
+
+// getPreviewBackdropImage generates a 16:9 backdrop from the preview
+// images, see imageutil.Backdrop.
+func (e *Engine) getPreviewBackdropImage(provider mt.Provider, urls []string) (image.Image, error) {
+	var (
+		stills []image.Image
+		err    error
+	)
+	for _, url := range urls {
+		if len(stills) == maxBackdropStills {
+			break
+		}
+		var img image.Image
+		if img, err = e.getImageByURL(provider, url); err != nil {
+			continue // skip broken stills.
+		}
+		stills = append(stills, img)
+	}
+	if len(stills) == 0 {
+		if err == nil {
+			err = mt.ErrImageNotFound
+		}
+		return nil, err
+	}
+	defer metrics.ObserveImage("backdrop", time.Now())
+	return imageutil.Backdrop(stills, R.ThumbImageRatio), nil
 }
 
 func (e *Engine) GetImageByURL(provider mt.Provider, url string, ratio, pos float64, auto bool) (img image.Image, err error) {
+	start := time.Now()
 	if img, err = e.getImageByURL(provider, url); err != nil {
 		return
 	}
+	metrics.ObserveImage("fetch", start)
 	if auto {
-		pos = pigo.CalculatePosition(img, ratio, pos)
+		start = time.Now()
+		pos = face.Position(e.faceDetector, img, ratio, pos)
+		metrics.ObserveImage("face_detection", start)
 	}
+	defer metrics.ObserveImage("crop", time.Now())
 	return imageutil.CropImagePosition(img, ratio, pos), nil
 }
 
+// GetCoverImageByURL crops the front cover out of the DVD cover scan
+// of the url, see imageutil.CropCover.
+func (e *Engine) GetCoverImageByURL(provider mt.Provider, url string, ratio float64) (img image.Image, err error) {
+	start := time.Now()
+	if img, err = e.getImageByURL(provider, url); err != nil {
+		return
+	}
+	metrics.ObserveImage("fetch", start)
+	defer metrics.ObserveImage("crop", time.Now())
+	return imageutil.CropCover(img, ratio), nil
+}
+
+// GetLetterboxImageByURL pads the image of the url to the ratio, see
+// imageutil.Letterbox.
+func (e *Engine) GetLetterboxImageByURL(provider mt.Provider, url string, ratio float64) (img image.Image, err error) {
+	start := time.Now()
+	if img, err = e.getImageByURL(provider, url); err != nil {
+		return
+	}
+	metrics.ObserveImage("fetch", start)
+	defer metrics.ObserveImage("letterbox", time.Now())
+	return imageutil.Letterbox(img, ratio), nil
+}
+
 func (e *Engine) getImageByURL(provider mt.Provider, url string) (img image.Image, err error) {
+	if e.cache != nil {
+		return e.getCachedImageByURL(provider, url)
+	}
 	resp, err := e.Fetch(url, provider)
 	if err != nil {
 		return
@@ -82,6 +233,27 @@ func (e *Engine) getImageByURL(provider mt.Provider, url string) (img image.Imag
 	return
 }
 
+// getCachedImageByURL looks up the cache before fetching the image,
+// and caches the fetched one as is.
+func (e *Engine) getCachedImageByURL(provider mt.Provider, url string) (img image.Image, err error) {
+	key := "image:" + url
+	data, cacheErr := e.cache.Get(key)
+	if cacheErr != nil {
+		var resp *http.Response
+		if resp, err = e.Fetch(url, provider); err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return
+		}
+	}
+	if img, _, err = imageutil.Decode(bytes.NewReader(data)); err == nil && cacheErr != nil {
+		_ = e.cache.Set(key, data, e.cacheTTL) // valid image only, ignore error.
+	}
+	return
+}
+
 func (e *Engine) getPreferredMovieImageURLAndInfo(name, id string, thumb bool) (url string, info *model.MovieInfo, err error) {
 	info, err = e.GetMovieInfoByProviderID(name, id, true)
 	if err != nil {
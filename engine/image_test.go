@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestEngine_GetPreviewBackdropImage(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, image.NewGray(image.Rect(0, 0, 300, 450))))
+	still := buf.Bytes()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/still.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(still)
+	}))
+	defer srv.Close()
+
+	e := New()
+	img, err := e.getPreviewBackdropImage(nil, []string{
+		srv.URL + "/missing.png",
+		srv.URL + "/still.png",
+		srv.URL + "/still.png",
+		srv.URL + "/still.png",
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 800, img.Bounds().Dx(), 1)
+	assert.Equal(t, 450, img.Bounds().Dy())
+
+	_, err = e.getPreviewBackdropImage(nil, nil)
+	assert.ErrorIs(t, err, mt.ErrImageNotFound)
+	_, err = e.getPreviewBackdropImage(nil, []string{srv.URL + "/missing.png"})
+	assert.Error(t, err)
+}
@@ -1,13 +1,21 @@
 package engine
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
 
 	"github.com/metatube-community/metatube-sdk-go/common/fetch"
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/common/tracing"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/store"
 )
 
 // Special environment prefixes for setting provider priorities.
@@ -21,16 +29,67 @@ func (e *Engine) init() *Engine {
 	e.initFetcher()
 	e.initActorProviders()
 	e.initMovieProviders()
+	e.initProviderConfigs()
+	e.initPayloadRecorders()
 	e.initAllProviderPriorities()
+	e.initCookies()
+	e.initJobHandlers()
+	e.initCacheSweeper()
 	return e
 }
 
+// cacheSweepInterval is the interval of deleting expired entries of
+// cache stores that keep them until read, e.g. on disk.
+const cacheSweepInterval = time.Hour
+
+func (e *Engine) initCacheSweeper() {
+	s, ok := e.cache.(store.Sweeper)
+	if !ok {
+		return
+	}
+	e.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(cacheSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := s.DeleteExpired(); err != nil {
+				e.logger.Warn("sweep cache store", slog.Any("error", err))
+			}
+		}
+	})
+}
+
+func (e *Engine) initCookies() {
+	if err := e.loadCookies(); err != nil {
+		e.logger.Warn("load cookies", slog.Any("error", err))
+	}
+}
+
 func (e *Engine) initLogger() {
-	e.logger = log.New(os.Stdout, "[ENGINE]\u0020", log.LstdFlags|log.Llongfile)
+	if e.logger == nil {
+		e.logger = slog.Default()
+	}
+	e.logger = e.logger.With(slog.String("component", "engine"))
 }
 
 func (e *Engine) initFetcher() {
-	e.fetcher = fetch.Default(&fetch.Config{Timeout: e.timeout})
+	var t http.RoundTripper = cleanhttp.DefaultPooledTransport()
+	if e.transport != nil {
+		t = e.transport
+	}
+	e.fetcher = fetch.Default(&fetch.Config{
+		Timeout:   e.timeout,
+		Transport: tracing.NewTransport(logger.NewTransport(t)),
+	})
+	// streams, e.g. videos, may take longer than the request
+	// timeout, they are canceled along with the request instead.
+	e.streamer = fetch.Default(&fetch.Config{
+		Transport: tracing.NewTransport(logger.NewTransport(t)),
+	})
 }
 
 func (e *Engine) initAllProviderPriorities() {
@@ -70,10 +129,21 @@ func (e *Engine) initActorProviders() {
 		if s, ok := provider.(mt.RequestTimeoutSetter); ok {
 			s.SetRequestTimeout(e.timeout)
 		}
+		if s, ok := provider.(mt.TransportSetter); ok && e.transport != nil {
+			s.SetTransport(e.transport)
+		}
+		if s, ok := provider.(mt.CacheSetter); ok && e.cache != nil {
+			s.SetCache(e.cache, e.cacheTTL)
+		}
+		if s, ok := provider.(mt.SharedRateLimitSetter); ok {
+			if r, ok := e.cache.(store.Reserver); ok {
+				s.SetSharedRateLimit(r)
+			}
+		}
 		// Add actor provider by name.
 		e.actorProviders[strings.ToUpper(name)] = provider
 		// Add actor provider by host.
-		host := provider.URL().Hostname()
+		host := hostKey(provider.URL().Hostname())
 		e.actorHostProviders[host] = append(e.actorHostProviders[host], provider)
 	}
 }
@@ -87,10 +157,21 @@ func (e *Engine) initMovieProviders() {
 		if s, ok := provider.(mt.RequestTimeoutSetter); ok {
 			s.SetRequestTimeout(e.timeout)
 		}
+		if s, ok := provider.(mt.TransportSetter); ok && e.transport != nil {
+			s.SetTransport(e.transport)
+		}
+		if s, ok := provider.(mt.CacheSetter); ok && e.cache != nil {
+			s.SetCache(e.cache, e.cacheTTL)
+		}
+		if s, ok := provider.(mt.SharedRateLimitSetter); ok {
+			if r, ok := e.cache.(store.Reserver); ok {
+				s.SetSharedRateLimit(r)
+			}
+		}
 		// Add movie provider by name.
 		e.movieProviders[strings.ToUpper(name)] = provider
 		// Add movie provider by host.
-		host := provider.URL().Hostname()
+		host := hostKey(provider.URL().Hostname())
 		e.movieHostProviders[host] = append(e.movieHostProviders[host], provider)
 	}
 }
@@ -0,0 +1,342 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// DefaultJobWorkers is the default number of concurrent jobs.
+const DefaultJobWorkers = 2
+
+// Built-in job types.
+const (
+	JobRefreshMovie = "refresh_movie"
+	JobRefreshActor = "refresh_actor"
+	JobCheckFollows = "check_follows"
+	JobRefreshStale = "refresh_stale"
+
+	JobValidateArtwork = "validate_artwork"
+	JobGeneratePreview = "generate_preview"
+	JobReparseMovies   = "reparse_movies"
+)
+
+// jobPollInterval is the interval of polling pending jobs, in case
+// they are submitted by other processes sharing the database.
+const jobPollInterval = 10 * time.Second
+
+// jobLeaseTTL is the lease of a running job, it's renewed every third
+// of it, so that only jobs of crashed runners are reclaimed.
+const jobLeaseTTL = time.Minute
+
+var (
+	ErrInvalidJob  = errors.New(http.StatusBadRequest, "invalid job")
+	ErrJobNotFound = errors.New(http.StatusNotFound, "job not found")
+)
+
+// JobHandler runs a job with its params, the result is stored as JSON.
+type JobHandler func(ctx context.Context, params json.RawMessage) (result any, err error)
+
+type jobQueue struct {
+	workers int
+	// owner identifies the runner in job leases.
+	owner    string
+	leaseTTL time.Duration
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+	// claim serializes claiming of pending jobs.
+	claim sync.Mutex
+	wake  chan struct{}
+}
+
+func newJobQueue() *jobQueue {
+	return &jobQueue{
+		workers:  DefaultJobWorkers,
+		owner:    jobOwner(),
+		leaseTTL: jobLeaseTTL,
+		handlers: make(map[string]JobHandler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// jobOwner returns a unique name of the runner, the host name is
+// included for troubleshooting.
+func jobOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s/%d/%s", host, os.Getpid(), logger.NewRequestID())
+}
+
+func (q *jobQueue) handler(typ string) (JobHandler, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	h, ok := q.handlers[typ]
+	return h, ok
+}
+
+// notify wakes up an idle worker, if any.
+func (q *jobQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+type refreshJobParams struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+}
+
+func (e *Engine) initJobHandlers() {
+	e.RegisterJobHandler(JobRefreshMovie, func(ctx context.Context, params json.RawMessage) (any, error) {
+		p := &refreshJobParams{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		return e.WithContext(ctx).GetMovieInfoByProviderID(p.Provider, p.ID, false)
+	})
+	e.RegisterJobHandler(JobRefreshActor, func(ctx context.Context, params json.RawMessage) (any, error) {
+		p := &refreshJobParams{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		return e.WithContext(ctx).GetActorInfoByProviderID(p.Provider, p.ID, false)
+	})
+	e.RegisterJobHandler(JobCheckFollows, func(ctx context.Context, _ json.RawMessage) (any, error) {
+		return nil, e.WithContext(ctx).CheckFollows()
+	})
+	e.RegisterJobHandler(JobRefreshStale, e.refreshStaleJob)
+	e.RegisterJobHandler(JobValidateArtwork, e.validateArtworkJob)
+	e.RegisterJobHandler(JobGeneratePreview, e.generatePreviewJob)
+	e.RegisterJobHandler(JobReparseMovies, e.reparseMoviesJob)
+}
+
+// RegisterJobHandler registers the handler of the job type, it
+// replaces the existing handler of the same type, if any.
+func (e *Engine) RegisterJobHandler(typ string, h JobHandler) {
+	e.jobs.mu.Lock()
+	defer e.jobs.mu.Unlock()
+	e.jobs.handlers[typ] = h
+}
+
+// SubmitJob enqueues a job of the type, params must be a JSON object
+// or empty. The job is run by RunJobs in the background.
+func (e *Engine) SubmitJob(typ string, params json.RawMessage) (*model.Job, error) {
+	if _, ok := e.jobs.handler(typ); !ok {
+		return nil, ErrInvalidJob
+	}
+	if len(params) > 0 && json.Unmarshal(params, &map[string]json.RawMessage{}) != nil {
+		return nil, ErrInvalidJob
+	}
+	job := &model.Job{
+		Type:   typ,
+		Params: datatypes.JSON(params),
+		Status: model.JobPending,
+	}
+	if err := e.db.Create(job).Error; err != nil {
+		return nil, err
+	}
+	e.jobs.notify()
+	return job, nil
+}
+
+// GetJob returns the job of the id.
+func (e *Engine) GetJob(id uint) (*model.Job, error) {
+	job := &model.Job{}
+	if err := e.primaryDB().First(job, id).Error; goerr.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJobs returns the latest jobs, filtered by status if not empty.
+func (e *Engine) GetJobs(status model.JobStatus, limit int) (jobs []*model.Job, err error) {
+	tx := e.primaryDB().Order("id DESC")
+	if status != "" {
+		tx = tx.Where("status = ?", status)
+	}
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	err = tx.Find(&jobs).Error
+	return
+}
+
+// RunJobs runs pending jobs with a bounded pool of workers until ctx
+// is done. Jobs interrupted by a shutdown are left pending for the next
+// run, and running jobs whose leases expired, e.g. of crashed runners,
+// are reclaimed. Jobs of other live runners are never taken over.
+func (e *Engine) RunJobs(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < e.jobs.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.jobWorker(ctx)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (e *Engine) jobWorker(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		job, err := e.claimJob()
+		if err != nil {
+			e.logger.Error("claim job", slog.Any("error", err))
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-e.jobs.wake:
+			case <-ticker.C:
+			}
+			continue
+		}
+		// there may be more pending jobs for other workers.
+		e.jobs.notify()
+		e.runJob(ctx, job)
+	}
+}
+
+// claimable matches pending jobs, and running ones of expired leases,
+// including those of runners without leases.
+func claimable(tx *gorm.DB, now time.Time) *gorm.DB {
+	return tx.Where("status = ? OR (status = ? AND (lease_expires_at IS NULL OR lease_expires_at < ?))",
+		model.JobPending, model.JobRunning, now)
+}
+
+// claimJob leases the oldest claimable job to the runner and returns
+// it, or nil if there is none.
+func (e *Engine) claimJob() (*model.Job, error) {
+	e.jobs.claim.Lock()
+	defer e.jobs.claim.Unlock()
+
+	now := time.Now()
+	var jobs []*model.Job
+	if err := claimable(e.primaryDB(), now).
+		Order("id").
+		Limit(1).
+		Find(&jobs).Error; err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	job := jobs[0]
+	expires := now.Add(e.jobs.leaseTTL)
+	tx := claimable(e.db.Model(job), now).
+		Updates(&model.Job{
+			Status:         model.JobRunning,
+			Owner:          e.jobs.owner,
+			LeaseExpiresAt: &expires,
+			StartedAt:      &now,
+		})
+	if tx.Error != nil || tx.RowsAffected == 0 /* claimed by others */ {
+		return nil, tx.Error
+	}
+	job.Status, job.Owner, job.LeaseExpiresAt, job.StartedAt = model.JobRunning, e.jobs.owner, &expires, &now
+	return job, nil
+}
+
+// leased scopes updates to the job while its lease is held.
+func (e *Engine) leased(job *model.Job) *gorm.DB {
+	return e.db.Model(job).Where("status = ? AND owner = ?", model.JobRunning, e.jobs.owner)
+}
+
+// renewLease extends the lease of the job until ctx is done, lost is
+// called if the lease is taken over meanwhile, e.g. after it expired
+// while the runner was stalled.
+func (e *Engine) renewLease(ctx context.Context, job *model.Job, lost func()) {
+	ticker := time.NewTicker(e.jobs.leaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		tx := e.leased(job).Update("lease_expires_at", time.Now().Add(e.jobs.leaseTTL))
+		if tx.Error != nil {
+			e.logger.Warn("renew job lease", slog.Uint64("id", uint64(job.ID)), slog.Any("error", tx.Error))
+			continue // retried until the lease expires.
+		}
+		if tx.RowsAffected == 0 {
+			lost()
+			return
+		}
+	}
+}
+
+func (e *Engine) runJob(ctx context.Context, job *model.Job) {
+	startTime := time.Now()
+	jobCtx, cancel := context.WithCancel(ctx)
+	lost := atomic.NewBool(false)
+	go e.renewLease(jobCtx, job, func() {
+		lost.Store(true)
+		cancel()
+	})
+	result, err := e.callJobHandler(jobCtx, job)
+	cancel()
+	if lost.Load() {
+		e.logger.Warn("job lease lost", slog.Uint64("id", uint64(job.ID)), slog.String("type", job.Type))
+		return
+	}
+	if ctx.Err() != nil {
+		// interrupted by shutdown, resume it on the next run.
+		e.leased(job).Updates(map[string]any{
+			"status":           model.JobPending,
+			"owner":            "",
+			"lease_expires_at": nil,
+		}) // ignore error
+		return
+	}
+
+	now := time.Now()
+	job.Status, job.FinishedAt = model.JobSucceeded, &now
+	if err == nil && result != nil {
+		job.Result, err = json.Marshal(result)
+	}
+	if err != nil {
+		job.Status, job.Error = model.JobFailed, err.Error()
+	}
+	job.LeaseExpiresAt = nil
+	if dbErr := e.leased(job).
+		Select("status", "result", "error", "finished_at", "lease_expires_at").
+		Updates(job).Error; dbErr != nil {
+		e.logger.Error("save job", slog.Uint64("id", uint64(job.ID)), slog.Any("error", dbErr))
+	}
+	e.logger.Info("run job",
+		slog.Uint64("id", uint64(job.ID)),
+		slog.String("type", job.Type),
+		slog.String("status", string(job.Status)),
+		slog.Duration("duration", time.Since(startTime)))
+}
+
+func (e *Engine) callJobHandler(ctx context.Context, job *model.Job) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	h, ok := e.jobs.handler(job.Type)
+	if !ok {
+		return nil, ErrInvalidJob
+	}
+	// jobs are bulk work, so yield to interactive lookups.
+	return h(WithPriority(ctx, PriorityBackground), json.RawMessage(job.Params))
+}
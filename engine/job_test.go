@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	goerr "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_RunJobs(t *testing.T) {
+	e := Default()
+	e.RegisterJobHandler("test_echo", func(_ context.Context, params json.RawMessage) (any, error) {
+		return params, nil
+	})
+	e.RegisterJobHandler("test_fail", func(context.Context, json.RawMessage) (any, error) {
+		return nil, goerr.New("failed")
+	})
+
+	_, err := e.SubmitJob("test_unknown", nil)
+	assert.ErrorIs(t, err, ErrInvalidJob)
+	_, err = e.SubmitJob("test_echo", json.RawMessage(`{`))
+	assert.ErrorIs(t, err, ErrInvalidJob)
+	_, err = e.SubmitJob("test_echo", json.RawMessage(`1`))
+	assert.ErrorIs(t, err, ErrInvalidJob)
+
+	// interrupted jobs are resumed.
+	interrupted := &model.Job{Type: "test_echo", Params: []byte(`{}`), Status: model.JobRunning}
+	require.NoError(t, e.db.Create(interrupted).Error)
+
+	echo, err := e.SubmitJob("test_echo", json.RawMessage(`{"a":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, model.JobPending, echo.Status)
+	fail, err := e.SubmitJob("test_fail", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, e.RunJobs(ctx))
+	}()
+	defer func() { cancel(); <-done }()
+
+	wait := func(id uint) *model.Job {
+		var job *model.Job
+		require.Eventually(t, func() bool {
+			job, err = e.GetJob(id)
+			return err == nil && job.Done()
+		}, 5*time.Second, 10*time.Millisecond)
+		return job
+	}
+
+	job := wait(echo.ID)
+	assert.Equal(t, model.JobSucceeded, job.Status)
+	assert.JSONEq(t, `{"a":1}`, string(job.Result))
+	assert.NotNil(t, job.FinishedAt)
+
+	job = wait(fail.ID)
+	assert.Equal(t, model.JobFailed, job.Status)
+	assert.Equal(t, "failed", job.Error)
+
+	job = wait(interrupted.ID)
+	assert.Equal(t, model.JobSucceeded, job.Status)
+
+	jobs, err := e.GetJobs(model.JobFailed, 0)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, fail.ID, jobs[0].ID)
+
+	_, err = e.GetJob(12345)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestEngine_ClaimJobLease(t *testing.T) {
+	db, err := database.Open(&database.Config{DSN: "file:job_lease_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Second)
+	live := &model.Job{Type: JobCheckFollows, Status: model.JobRunning, Owner: "other", LeaseExpiresAt: &future}
+	require.NoError(t, e.db.Create(live).Error)
+	expired := &model.Job{Type: JobCheckFollows, Status: model.JobRunning, Owner: "other", LeaseExpiresAt: &past}
+	require.NoError(t, e.db.Create(expired).Error)
+
+	// jobs of live runners are never taken over.
+	job, err := e.claimJob()
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, expired.ID, job.ID)
+	assert.Equal(t, e.jobs.owner, job.Owner)
+	assert.True(t, job.LeaseExpiresAt.After(time.Now()))
+
+	job, err = e.claimJob()
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	// leases are renewed while the job runs.
+	e.jobs.leaseTTL = 30 * time.Millisecond
+	claimed, err := e.GetJob(expired.ID)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Now()
+	go e.renewLease(ctx, claimed, func() {})
+	time.Sleep(100 * time.Millisecond)
+	renewed, err := e.GetJob(expired.ID)
+	require.NoError(t, err)
+	assert.WithinRange(t, *renewed.LeaseExpiresAt, start.Add(50*time.Millisecond), start.Add(time.Second))
+
+	// a lease taken over is reported as lost.
+	lost := make(chan struct{})
+	require.NoError(t, e.db.Model(claimed).Update("owner", "other").Error)
+	go e.renewLease(ctx, claimed, func() { close(lost) })
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("lease loss not reported")
+	}
+}
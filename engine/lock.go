@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+// minScrapeLockTTL is the min time a scrape lock is held, scrapes may
+// take several requests, each of which might take the request timeout.
+const minScrapeLockTTL = time.Minute
+
+// clockSkew tolerates clock differences among replicas.
+const clockSkew = time.Second
+
+// scrapeLockTTL returns the max time a scrape lock is held.
+func (e *Engine) scrapeLockTTL() time.Duration {
+	return max(4*e.timeout, minScrapeLockTTL)
+}
+
+// lockedScrape scrapes while holding the lock of the key, if the cache
+// store supports locking, so that replicas sharing the store never
+// scrape the same page at once. If another replica holds the lock, the
+// result it saves is loaded instead, once it's found.
+func lockedScrape[T any](e *Engine, key string, load func(since time.Time) (T, bool), scrape func() (T, error)) (T, error) {
+	locker, ok := e.cache.(store.Locker)
+	if !ok {
+		return scrape()
+	}
+	since := time.Now().Add(-clockSkew)
+	var (
+		loaded T
+		found  bool
+	)
+	unlock := store.Acquire(e.ctx, locker, "lock:"+key, e.scrapeLockTTL(), func() bool {
+		loaded, found = load(since)
+		return found
+	})
+	if unlock != nil {
+		defer unlock()
+	}
+	if found {
+		return loaded, nil
+	}
+	// the client is gone while waiting.
+	if err := e.ctx.Err(); err != nil {
+		return loaded, err
+	}
+	// scraped by the holder right before the lock is acquired.
+	if loaded, found = load(since); found {
+		return loaded, nil
+	}
+	return scrape()
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+func TestLockedScrape(t *testing.T) {
+	cache := store.NewMemory(0)
+	e := New(WithCache(cache, time.Hour))
+
+	var (
+		saved   atomic.Bool
+		scrapes atomic.Int32
+	)
+	load := func(time.Time) (string, bool) { return "saved", saved.Load() }
+	scrape := func() (string, error) {
+		scrapes.Add(1)
+		return "scraped", nil
+	}
+
+	v, err := lockedScrape(e, "key", load, scrape)
+	require.NoError(t, err)
+	assert.Equal(t, "scraped", v)
+
+	// held by another replica, which saves the result.
+	unlock, ok, _ := cache.TryLock("lock:key", time.Minute)
+	require.True(t, ok)
+	go func() {
+		time.Sleep(2 * store.LockPollInterval)
+		saved.Store(true)
+		unlock()
+	}()
+	v, err = lockedScrape(e, "key", load, scrape)
+	require.NoError(t, err)
+	assert.Equal(t, "saved", v)
+	assert.EqualValues(t, 1, scrapes.Load())
+
+	// stops waiting once the client is gone.
+	unlock, ok, _ = cache.TryLock("lock:key", time.Minute)
+	require.True(t, ok)
+	defer unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	saved.Store(false)
+	_, err = lockedScrape(e.WithContext(ctx), "key", load, scrape)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualValues(t, 1, scrapes.Load())
+
+	// no locking without cache.
+	v, err = lockedScrape(New(), "key", load, scrape)
+	require.NoError(t, err)
+	assert.Equal(t, "scraped", v)
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/comparer"
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// titleMatchThreshold is the min similarity of a fuzzy title match.
+const titleMatchThreshold = 0.6
+
+// matchMovie returns how the keyword matches the search result.
+func matchMovie(keyword string, result *model.MovieSearchResult) *model.MovieMatch {
+	keyword = strings.TrimSpace(keyword)
+	switch {
+	case strings.EqualFold(keyword, result.ID):
+		return &model.MovieMatch{Type: model.MatchByID, Confidence: 1}
+	case number.Normalize(keyword) == number.Normalize(result.Number):
+		return &model.MovieMatch{Type: model.MatchByNumber, Confidence: 1}
+	}
+	if similarity := comparer.Compare(keyword, result.Title); similarity >= titleMatchThreshold {
+		return &model.MovieMatch{Type: model.MatchByTitle, Confidence: similarity}
+	}
+	// the first result is never more confident than half.
+	return &model.MovieMatch{
+		Type:       model.MatchFirstResult,
+		Confidence: comparer.Compare(number.Normalize(keyword), number.Normalize(result.Number)) / 2,
+	}
+}
+
+// LookupMovieInfo searches the keyword from all providers, and returns
+// the movie info of the best matched result, with the match attached.
+func (e *Engine) LookupMovieInfo(keyword string) (*model.MovieInfo, error) {
+	if keyword = strings.TrimSpace(keyword); keyword == "" {
+		return nil, mt.ErrInvalidKeyword
+	}
+	results, err := e.SearchMovieAll(keyword, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, mt.ErrInfoNotFound
+	}
+	// results are sorted by relevance, the first exact match of the
+	// same edition is the best match.
+	best := bestResult(keyword, results)
+	info, err := e.GetMovieInfoByProviderID(best.Provider, best.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	info.Match = matchMovie(keyword, best)
+	return info, nil
+}
+
+// GetMovieInfoByNumber gets the movie info of the number, unlike
+// GetMovieInfoByProviderID no provider-specific ID is needed. Known IDs
+// of the number skip searching. Otherwise, amateur numbers are routed
+// to the provider of their series first, then all providers are
+// searched, and only exact matches of the normalized number are
+// accepted, best matched first.
+func (e *Engine) GetMovieInfoByNumber(num string, lazy bool) (*model.MovieInfo, error) {
+	if num = number.Trim(num); num == "" {
+		return nil, mt.ErrInvalidKeyword
+	}
+	if numbers, err := e.GetMovieNumbers(num); err == nil {
+		for _, known := range numbers {
+			if info, err := e.GetMovieInfoByProviderID(known.Provider, known.ID, lazy); err == nil {
+				info.Match = &model.MovieMatch{Type: model.MatchByNumber, Confidence: 1}
+				return info, nil
+			}
+		}
+	}
+	var candidates []*model.MovieSearchResult
+	if series, ok := number.LookupSeries(num); ok {
+		if results, err := e.SearchMovie(num, series.Provider, true); err == nil {
+			candidates = exactResults(num, results)
+		}
+	}
+	if len(candidates) == 0 {
+		results, err := e.SearchMovieAll(num, true)
+		if err != nil {
+			return nil, err
+		}
+		candidates = exactResults(num, results)
+	}
+	if len(candidates) == 0 {
+		return nil, mt.ErrInfoNotFound
+	}
+	best := bestResult(num, candidates)
+	candidates = append([]*model.MovieSearchResult{best}, slices.DeleteFunc(candidates,
+		func(v *model.MovieSearchResult) bool { return v == best })...)
+
+	var err error
+	for _, result := range candidates {
+		var info *model.MovieInfo
+		if info, err = e.GetMovieInfoByProviderID(result.Provider, result.ID, lazy); err == nil {
+			info.Match = &model.MovieMatch{Type: model.MatchByNumber, Confidence: 1}
+			return info, nil
+		}
+	}
+	return nil, err
+}
+
+// exactResults returns the results of the same normalized number.
+func exactResults(num string, results []*model.MovieSearchResult) (exact []*model.MovieSearchResult) {
+	for _, result := range results {
+		if number.Normalize(result.Number) == number.Normalize(num) {
+			exact = append(exact, result)
+		}
+	}
+	return
+}
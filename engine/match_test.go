@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestMatchMovie(t *testing.T) {
+	result := &model.MovieSearchResult{ID: "abp00030", Number: "ABP-030", Title: "新人デビュー作品"}
+	for _, unit := range []struct {
+		keyword string
+		typ     model.MatchType
+	}{
+		{"ABP00030", model.MatchByID},
+		{"abp-030", model.MatchByNumber},
+		{"新人デビュー作品", model.MatchByTitle},
+		{"ABP-031", model.MatchFirstResult},
+	} {
+		match := matchMovie(unit.keyword, result)
+		assert.Equal(t, unit.typ, match.Type, unit.keyword)
+		if unit.typ == model.MatchFirstResult {
+			assert.LessOrEqual(t, match.Confidence, 0.5, unit.keyword)
+		} else {
+			assert.GreaterOrEqual(t, match.Confidence, titleMatchThreshold, unit.keyword)
+		}
+	}
+}
+
+func TestBestResult(t *testing.T) {
+	results := []*model.MovieSearchResult{
+		{ID: "abp00030best", Number: "ABP-030", Editions: []string{"compilation"}},
+		{ID: "abp00030", Number: "ABP-030"},
+		{ID: "abp00031", Number: "ABP-031"},
+	}
+	assert.Equal(t, "abp00030", bestResult("ABP-030", results).ID)
+	assert.Equal(t, "abp00030best", bestResult("ABP-030 総集編", results).ID)
+	assert.Equal(t, "abp00030best", bestResult("ABP-999", results).ID)
+}
+
+func TestExactResults(t *testing.T) {
+	results := []*model.MovieSearchResult{
+		{ID: "118abp00123", Number: "ABP-123"},
+		{ID: "abp00124", Number: "ABP-124"},
+		{ID: "ABP-123", Number: "abp123"},
+	}
+	exact := exactResults("abp-123", results)
+	if assert.Len(t, exact, 2) {
+		assert.Equal(t, "118abp00123", exact[0].ID)
+		assert.Equal(t, "ABP-123", exact[1].ID)
+	}
+
+	_, err := Default().GetMovieInfoByNumber("  ", true)
+	assert.ErrorIs(t, err, mt.ErrInvalidKeyword)
+}
+
+func TestEngine_LookupMovieInfoNotFound(t *testing.T) {
+	db, err := database.Open(&database.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+	// no providers to search, results come from the database only.
+	e.movieProviders = map[string]mt.MovieProvider{}
+
+	_, err = e.LookupMovieInfo("ABP-050")
+	assert.ErrorIs(t, err, mt.ErrInfoNotFound)
+
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:       "abp00050",
+		Number:   "ABP-050",
+		Title:    "Title",
+		Provider: "FANZA",
+		Homepage: "https://www.dmm.co.jp/",
+		CoverURL: "https://www.dmm.co.jp/cover.jpg",
+	}).Error)
+	b, err := NewBlocklist([]string{"ABP-050"}, nil, nil)
+	require.NoError(t, err)
+	e.SetBlocklist(b)
+
+	// all results are blocked.
+	_, err = e.LookupMovieInfo("ABP-050")
+	assert.ErrorIs(t, err, mt.ErrInfoNotFound)
+
+	assert.Nil(t, bestResult("ABP-050", nil))
+}
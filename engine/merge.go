@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/lib/pq"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// movieField is a mergeable field of the movie info.
+type movieField struct {
+	name  string
+	empty func(*model.MovieInfo) bool
+	copy  func(dst, src *model.MovieInfo)
+}
+
+func valueField[T comparable](name string, ptr func(*model.MovieInfo) *T) movieField {
+	return movieField{
+		name: name,
+		empty: func(m *model.MovieInfo) bool {
+			var zero T
+			return *ptr(m) == zero
+		},
+		copy: func(dst, src *model.MovieInfo) { *ptr(dst) = *ptr(src) },
+	}
+}
+
+func listField(name string, ptr func(*model.MovieInfo) *pq.StringArray) movieField {
+	return movieField{
+		name:  name,
+		empty: func(m *model.MovieInfo) bool { return len(*ptr(m)) == 0 },
+		copy:  func(dst, src *model.MovieInfo) { *ptr(dst) = *ptr(src) },
+	}
+}
+
+// movieFields are named after the JSON keys of the movie info.
+var movieFields = []movieField{
+	valueField("title", func(m *model.MovieInfo) *string { return &m.Title }),
+	valueField("summary", func(m *model.MovieInfo) *string { return &m.Summary }),
+	valueField("director", func(m *model.MovieInfo) *string { return &m.Director }),
+	listField("actors", func(m *model.MovieInfo) *pq.StringArray { return &m.Actors }),
+	valueField("thumb_url", func(m *model.MovieInfo) *string { return &m.ThumbURL }),
+	valueField("big_thumb_url", func(m *model.MovieInfo) *string { return &m.BigThumbURL }),
+	valueField("cover_url", func(m *model.MovieInfo) *string { return &m.CoverURL }),
+	valueField("big_cover_url", func(m *model.MovieInfo) *string { return &m.BigCoverURL }),
+	valueField("preview_video_url", func(m *model.MovieInfo) *string { return &m.PreviewVideoURL }),
+	{
+		name:  "preview_images",
+		empty: func(m *model.MovieInfo) bool { return len(m.PreviewImages) == 0 },
+		copy: func(dst, src *model.MovieInfo) {
+			dst.PreviewImages, dst.PreviewImageDetails = src.PreviewImages, src.PreviewImageDetails
+		},
+	},
+	valueField("maker", func(m *model.MovieInfo) *string { return &m.Maker }),
+	valueField("label", func(m *model.MovieInfo) *string { return &m.Label }),
+	valueField("series", func(m *model.MovieInfo) *string { return &m.Series }),
+	listField("genres", func(m *model.MovieInfo) *pq.StringArray { return &m.Genres }),
+	valueField("score", func(m *model.MovieInfo) *float64 { return &m.Score }),
+	valueField("review_count", func(m *model.MovieInfo) *int { return &m.ReviewCount }),
+	valueField("wishlist_count", func(m *model.MovieInfo) *int { return &m.WishlistCount }),
+	valueField("sales_rank", func(m *model.MovieInfo) *int { return &m.SalesRank }),
+	valueField("runtime", func(m *model.MovieInfo) *int { return &m.Runtime }),
+	{
+		name:  "related_movies",
+		empty: func(m *model.MovieInfo) bool { return len(m.RelatedMovies) == 0 },
+		copy:  func(dst, src *model.MovieInfo) { dst.RelatedMovies = src.RelatedMovies },
+	},
+	valueField("release_date", func(m *model.MovieInfo) *datatypes.Date { return &m.ReleaseDate }),
+	valueField("streaming_date", func(m *model.MovieInfo) *datatypes.Date { return &m.StreamingDate }),
+	valueField("physical_date", func(m *model.MovieInfo) *datatypes.Date { return &m.PhysicalDate }),
+}
+
+// mergeMovieInfo fills in empty fields of dst from src, and records the
+// provider of src for them. It returns the number of empty fields left.
+func mergeMovieInfo(dst, src *model.MovieInfo) (missing int) {
+	if dst.Sources == nil {
+		dst.Sources = make(map[string]string)
+	}
+	for _, field := range movieFields {
+		switch {
+		case !field.empty(dst):
+			if _, ok := dst.Sources[field.name]; !ok {
+				dst.Sources[field.name] = dst.Provider
+			}
+		case !field.empty(src):
+			field.copy(dst, src)
+			dst.Sources[field.name] = src.Provider
+		default:
+			missing++
+		}
+	}
+	if src != dst && src.Provider != dst.Provider {
+		for _, rating := range src.Ratings {
+			if !slices.ContainsFunc(dst.Ratings, func(r *model.MovieRating) bool {
+				return r.Provider == rating.Provider
+			}) {
+				dst.Ratings = append(dst.Ratings, rating)
+			}
+		}
+		dst.NormalizedScore = averageScore(dst.Ratings)
+	}
+	return
+}
+
+// GetMergedMovieInfo gets the movie info from the provider, and fills in
+// its missing fields from the same movie, i.e. the same normalized number,
+// of other providers in priority order. Sources of fields are recorded.
+func (e *Engine) GetMergedMovieInfo(name, id string, lazy bool) (*model.MovieInfo, error) {
+	info, err := e.GetMovieInfoByProviderID(name, id, lazy)
+	if err != nil {
+		return nil, err
+	}
+	merged := *info // shallow copy, no changes to the saved info.
+	merged.Sources = nil
+	merged.Ratings = slices.Clone(info.Ratings)
+	if mergeMovieInfo(&merged, info) == 0 {
+		return &merged, nil
+	}
+
+	e.forEachSameMovie(info, func(other *model.MovieInfo) bool {
+		return mergeMovieInfo(&merged, other) != 0
+	})
+	return &merged, nil
+}
+
+// forEachSameMovie calls fn with the same movie, i.e. the same normalized
+// number, of other providers in priority order, until fn returns false.
+func (e *Engine) forEachSameMovie(info *model.MovieInfo, fn func(*model.MovieInfo) bool) {
+	results, err := e.SearchMovieAll(info.Number, true)
+	if err != nil {
+		return // ignore search errors.
+	}
+	for _, result := range results {
+		if result.Provider == info.Provider ||
+			number.Normalize(result.Number) != number.Normalize(info.Number) {
+			continue
+		}
+		other, err := e.GetMovieInfoByProviderID(result.Provider, result.ID, true)
+		if err != nil {
+			e.logger.Warn("get same movie info",
+				slog.String("provider", result.Provider),
+				slog.String("id", result.ID),
+				slog.Any("error", err))
+			continue
+		}
+		if !fn(other) {
+			break
+		}
+	}
+}
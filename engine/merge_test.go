@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestMergeMovieInfo(t *testing.T) {
+	dst := &model.MovieInfo{
+		Provider: "JavBus",
+		Title:    "Title",
+		CoverURL: "https://example.com/cover.jpg",
+	}
+	src := &model.MovieInfo{
+		Provider: "FANZA",
+		Title:    "Other Title",
+		Summary:  "Summary",
+		Actors:   []string{"Actor"},
+		Runtime:  120,
+	}
+	missing := mergeMovieInfo(dst, src)
+	assert.Positive(t, missing)
+	assert.Equal(t, "Title", dst.Title)
+	assert.Equal(t, "Summary", dst.Summary)
+	assert.Equal(t, []string{"Actor"}, []string(dst.Actors))
+	assert.Equal(t, 120, dst.Runtime)
+	assert.Equal(t, "JavBus", dst.Sources["title"])
+	assert.Equal(t, "JavBus", dst.Sources["cover_url"])
+	assert.Equal(t, "FANZA", dst.Sources["summary"])
+	assert.Equal(t, "FANZA", dst.Sources["runtime"])
+	assert.NotContains(t, dst.Sources, "director")
+}
+
+func TestMergeMovieInfo_PreviewImages(t *testing.T) {
+	src := &model.MovieInfo{Provider: "DUGA"}
+	src.AddPreviewImage("https://example.com/1.jpg", 0, "Scene 1")
+	src.AddPreviewImage("https://example.com/3.jpg", 3, "")
+	dst := &model.MovieInfo{Provider: "JavBus"}
+	mergeMovieInfo(dst, src)
+	assert.Equal(t, []string{"https://example.com/1.jpg", "https://example.com/3.jpg"}, []string(dst.PreviewImages))
+	if assert.Len(t, dst.PreviewImageList(), 2) {
+		assert.Equal(t, "Scene 1", dst.PreviewImageList()[0].Caption)
+		assert.Equal(t, 3, dst.PreviewImageList()[1].Index)
+	}
+
+	flat := &model.MovieInfo{PreviewImages: []string{"a.jpg", "b.jpg"}}
+	assert.Equal(t, 2, flat.PreviewImageList()[1].Index)
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/metatube-community/metatube-sdk-go/common/tracing"
+	"github.com/metatube-community/metatube-sdk-go/metrics"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// Provider operation names used in metrics, logs and traces.
+const (
+	opSearchMovie         = "search_movie"
+	opSearchActor         = "search_actor"
+	opMovieInfo           = "movie_info"
+	opActorInfo           = "actor_info"
+	opReviews             = "movie_reviews"
+	opSearchMovieByActor  = "search_movie_by_actor"
+	opSearchMovieByGenre  = "search_movie_by_genre"
+	opSearchMovieBySeries = "search_movie_by_series"
+	opSearchMovieByMaker  = "search_movie_by_maker"
+	opMovieCalendar       = "movie_calendar"
+)
+
+// observe starts observing a provider operation, the returned function
+// records metrics, logs and the trace span of it, and is meant to be
+// deferred right before calling the provider with the returned context,
+// which carries the span. It also waits for a call slot of the priority
+// of the engine context, and returns the error of the context instead
+// if it's done meanwhile, the provider must not be called then.
+func (e *Engine) observe(provider mt.Provider, operation, key string) (context.Context, func(err *error), error) {
+	release := func() {}
+	if e.sched != nil {
+		r, err := e.sched.acquire(e.ctx, provider.Name(), PriorityFromContext(e.ctx))
+		if err != nil {
+			return nil, nil, err
+		}
+		release = r
+	}
+	start := time.Now()
+	ctx, span := tracing.Start(e.ctx, "provider."+operation,
+		attribute.String("provider", provider.Name()),
+		attribute.String("key", key))
+	return ctx, func(err *error) {
+		release()
+		tracing.End(span, *err)
+		metrics.ObserveProvider(provider.Name(), operation, start, *err)
+
+		attrs := []any{
+			slog.String("provider", provider.Name()),
+			slog.String("operation", operation),
+			slog.String("key", key),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if *err != nil {
+			e.logger.Debug("provider call failed", append(attrs, slog.Any("error", *err))...)
+			return
+		}
+		e.logger.Debug("provider call", attrs...)
+	}, nil
+}
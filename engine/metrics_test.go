@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/metatube-community/metatube-sdk-go/common/tracing"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_ObserveSpanContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(tp)
+
+	ctx, span := tracing.Start(context.Background(), "request")
+	e := Default().WithContext(ctx)
+	provider := e.MustGetMovieProviderByName("fanza")
+
+	var callSpan trace.SpanContext
+	_, err := e.scrapeMovieInfo(provider, "span00001", func(ctx context.Context) (*model.MovieInfo, error) {
+		callSpan = trace.SpanContextFromContext(ctx)
+		return nil, assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	call, request := spans[0], spans[1]
+	assert.Equal(t, "provider."+opMovieInfo, call.Name())
+	assert.Equal(t, call.SpanContext().SpanID(), callSpan.SpanID())
+	assert.Equal(t, request.SpanContext().SpanID(), call.Parent().SpanID())
+}
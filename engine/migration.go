@@ -0,0 +1,264 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	gormtypes "gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+)
+
+// migrations are the versioned schema migrations of the engine, new
+// ones are appended and released ones must never be changed, so that
+// every database goes through the same steps. Migrations never use the
+// live models, which change over time, but the frozen schemas below.
+var migrations = []*database.Migration{
+	{
+		// baseline of databases created by auto migration.
+		ID: "202610160001",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&movieInfoV1{},
+				&actorInfoV1{},
+				&movieReviewInfoV1{},
+				&followV1{},
+				&followReleaseV1{},
+				&collectionItemV1{},
+				&movieOverrideV1{},
+				&jobV1{},
+				&movieNumberV1{},
+				&numberStateV1{},
+			)
+		},
+	},
+	{
+		// raw provider payloads, JSONB snapshots in Postgres.
+		ID: "202610160002",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&moviePayloadV1{})
+		},
+	},
+	{
+		// leases of running jobs.
+		ID: "202610160003",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&jobLeaseV1{})
+		},
+	},
+	{
+		// attempts of scheduled refreshes.
+		ID: "202610160004",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&refreshAttemptV1{})
+		},
+	},
+}
+
+// Frozen schemas of migration 202610160001.
+
+type movieInfoV1 struct {
+	ID                  string `gorm:"primaryKey"`
+	Number              string
+	Title               string
+	Summary             string
+	Provider            string `gorm:"primaryKey"`
+	Homepage            string
+	Director            string
+	Actors              pq.StringArray `gorm:"type:text[]"`
+	ThumbURL            string
+	BigThumbURL         string
+	CoverURL            string
+	BigCoverURL         string
+	PreviewVideoURL     string
+	PreviewVideoHLSURL  string
+	PreviewImages       pq.StringArray `gorm:"type:text[]"`
+	PreviewImageDetails string
+	Maker               string
+	Label               string
+	Series              string
+	Genres              pq.StringArray `gorm:"type:text[]"`
+	Score               float64
+	Rating              string
+	ReviewCount         int
+	WishlistCount       int
+	SalesRank           int
+	Runtime             int
+	ReleaseDate         datatypes.Date
+	StreamingDate       datatypes.Date
+	PhysicalDate        datatypes.Date
+	RelatedMovies       string
+	VR                  string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (*movieInfoV1) TableName() string { return "movie_metadata" }
+
+type actorInfoV1 struct {
+	ID           string `gorm:"primaryKey"`
+	Name         string
+	Provider     string `gorm:"primaryKey"`
+	Homepage     string
+	Summary      string
+	Hobby        string
+	Skill        string
+	BloodType    string
+	CupSize      string
+	Measurements string
+	Nationality  string
+	Height       int
+	Aliases      pq.StringArray `gorm:"type:text[]"`
+	Images       pq.StringArray `gorm:"type:text[]"`
+	Birthday     datatypes.Date
+	DebutDate    datatypes.Date
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (*actorInfoV1) TableName() string { return "actor_metadata" }
+
+type movieReviewInfoV1 struct {
+	ID        string `gorm:"primaryKey"`
+	Provider  string `gorm:"primaryKey"`
+	Reviews   gormtypes.JSON
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (*movieReviewInfoV1) TableName() string { return "movie_reviews" }
+
+type followV1 struct {
+	ID        uint   `gorm:"primaryKey"`
+	User      string `gorm:"column:username;uniqueIndex:idx_follow"`
+	Type      string `gorm:"uniqueIndex:idx_follow"`
+	Name      string `gorm:"uniqueIndex:idx_follow"`
+	CheckedAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (*followV1) TableName() string { return "follows" }
+
+type followReleaseV1 struct {
+	FollowID    uint   `gorm:"primaryKey"`
+	Provider    string `gorm:"primaryKey"`
+	ID          string `gorm:"primaryKey"`
+	Number      string
+	Title       string
+	Homepage    string
+	ThumbURL    string
+	ReleaseDate datatypes.Date
+	Seen        bool `gorm:"index"`
+	CreatedAt   time.Time
+}
+
+func (*followReleaseV1) TableName() string { return "follow_releases" }
+
+type collectionItemV1 struct {
+	ID         uint   `gorm:"primaryKey"`
+	User       string `gorm:"column:username;uniqueIndex:idx_collection_item"`
+	Collection string `gorm:"uniqueIndex:idx_collection_item"`
+	Provider   string `gorm:"uniqueIndex:idx_collection_item"`
+	ItemID     string `gorm:"column:item_id;uniqueIndex:idx_collection_item"`
+	Note       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (*collectionItemV1) TableName() string { return "collection_items" }
+
+type movieOverrideV1 struct {
+	ID        string `gorm:"primaryKey"`
+	Provider  string `gorm:"primaryKey"`
+	Fields    gormtypes.JSON
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (*movieOverrideV1) TableName() string { return "movie_overrides" }
+
+type jobV1 struct {
+	ID         uint   `gorm:"primaryKey"`
+	Type       string `gorm:"index"`
+	Params     gormtypes.JSON
+	Status     string `gorm:"index"`
+	Result     gormtypes.JSON
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+func (*jobV1) TableName() string { return "jobs" }
+
+type movieNumberV1 struct {
+	Number    string `gorm:"primaryKey"`
+	Provider  string `gorm:"primaryKey"`
+	ID        string `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (*movieNumberV1) TableName() string { return "movie_numbers" }
+
+type numberStateV1 struct {
+	User      string `gorm:"column:username;primaryKey"`
+	Number    string `gorm:"primaryKey"`
+	Owned     bool
+	OwnedAt   *time.Time
+	Watched   bool
+	WatchedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (*numberStateV1) TableName() string { return "number_states" }
+
+// Frozen schema of migration 202610160002.
+
+type moviePayloadV1 struct {
+	ID          string `gorm:"primaryKey"`
+	Provider    string `gorm:"primaryKey"`
+	URL         string
+	ContentType string
+	Body        string
+	Snapshot    gormtypes.JSON
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (*moviePayloadV1) TableName() string { return "movie_payloads" }
+
+// Frozen schema of migration 202610160003.
+
+type jobLeaseV1 struct {
+	ID             uint   `gorm:"primaryKey"`
+	Type           string `gorm:"index"`
+	Params         gormtypes.JSON
+	Status         string `gorm:"index"`
+	Result         gormtypes.JSON
+	Error          string
+	Owner          string
+	LeaseExpiresAt *time.Time
+	CreatedAt      time.Time
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+}
+
+func (*jobLeaseV1) TableName() string { return "jobs" }
+
+// Frozen schema of migration 202610160004.
+
+type refreshAttemptV1 struct {
+	Type          string `gorm:"primaryKey"`
+	Provider      string `gorm:"primaryKey"`
+	ID            string `gorm:"primaryKey"`
+	LastAttemptAt time.Time
+	Failures      int
+	NextAttemptAt time.Time `gorm:"index"`
+}
+
+func (*refreshAttemptV1) TableName() string { return "refresh_attempts" }
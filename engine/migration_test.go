@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestMigrations(t *testing.T) {
+	db, err := database.Open(&database.Config{DSN: "file:migration_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+
+	// the migrated schema must cover all the columns and indexes of
+	// the live models, i.e. model changes come with migrations.
+	for _, m := range []any{
+		&model.MovieInfo{},
+		&model.ActorInfo{},
+		&model.MovieReviewInfo{},
+		&model.Follow{},
+		&model.FollowRelease{},
+		&model.CollectionItem{},
+		&model.MovieOverride{},
+		&model.Job{},
+		&model.MovieNumber{},
+		&model.NumberState{},
+		&model.MoviePayload{},
+		&model.RefreshAttempt{},
+	} {
+		stmt := db.Model(m).Statement
+		require.NoError(t, stmt.Parse(m))
+		require.True(t, db.Migrator().HasTable(m), stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" {
+				assert.True(t, db.Migrator().HasColumn(m, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+			}
+		}
+		for _, idx := range stmt.Schema.ParseIndexes() {
+			assert.True(t, db.Migrator().HasIndex(m, idx.Name), "%s.%s", stmt.Schema.Table, idx.Name)
+		}
+	}
+}
+
+func TestMigrations_Steps(t *testing.T) {
+	db, err := database.Open(&database.Config{DSN: "file:migration_steps_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+
+	// released migrations are never changed, later columns come with
+	// their own steps.
+	_, err = database.Migrate(db, migrations[:1])
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasTable(&model.Job{}))
+	assert.False(t, db.Migrator().HasColumn(&model.Job{}, "owner"))
+
+	applied, err := database.Migrate(db, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"202610160002", "202610160003", "202610160004"}, applied)
+	assert.True(t, db.Migrator().HasColumn(&model.Job{}, "owner"))
+	assert.True(t, db.Migrator().HasColumn(&model.Job{}, "lease_expires_at"))
+}
@@ -1,7 +1,8 @@
 package engine
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"sort"
 	"strings"
 	"sync"
@@ -12,10 +13,16 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/collections"
 	"github.com/metatube-community/metatube-sdk-go/common/comparer"
 	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/metrics"
 	"github.com/metatube-community/metatube-sdk-go/model"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
 )
 
+// amateurProviderBoost boosts results from the preferred provider
+// of amateur series, as other providers often mismatch them.
+const amateurProviderBoost = 2
+
 func (e *Engine) searchMovieFromDB(keyword string, provider mt.MovieProvider, all bool) (results []*model.MovieSearchResult, err error) {
 	var infos []*model.MovieInfo
 	tx := e.db.
@@ -64,7 +71,15 @@ func (e *Engine) searchMovie(keyword string, provider mt.MovieProvider, fallback
 				}
 			}()
 		}
-		return searcher.SearchMovie(keyword)
+		var (
+			ctx  context.Context
+			done func(*error)
+		)
+		if ctx, done, err = e.observe(provider, opSearchMovie, keyword); err != nil {
+			return nil, err
+		}
+		defer done(&err)
+		return mt.WithContext(searcher, ctx).SearchMovie(keyword)
 	}
 	// Fallback to movie info querying.
 	info, err := e.getMovieInfoByProviderID(provider, keyword, true)
@@ -82,7 +97,14 @@ func (e *Engine) SearchMovie(keyword, name string, fallback bool) ([]*model.Movi
 	if err != nil {
 		return nil, err
 	}
-	return e.searchMovie(keyword, provider, fallback)
+	results, err := e.searchMovie(keyword, provider, fallback)
+	if err != nil {
+		return nil, err
+	}
+	results = e.filterBlocked(results)
+	sanitizeActors(results)
+	detectEditions(results)
+	return results, nil
 }
 
 func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchResult, err error) {
@@ -119,19 +141,14 @@ func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchRes
 		close(respCh)
 	}()
 
-	ds := make([]string, 0, len(e.movieProviders))
+	ds := make([]any, 0, len(e.movieProviders))
 	// response channel.
 	for resp := range respCh {
-		ds = append(ds, func(a, b, c any) string {
-			if c == nil {
-				c = "no error"
-			}
-			return fmt.Sprintf("%s(%s):<%v>", a, b, c)
-		}(
-			resp.Provider.Name(),
-			resp.EndTime.Sub(resp.StartTime),
-			resp.Error,
-		))
+		d := resp.EndTime.Sub(resp.StartTime).String()
+		if resp.Error != nil {
+			d += " " + resp.Error.Error()
+		}
+		ds = append(ds, slog.String(resp.Provider.Name(), d))
 
 		if resp.Error != nil {
 			continue
@@ -139,7 +156,10 @@ func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchRes
 		results = append(results, resp.Results...)
 	}
 
-	e.logger.Printf("Search keyword %s: %s", keyword, strings.Join(ds, " | "))
+	e.logger.Info("search movie",
+		slog.String("keyword", keyword),
+		slog.Int("results", len(results)),
+		slog.Group("providers", ds...))
 	return
 }
 
@@ -153,30 +173,38 @@ func (e *Engine) SearchMovieAll(keyword string, fallback bool) (results []*model
 		if err != nil {
 			return
 		}
-		if len(results) == 0 {
-			err = mt.ErrInfoNotFound
-			return
-		}
-		// remove duplicate results, if any.
+		// remove duplicate and blocked results, if any.
 		msr := collections.NewOrderedSet(func(v *model.MovieSearchResult) string { return v.Provider + v.ID })
 		msr.Add(results...)
-		results = msr.Slice()
+		results = e.filterBlocked(msr.Slice())
 		// post-processing
+		series, isAmateur := number.LookupSeries(keyword)
 		ps := new(collections.WeightedSlice[float64, *model.MovieSearchResult])
 		for _, result := range results {
 			if !result.Valid() /* validation check */ {
 				continue
 			}
 			if _, err := e.GetMovieProviderByName(result.Provider); err != nil {
-				e.logger.Printf("ignore provider %s as not found", result.Provider)
+				e.logger.Warn("ignore provider as not found", slog.String("provider", result.Provider))
 				continue
 			}
-			priority := comparer.Compare(keyword, result.Number) *
+			// amateur numbers may come with or without label prefixes.
+			priority := comparer.Compare(number.NormalizeAmateur(keyword), number.NormalizeAmateur(result.Number)) *
 				e.MustGetMovieProviderByName(result.Provider).Priority()
+			if isAmateur && strings.EqualFold(result.Provider, series.Provider) {
+				priority *= amateurProviderBoost
+			}
 			ps.Append(priority, result)
 		}
 		// sort according to priority.
 		results = ps.SortFunc(sort.Stable).Underlying()
+		// checked after filtering, as all results may be dropped.
+		if len(results) == 0 {
+			err = mt.ErrInfoNotFound
+			return
+		}
+		sanitizeActors(results)
+		detectEditions(results)
 	}()
 
 	if fallback /* query database for missing results  */ {
@@ -205,36 +233,139 @@ func (e *Engine) getMovieInfoFromDB(provider mt.MovieProvider, id string) (*mode
 	return info, err
 }
 
-func (e *Engine) getMovieInfoWithCallback(provider mt.MovieProvider, id string, lazy bool, callback func() (*model.MovieInfo, error)) (info *model.MovieInfo, err error) {
+func (e *Engine) getMovieInfoWithCallback(provider mt.MovieProvider, id string, lazy bool, callback func(ctx context.Context) (*model.MovieInfo, error)) (info *model.MovieInfo, err error) {
+	// forced refreshes bypass the HTTP cache.
+	e = e.withRefresh(lazy)
+	defer func() {
+		// scores are normalized and editions detected after overrides,
+		// titles are cleaned up after editions are detected.
+		if err == nil && info != nil {
+			rateMovieInfo(provider, info)
+			detectMovieEditions(info)
+			e.cleanMovieTitle(info)
+		}
+	}()
+	defer func() {
+		// direct lookups are exact matches.
+		if err == nil && info != nil && info.Match == nil {
+			info.Match = &model.MovieMatch{Type: model.MatchByID, Confidence: 1}
+		}
+	}()
+	defer func() {
+		// user corrections are applied after saving.
+		if err == nil && info != nil {
+			if oErr := e.applyMovieOverride(info); oErr != nil {
+				e.logger.Warn("apply movie override", slog.String("id", info.ID), slog.Any("error", oErr))
+			}
+		}
+	}()
 	defer func() {
 		// metadata validation check.
 		if err == nil && (info == nil || !info.Valid()) {
 			err = mt.ErrIncompleteMetadata
 		}
+		// content filtering, cached ones included.
+		if err == nil && e.blocklist.Load().BlocksMovieInfo(info) {
+			info, err = nil, ErrBlocked
+		}
 	}()
+	// refuse to scrape blocked IDs.
+	if e.blocklist.Load().BlocksNumber(id) {
+		return nil, ErrBlocked
+	}
 	// Query DB first (by id).
 	if lazy {
-		if info, err = e.getMovieInfoFromDB(provider, id); err == nil && info.Valid() {
+		if info, err = e.getCachedMovieInfo(provider, id); err == nil && info.Valid() {
+			metrics.ObserveCache(opMovieInfo, true)
 			return // ignore DB query error.
 		}
+		metrics.ObserveCache(opMovieInfo, false)
+	}
+	// concurrent scrapes of the same movie are collapsed into one.
+	key := sharedKey(opMovieInfo, provider, id)
+	v, err, shared := e.group.Do(e.flightKey(key), func() (any, error) {
+		return lockedScrape(e, key, func(since time.Time) (*model.MovieInfo, bool) {
+			info, err := e.withPrimary().getMovieInfoFromDB(provider, id)
+			return info, err == nil && info.Valid() && !info.UpdatedAt.Before(since)
+		}, func() (*model.MovieInfo, error) {
+			return e.scrapeMovieInfo(provider, id, callback)
+		})
+	})
+	if info, _ = v.(*model.MovieInfo); shared && info != nil {
+		info = cloneMovieInfo(info)
+	}
+	return
+}
+
+func (e *Engine) scrapeMovieInfo(provider mt.MovieProvider, id string, callback func(ctx context.Context) (*model.MovieInfo, error)) (info *model.MovieInfo, err error) {
+	if err = e.preScrapeMovie(provider, id); err != nil {
+		return
 	}
 	// delayed info auto-save.
 	defer func() {
-		if err == nil && info.Valid() {
+		if err == nil && info.Valid() && !e.blocklist.Load().BlocksMovieInfo(info) {
+			event := webhook.MovieScraped
+			if e.notifier != nil && e.existsInDB(&model.MovieInfo{}, info.Provider, info.ID) {
+				event = webhook.MovieRefreshed
+			}
 			e.db.Clauses(clause.OnConflict{
 				UpdateAll: true,
 			}).Create(info) // ignore error
+			e.evictMovieInfo(info.Provider, info.ID)
+			e.learnMovieNumber(info) // ignore error
+			e.notify(event, info.Provider, info.ID, info)
 		}
 	}()
-	return callback()
+	defer func() {
+		// hooks see the filled in info right before it is saved.
+		if err == nil && info != nil {
+			e.postScrapeMovie(provider, info)
+		}
+	}()
+	defer func() {
+		// placeholder and multi-name actors of any provider.
+		if err == nil && info != nil {
+			sanitizeMovieActors(info)
+		}
+	}()
+	defer func() {
+		// fill in the maker of known amateur series.
+		if err == nil && info != nil && info.Maker == "" {
+			if series, ok := number.LookupSeries(info.Number); ok {
+				info.Maker = series.Maker
+			}
+		}
+	}()
+	defer func() {
+		// placeholder covers are dropped by providers, pull a real one.
+		if err == nil && info != nil && info.CoverURL == "" && info.Number != "" {
+			e.fillMissingArtwork(info)
+		}
+	}()
+	defer func() {
+		// field stats and raw payloads are of the info as parsed by
+		// the provider, before any processing.
+		if err == nil && info != nil {
+			e.observeFields(provider, movieInfoType, info)
+		}
+		if err == nil && info.Valid() && e.payloads != nil {
+			e.saveMoviePayload(provider, info)
+		}
+	}()
+	ctx, done, err := e.observe(provider, opMovieInfo, id)
+	if err != nil {
+		return nil, err
+	}
+	defer done(&err)
+	return callback(ctx)
 }
 
 func (e *Engine) getMovieInfoByProviderID(provider mt.MovieProvider, id string, lazy bool) (*model.MovieInfo, error) {
 	if id = provider.NormalizeMovieID(id); id == "" {
 		return nil, mt.ErrInvalidID
 	}
-	return e.getMovieInfoWithCallback(provider, id, lazy, func() (*model.MovieInfo, error) {
-		return provider.GetMovieInfoByID(id)
+	return e.getMovieInfoWithCallback(provider, id, lazy, func(ctx context.Context) (*model.MovieInfo, error) {
+		return mt.WithContext(provider, ctx).GetMovieInfoByID(id)
 	})
 }
 
@@ -254,15 +385,21 @@ func (e *Engine) getMovieInfoByProviderURL(provider mt.MovieProvider, rawURL str
 	case id == "":
 		return nil, mt.ErrInvalidURL
 	}
-	return e.getMovieInfoWithCallback(provider, id, lazy, func() (*model.MovieInfo, error) {
-		return provider.GetMovieInfoByURL(rawURL)
+	return e.getMovieInfoWithCallback(provider, id, lazy, func(ctx context.Context) (*model.MovieInfo, error) {
+		return mt.WithContext(provider, ctx).GetMovieInfoByURL(rawURL)
 	})
 }
 
+// GetMovieInfoByURL gets the movie info of the link, the provider is
+// picked automatically by the host and path of the link.
 func (e *Engine) GetMovieInfoByURL(rawURL string, lazy bool) (*model.MovieInfo, error) {
-	provider, err := e.GetMovieProviderByURL(rawURL)
+	u, err := parseLink(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := resolveProvider(e.movieHostProviders, u)
 	if err != nil {
 		return nil, err
 	}
-	return e.getMovieInfoByProviderURL(provider, rawURL, lazy)
+	return e.getMovieInfoByProviderURL(provider, u.String(), lazy)
 }
@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"sort"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// learnMovieNumber records the mapping of the normalized number to the
+// provider-specific ID of the scraped movie.
+func (e *Engine) learnMovieNumber(info *model.MovieInfo) error {
+	num := number.Normalize(info.Number)
+	if num == "" {
+		return nil
+	}
+	return e.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&model.MovieNumber{
+		Number:   num,
+		Provider: info.Provider,
+		ID:       info.ID,
+	}).Error
+}
+
+// GetMovieNumbers returns the known provider-specific IDs of the number,
+// providers of higher priority first.
+func (e *Engine) GetMovieNumbers(num string) (numbers []*model.MovieNumber, err error) {
+	if err = e.db.
+		Where("number = ?", number.Normalize(num)).
+		Find(&numbers).Error; err != nil {
+		return nil, err
+	}
+	priority := func(v *model.MovieNumber) float64 {
+		if provider, err := e.GetMovieProviderByName(v.Provider); err == nil {
+			return provider.Priority()
+		}
+		return 0
+	}
+	sort.SliceStable(numbers, func(i, j int) bool {
+		return priority(numbers[i]) > priority(numbers[j])
+	})
+	return
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_GetMovieInfoByNumber_Known(t *testing.T) {
+	e := Default()
+	info := &model.MovieInfo{
+		ID:       "118num00077",
+		Number:   "NUM-077",
+		Title:    "Title",
+		Provider: "FANZA",
+		Homepage: "https://www.dmm.co.jp/num00077",
+		CoverURL: "https://www.dmm.co.jp/num00077.jpg",
+	}
+	require.NoError(t, e.db.Create(info).Error)
+	require.NoError(t, e.learnMovieNumber(info))
+
+	numbers, err := e.GetMovieNumbers("num77")
+	require.NoError(t, err)
+	if assert.Len(t, numbers, 1) {
+		assert.Equal(t, "118num00077", numbers[0].ID)
+	}
+
+	got, err := e.GetMovieInfoByNumber("NUM-77", true)
+	require.NoError(t, err)
+	assert.Equal(t, "118num00077", got.ID)
+	assert.Equal(t, model.MatchByNumber, got.Match.Type)
+}
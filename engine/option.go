@@ -1,7 +1,18 @@
 package engine
 
 import (
+	"log/slog"
+	"net/http"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/metatube-community/metatube-sdk-go/common/ffmpeg"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face"
+	"github.com/metatube-community/metatube-sdk-go/store"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
 )
 
 type Option func(*Engine)
@@ -12,8 +23,179 @@ func WithEngineName(name string) Option {
 	}
 }
 
-func WithRequestTimeout(timeout time.Duration) Option {
+// WithDB sets the database of the engine.
+func WithDB(db *gorm.DB) Option {
+	return func(e *Engine) {
+		e.db = db
+	}
+}
+
+// WithTimeout sets the timeout of provider requests.
+func WithTimeout(timeout time.Duration) Option {
 	return func(e *Engine) {
 		e.timeout = timeout
 	}
 }
+
+// WithRequestTimeout sets the timeout of provider requests.
+//
+// Deprecated: use WithTimeout instead.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return WithTimeout(timeout)
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(e *Engine) {
+		e.logger = logger
+	}
+}
+
+func WithWebhook(notifier *webhook.Notifier) Option {
+	return func(e *Engine) {
+		e.notifier = notifier
+	}
+}
+
+// WithCookieFile persists provider cookies to the file, so that
+// sessions and age checks survive restarts.
+func WithCookieFile(path string) Option {
+	return func(e *Engine) {
+		e.cookieFile = path
+	}
+}
+
+// WithBlocklist filters blocked movies out of search results and
+// refuses to scrape them.
+func WithBlocklist(b *Blocklist) Option {
+	return func(e *Engine) {
+		e.blocklist.Store(b)
+	}
+}
+
+// WithHooks calls the hooks around scrapes of movie infos.
+func WithHooks(h Hooks) Option {
+	return func(e *Engine) {
+		e.hooks.Store(&h)
+	}
+}
+
+// WithTitleCleaner strips noise from titles by the cleaner instead of
+// the one of DefaultTitleRules, nil disables title cleanup.
+func WithTitleCleaner(c *TitleCleaner) Option {
+	return func(e *Engine) {
+		e.titleCleaner.Store(c)
+	}
+}
+
+// WithTranslator sets the default translator of the engine.
+func WithTranslator(t translate.Translator) Option {
+	return func(e *Engine) {
+		e.translator = t
+	}
+}
+
+// WithProviderConfig configures the named actor and movie providers,
+// priorities from environment variables still take precedence.
+func WithProviderConfig(name string, c *ProviderConfig) Option {
+	return func(e *Engine) {
+		e.providers[strings.ToUpper(name)] = c
+	}
+}
+
+// WithTransport injects the transport into the engine and all providers
+// that support it, e.g. for mTLS proxies or recording middlewares.
+func WithTransport(t http.RoundTripper) Option {
+	return func(e *Engine) {
+		e.transport = t
+	}
+}
+
+// WithHTTPClient is like WithTransport, but takes the transport of the
+// client, http.DefaultTransport is used if it's nil.
+func WithHTTPClient(c *http.Client) Option {
+	return func(e *Engine) {
+		e.transport = c.Transport
+		if e.transport == nil {
+			e.transport = http.DefaultTransport
+		}
+	}
+}
+
+// WithCache caches HTTP responses of providers and fetched images in
+// the store for ttl, e.g. a Redis store shared by replicas.
+func WithCache(s store.Store, ttl time.Duration) Option {
+	return func(e *Engine) {
+		e.cache = s
+		e.cacheTTL = ttl
+	}
+}
+
+// WithJobWorkers sets the number of jobs run concurrently by RunJobs.
+func WithJobWorkers(n int) Option {
+	return func(e *Engine) {
+		e.jobs.workers = n
+	}
+}
+
+// WithScrapeWorkers sets the number of concurrent provider calls, calls
+// are scheduled by their priority, see WithPriority. Non-positive n
+// disables the limit and thus the scheduling.
+func WithScrapeWorkers(n int) Option {
+	return func(e *Engine) {
+		e.sched = nil
+		if n > 0 {
+			e.sched = newScheduler(n)
+		}
+	}
+}
+
+// WithFaceDetector sets the face detector used to position automatic
+// image crops, the built-in pigo detector is used by default.
+func WithFaceDetector(d face.Detector) Option {
+	return func(e *Engine) {
+		if d != nil {
+			e.faceDetector = d
+		}
+	}
+}
+
+// WithPreview enables animated previews of trailers, generated by
+// ffmpeg and cached in the dir.
+func WithPreview(dir string, ff *ffmpeg.FFmpeg) Option {
+	return func(e *Engine) {
+		e.previewDir = dir
+		e.ffmpeg = ff
+		e.previewSem = make(chan struct{}, DefaultPreviewWorkers)
+	}
+}
+
+// WithPreviewLimits bounds the cache of previews, previews not served
+// for maxAge are evicted, and so are the least recently served ones
+// while the cache exceeds maxSize bytes. Zero disables either limit.
+func WithPreviewLimits(maxSize int64, maxAge time.Duration) Option {
+	return func(e *Engine) {
+		e.previewMaxSize = maxSize
+		e.previewMaxAge = maxAge
+	}
+}
+
+// WithRawPayloads stores the raw documents of scraped movie infos in
+// the database, along with snapshots of the infos as parsed by the
+// providers, so that they can be parsed again after parser fixes.
+func WithRawPayloads() Option {
+	return func(e *Engine) {
+		e.payloads = make(map[string]*payloadRing)
+	}
+}
+
+// WithRecordCache keeps the hottest movie and actor infos in an
+// in-memory LRU of the size above the database, non-positive size
+// disables it.
+func WithRecordCache(size int) Option {
+	return func(e *Engine) {
+		e.records = nil
+		if size > 0 {
+			e.records = newRecordCache(size)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+type upperTranslator struct{}
+
+func (upperTranslator) Translate(text, _, _ string) (string, error) {
+	return strings.ToUpper(text), nil
+}
+
+func TestNew_Options(t *testing.T) {
+	priority := 42.0
+	e := New(
+		WithTimeout(5*time.Second),
+		WithTranslator(upperTranslator{}),
+		WithProviderConfig("fanza", &ProviderConfig{Priority: &priority}))
+	assert.NotNil(t, e.db)
+	assert.Equal(t, 5*time.Second, e.timeout)
+
+	provider, err := e.GetMovieProviderByName("FANZA")
+	require.NoError(t, err)
+	assert.Equal(t, priority, provider.Priority())
+
+	text, err := e.Translate("abc", "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "ABC", text)
+
+	_, err = New().Translate("abc", "en", "ja")
+	assert.ErrorIs(t, err, ErrTranslatorNotSet)
+}
+
+func TestEngine_CropModeOf(t *testing.T) {
+	e := New(
+		WithProviderConfig("fanza", &ProviderConfig{CropMode: CropModeFace}),
+		WithProviderConfig("javbus", &ProviderConfig{}))
+	for _, unit := range []struct {
+		name string
+		want CropMode
+		ok   bool
+	}{
+		{"FANZA", CropModeFace, true},
+		{"JavBus", CropModeCover, true},
+		{"MGS", CropModeCover, true},
+		{"Getchu", CropModeLetterbox, true},
+		{"HEYZO", "", false},
+	} {
+		mode, ok := e.cropModeOf(unit.name)
+		assert.Equal(t, unit.want, mode, unit.name)
+		assert.Equal(t, unit.ok, ok, unit.name)
+	}
+
+	anime := &model.MovieInfo{Genres: []string{"Anime"}}
+	assert.Equal(t, CropModeLetterbox, e.movieCropMode("HEYZO", anime))
+	assert.Equal(t, CropModeFace, e.movieCropMode("FANZA", anime))
+	assert.Equal(t, CropModePosition, e.movieCropMode("HEYZO", &model.MovieInfo{Genres: []string{"Drama"}}))
+
+	_, err := ParseCropMode("center")
+	assert.Error(t, err)
+}
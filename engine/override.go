@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+var ErrOverrideNotFound = errors.New(http.StatusNotFound, "override not found")
+
+// overridable reports whether the field can be overridden, only
+// mergeable fields are allowed, IDs and URLs of pages are not.
+func overridable(name string) bool {
+	for _, field := range movieFields {
+		if field.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) getMovieOverride(provider, id string) (*model.MovieOverride, error) {
+	override := &model.MovieOverride{}
+	err := e.db.
+		Where("provider = ?", provider).
+		Where("id = ? COLLATE NOCASE", id).
+		First(override).Error
+	return override, err
+}
+
+// applyMovieOverride applies the user corrections, if any, to the info.
+func (e *Engine) applyMovieOverride(info *model.MovieInfo) error {
+	override, err := e.getMovieOverride(info.Provider, info.ID)
+	if err != nil {
+		return nil // no overrides.
+	}
+	return json.Unmarshal(override.Fields, info)
+}
+
+// GetMovieOverride returns the user corrections of the movie.
+func (e *Engine) GetMovieOverride(name, id string) (*model.MovieOverride, error) {
+	provider, err := e.GetMovieProviderByName(name)
+	if err != nil {
+		return nil, err
+	}
+	override, err := e.getMovieOverride(provider.Name(), provider.NormalizeMovieID(id))
+	if err != nil {
+		return nil, ErrOverrideNotFound
+	}
+	return override, nil
+}
+
+// PatchMovieOverride merges the fields into the user corrections of the
+// movie, a null field removes its correction. It returns the movie info
+// with all corrections applied.
+func (e *Engine) PatchMovieOverride(name, id string, fields map[string]json.RawMessage) (*model.MovieInfo, error) {
+	for key := range fields {
+		if !overridable(key) {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("field not overridable: %s", key))
+		}
+	}
+	info, err := e.GetMovieInfoByProviderID(name, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]json.RawMessage)
+	if override, err := e.getMovieOverride(info.Provider, info.ID); err == nil {
+		_ = json.Unmarshal(override.Fields, &merged) // ignore error.
+	}
+	for key, value := range fields {
+		if string(value) == "null" {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	// check the types of fields.
+	if err = json.Unmarshal(data, &model.MovieInfo{}); err != nil {
+		return nil, errors.New(http.StatusBadRequest, err.Error())
+	}
+
+	if err = e.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&model.MovieOverride{
+		ID:       info.ID,
+		Provider: info.Provider,
+		Fields:   data,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return e.GetMovieInfoByProviderID(name, id, true)
+}
+
+// DeleteMovieOverride removes all user corrections of the movie.
+func (e *Engine) DeleteMovieOverride(name, id string) error {
+	provider, err := e.GetMovieProviderByName(name)
+	if err != nil {
+		return err
+	}
+	result := e.db.
+		Where("provider = ?", provider.Name()).
+		Where("id = ? COLLATE NOCASE", provider.NormalizeMovieID(id)).
+		Delete(&model.MovieOverride{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOverrideNotFound
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_MovieOverride(t *testing.T) {
+	e := Default()
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:       "abp00030",
+		Number:   "ABP-030",
+		Title:    "Wrong Title",
+		Provider: "FANZA",
+		Homepage: "https://www.dmm.co.jp/",
+		CoverURL: "https://www.dmm.co.jp/cover.jpg",
+		Actors:   []string{"Wrong Actor"},
+	}).Error)
+
+	_, err := e.PatchMovieOverride("fanza", "abp00030", map[string]json.RawMessage{
+		"homepage": json.RawMessage(`"https://example.com/"`),
+	})
+	assert.Error(t, err)
+	_, err = e.PatchMovieOverride("fanza", "abp00030", map[string]json.RawMessage{
+		"runtime": json.RawMessage(`"abc"`),
+	})
+	assert.Error(t, err)
+
+	info, err := e.PatchMovieOverride("fanza", "abp00030", map[string]json.RawMessage{
+		"title":  json.RawMessage(`"Title"`),
+		"actors": json.RawMessage(`["Actor"]`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Title", info.Title)
+	assert.Equal(t, []string{"Actor"}, []string(info.Actors))
+
+	// scraped data is kept as is, and overrides survive refreshes.
+	raw, err := e.getMovieInfoFromDB(e.MustGetMovieProviderByName("fanza"), "abp00030")
+	require.NoError(t, err)
+	assert.Equal(t, "Wrong Title", raw.Title)
+	require.NoError(t, e.db.Model(raw).Update("title", "Refreshed Title").Error)
+	info, err = e.GetMovieInfoByProviderID("fanza", "abp00030", true)
+	require.NoError(t, err)
+	assert.Equal(t, "Title", info.Title)
+
+	// null removes the override.
+	info, err = e.PatchMovieOverride("fanza", "abp00030", map[string]json.RawMessage{
+		"title": json.RawMessage(`null`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Refreshed Title", info.Title)
+	assert.Equal(t, []string{"Actor"}, []string(info.Actors))
+
+	require.NoError(t, e.DeleteMovieOverride("fanza", "abp00030"))
+	assert.ErrorIs(t, e.DeleteMovieOverride("fanza", "abp00030"), ErrOverrideNotFound)
+	_, err = e.GetMovieOverride("fanza", "abp00030")
+	assert.ErrorIs(t, err, ErrOverrideNotFound)
+}
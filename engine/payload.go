@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"encoding/json"
+	goerr "errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// payloadRingSize is the number of recent documents kept per provider
+// to be matched with the scraped movie infos.
+const payloadRingSize = 32
+
+var ErrPayloadNotFound = errors.New(http.StatusNotFound, "payload not found")
+
+// payloadRing keeps the recent documents fetched by a provider, there
+// is no request context in providers, so the document of a scrape is
+// found by its URL afterwards.
+type payloadRing struct {
+	mu    sync.Mutex
+	pages [payloadRingSize]*mt.Payload
+	next  int
+}
+
+func (r *payloadRing) add(p *mt.Payload) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages[r.next] = p
+	r.next = (r.next + 1) % payloadRingSize
+}
+
+// find returns the latest document of the homepage, i.e. the detail
+// page reported by the provider, documents of other URLs, e.g. search
+// pages, are never matched.
+func (r *payloadRing) find(homepage string) *mt.Payload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := 1; i <= payloadRingSize; i++ {
+		p := r.pages[(r.next-i+payloadRingSize)%payloadRingSize]
+		if p == nil {
+			break
+		}
+		if sameURL(p.URL, homepage) {
+			return p
+		}
+	}
+	return nil
+}
+
+// sameURL reports whether the URLs are the same page, regardless of
+// the case of hosts, trailing slashes and fragments.
+func sameURL(a, b string) bool {
+	u, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	v, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, v.Host) &&
+		strings.TrimSuffix(u.Path, "/") == strings.TrimSuffix(v.Path, "/") &&
+		u.RawQuery == v.RawQuery
+}
+
+// initPayloadRecorders starts recording the documents of the movie
+// providers if raw payloads are stored, see WithRawPayloads.
+func (e *Engine) initPayloadRecorders() {
+	if e.payloads == nil {
+		return
+	}
+	for name, provider := range e.movieProviders {
+		recorder, ok := provider.(mt.PayloadRecorder)
+		if !ok {
+			continue
+		}
+		ring := &payloadRing{}
+		recorder.SetPayloadRecorder(ring.add)
+		e.payloads[name] = ring
+	}
+}
+
+// saveMoviePayload stores the raw document and the snapshot of the
+// movie info as parsed by the provider, errors are only logged since
+// payloads are best-effort.
+func (e *Engine) saveMoviePayload(provider mt.MovieProvider, info *model.MovieInfo) {
+	ring, ok := e.payloads[provider.Name()]
+	if !ok {
+		return
+	}
+	snapshot, err := json.Marshal(info)
+	if err != nil {
+		return
+	}
+	payload := &model.MoviePayload{
+		ID:       info.ID,
+		Provider: info.Provider,
+		Snapshot: datatypes.JSON(snapshot),
+	}
+	// documents of earlier scrapes are kept if none is recorded,
+	// e.g. infos re-parsed from the stored documents.
+	onConflict := clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"snapshot", "updated_at"}),
+	}
+	if p := ring.find(info.Homepage); p != nil {
+		payload.URL = p.URL
+		payload.ContentType = p.ContentType
+		// text columns of Postgres refuse NUL and invalid UTF-8.
+		payload.Body = strings.ReplaceAll(strings.ToValidUTF8(string(p.Body), ""), "\x00", "")
+		onConflict = clause.OnConflict{UpdateAll: true}
+	}
+	if err = e.db.Clauses(onConflict).Create(payload).Error; err != nil {
+		e.logger.Warn("save movie payload",
+			slog.String("provider", info.Provider),
+			slog.String("id", info.ID),
+			slog.Any("error", err))
+	}
+}
+
+// GetMoviePayload returns the stored raw payload of the movie.
+func (e *Engine) GetMoviePayload(name, id string) (*model.MoviePayload, error) {
+	provider, err := e.GetMovieProviderByName(name)
+	if err != nil {
+		return nil, err
+	}
+	payload := &model.MoviePayload{}
+	if err = e.db.
+		Where("provider = ?", provider.Name()).
+		Where("id = ? COLLATE NOCASE", id).
+		First(payload).Error; goerr.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPayloadNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
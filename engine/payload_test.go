@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestPayloadRing_Find(t *testing.T) {
+	r := &payloadRing{}
+	assert.Nil(t, r.find("https://example.com/a"))
+
+	r.add(&mt.Payload{URL: "https://EXAMPLE.com/movie/abc123/#top"})
+	r.add(&mt.Payload{URL: "https://example.com/search?q=ABC123"})
+	r.add(&mt.Payload{URL: "https://example.com/other"})
+	assert.Equal(t, "https://EXAMPLE.com/movie/abc123/#top", r.find("https://example.com/movie/abc123").URL)
+	// pages of other URLs are never matched, even if they contain the ID.
+	assert.Nil(t, r.find("https://example.com/detail"))
+
+	// old documents are dropped.
+	for range payloadRingSize {
+		r.add(&mt.Payload{URL: "https://example.com/other"})
+	}
+	assert.Nil(t, r.find("https://example.com/movie/abc123"))
+}
+
+func TestEngine_RawPayloads(t *testing.T) {
+	e := New(WithRawPayloads())
+	require.NoError(t, e.DBAutoMigrate(true))
+	provider, err := e.GetMovieProviderByName("FANZA")
+	require.NoError(t, err)
+	ring, ok := e.payloads[provider.Name()]
+	require.True(t, ok)
+
+	homepage := "https://example.com/payload00001"
+	ring.add(&mt.Payload{URL: homepage, ContentType: "text/html", Body: []byte("<h1>Title\x00</h1>")})
+	_, err = e.scrapeMovieInfo(provider, "payload00001", func(context.Context) (*model.MovieInfo, error) {
+		return &model.MovieInfo{
+			ID: "payload00001", Number: "PAYLOAD-001", Title: "Title", Provider: "FANZA",
+			Homepage: homepage, CoverURL: "https://example.com/payload00001.jpg",
+		}, nil
+	})
+	require.NoError(t, err)
+
+	payload, err := e.GetMoviePayload("fanza", "PAYLOAD00001")
+	require.NoError(t, err)
+	assert.Equal(t, homepage, payload.URL)
+	assert.Equal(t, "text/html", payload.ContentType)
+	assert.Equal(t, "<h1>Title</h1>", payload.Body)
+	snapshot := &model.MovieInfo{}
+	require.NoError(t, json.Unmarshal(payload.Snapshot, snapshot))
+	assert.Equal(t, "PAYLOAD-001", snapshot.Number)
+
+	_, err = e.GetMoviePayload("FANZA", "payload00002")
+	assert.ErrorIs(t, err, ErrPayloadNotFound)
+}
@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/common/ffmpeg"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// Defaults of preview generation.
+const (
+	// DefaultPreviewTimeout bounds the download and encoding of a preview.
+	DefaultPreviewTimeout = 5 * time.Minute
+	// DefaultPreviewWorkers is the number of previews encoded at once,
+	// as ffmpeg is CPU-bound.
+	DefaultPreviewWorkers = 2
+	// DefaultPreviewMaxSize is the default size limit of cached previews.
+	DefaultPreviewMaxSize = 1 << 30
+	// DefaultPreviewMaxAge is the default age limit of cached previews,
+	// since they were last served.
+	DefaultPreviewMaxAge = 30 * 24 * time.Hour
+)
+
+var (
+	ErrPreviewDisabled      = errors.New(http.StatusNotImplemented, "preview generation is disabled")
+	ErrInvalidPreviewFormat = errors.New(http.StatusBadRequest, "invalid preview format")
+	ErrPreviewVideoNotFound = errors.New(http.StatusNotFound, "preview video not found")
+)
+
+type previewJobParams struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+	Format   string `json:"format"`
+}
+
+// GetMoviePreview returns the path of the animated preview of the movie
+// trailer in the format, either gif or webp, the default is webp. The
+// preview is generated on the first call and cached on disk, the same
+// previews requested at once are generated only once.
+func (e *Engine) GetMoviePreview(name, id, format string) (string, error) {
+	if e.previewDir == "" || e.ffmpeg == nil {
+		return "", ErrPreviewDisabled
+	}
+	switch format {
+	case "":
+		format = ffmpeg.WebP
+	case ffmpeg.GIF, ffmpeg.WebP:
+	default:
+		return "", ErrInvalidPreviewFormat
+	}
+	provider, err := e.GetMovieProviderByName(name)
+	if err != nil {
+		return "", err
+	}
+
+	path := e.previewPath(provider, id, format)
+	if _, err = os.Stat(path); err == nil {
+		// modification times are of the last serving, for eviction.
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return path, nil
+	}
+	ch := e.group.DoChan(sharedKey("preview."+format, provider, id), func() (any, error) {
+		// detached from the caller, so that its cancellation never fails
+		// the other callers waiting for the same preview.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(e.ctx), DefaultPreviewTimeout)
+		defer cancel()
+		return nil, e.WithContext(ctx).generatePreview(provider, id, format, path)
+	})
+	select {
+	case r := <-ch:
+		if r.Err != nil {
+			return "", r.Err
+		}
+		return path, nil
+	case <-e.ctx.Done():
+		return "", e.ctx.Err()
+	}
+}
+
+// previewPath returns the cache path of the preview, ids are hashed
+// as they may contain path separators.
+func (e *Engine) previewPath(provider mt.Provider, id, format string) string {
+	sum := sha1.Sum([]byte(id))
+	return filepath.Join(e.previewDir, provider.Name(), hex.EncodeToString(sum[:])+"."+format)
+}
+
+func (e *Engine) generatePreview(provider mt.MovieProvider, id, format, path string) error {
+	select {
+	case e.previewSem <- struct{}{}:
+		defer func() { <-e.previewSem }()
+	case <-e.ctx.Done():
+		return e.ctx.Err()
+	}
+
+	info, err := e.GetMovieInfoByProviderID(provider.Name(), id, true)
+	if err != nil {
+		return err
+	}
+	if info.PreviewVideoURL == "" {
+		return ErrPreviewVideoNotFound
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	input := info.PreviewVideoURL
+	// HLS streams are read by ffmpeg directly, as segments are
+	// referenced by the playlist.
+	if !strings.Contains(strings.ToLower(input), ".m3u8") {
+		video, err := e.downloadPreviewVideo(provider, input, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		defer os.Remove(video)
+		input = video
+	}
+
+	opts := ffmpeg.DefaultPreviewOptions
+	opts.Format = format
+	// write to a temporary file first, so that partial previews are
+	// never served.
+	tmp := path + ".part"
+	defer os.Remove(tmp)
+	if err = e.ffmpeg.Preview(e.ctx, input, tmp, opts); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return err
+	}
+	if err = e.evictPreviews(path); err != nil {
+		e.logger.Warn("evict previews", slog.Any("error", err))
+	}
+	return nil
+}
+
+// evictPreviews removes the previews not served for the max age, and
+// then the least recently served ones while the cache exceeds the max
+// size, except the preview to keep. Partial previews and videos being
+// downloaded are left alone.
+func (e *Engine) evictPreviews(keep string) error {
+	if e.previewMaxSize <= 0 && e.previewMaxAge <= 0 {
+		return nil
+	}
+	type preview struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		previews []preview
+		total    int64
+	)
+	now := time.Now()
+	err := filepath.WalkDir(e.previewDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); d.IsDir() || (ext != "."+ffmpeg.GIF && ext != "."+ffmpeg.WebP) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return nil // removed meanwhile.
+		}
+		if e.previewMaxAge > 0 && now.Sub(fi.ModTime()) > e.previewMaxAge {
+			_ = os.Remove(path)
+			return nil
+		}
+		previews = append(previews, preview{path: path, size: fi.Size(), modTime: fi.ModTime()})
+		total += fi.Size()
+		return nil
+	})
+	if err != nil || e.previewMaxSize <= 0 {
+		return err
+	}
+	sort.Slice(previews, func(i, j int) bool {
+		return previews[i].modTime.Before(previews[j].modTime)
+	})
+	for _, p := range previews {
+		if total <= e.previewMaxSize {
+			break
+		}
+		if p.path == keep {
+			continue
+		}
+		if err = os.Remove(p.path); err == nil || os.IsNotExist(err) {
+			total -= p.size
+		}
+	}
+	return nil
+}
+
+// downloadPreviewVideo downloads the video into the dir with the
+// headers and proxy of the provider, and returns the file path.
+func (e *Engine) downloadPreviewVideo(provider mt.Provider, url, dir string) (string, error) {
+	resp, err := e.Fetch(url, provider)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.CreateTemp(dir, "video-*")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func (e *Engine) generatePreviewJob(ctx context.Context, params json.RawMessage) (any, error) {
+	p := &previewJobParams{}
+	if err := json.Unmarshal(params, p); err != nil {
+		return nil, err
+	}
+	path, err := e.WithContext(ctx).GetMoviePreview(p.Provider, p.ID, p.Format)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"path": path}, nil
+}
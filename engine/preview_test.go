@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/common/ffmpeg"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestEngine_GetMoviePreview(t *testing.T) {
+	_, err := New().GetMoviePreview("unknown", "1", "")
+	assert.ErrorIs(t, err, ErrPreviewDisabled)
+
+	e := New(WithPreview(t.TempDir(), &ffmpeg.FFmpeg{}))
+	_, err = e.GetMoviePreview("unknown", "1", "png")
+	assert.ErrorIs(t, err, ErrInvalidPreviewFormat)
+	_, err = e.GetMoviePreview("unknown", "1", ffmpeg.GIF)
+	assert.ErrorIs(t, err, mt.ErrProviderNotFound)
+}
+
+func TestEngine_EvictPreviews(t *testing.T) {
+	dir := t.TempDir()
+	e := New(WithPreview(dir, &ffmpeg.FFmpeg{}), WithPreviewLimits(250, time.Hour))
+	now := time.Now()
+	write := func(name string, size int, age time.Duration) string {
+		path := filepath.Join(dir, "provider", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+		return path
+	}
+	expired := write("expired.webp", 10, 2*time.Hour)
+	oldest := write("oldest.gif", 100, 30*time.Minute)
+	older := write("older.webp", 100, 20*time.Minute)
+	keep := write("keep.webp", 100, 40*time.Minute)
+	partial := write("newest.webp.part", 500, 3*time.Hour)
+
+	require.NoError(t, e.evictPreviews(keep))
+	for path, exists := range map[string]bool{
+		expired: false,
+		oldest:  false,
+		older:   true,
+		keep:    true,
+		partial: true,
+	} {
+		_, err := os.Stat(path)
+		assert.Equal(t, exists, err == nil, path)
+	}
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/metatube-community/metatube-sdk-go/common/priority"
+)
+
+// DefaultScrapeWorkers is the default number of concurrent provider calls.
+const DefaultScrapeWorkers = 32
+
+// Priority is the scheduling class of provider calls, it's carried by
+// the contexts of provider requests, so that the rate limiters of the
+// providers schedule them by it as well.
+type Priority = priority.Priority
+
+const (
+	// PriorityInteractive is for lookups a user is waiting for, e.g.
+	// the identify dialogs of media servers, it is the default.
+	PriorityInteractive = priority.Interactive
+	// PriorityBackground is for bulk work, e.g. jobs and library scans.
+	PriorityBackground = priority.Background
+)
+
+// WithPriority returns a copy of ctx with the priority, engines bound
+// to it by WithContext schedule their provider calls with it.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return priority.WithContext(ctx, p)
+}
+
+// PriorityFromContext returns the priority of ctx, calls without one
+// are interactive.
+func PriorityFromContext(ctx context.Context) Priority {
+	return priority.FromContext(ctx)
+}
+
+// scheduler is the pool of provider call slots. Interactive calls are
+// granted before background ones, and background calls to a provider
+// wait while interactive calls to it are pending, so that they don't
+// take the rate limit tokens of the provider from interactive calls.
+type scheduler struct {
+	mu      sync.Mutex
+	free    int
+	waiters [2]*list.List // of *slotWaiter, indexed by Priority
+	// Name:Count Map of Waiting and Running Interactive Calls
+	interactive map[string]int
+}
+
+type slotWaiter struct {
+	provider string
+	ready    chan struct{}
+}
+
+func newScheduler(workers int) *scheduler {
+	return &scheduler{
+		free:        workers,
+		waiters:     [2]*list.List{list.New(), list.New()},
+		interactive: make(map[string]int),
+	}
+}
+
+// acquire blocks until a slot for the call to the provider is granted
+// or ctx is done, and returns the function to release the slot.
+func (s *scheduler) acquire(ctx context.Context, provider string, p Priority) (release func(), err error) {
+	if p != PriorityBackground {
+		p = PriorityInteractive
+	}
+
+	s.mu.Lock()
+	if p == PriorityInteractive {
+		s.interactive[provider]++
+	}
+	w := &slotWaiter{provider: provider, ready: make(chan struct{})}
+	elem := s.waiters[p].PushBack(w)
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return s.releaser(provider, p), nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	select {
+	case <-w.ready: // granted meanwhile, give it back.
+		s.free++
+	default:
+		s.waiters[p].Remove(elem)
+	}
+	if p == PriorityInteractive {
+		s.done(provider)
+	}
+	s.dispatch()
+	s.mu.Unlock()
+	return nil, ctx.Err()
+}
+
+func (s *scheduler) releaser(provider string, p Priority) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.free++
+			if p == PriorityInteractive {
+				s.done(provider)
+			}
+			s.dispatch()
+		})
+	}
+}
+
+// done removes an interactive call to the provider, s.mu must be held.
+func (s *scheduler) done(provider string) {
+	if s.interactive[provider]--; s.interactive[provider] <= 0 {
+		delete(s.interactive, provider)
+	}
+}
+
+// dispatch grants free slots to waiters, s.mu must be held.
+func (s *scheduler) dispatch() {
+	for s.free > 0 {
+		if elem := s.waiters[PriorityInteractive].Front(); elem != nil {
+			s.grant(PriorityInteractive, elem)
+			continue
+		}
+		elem := s.waiters[PriorityBackground].Front()
+		for elem != nil && s.interactive[elem.Value.(*slotWaiter).provider] > 0 {
+			elem = elem.Next()
+		}
+		if elem == nil {
+			return
+		}
+		s.grant(PriorityBackground, elem)
+	}
+}
+
+func (s *scheduler) grant(p Priority, elem *list.Element) {
+	s.waiters[p].Remove(elem)
+	s.free--
+	close(elem.Value.(*slotWaiter).ready)
+}
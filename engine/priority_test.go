@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	assert.Equal(t, PriorityInteractive, PriorityFromContext(context.Background()))
+	assert.Equal(t, PriorityBackground, PriorityFromContext(WithPriority(context.Background(), PriorityBackground)))
+}
+
+func acquireAsync(s *scheduler, provider string, p Priority) <-chan func() {
+	ch := make(chan func(), 1)
+	go func() {
+		release, _ := s.acquire(context.Background(), provider, p)
+		ch <- release
+	}()
+	return ch
+}
+
+func TestScheduler_Preempt(t *testing.T) {
+	s := newScheduler(1)
+	release, err := s.acquire(context.Background(), "A", PriorityBackground)
+	require.NoError(t, err)
+
+	background := acquireAsync(s, "B", PriorityBackground)
+	time.Sleep(20 * time.Millisecond)
+	interactive := acquireAsync(s, "B", PriorityInteractive)
+	time.Sleep(20 * time.Millisecond)
+
+	release()
+	select {
+	case r := <-interactive:
+		r()
+	case <-background:
+		t.Fatal("background call is granted before interactive one")
+	case <-time.After(time.Second):
+		t.Fatal("interactive call is not granted")
+	}
+	select {
+	case r := <-background:
+		r()
+	case <-time.After(time.Second):
+		t.Fatal("background call is not granted")
+	}
+}
+
+func TestScheduler_ProviderBusy(t *testing.T) {
+	s := newScheduler(2)
+	release, err := s.acquire(context.Background(), "A", PriorityInteractive)
+	require.NoError(t, err)
+
+	// background calls to A wait for its interactive calls.
+	busy := acquireAsync(s, "A", PriorityBackground)
+	idle := acquireAsync(s, "B", PriorityBackground)
+	select {
+	case r := <-idle:
+		r()
+	case <-time.After(time.Second):
+		t.Fatal("background call to idle provider is not granted")
+	}
+	select {
+	case <-busy:
+		t.Fatal("background call to busy provider is granted")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case r := <-busy:
+		r()
+	case <-time.After(time.Second):
+		t.Fatal("background call is not granted")
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	s := newScheduler(1)
+	release, err := s.acquire(context.Background(), "A", PriorityInteractive)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = s.acquire(ctx, "A", PriorityInteractive)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release() // no-op
+	assert.Equal(t, 1, s.free)
+	assert.Empty(t, s.interactive)
+}
+
+func TestEngine_ObserveCanceled(t *testing.T) {
+	e := New(WithScrapeWorkers(1))
+	release, err := e.sched.acquire(context.Background(), "fanza", PriorityInteractive)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var called bool
+	_, err = e.WithContext(ctx).scrapeMovieInfo(e.MustGetMovieProviderByName("fanza"), "cancel00001",
+		func(context.Context) (*model.MovieInfo, error) {
+			called = true
+			return nil, nil
+		})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, called, "provider is called without a slot")
+}
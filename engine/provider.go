@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// ProviderConfig is the settings of a provider, zero values keep
+// the built-in ones.
+type ProviderConfig struct {
+	// Priority overrides the built-in priority if set.
+	Priority *float64
+	// Proxy of HTTP requests, nil means the environment proxy.
+	Proxy *url.URL
+	// RateLimit is the minimum interval between requests.
+	RateLimit time.Duration
+	// Cookies are set for the base URL of the provider.
+	Cookies []*http.Cookie
+	// BaseURLs override the built-in base URLs in lookup order,
+	// e.g. regional domains of the provider.
+	BaseURLs []*url.URL
+	// CropMode of primary images, the default of the provider if empty.
+	CropMode CropMode
+	// XPaths replace the built-in XPath selectors of the keys with
+	// the values, e.g. to hotfix extraction after layout changes.
+	XPaths map[string]string
+}
+
+// CropMode is how primary images of movies are cropped when no
+// position is given.
+type CropMode string
+
+const (
+	// CropModePosition crops at the right, where the front cover
+	// usually is, and detects faces for amateur numbers.
+	CropModePosition CropMode = "position"
+	// CropModeFace always crops around the detected face.
+	CropModeFace CropMode = "face"
+	// CropModeCover crops the front cover of DVD cover scans by the
+	// boundary of the spine, and detects faces for amateur numbers.
+	CropModeCover CropMode = "cover"
+	// CropModeLetterbox pads images to the ratio instead of cropping,
+	// e.g. for anime covers where cropping destroys the art.
+	CropModeLetterbox CropMode = "letterbox"
+)
+
+// ParseCropMode parses the crop mode, case-insensitive.
+func ParseCropMode(s string) (CropMode, error) {
+	switch mode := CropMode(strings.ToLower(s)); mode {
+	case CropModePosition, CropModeFace, CropModeCover, CropModeLetterbox:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid crop mode: %s", s)
+}
+
+// defaultCropModes are crop modes of providers that serve scans of
+// the whole DVD cover as primary images.
+var defaultCropModes = map[string]CropMode{
+	"FANZA":  CropModeCover,
+	"JAVBUS": CropModeCover,
+	"MGS":    CropModeCover,
+	// anime and game covers.
+	"GETCHU": CropModeLetterbox,
+}
+
+// cropModeOf returns the crop mode of the named provider, either set
+// by its config or the default of the provider.
+func (e *Engine) cropModeOf(name string) (CropMode, bool) {
+	name = strings.ToUpper(name)
+	if c, ok := e.providers[name]; ok && c != nil && c.CropMode != "" {
+		return c.CropMode, true
+	}
+	mode, ok := defaultCropModes[name]
+	return mode, ok
+}
+
+func (e *Engine) initProviderConfigs() {
+	for name, provider := range e.actorProviders {
+		e.applyProviderConfig(name, provider)
+	}
+	for name, provider := range e.movieProviders {
+		e.applyProviderConfig(name, provider)
+	}
+}
+
+// applyProviderConfig applies the config of the name to the provider.
+func (e *Engine) applyProviderConfig(name string, provider mt.Provider) {
+	c, ok := e.providers[name]
+	if !ok || c == nil {
+		return
+	}
+	if c.Priority != nil {
+		provider.SetPriority(*c.Priority)
+	}
+	if s, ok := provider.(mt.ProxySetter); ok && c.Proxy != nil {
+		s.SetProxy(c.Proxy)
+	}
+	if s, ok := provider.(mt.RateLimitSetter); ok && c.RateLimit > 0 {
+		s.SetRateLimit(c.RateLimit)
+	}
+	if s, ok := provider.(mt.BaseURLSetter); ok && len(c.BaseURLs) > 0 {
+		s.SetBaseURLs(c.BaseURLs)
+	}
+	if s, ok := provider.(mt.XPathOverrider); ok && len(c.XPaths) > 0 {
+		s.SetXPathOverrides(c.XPaths)
+	}
+	if m, ok := provider.(mt.CookieManager); ok && len(c.Cookies) > 0 {
+		if err := m.SetCookies("", c.Cookies); err != nil {
+			e.logger.Warn("set provider cookies", slog.String("provider", name), slog.Any("error", err))
+		}
+	}
+}
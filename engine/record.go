@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// DefaultRecordCacheTTL is the time to live of cached records, it
+// bounds the staleness of records updated by other replicas.
+const DefaultRecordCacheTTL = 5 * time.Minute
+
+// recordCache is an in-memory LRU of the hottest movie and actor infos
+// above the database, so that repeated lookups, e.g. of a library scan,
+// need no round-trips. Infos are cloned in and out of the cache, since
+// they are modified by the callers.
+type recordCache struct {
+	movies *ttlcache.Cache[string, *model.MovieInfo]
+	actors *ttlcache.Cache[string, *model.ActorInfo]
+}
+
+func newRecordCache(size int) *recordCache {
+	return &recordCache{
+		movies: ttlcache.New[string, *model.MovieInfo](
+			ttlcache.WithTTL[string, *model.MovieInfo](DefaultRecordCacheTTL),
+			ttlcache.WithCapacity[string, *model.MovieInfo](uint64(size)),
+			ttlcache.WithDisableTouchOnHit[string, *model.MovieInfo]()),
+		actors: ttlcache.New[string, *model.ActorInfo](
+			ttlcache.WithTTL[string, *model.ActorInfo](DefaultRecordCacheTTL),
+			ttlcache.WithCapacity[string, *model.ActorInfo](uint64(size)),
+			ttlcache.WithDisableTouchOnHit[string, *model.ActorInfo]()),
+	}
+}
+
+// recordKey is the cache key of the record, IDs are matched without
+// case the same as the database lookups.
+func recordKey(provider, id string) string {
+	return provider + ":" + strings.ToLower(id)
+}
+
+// getCachedMovieInfo returns the movie info from the record cache if
+// enabled, or else from the database, valid ones are cached.
+func (e *Engine) getCachedMovieInfo(provider mt.MovieProvider, id string) (*model.MovieInfo, error) {
+	if e.records == nil {
+		return e.getMovieInfoFromDB(provider, id)
+	}
+	key := recordKey(provider.Name(), id)
+	if item := e.records.movies.Get(key); item != nil {
+		return cloneMovieInfo(item.Value()), nil
+	}
+	info, err := e.getMovieInfoFromDB(provider, id)
+	if err == nil && info.Valid() {
+		e.records.movies.Set(key, cloneMovieInfo(info), ttlcache.DefaultTTL)
+	}
+	return info, err
+}
+
+// getCachedActorInfo is the same as getCachedMovieInfo, but for actors.
+func (e *Engine) getCachedActorInfo(provider mt.ActorProvider, id string) (*model.ActorInfo, error) {
+	if e.records == nil {
+		return e.getActorInfoFromDB(provider, id)
+	}
+	key := recordKey(provider.Name(), id)
+	if item := e.records.actors.Get(key); item != nil {
+		return cloneActorInfo(item.Value()), nil
+	}
+	info, err := e.getActorInfoFromDB(provider, id)
+	if err == nil && info.Valid() {
+		e.records.actors.Set(key, cloneActorInfo(info), ttlcache.DefaultTTL)
+	}
+	return info, err
+}
+
+// evictMovieInfo drops the movie info from the record cache, it must be
+// called whenever the record is changed in the database.
+func (e *Engine) evictMovieInfo(provider, id string) {
+	if e.records != nil {
+		e.records.movies.Delete(recordKey(provider, id))
+	}
+}
+
+// evictActorInfo is the same as evictMovieInfo, but for actors.
+func (e *Engine) evictActorInfo(provider, id string) {
+	if e.records != nil {
+		e.records.actors.Delete(recordKey(provider, id))
+	}
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_RecordCache(t *testing.T) {
+	e := New(WithRecordCache(1))
+	require.NoError(t, e.DBAutoMigrate(true))
+	provider, err := e.GetMovieProviderByName("FANZA")
+	require.NoError(t, err)
+
+	for _, id := range []string{"record00001", "record00002"} {
+		require.NoError(t, e.db.Create(&model.MovieInfo{
+			ID: id, Number: "RECORD-001", Title: "Title", Provider: provider.Name(),
+			Homepage: "https://example.com/" + id, CoverURL: "https://example.com/" + id + ".jpg",
+		}).Error)
+	}
+	update := func(id, title string) {
+		require.NoError(t, e.db.Model(&model.MovieInfo{}).
+			Where("provider = ? AND id = ?", provider.Name(), id).
+			Update("title", title).Error)
+	}
+
+	info, err := e.getCachedMovieInfo(provider, "RECORD00001")
+	require.NoError(t, err)
+	assert.Equal(t, "Title", info.Title)
+
+	// cached records are served without the database.
+	info.Title = "Modified"
+	update("record00001", "Updated")
+	info, err = e.getCachedMovieInfo(provider, "record00001")
+	require.NoError(t, err)
+	assert.Equal(t, "Title", info.Title)
+
+	e.evictMovieInfo(provider.Name(), "record00001")
+	info, err = e.getCachedMovieInfo(provider, "record00001")
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", info.Title)
+
+	// least recently used records are evicted.
+	_, err = e.getCachedMovieInfo(provider, "record00002")
+	require.NoError(t, err)
+	update("record00001", "Evicted")
+	info, err = e.getCachedMovieInfo(provider, "record00001")
+	require.NoError(t, err)
+	assert.Equal(t, "Evicted", info.Title)
+
+	// disabled by default.
+	e = New()
+	update("record00002", "Uncached")
+	info, err = e.getCachedMovieInfo(provider, "record00002")
+	require.NoError(t, err)
+	assert.Equal(t, "Uncached", info.Title)
+}
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cron"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// DefaultRefreshMaxAge is the default age of metadata to be refreshed.
+const DefaultRefreshMaxAge = 30 * 24 * time.Hour
+
+// Backoff of failed refreshes, doubled by each consecutive failure.
+const (
+	refreshBackoff    = time.Hour
+	maxRefreshBackoff = DefaultRefreshMaxAge
+)
+
+// Types of refreshed records.
+const (
+	refreshMovie = "movie"
+	refreshActor = "actor"
+)
+
+// RefreshCount is the number of records refreshed of a kind.
+type RefreshCount struct {
+	Stale     int `json:"stale"`
+	Refreshed int `json:"refreshed"`
+	Failed    int `json:"failed"`
+}
+
+// RefreshReport is the summary of a refresh run.
+type RefreshReport struct {
+	MaxAge     datatypes.Duration `json:"max_age"`
+	Movies     RefreshCount       `json:"movies"`
+	Actors     RefreshCount       `json:"actors"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+}
+
+type refreshStaleJobParams struct {
+	// MaxAge is in seconds, or a duration string such as 720h.
+	MaxAge datatypes.Duration `json:"max_age"`
+	Limit  int                `json:"limit"`
+}
+
+type staleRecord struct {
+	Provider string
+	ID       string
+}
+
+// RefreshStale re-scrapes movies and actors not updated within maxAge,
+// at most limit of each if limit > 0. Records never attempted come
+// first, then those of fewer failures and older attempts, and records
+// of failed attempts are skipped until their backoff is over. Actor
+// images are refreshed along with actors.
+func (e *Engine) RefreshStale(maxAge time.Duration, limit int) (*RefreshReport, error) {
+	report := &RefreshReport{MaxAge: datatypes.Duration(maxAge), StartedAt: time.Now()}
+	before := report.StartedAt.Add(-maxAge)
+
+	movies, err := e.findStale(&model.MovieInfo{}, refreshMovie, before, report.StartedAt, limit)
+	if err != nil {
+		return nil, err
+	}
+	report.Movies.Stale = len(movies)
+	for _, r := range movies {
+		if err = e.ctx.Err(); err != nil {
+			return nil, err
+		}
+		_, err := e.GetMovieInfoByProviderID(r.Provider, r.ID, false)
+		e.recordRefreshAttempt(refreshMovie, r, err)
+		if err != nil {
+			report.Movies.Failed++
+			continue
+		}
+		report.Movies.Refreshed++
+	}
+
+	actors, err := e.findStale(&model.ActorInfo{}, refreshActor, before, report.StartedAt, limit)
+	if err != nil {
+		return nil, err
+	}
+	report.Actors.Stale = len(actors)
+	for _, r := range actors {
+		if err = e.ctx.Err(); err != nil {
+			return nil, err
+		}
+		_, err := e.GetActorInfoByProviderID(r.Provider, r.ID, false)
+		e.recordRefreshAttempt(refreshActor, r, err)
+		if err != nil {
+			report.Actors.Failed++
+			continue
+		}
+		report.Actors.Refreshed++
+	}
+
+	report.FinishedAt = time.Now()
+	e.logger.Info("refresh stale",
+		slog.Duration("max_age", report.MaxAge.Std()),
+		slog.Group("movies",
+			slog.Int("stale", report.Movies.Stale),
+			slog.Int("refreshed", report.Movies.Refreshed),
+			slog.Int("failed", report.Movies.Failed)),
+		slog.Group("actors",
+			slog.Int("stale", report.Actors.Stale),
+			slog.Int("refreshed", report.Actors.Refreshed),
+			slog.Int("failed", report.Actors.Failed)),
+		slog.Duration("duration", report.FinishedAt.Sub(report.StartedAt)))
+	return report, nil
+}
+
+// findStale returns the records of v not updated since before, whose
+// backoff is over by now, in the order of refreshing.
+func (e *Engine) findStale(v schema.Tabler, typ string, before, now time.Time, limit int) (records []staleRecord, err error) {
+	table := v.TableName()
+	tx := e.db.Model(v).
+		Select(table+".provider, "+table+".id").
+		Joins("LEFT JOIN "+model.RefreshAttemptsTableName+" a ON a.type = ? AND a.provider = "+table+".provider AND a.id = "+table+".id", typ).
+		Where(table+".updated_at < ?", before).
+		Where("a.next_attempt_at IS NULL OR a.next_attempt_at <= ?", now).
+		// NULLs are sorted differently by databases, so never attempted
+		// records are ordered explicitly.
+		Order("a.last_attempt_at IS NOT NULL, a.failures, a.last_attempt_at, " + table + ".updated_at")
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	err = tx.Scan(&records).Error
+	return
+}
+
+// recordRefreshAttempt records the attempt of refreshing the record,
+// failed ones are backed off exponentially, and successful ones reset
+// the failures.
+func (e *Engine) recordRefreshAttempt(typ string, r staleRecord, err error) {
+	now := time.Now()
+	attempt := &model.RefreshAttempt{}
+	if dbErr := e.db.
+		Where("type = ? AND provider = ? AND id = ?", typ, r.Provider, r.ID).
+		Limit(1).
+		Find(attempt).Error; dbErr != nil {
+		e.logger.Warn("find refresh attempt", slog.Any("error", dbErr))
+	}
+	attempt.Type, attempt.Provider, attempt.ID = typ, r.Provider, r.ID
+	attempt.LastAttemptAt, attempt.NextAttemptAt = now, now
+	if err == nil {
+		attempt.Failures = 0
+	} else {
+		attempt.Failures++
+		attempt.NextAttemptAt = now.Add(backoff(attempt.Failures))
+	}
+	if dbErr := e.db.Save(attempt).Error; dbErr != nil {
+		e.logger.Warn("save refresh attempt", slog.Any("error", dbErr))
+	}
+}
+
+// backoff returns the backoff of the consecutive failures.
+func backoff(failures int) time.Duration {
+	d := refreshBackoff
+	for i := 1; i < failures && d < maxRefreshBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxRefreshBackoff)
+}
+
+func (e *Engine) refreshStaleJob(ctx context.Context, params json.RawMessage) (any, error) {
+	p := &refreshStaleJobParams{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+	}
+	maxAge := DefaultRefreshMaxAge
+	if p.MaxAge > 0 {
+		maxAge = p.MaxAge.Std()
+	}
+	return e.WithContext(ctx).RefreshStale(maxAge, p.Limit)
+}
+
+// RunSchedule submits a job of the type with params at each activation
+// of the schedule, until ctx is done. Each run's summary is kept as the
+// result of its job.
+func (e *Engine) RunSchedule(ctx context.Context, schedule cron.Schedule, typ string, params json.RawMessage) {
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			return // never activates again.
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		job, err := e.SubmitJob(typ, params)
+		if err != nil {
+			e.logger.Error("submit scheduled job", slog.String("type", typ), slog.Any("error", err))
+			continue
+		}
+		e.logger.Info("submit scheduled job", slog.String("type", typ), slog.Uint64("id", uint64(job.ID)))
+	}
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_RefreshStale(t *testing.T) {
+	e := Default()
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:          "stale-1",
+		Provider:    "UNKNOWN",
+		TimeTracker: model.TimeTracker{CreatedAt: old, UpdatedAt: old},
+	}).Error)
+	require.NoError(t, e.db.Create(&model.MovieInfo{
+		ID:       "fresh-1",
+		Provider: "UNKNOWN",
+	}).Error)
+
+	report, err := e.RefreshStale(24*time.Hour, 0)
+	require.NoError(t, err)
+	assert.Equal(t, RefreshCount{Stale: 1, Failed: 1}, report.Movies)
+	assert.Equal(t, RefreshCount{}, report.Actors)
+	assert.False(t, report.FinishedAt.Before(report.StartedAt))
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"max_age":86400`)
+}
+
+func TestRefreshStaleJobParams(t *testing.T) {
+	for _, params := range []string{`{"max_age":"48h"}`, `{"max_age":172800}`, `{"max_age":"172800"}`} {
+		p := &refreshStaleJobParams{}
+		require.NoError(t, json.Unmarshal([]byte(params), p), params)
+		assert.Equal(t, 48*time.Hour, p.MaxAge.Std(), params)
+	}
+	p := &refreshStaleJobParams{}
+	assert.Error(t, json.Unmarshal([]byte(`{"max_age":"two days"}`), p))
+}
+
+func TestEngine_RefreshStaleBackoff(t *testing.T) {
+	db, err := database.Open(&database.Config{DSN: "file:refresh_backoff_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, id := range []string{"stale-1", "stale-2"} {
+		require.NoError(t, e.db.Create(&model.MovieInfo{
+			ID:          id,
+			Provider:    "UNKNOWN",
+			TimeTracker: model.TimeTracker{CreatedAt: old, UpdatedAt: old},
+		}).Error)
+	}
+	// stale-2 failed before, so stale-1 is refreshed first.
+	e.recordRefreshAttempt(refreshMovie, staleRecord{Provider: "UNKNOWN", ID: "stale-2"}, assert.AnError)
+	require.NoError(t, e.db.Model(&model.RefreshAttempt{}).Where("id = ?", "stale-2").Update("next_attempt_at", old).Error)
+	records, err := e.findStale(&model.MovieInfo{}, refreshMovie, time.Now().Add(-24*time.Hour), time.Now(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []staleRecord{{"UNKNOWN", "stale-1"}, {"UNKNOWN", "stale-2"}}, records)
+
+	report, err := e.RefreshStale(24*time.Hour, 0)
+	require.NoError(t, err)
+	assert.Equal(t, RefreshCount{Stale: 2, Failed: 2}, report.Movies)
+
+	// failed records are backed off.
+	report, err = e.RefreshStale(24*time.Hour, 0)
+	require.NoError(t, err)
+	assert.Equal(t, RefreshCount{}, report.Movies)
+
+	attempt := &model.RefreshAttempt{}
+	require.NoError(t, e.db.Where("id = ?", "stale-2").First(attempt).Error)
+	assert.Equal(t, 2, attempt.Failures)
+	assert.WithinDuration(t, attempt.LastAttemptAt.Add(2*time.Hour), attempt.NextAttemptAt, time.Second)
+
+	assert.Equal(t, time.Hour, backoff(1))
+	assert.Equal(t, 4*time.Hour, backoff(3))
+	assert.Equal(t, maxRefreshBackoff, backoff(100))
+}
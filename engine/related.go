@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// maxRelatedMovies is the max number of related movies of each
+// relation found in the database.
+const maxRelatedMovies = 10
+
+// GetRelatedMovies returns the movies related to the movie. The ones
+// recommended by the provider come first, followed by the newest ones
+// of the same series and the same first actress in the database.
+func (e *Engine) GetRelatedMovies(name, id string, lazy bool) ([]*model.RelatedMovie, error) {
+	info, err := e.GetMovieInfoByProviderID(name, id, lazy)
+	if err != nil {
+		return nil, err
+	}
+	related := slices.Clone(info.RelatedMovies)
+	seen := map[string]bool{info.Provider + info.ID: true}
+	for _, movie := range related {
+		seen[movie.Provider+movie.ID] = true
+	}
+	add := func(relation, query string, args ...any) {
+		var infos []*model.MovieInfo
+		if err := e.db.
+			Where(query, args...).
+			Order("release_date DESC").
+			Limit(maxRelatedMovies).
+			Find(&infos).Error; err != nil {
+			// related movies are optional, the movie is still served.
+			e.logger.Warn("get related movies",
+				slog.String("relation", relation),
+				slog.Any("error", err))
+			return
+		}
+		for _, other := range infos {
+			if seen[other.Provider+other.ID] || !other.Valid() {
+				continue
+			}
+			seen[other.Provider+other.ID] = true
+			related = append(related, &model.RelatedMovie{
+				ID:       other.ID,
+				Number:   other.Number,
+				Title:    other.Title,
+				Provider: other.Provider,
+				Homepage: other.Homepage,
+				ThumbURL: other.ThumbURL,
+				Relation: relation,
+			})
+		}
+	}
+	if info.Series != "" {
+		add(model.RelatedSameSeries, "series = ?", info.Series)
+	}
+	if len(info.Actors) > 0 && info.Actors[0] != "" {
+		if e.DBType() == database.Postgres {
+			add(model.RelatedSameActor, "? = ANY(actors)", info.Actors[0])
+		} else {
+			// arrays are stored as Postgres array literals, i.e. {"a","b"},
+			// elements are matched with their quotes and delimiters.
+			elem := quoteArrayElement(info.Actors[0])
+			add(model.RelatedSameActor, "(instr(actors, ?) > 0 OR instr(actors, ?) > 0)", "{"+elem, ","+elem)
+		}
+	}
+	return related, nil
+}
+
+// quoteArrayElement quotes the element as in Postgres array literals,
+// the same as pq.StringArray does.
+func quoteArrayElement(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
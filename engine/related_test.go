@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_GetRelatedMovies(t *testing.T) {
+	e := Default()
+	movie := func(id, series string, actors ...string) *model.MovieInfo {
+		return &model.MovieInfo{
+			ID:       id,
+			Number:   id,
+			Title:    "Title " + id,
+			Provider: "FANZA",
+			Homepage: "https://www.dmm.co.jp/" + id,
+			CoverURL: "https://www.dmm.co.jp/" + id + ".jpg",
+			Series:   series,
+			Actors:   actors,
+		}
+	}
+	info := movie("rel00001", "Related Series", "Related Actress")
+	info.RelatedMovies = []*model.RelatedMovie{
+		{ID: "rel00002", Provider: "FANZA", Relation: model.RelatedAlsoViewed},
+	}
+	for _, m := range []*model.MovieInfo{
+		info,
+		movie("rel00002", "Related Series"),
+		movie("rel00003", "Related Series"),
+		movie("rel00004", "", "Related Actress"),
+		movie("rel00005", "Other Series"),
+		// names containing the actress are other actresses.
+		movie("rel00006", "", "Related Actress II"),
+		movie("rel00007", "", `Other, "Related Actress`),
+	} {
+		require.NoError(t, e.db.Create(m).Error)
+	}
+
+	related, err := e.GetRelatedMovies("FANZA", "rel00001", true)
+	require.NoError(t, err)
+	var got []string
+	for _, m := range related {
+		got = append(got, m.ID+"/"+m.Relation)
+	}
+	assert.Equal(t, []string{
+		"rel00002/" + model.RelatedAlsoViewed,
+		"rel00003/" + model.RelatedSameSeries,
+		"rel00004/" + model.RelatedSameActor,
+	}, got)
+}
+
+func TestEngine_GetRelatedMoviesSameActor(t *testing.T) {
+	e := Default()
+	movie := func(id string, actors ...string) *model.MovieInfo {
+		return &model.MovieInfo{
+			ID:       id,
+			Number:   id,
+			Title:    "Title " + id,
+			Provider: "FANZA",
+			Homepage: "https://www.dmm.co.jp/" + id,
+			CoverURL: "https://www.dmm.co.jp/" + id + ".jpg",
+			Actors:   actors,
+		}
+	}
+	for _, m := range []*model.MovieInfo{
+		movie("act00001", "Ai"),
+		movie("act00002", "Aika"),
+		movie("act00003", "Mai", "Ai"),
+		movie("act00004", "A_i"),
+	} {
+		require.NoError(t, e.db.Create(m).Error)
+	}
+
+	related, err := e.GetRelatedMovies("FANZA", "act00001", true)
+	require.NoError(t, err)
+	var got []string
+	for _, m := range related {
+		got = append(got, m.ID)
+	}
+	assert.Equal(t, []string{"act00003"}, got)
+}
@@ -0,0 +1,261 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	goerr "errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// maxReparseChanges is the maximum number of changed movies listed in
+// a re-parse report, all of them are counted still.
+const maxReparseChanges = 1000
+
+// FieldChange is a changed field of a re-parsed movie info, the values
+// are of the JSON schema of the info.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// ReparseChange is the changed fields of a re-parsed movie info.
+type ReparseChange struct {
+	Provider string         `json:"provider"`
+	ID       string         `json:"id"`
+	Fields   []*FieldChange `json:"fields"`
+	// Regressed is whether the re-parsed info has fewer fields filled
+	// than the snapshot, it is never saved then.
+	Regressed bool `json:"regressed,omitempty"`
+}
+
+// ReparseReport is the summary of a re-parse run.
+type ReparseReport struct {
+	DryRun    bool `json:"dry_run"`
+	Parsed    int  `json:"parsed"`
+	Changed   int  `json:"changed"`
+	Unchanged int  `json:"unchanged"`
+	Failed    int  `json:"failed"`
+	// Regressed are the changed ones not saved, see ReparseChange.
+	Regressed int `json:"regressed"`
+	// Changes are the diffs of the first changed movies.
+	Changes    []*ReparseChange `json:"changes"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+}
+
+type reparseMoviesJobParams struct {
+	Provider string `json:"provider"`
+	Limit    int    `json:"limit"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+// ReparseMovies parses the stored raw payloads of movies again with the
+// current parsers, of the provider only if not empty and at most limit
+// of them if limit > 0. Only one document is stored per movie, so movies
+// of providers that fetch other documents as well fail. Changed infos
+// are saved offline unless dryRun or they have fewer fields filled, and
+// the report lists their changes against the snapshots parsed when
+// they were scraped.
+func (e *Engine) ReparseMovies(provider string, limit int, dryRun bool) (*ReparseReport, error) {
+	report := &ReparseReport{DryRun: dryRun, Changes: []*ReparseChange{}, StartedAt: time.Now()}
+
+	tx := e.db.Model(&model.MoviePayload{}).
+		Select("provider, id").
+		Where("body <> ''").
+		Order("provider, id")
+	if provider != "" {
+		p, err := e.GetMovieProviderByName(provider)
+		if err != nil {
+			return nil, err
+		}
+		tx = tx.Where("provider = ?", p.Name())
+	}
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+	var records []staleRecord
+	if err := tx.Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	for _, r := range records {
+		if err := e.ctx.Err(); err != nil {
+			return nil, err
+		}
+		change, err := e.reparseMovie(r.Provider, r.ID, dryRun)
+		if err != nil {
+			e.logger.Warn("reparse movie",
+				slog.String("provider", r.Provider),
+				slog.String("id", r.ID),
+				slog.Any("error", err))
+			report.Failed++
+			continue
+		}
+		report.Parsed++
+		if change == nil {
+			report.Unchanged++
+			continue
+		}
+		report.Changed++
+		if change.Regressed {
+			report.Regressed++
+		}
+		if len(report.Changes) < maxReparseChanges {
+			report.Changes = append(report.Changes, change)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	e.logger.Info("reparse movies",
+		slog.Bool("dry_run", dryRun),
+		slog.Int("parsed", report.Parsed),
+		slog.Int("changed", report.Changed),
+		slog.Int("regressed", report.Regressed),
+		slog.Int("failed", report.Failed),
+		slog.Duration("duration", report.FinishedAt.Sub(report.StartedAt)))
+	return report, nil
+}
+
+// reparseMovie parses the stored payload of the movie again, and
+// returns its changes, nil if nothing is changed.
+func (e *Engine) reparseMovie(name, id string, dryRun bool) (*ReparseChange, error) {
+	payload, err := e.GetMoviePayload(name, id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := e.DryRunParse(&DryRun{
+		Provider:       payload.Provider,
+		URL:            payload.URL,
+		HTML:           []byte(payload.Body),
+		SingleDocument: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	info, ok := result.Info.(*model.MovieInfo)
+	if !ok || info == nil {
+		if result.Error != "" {
+			return nil, goerr.New(result.Error)
+		}
+		return nil, mt.ErrIncompleteMetadata
+	}
+	snapshot, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := diffJSON(payload.Snapshot, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	// parser fixes fill in more fields, not fewer.
+	regressed := filledFields(snapshot) < filledFields(payload.Snapshot)
+	if !dryRun && !regressed {
+		if !info.Valid() {
+			return nil, mt.ErrIncompleteMetadata
+		}
+		if e.blocklist.Load().BlocksMovieInfo(info) {
+			return nil, ErrBlocked
+		}
+		// saved offline, unlike scraped ones, neither webhooks are sent
+		// nor missing artwork is fetched from providers.
+		sanitizeMovieActors(info)
+		if info.Maker == "" {
+			if series, ok := number.LookupSeries(info.Number); ok {
+				info.Maker = series.Maker
+			}
+		}
+		if err = e.db.Clauses(clause.OnConflict{
+			UpdateAll: true,
+		}).Create(info).Error; err != nil {
+			return nil, err
+		}
+		e.evictMovieInfo(info.Provider, info.ID)
+		if err = e.db.Model(payload).
+			Update("snapshot", datatypes.JSON(snapshot)).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &ReparseChange{Provider: payload.Provider, ID: payload.ID, Fields: fields, Regressed: regressed}, nil
+}
+
+// filledFields returns the number of non-empty fields of the JSON info.
+func filledFields(data []byte) (n int) {
+	if len(data) == 0 {
+		return 0
+	}
+	for _, empty := range jsonFields(json.RawMessage(data)) {
+		if !empty {
+			n++
+		}
+	}
+	return
+}
+
+// diffJSON returns the changed top-level fields of the JSON objects,
+// sorted by their names.
+func diffJSON(x, y []byte) ([]*FieldChange, error) {
+	var a, b map[string]json.RawMessage
+	if len(x) > 0 {
+		if err := json.Unmarshal(x, &a); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(y, &b); err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(b))
+	for name := range a {
+		names[name] = struct{}{}
+	}
+	for name := range b {
+		names[name] = struct{}{}
+	}
+	var changes []*FieldChange
+	for name := range names {
+		if jsonEqual(a[name], b[name]) {
+			continue
+		}
+		changes = append(changes, &FieldChange{Field: name, Old: a[name], New: b[name]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// jsonEqual reports whether the JSON values are the same, regardless
+// of white spaces and key orders.
+func jsonEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var x, y any
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	m, _ := json.Marshal(x)
+	n, _ := json.Marshal(y)
+	return bytes.Equal(m, n)
+}
+
+func (e *Engine) reparseMoviesJob(ctx context.Context, params json.RawMessage) (any, error) {
+	p := &reparseMoviesJobParams{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+	}
+	return e.WithContext(ctx).ReparseMovies(p.Provider, p.Limit, p.DryRun)
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/webhook"
+)
+
+func TestDiffJSON(t *testing.T) {
+	changes, err := diffJSON(
+		[]byte(`{"title":"Old","genres":["A","B"],"score":1,"removed":true}`),
+		[]byte(`{"score":1,"genres":["A","B"],"title":"New","added":[]}`))
+	require.NoError(t, err)
+	var fields []string
+	for _, c := range changes {
+		fields = append(fields, c.Field)
+	}
+	assert.Equal(t, []string{"added", "removed", "title"}, fields)
+	assert.JSONEq(t, `"Old"`, string(changes[2].Old))
+	assert.JSONEq(t, `"New"`, string(changes[2].New))
+
+	changes, err = diffJSON(nil, []byte(`{"title":"New"}`))
+	require.NoError(t, err)
+	assert.Len(t, changes, 1)
+}
+
+func TestEngine_ReparseMovies(t *testing.T) {
+	var events atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		events.Add(1)
+	}))
+	defer srv.Close()
+	e := New(WithWebhook(webhook.New([]string{srv.URL}, time.Second)))
+	require.NoError(t, e.DBAutoMigrate(true))
+	require.NoError(t, e.db.Create(&model.MoviePayload{
+		ID:       "REP-001",
+		Provider: "JavBus",
+		URL:      "https://www.javbus.com/REP-001",
+		Body: `<html><body>
+<a class="bigImage" href="/pics/cover/rep_b.jpg"><img title="New Title" src="/pics/cover/rep_b.jpg"></a>
+<div class="col-md-3 info"><p><span>品番:</span> <span>REP-001</span></p></div>
+</body></html>`,
+		Snapshot: []byte(`{"id":"REP-001","number":"REP-001","title":"Old Title"}`),
+	}).Error)
+
+	report, err := e.ReparseMovies("javbus", 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Parsed)
+	assert.Equal(t, 1, report.Changed)
+	require.Len(t, report.Changes, 1)
+	var title *FieldChange
+	for _, c := range report.Changes[0].Fields {
+		if c.Field == "title" {
+			title = c
+		}
+	}
+	require.NotNil(t, title)
+	assert.JSONEq(t, `"Old Title"`, string(title.Old))
+	assert.JSONEq(t, `"New Title"`, string(title.New))
+	assert.False(t, e.existsInDB(&model.MovieInfo{}, "JavBus", "REP-001"))
+
+	report, err = e.ReparseMovies("javbus", 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Changed)
+	provider, err := e.GetMovieProviderByName("javbus")
+	require.NoError(t, err)
+	saved, err := e.getMovieInfoFromDB(provider, "REP-001")
+	require.NoError(t, err)
+	assert.Equal(t, "New Title", saved.Title)
+	// re-parsed infos are saved offline.
+	e.notifier.Wait()
+	assert.Zero(t, events.Load())
+
+	// snapshots are updated along with the infos.
+	report, err = e.ReparseMovies("javbus", 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Empty(t, report.Changes)
+
+	_, err = e.ReparseMovies("unknown", 0, true)
+	assert.Error(t, err)
+}
+
+func TestEngine_ReparseMoviesRegressed(t *testing.T) {
+	db, err := database.Open(&database.Config{DSN: "file:reparse_regressed_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	e := New(WithDB(db))
+	require.NoError(t, e.DBAutoMigrate(true))
+
+	// e.g. the stored page is a stub of a removed movie.
+	require.NoError(t, e.db.Create(&model.MoviePayload{
+		ID:       "REP-002",
+		Provider: "JavBus",
+		URL:      "https://www.javbus.com/REP-002",
+		Body: `<html><body>
+<div class="col-md-3 info"><p><span>品番:</span> <span>REP-002</span></p></div>
+</body></html>`,
+		Snapshot: []byte(`{"id":"REP-002","number":"REP-002","title":"Title","summary":"Summary",
+"director":"D","maker":"M","label":"L","series":"S","actors":["A"],"genres":["G"],"runtime":120,
+"cover_url":"https://www.javbus.com/cover.jpg","thumb_url":"https://www.javbus.com/thumb.jpg"}`),
+	}).Error)
+
+	report, err := e.ReparseMovies("javbus", 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Changed)
+	assert.Equal(t, 1, report.Regressed)
+	require.Len(t, report.Changes, 1)
+	assert.True(t, report.Changes[0].Regressed)
+	assert.False(t, e.existsInDB(&model.MovieInfo{}, "JavBus", "REP-002"))
+
+	// snapshots are kept, too.
+	payload, err := e.GetMoviePayload("javbus", "REP-002")
+	require.NoError(t, err)
+	assert.Contains(t, string(payload.Snapshot), `"summary":"Summary"`)
+}
+
+func TestHTMLTransport(t *testing.T) {
+	transport := &htmlTransport{html: []byte("<html></html>"), url: "https://www.javbus.com/REP-001"}
+	for _, unit := range []struct {
+		url string
+		err error
+	}{
+		{"https://www.javbus.com/REP-001", nil},
+		{"https://WWW.JAVBUS.COM/REP-001/", nil},
+		{"https://www.javbus.com/ajax/uncledatoolsbyajax.php?gid=1", ErrMultiDocument},
+		{"https://api.example.com/REP-001", ErrMultiDocument},
+	} {
+		req, err := http.NewRequest(http.MethodGet, unit.url, nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		if unit.err != nil {
+			assert.ErrorIs(t, err, unit.err, unit.url)
+			continue
+		}
+		require.NoError(t, err, unit.url)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "<html></html>", string(body))
+	}
+
+	// all requests without the URL.
+	transport.url = ""
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com/REP-001", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.NoError(t, err)
+}
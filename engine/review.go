@@ -1,11 +1,13 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm/clause"
 
+	"github.com/metatube-community/metatube-sdk-go/metrics"
 	"github.com/metatube-community/metatube-sdk-go/model"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 )
@@ -20,8 +22,10 @@ func (e *Engine) getMovieReviewsFromDB(provider mt.MovieProvider, id string) (*m
 }
 
 func (e *Engine) getMovieReviewsWithCallback(provider mt.MovieProvider, id string, lazy bool,
-	callback func() ([]*model.MovieReviewDetail, error),
+	callback func(ctx context.Context) ([]*model.MovieReviewDetail, error),
 ) (info *model.MovieReviewInfo, err error) {
+	// forced refreshes bypass the HTTP cache.
+	e = e.withRefresh(lazy)
 	defer func() {
 		// metadata validation check.
 		if err == nil && (info == nil || !info.Valid()) {
@@ -31,8 +35,10 @@ func (e *Engine) getMovieReviewsWithCallback(provider mt.MovieProvider, id strin
 	// Query DB first (by id).
 	if lazy {
 		if info, err = e.getMovieReviewsFromDB(provider, id); err == nil && info.Valid() {
+			metrics.ObserveCache(opReviews, true)
 			return // ignore DB query error.
 		}
+		metrics.ObserveCache(opReviews, false)
 	}
 	// delayed info auto-save.
 	defer func() {
@@ -44,7 +50,13 @@ func (e *Engine) getMovieReviewsWithCallback(provider mt.MovieProvider, id strin
 	}()
 
 	var reviews []*model.MovieReviewDetail
-	if reviews, err = callback(); err != nil {
+	ctx, done, err := e.observe(provider, opReviews, id)
+	if err != nil {
+		return
+	}
+	reviews, err = callback(ctx)
+	done(&err)
+	if err != nil {
 		return
 	}
 
@@ -66,8 +78,8 @@ func (e *Engine) getMovieReviewsByProviderID(provider mt.MovieProvider, id strin
 		return nil, fmt.Errorf("reviews not supported by %s", provider.Name())
 	}
 
-	return e.getMovieReviewsWithCallback(provider, id, lazy, func() ([]*model.MovieReviewDetail, error) {
-		return reviewer.GetMovieReviewsByID(id)
+	return e.getMovieReviewsWithCallback(provider, id, lazy, func(ctx context.Context) ([]*model.MovieReviewDetail, error) {
+		return mt.WithContext(reviewer, ctx).GetMovieReviewsByID(id)
 	})
 }
 
@@ -93,8 +105,8 @@ func (e *Engine) getMovieReviewsByProviderURL(provider mt.MovieProvider, rawURL
 		return nil, fmt.Errorf("reviews not supported by %s", provider.Name())
 	}
 
-	return e.getMovieReviewsWithCallback(provider, id, lazy, func() ([]*model.MovieReviewDetail, error) {
-		return reviewer.GetMovieReviewsByURL(rawURL)
+	return e.getMovieReviewsWithCallback(provider, id, lazy, func(ctx context.Context) ([]*model.MovieReviewDetail, error) {
+		return mt.WithContext(reviewer, ctx).GetMovieReviewsByURL(rawURL)
 	})
 }
 
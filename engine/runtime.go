@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+const (
+	// runtimeMismatchMinutes and runtimeMismatchRatio are both exceeded
+	// when the runtime is far off the actual duration.
+	runtimeMismatchMinutes = 10
+	runtimeMismatchRatio   = 0.1
+)
+
+// runtimeDiff returns the absolute difference in minutes.
+func runtimeDiff(runtime, actual int) int {
+	if runtime > actual {
+		return runtime - actual
+	}
+	return actual - runtime
+}
+
+// runtimeMismatch reports whether the runtime in minutes is far off
+// the actual duration in minutes.
+func runtimeMismatch(runtime, actual int) bool {
+	diff := runtimeDiff(runtime, actual)
+	return diff > runtimeMismatchMinutes &&
+		float64(diff) > float64(actual)*runtimeMismatchRatio
+}
+
+// SelectRuntime takes the actual duration of the movie file as the
+// source of truth, and sets the runtime of the movie in place to the
+// one closest to it among providers of the same movie. The movie is
+// flagged if the selected runtime is still far off the duration.
+func (e *Engine) SelectRuntime(info *model.MovieInfo, duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+	actual := int(math.Round(duration.Minutes()))
+	runtime, source := info.Runtime, info.Provider
+	if runtime == 0 || runtimeDiff(runtime, actual) > 1 {
+		e.forEachSameMovie(info, func(other *model.MovieInfo) bool {
+			if other.Runtime > 0 && (runtime == 0 ||
+				runtimeDiff(other.Runtime, actual) < runtimeDiff(runtime, actual)) {
+				runtime, source = other.Runtime, other.Provider
+			}
+			return runtimeDiff(runtime, actual) > 1
+		})
+	}
+	if runtime == 0 {
+		return
+	}
+	info.Runtime = runtime
+	if info.Sources != nil {
+		info.Sources["runtime"] = source
+	}
+	if info.RuntimeMismatch = runtimeMismatch(runtime, actual); info.RuntimeMismatch {
+		e.logger.Warn("runtime mismatch",
+			slog.String("provider", info.Provider),
+			slog.String("id", info.ID),
+			slog.Int("runtime", runtime),
+			slog.Int("duration", actual))
+	}
+}
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestRuntimeMismatch(t *testing.T) {
+	for _, unit := range []struct {
+		runtime, actual int
+		want            bool
+	}{
+		{120, 120, false},
+		{120, 125, false},
+		{120, 131, false},
+		{120, 140, true},
+		{150, 120, true},
+		{25, 10, true},
+		{20, 10, false},
+	} {
+		assert.Equal(t, unit.want, runtimeMismatch(unit.runtime, unit.actual), unit)
+	}
+}
+
+func TestEngine_SelectRuntime(t *testing.T) {
+	engine := Default()
+	info := &model.MovieInfo{
+		ID:       "runtime-1",
+		Provider: "none",
+		Runtime:  120,
+		Sources:  map[string]string{},
+	}
+	engine.SelectRuntime(info, 119*time.Minute+40*time.Second)
+	assert.Equal(t, 120, info.Runtime)
+	assert.Equal(t, "none", info.Sources["runtime"])
+	assert.False(t, info.RuntimeMismatch)
+
+	engine.SelectRuntime(info, 0)
+	assert.False(t, info.RuntimeMismatch)
+}
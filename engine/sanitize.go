@@ -0,0 +1,19 @@
+package engine
+
+import (
+	"github.com/metatube-community/metatube-sdk-go/common/parser"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// sanitizeActors splits multi-name actors of the search results and
+// drops placeholders in place, so that all providers are consistent.
+func sanitizeActors(results []*model.MovieSearchResult) {
+	for _, result := range results {
+		result.Actors = parser.SanitizeActorNames(result.Actors)
+	}
+}
+
+// sanitizeMovieActors is like sanitizeActors, but of the movie info.
+func sanitizeMovieActors(info *model.MovieInfo) {
+	info.Actors = parser.SanitizeActorNames(info.Actors)
+}
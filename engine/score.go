@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+const (
+	// DefaultScoreScale is the full mark of the score of providers.
+	DefaultScoreScale = 5
+	// NormalizedScoreScale is the full mark of normalized scores.
+	NormalizedScoreScale = 10
+)
+
+// scaleScore converts the rating into the 0-10 scale linearly.
+func scaleScore(rating *model.MovieRating) float64 {
+	if rating.Scale <= 0 {
+		return 0
+	}
+	return rating.Value / rating.Scale * NormalizedScoreScale
+}
+
+// rateMovieInfo records the raw rating of the provider, and normalizes
+// it into the 0-10 scale with the converter of the provider.
+func rateMovieInfo(provider mt.MovieProvider, info *model.MovieInfo) {
+	rating := info.Rating
+	if rating == nil {
+		if info.Score <= 0 {
+			info.Ratings, info.NormalizedScore = nil, 0
+			return
+		}
+		rating = &model.MovieRating{Value: info.Score, Scale: DefaultScoreScale}
+	}
+	rating = &model.MovieRating{ // no changes to the saved rating.
+		Provider: info.Provider,
+		Value:    rating.Value,
+		Scale:    rating.Scale,
+		Votes:    rating.Votes,
+	}
+	if rating.Votes == 0 {
+		rating.Votes = info.ReviewCount
+	}
+	if normalizer, ok := provider.(mt.ScoreNormalizer); ok {
+		rating.Normalized = normalizer.NormalizeScore(rating)
+	} else {
+		rating.Normalized = scaleScore(rating)
+	}
+	rating.Normalized = roundScore(math.Max(0, math.Min(rating.Normalized, NormalizedScoreScale)))
+	info.Ratings = []*model.MovieRating{rating}
+	info.NormalizedScore = rating.Normalized
+}
+
+// averageScore returns the mean of normalized ratings.
+func averageScore(ratings []*model.MovieRating) float64 {
+	if len(ratings) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, rating := range ratings {
+		sum += rating.Normalized
+	}
+	return roundScore(sum / float64(len(ratings)))
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
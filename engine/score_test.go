@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+type percentProvider struct{ mt.MovieProvider }
+
+func (percentProvider) NormalizeScore(rating *model.MovieRating) float64 {
+	return rating.Value / 10
+}
+
+func TestRateMovieInfo(t *testing.T) {
+	provider := Default().MustGetMovieProviderByName("fanza")
+
+	info := &model.MovieInfo{Provider: "FANZA", Score: 4.2}
+	rateMovieInfo(provider, info)
+	assert.Equal(t, 8.4, info.NormalizedScore)
+	assert.Equal(t, 5.0, info.Ratings[0].Scale)
+	assert.Nil(t, info.Rating)
+
+	info = &model.MovieInfo{Provider: "JAV321", Score: 4.5,
+		Rating: &model.MovieRating{Value: 45, Scale: 50, Votes: 12}}
+	rateMovieInfo(provider, info)
+	assert.Equal(t, 9.0, info.NormalizedScore)
+	assert.Equal(t, 12, info.Ratings[0].Votes)
+	assert.Zero(t, info.Rating.Normalized)
+
+	info = &model.MovieInfo{Provider: "Percent",
+		Rating: &model.MovieRating{Value: 120, Scale: 100}}
+	rateMovieInfo(percentProvider{provider}, info)
+	assert.Equal(t, 10.0, info.NormalizedScore)
+
+	info = &model.MovieInfo{Provider: "FANZA"}
+	rateMovieInfo(provider, info)
+	assert.Empty(t, info.Ratings)
+	assert.Zero(t, info.NormalizedScore)
+}
+
+func TestMergeMovieInfo_Ratings(t *testing.T) {
+	dst := &model.MovieInfo{Provider: "FANZA", Score: 4}
+	rateMovieInfo(Default().MustGetMovieProviderByName("fanza"), dst)
+	src := &model.MovieInfo{Provider: "JavBus", Ratings: []*model.MovieRating{
+		{Provider: "JavBus", Value: 3, Scale: 5, Normalized: 6},
+	}}
+	mergeMovieInfo(dst, src)
+	mergeMovieInfo(dst, src)
+	assert.Len(t, dst.Ratings, 2)
+	assert.Equal(t, 7.0, dst.NormalizedScore)
+}
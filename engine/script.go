@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"encoding/json"
+	goerr "errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/robertkrimen/otto"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// DefaultScriptTimeout is the maximum run time of a script function.
+const DefaultScriptTimeout = time.Second
+
+var errScriptTimeout = goerr.New("script timeout")
+
+// ScriptHook is a Hook written in JavaScript, it calls the functions
+// defined by the script, both of which are optional:
+//
+//	// throws to abort the scrape.
+//	function preScrape(provider, id) {}
+//	// mutates the info in place or returns a new one.
+//	function postScrape(info) {}
+//
+// The info is of the same JSON form as in the API responses.
+type ScriptHook struct {
+	name    string
+	timeout time.Duration
+
+	mu sync.Mutex
+	vm *otto.Otto
+}
+
+// NewScriptHook returns a *ScriptHook of the source, the name is used
+// in errors only.
+func NewScriptHook(name, src string) (*ScriptHook, error) {
+	vm := otto.New()
+	if _, err := vm.Run(src); err != nil {
+		return nil, fmt.Errorf("script %s: %w", name, err)
+	}
+	return &ScriptHook{
+		name:    name,
+		timeout: DefaultScriptTimeout,
+		vm:      vm,
+	}, nil
+}
+
+// LoadScriptHook returns a *ScriptHook of the script file.
+func LoadScriptHook(path string) (*ScriptHook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewScriptHook(path, string(data))
+}
+
+// PreScrape calls preScrape(provider, id) of the script, exceptions
+// are returned as forbidden errors.
+func (h *ScriptHook) PreScrape(provider, id string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, err := h.call("preScrape", provider, id); err != nil {
+		return errors.New(http.StatusForbidden, err.Error())
+	}
+	return nil
+}
+
+// PostScrape calls postScrape(info) of the script.
+func (h *ScriptHook) PostScrape(info *model.MovieInfo) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if f, _ := h.vm.Get("postScrape"); !f.IsFunction() {
+		return nil
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	obj, err := h.vm.Call("JSON.parse", nil, string(data))
+	if err != nil {
+		return err
+	}
+	v, err := h.call("postScrape", obj)
+	if err != nil {
+		return err
+	}
+	if !v.IsObject() {
+		v = obj // mutated in place.
+	}
+	if v, err = h.vm.Call("JSON.stringify", nil, v); err != nil {
+		return err
+	}
+	out := &model.MovieInfo{}
+	if err = json.Unmarshal([]byte(v.String()), out); err != nil {
+		return fmt.Errorf("script %s: postScrape: %w", h.name, err)
+	}
+	// the info is saved under its key, scripts never move it.
+	out.ID, out.Provider = info.ID, info.Provider
+	out.TimeTracker = info.TimeTracker
+	*info = *out
+	return nil
+}
+
+// call calls the function of the script with timeout, it is a no-op
+// if the function is not defined.
+func (h *ScriptHook) call(name string, args ...any) (v otto.Value, err error) {
+	f, _ := h.vm.Get(name)
+	if !f.IsFunction() {
+		return otto.UndefinedValue(), nil
+	}
+	interrupt := make(chan func(), 1)
+	h.vm.Interrupt = interrupt
+	timer := time.AfterFunc(h.timeout, func() {
+		interrupt <- func() { panic(errScriptTimeout) }
+	})
+	defer func() {
+		// interrupts sent right before the timer is stopped are never
+		// run by the following calls, e.g. of JSON.stringify.
+		timer.Stop()
+		h.vm.Interrupt = nil
+		if r := recover(); r != nil {
+			if r != errScriptTimeout {
+				panic(r)
+			}
+			err = errScriptTimeout
+		}
+		if err != nil {
+			err = fmt.Errorf("script %s: %s: %w", h.name, name, err)
+		}
+	}()
+	return f.Call(otto.NullValue(), args...)
+}
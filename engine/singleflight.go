@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// sharedKey returns the key of an upstream request, identical
+// requests in flight share the same key.
+func sharedKey(operation string, provider mt.Provider, id string) string {
+	return operation + "/" + provider.Name() + "/" + id
+}
+
+// flightKey returns the singleflight key of the shared key, calls of
+// different priorities are never collapsed, so that interactive calls
+// never wait for background ones queued behind the scheduler.
+func (e *Engine) flightKey(key string) string {
+	return key + "/" + PriorityFromContext(e.ctx).String()
+}
+
+// cloneMovieInfo deep-copies the shared info, so that each caller can
+// modify its own copy, e.g. by applying overrides.
+func cloneMovieInfo(info *model.MovieInfo) *model.MovieInfo {
+	c := *info
+	c.Actors = slices.Clone(info.Actors)
+	c.PreviewImages = slices.Clone(info.PreviewImages)
+	c.PreviewImageDetails = clonePointers(info.PreviewImageDetails)
+	c.Genres = slices.Clone(info.Genres)
+	c.Rating = clonePointer(info.Rating)
+	c.Ratings = clonePointers(info.Ratings)
+	c.Editions = slices.Clone(info.Editions)
+	c.RelatedMovies = clonePointers(info.RelatedMovies)
+	if info.VR != nil {
+		vr := *info.VR
+		vr.Devices = slices.Clone(info.VR.Devices)
+		c.VR = &vr
+	}
+	c.Sources = maps.Clone(info.Sources)
+	c.ActorAges = maps.Clone(info.ActorAges)
+	c.Match = clonePointer(info.Match)
+	return &c
+}
+
+// cloneActorInfo deep-copies the shared info, like cloneMovieInfo.
+func cloneActorInfo(info *model.ActorInfo) *model.ActorInfo {
+	c := *info
+	c.Aliases = slices.Clone(info.Aliases)
+	c.Images = slices.Clone(info.Images)
+	c.Sources = maps.Clone(info.Sources)
+	return &c
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+func clonePointers[T any](s []*T) []*T {
+	if s == nil {
+		return nil
+	}
+	c := make([]*T, len(s))
+	for i, p := range s {
+		c[i] = clonePointer(p)
+	}
+	return c
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_SingleflightMovieInfo(t *testing.T) {
+	e := Default()
+	provider := e.MustGetMovieProviderByName("fanza")
+
+	calls := atomic.NewInt32(0)
+	callback := func(context.Context) (*model.MovieInfo, error) {
+		calls.Inc()
+		time.Sleep(100 * time.Millisecond)
+		return &model.MovieInfo{
+			ID:       "abp00050",
+			Number:   "ABP-050",
+			Title:    "Title",
+			Provider: provider.Name(),
+			Homepage: "https://www.dmm.co.jp/",
+			CoverURL: "https://www.dmm.co.jp/cover.jpg",
+			Actors:   []string{"Actor"},
+		}, nil
+	}
+
+	const n = 5
+	var wg sync.WaitGroup
+	infos := make([]*model.MovieInfo, n)
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			info, err := e.getMovieInfoWithCallback(provider, "abp00050", false, callback)
+			require.NoError(t, err)
+			infos[i] = info
+		}(i)
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+	for _, info := range infos[1:] {
+		assert.Equal(t, infos[0].Title, info.Title)
+		// each caller gets its own copy.
+		assert.NotSame(t, infos[0], info)
+	}
+}
+
+func TestCloneMovieInfo(t *testing.T) {
+	info := &model.MovieInfo{
+		ID:                  "abp00050",
+		Actors:              []string{"Actor"},
+		PreviewImages:       []string{"https://example.com/1.jpg"},
+		PreviewImageDetails: []*model.PreviewImage{{URL: "https://example.com/1.jpg", Index: 1}},
+		Genres:              []string{"Genre"},
+		Rating:              &model.MovieRating{Provider: "FANZA", Value: 4, Scale: 5},
+		Ratings:             []*model.MovieRating{{Provider: "FANZA", Value: 4, Scale: 5}},
+		Editions:            []string{"4k"},
+		RelatedMovies:       []*model.RelatedMovie{{ID: "abp00051"}},
+		VR:                  &model.MovieVRInfo{Devices: []string{"Quest"}},
+		Sources:             map[string]string{"title": "FANZA"},
+		ActorAges:           map[string]int{"Actor": 20},
+		Match:               &model.MovieMatch{Type: model.MatchByID, Confidence: 1},
+	}
+	want := cloneMovieInfo(info)
+
+	// clones are modified concurrently, run with -race.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := cloneMovieInfo(info)
+			c.Actors[0] = "Other"
+			c.PreviewImages[0] = ""
+			c.PreviewImageDetails[0].Index = 2
+			c.Genres[0] = "Other"
+			c.Rating.Value = 1
+			c.Ratings[0].Value = 1
+			c.Editions[0] = "vr"
+			c.RelatedMovies[0].ID = ""
+			c.VR.Devices[0] = "Other"
+			c.Sources["runtime"] = "JavBus"
+			c.ActorAges["Other"] = 30
+			c.Match.Confidence = 0.5
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, want, info)
+
+	actor := &model.ActorInfo{Aliases: []string{"Alias"}, Sources: map[string]string{"name": "FANZA"}}
+	c := cloneActorInfo(actor)
+	c.Aliases[0] = "Other"
+	c.Sources["name"] = "Other"
+	assert.Equal(t, "Alias", actor.Aliases[0])
+	assert.Equal(t, "FANZA", actor.Sources["name"])
+}
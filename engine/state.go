@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// MaxNumberStates is the maximum numbers to update in a single call.
+const MaxNumberStates = 1000
+
+var ErrInvalidNumberState = errors.New(http.StatusBadRequest, "invalid number state")
+
+// SetNumberStates marks the numbers of the user as owned or watched at
+// the time, a nil flag leaves the state unchanged. Timestamps are kept
+// if the state is already set, and cleared if the state is unset.
+func (e *Engine) SetNumberStates(user string, numbers []string, owned, watched *bool, at time.Time) (states []*model.NumberState, err error) {
+	if len(numbers) == 0 || len(numbers) > MaxNumberStates || (owned == nil && watched == nil) {
+		return nil, ErrInvalidNumberState
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	err = e.db.Transaction(func(tx *gorm.DB) error {
+		seen := make(map[string]struct{}, len(numbers))
+		for _, num := range numbers {
+			if num = number.Normalize(num); num == "" {
+				return ErrInvalidNumberState
+			}
+			if _, ok := seen[num]; ok {
+				continue
+			}
+			seen[num] = struct{}{}
+
+			state := &model.NumberState{User: user, Number: num}
+			if err := tx.
+				Where(state).
+				FirstOrInit(state).Error; err != nil {
+				return err
+			}
+			if owned != nil {
+				state.Owned, state.OwnedAt = *owned, stateTime(*owned, state.Owned, state.OwnedAt, at)
+			}
+			if watched != nil {
+				state.Watched, state.WatchedAt = *watched, stateTime(*watched, state.Watched, state.WatchedAt, at)
+			}
+			if err := tx.
+				Clauses(clause.OnConflict{UpdateAll: true}).
+				Create(state).Error; err != nil {
+				return err
+			}
+			states = append(states, state)
+		}
+		return nil
+	})
+	return
+}
+
+// stateTime returns the timestamp of a state changed from old to v.
+func stateTime(v, old bool, t *time.Time, at time.Time) *time.Time {
+	switch {
+	case !v:
+		return nil
+	case old && t != nil:
+		return t
+	default:
+		return &at
+	}
+}
+
+// GetNumberStates returns the states of the user, filtered by owned or
+// watched flags if not nil, most recently updated first.
+func (e *Engine) GetNumberStates(user string, owned, watched *bool) (states []*model.NumberState, err error) {
+	tx := e.primaryDB().Where("username = ?", user)
+	if owned != nil {
+		tx = tx.Where("owned = ?", *owned)
+	}
+	if watched != nil {
+		tx = tx.Where("watched = ?", *watched)
+	}
+	err = tx.
+		Order("updated_at DESC").
+		Find(&states).Error
+	return
+}
+
+// AnnotateNumberStates sets the owned and watched flags of the results
+// by the states of the user.
+func (e *Engine) AnnotateNumberStates(user string, results []*model.MovieSearchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	numbers := make([]string, 0, len(results))
+	for _, result := range results {
+		if num := number.Normalize(result.Number); num != "" {
+			numbers = append(numbers, num)
+		}
+	}
+	var states []*model.NumberState
+	if err := e.primaryDB().
+		Where("username = ?", user).
+		Where("number IN ?", numbers).
+		Find(&states).Error; err != nil {
+		return err
+	}
+	if len(states) == 0 {
+		return nil
+	}
+	index := make(map[string]*model.NumberState, len(states))
+	for _, state := range states {
+		index[state.Number] = state
+	}
+	for _, result := range results {
+		if state, ok := index[number.Normalize(result.Number)]; ok {
+			result.Owned, result.Watched = state.Owned, state.Watched
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_NumberStates(t *testing.T) {
+	e := Default()
+
+	yes, no := true, false
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.AddDate(0, 1, 0)
+
+	states, err := e.SetNumberStates("erin", []string{"abp-030", "ABP00030", "ssis-001"}, &yes, nil, t1)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, "ABP30", states[0].Number)
+	assert.True(t, states[0].OwnedAt.Equal(t1))
+
+	// owned timestamps are kept, watched ones are set.
+	states, err = e.SetNumberStates("erin", []string{"ABP-030"}, &yes, &yes, t2)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.True(t, states[0].OwnedAt.Equal(t1))
+	assert.True(t, states[0].WatchedAt.Equal(t2))
+
+	_, err = e.SetNumberStates("erin", []string{"SSIS-001"}, &no, nil, t2)
+	require.NoError(t, err)
+
+	for _, unit := range []struct {
+		numbers        []string
+		owned, watched *bool
+	}{
+		{nil, &yes, nil},
+		{[]string{"ABP-030"}, nil, nil},
+		{[]string{" "}, &yes, nil},
+	} {
+		_, err := e.SetNumberStates("erin", unit.numbers, unit.owned, unit.watched, t1)
+		assert.ErrorIs(t, err, ErrInvalidNumberState)
+	}
+
+	states, err = e.GetNumberStates("erin", &yes, nil)
+	require.NoError(t, err)
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, "ABP30", states[0].Number)
+	}
+	states, err = e.GetNumberStates("erin", nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, states, 2)
+
+	results := []*model.MovieSearchResult{
+		{Number: "ABP-030"},
+		{Number: "SSIS-001"},
+		{Number: "IPX-001"},
+	}
+	require.NoError(t, e.AnnotateNumberStates("erin", results))
+	assert.True(t, results[0].Owned)
+	assert.True(t, results[0].Watched)
+	assert.False(t, results[1].Owned)
+	assert.False(t, results[1].Watched)
+	assert.False(t, results[2].Owned)
+}
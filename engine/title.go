@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// DefaultTitleRules are the built-in title cleanup rules, they match
+// promotional noise of storefronts, e.g. 【期間限定セール】, ※初回限定
+// and bracketed campaign text.
+var DefaultTitleRules = []string{
+	`【[^】]*(セール|限定|特典|キャンペーン|ポイント|早得|予約|先行|OFF|off|[%％])[^】]*】`,
+	`\[[^\]]*(セール|限定|特典|キャンペーン|ポイント|早得|予約|先行|(?i:sale|campaign|limited))[^\]]*\]`,
+	`[(（][^)）]*(期間限定|数量限定|初回限定|特典付き?)[^)）]*[)）]`,
+	`※\S*(限定|特典|期間|予約|セール)\S*`,
+}
+
+var (
+	defaultTitleCleaner, _ = NewTitleCleaner(DefaultTitleRules...)
+
+	spacesRe = regexp.MustCompile(`\s{2,}`)
+)
+
+// TitleCleaner strips noise from titles by a list of rules, i.e.
+// regular expressions of the text to remove.
+type TitleCleaner struct {
+	rules []*regexp.Regexp
+}
+
+// NewTitleCleaner returns a *TitleCleaner of the rules.
+func NewTitleCleaner(rules ...string) (*TitleCleaner, error) {
+	c := &TitleCleaner{}
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule)
+		if err != nil {
+			return nil, err
+		}
+		c.rules = append(c.rules, re)
+	}
+	return c, nil
+}
+
+// Clean returns the title with all matches of the rules removed, the
+// title is returned as is if nothing is left.
+func (c *TitleCleaner) Clean(title string) string {
+	if c == nil || len(c.rules) == 0 {
+		return title
+	}
+	cleaned := title
+	for _, re := range c.rules {
+		cleaned = re.ReplaceAllString(cleaned, " ")
+	}
+	if cleaned == title {
+		return title
+	}
+	if cleaned = strings.TrimSpace(spacesRe.ReplaceAllString(cleaned, " ")); cleaned == "" {
+		return title
+	}
+	return cleaned
+}
+
+// SetTitleCleaner replaces the title cleaner at runtime, nil disables
+// title cleanup.
+func (e *Engine) SetTitleCleaner(c *TitleCleaner) { e.titleCleaner.Store(c) }
+
+// cleanMovieTitle strips noise from the title of the movie info, it
+// is done before translation so that noise is never translated.
+func (e *Engine) cleanMovieTitle(info *model.MovieInfo) {
+	info.Title = e.titleCleaner.Load().Clean(info.Title)
+}
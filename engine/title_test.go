@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleCleaner(t *testing.T) {
+	c, err := NewTitleCleaner(DefaultTitleRules...)
+	require.NoError(t, err)
+
+	for _, unit := range []struct {
+		title, want string
+	}{
+		{"【期間限定セール】新人デビュー 美少女", "新人デビュー 美少女"},
+		{"新人デビュー ※初回限定 特典映像付き", "新人デビュー 特典映像付き"},
+		{"[Limited Sale] Debut [HD]", "Debut [HD]"},
+		{"新作（数量限定）タイトル", "新作 タイトル"},
+		{"【50%OFF】【4K】タイトル", "【4K】タイトル"},
+		{"【VR】タイトル  スペース", "【VR】タイトル  スペース"},
+		{"【期間限定セール】", "【期間限定セール】"},
+	} {
+		assert.Equal(t, unit.want, c.Clean(unit.title), unit.title)
+	}
+
+	var nilCleaner *TitleCleaner
+	assert.Equal(t, "【期間限定セール】A", nilCleaner.Clean("【期間限定セール】A"))
+
+	c, err = NewTitleCleaner(`^FHD\s*`)
+	require.NoError(t, err)
+	assert.Equal(t, "タイトル", c.Clean("FHD タイトル"))
+	assert.Equal(t, "【期間限定セール】A", c.Clean("【期間限定セール】A"))
+
+	_, err = NewTitleCleaner("(")
+	assert.Error(t, err)
+}
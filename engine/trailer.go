@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"net/http"
+
+	"github.com/metatube-community/metatube-sdk-go/common/fetch"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+)
+
+// trailerRequestHeaders are headers of range requests passed to the
+// upstream of trailers.
+var trailerRequestHeaders = []string{"Range", "If-Range"}
+
+// OpenMovieTrailer requests the preview video of the movie with the
+// Referer of the provider, which most video hosts require, and the
+// range headers of header, if any. The response is either 200 or 206
+// for ranges, and the caller must close its body.
+func (e *Engine) OpenMovieTrailer(name, id string, header http.Header) (*http.Response, error) {
+	provider, err := e.GetMovieProviderByName(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := e.GetMovieInfoByProviderID(provider.Name(), id, true)
+	if err != nil {
+		return nil, err
+	}
+	if info.PreviewVideoURL == "" {
+		return nil, ErrPreviewVideoNotFound
+	}
+
+	opts := []fetch.Option{
+		fetch.WithContext(e.ctx),
+		fetch.WithRaiseForStatus(false),
+		fetch.WithReferer(provider.URL().String()),
+	}
+	for _, key := range trailerRequestHeaders {
+		if value := header.Get(key); value != "" {
+			opts = append(opts, fetch.WithHeader(key, value))
+		}
+	}
+	resp, err := e.streamer.Get(info.PreviewVideoURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		return nil, errors.FromCode(resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"net/http"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+var ErrTranslatorNotSet = errors.New(http.StatusNotImplemented, "translator not set")
+
+// Translate translates the text with the default translator.
+func (e *Engine) Translate(text, from, to string) (string, error) {
+	if e.translator == nil {
+		return "", ErrTranslatorNotSet
+	}
+	return e.translator.Translate(text, from, to)
+}
+
+// SetCuratedTranslations replaces the curated translations at runtime,
+// nil disables them.
+func (e *Engine) SetCuratedTranslations(c *translate.Curated) { e.curated.Store(c) }
+
+// TranslateMovieInfo translates the title, summary and genres of the
+// movie to lang in place. Curated translations and the embedded genre
+// dictionary take precedence, and the default translator, if set,
+// translates the rest.
+func (e *Engine) TranslateMovieInfo(info *model.MovieInfo, lang string) error {
+	curated, ok := e.curated.Load().Lookup(info.Number, lang)
+	if !ok {
+		curated = &translate.CuratedTranslation{}
+	}
+	for _, field := range []struct {
+		text    *string
+		curated string
+	}{
+		{&info.Title, curated.Title},
+		{&info.Summary, curated.Summary},
+	} {
+		switch {
+		case field.curated != "":
+			*field.text = field.curated
+		case *field.text == "" || e.translator == nil:
+			// nothing to translate.
+		default:
+			text, err := e.translator.Translate(*field.text, "auto", lang)
+			if err != nil {
+				return err
+			}
+			*field.text = text
+		}
+	}
+	for i, genre := range info.Genres {
+		if name, ok := translate.TranslateGenre(genre, lang); ok {
+			info.Genres[i] = name
+			continue
+		}
+		if e.translator == nil {
+			continue
+		}
+		name, err := e.translator.Translate(genre, "auto", lang)
+		if err != nil {
+			return err
+		}
+		info.Genres[i] = name
+	}
+	return nil
+}
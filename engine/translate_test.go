@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEngine_TranslateMovieInfo(t *testing.T) {
+	e := New(WithTranslator(upperTranslator{}))
+	info := &model.MovieInfo{
+		Title:  "title",
+		Genres: []string{"巨乳", "genre"},
+	}
+	require.NoError(t, e.TranslateMovieInfo(info, "en"))
+	assert.Equal(t, "TITLE", info.Title)
+	assert.Empty(t, info.Summary)
+	assert.Equal(t, []string{"Big Tits", "GENRE"}, []string(info.Genres))
+}
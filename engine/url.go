@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"net/url"
+	"strings"
+
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// hostPrefixes are common subdomains of the same site, e.g. mobile ones.
+var hostPrefixes = []string{"www.", "m.", "sp.", "mobile."}
+
+// hostKey returns the lowercase host without common subdomains, so that
+// links to www.example.com and m.example.com resolve to the same key.
+func hostKey(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, prefix := range hostPrefixes {
+		if h, ok := strings.CutPrefix(host, prefix); ok {
+			return h
+		}
+	}
+	return host
+}
+
+// parseLink parses a pasted link, links without scheme are taken as
+// https, e.g. www.javbus.com/ABP-123.
+func parseLink(rawURL string) (*url.URL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, mt.ErrInvalidURL
+	}
+	return u, nil
+}
+
+// resolveProvider picks the provider of the link by its host, parent
+// domains included, e.g. video.dmm.co.jp for dmm.co.jp, and by the
+// longest path prefix if providers share the same host.
+func resolveProvider[T mt.Provider](hostProviders map[string][]T, u *url.URL) (provider T, err error) {
+	host := hostKey(u.Hostname())
+	for host != "" {
+		var matched string
+		for _, p := range hostProviders[host] {
+			if prefix := p.URL().Path; strings.HasPrefix(u.Path, prefix) &&
+				(matched == "" || len(prefix) > len(matched)) {
+				provider, matched = p, prefix
+			}
+		}
+		if matched != "" {
+			return provider, nil
+		}
+		_, host, _ = strings.Cut(host, ".")
+		if !strings.Contains(host, ".") {
+			break // no top-level domains.
+		}
+	}
+	return provider, mt.ErrProviderNotFound
+}
+
+// sameSite reports whether the link is of the site of the provider,
+// subdomains included, e.g. video.dmm.co.jp for dmm.co.jp.
+func sameSite(u *url.URL, provider mt.Provider) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host, site := hostKey(u.Hostname()), hostKey(provider.URL().Hostname())
+	return site != "" && (host == site || strings.HasSuffix(host, "."+site))
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestHostKey(t *testing.T) {
+	assert.Equal(t, "javbus.com", hostKey("www.javbus.com"))
+	assert.Equal(t, "dmm.co.jp", hostKey("WWW.DMM.CO.JP."))
+	assert.Equal(t, "video.dmm.co.jp", hostKey("video.dmm.co.jp"))
+	assert.Equal(t, "mgstage.com", hostKey("sp.mgstage.com"))
+}
+
+func TestSameSite(t *testing.T) {
+	provider := Default().MustGetMovieProviderByName("fanza")
+	for _, unit := range []struct {
+		link string
+		same bool
+	}{
+		{"https://www.dmm.co.jp/digital/videoa/-/detail/=/cid=abp00123/", true},
+		{"http://video.dmm.co.jp/av/content/?id=abp00123", true},
+		{"https://DMM.CO.JP./", true},
+		{"https://dmm.co.jp.example.com/", false},
+		{"https://notdmm.co.jp/", false},
+		{"ftp://www.dmm.co.jp/", false},
+		{"http://127.0.0.1/", false},
+	} {
+		u, err := url.Parse(unit.link)
+		require.NoError(t, err)
+		assert.Equal(t, unit.same, sameSite(u, provider), unit.link)
+	}
+}
+
+func TestEngine_GetMovieProviderByURL(t *testing.T) {
+	e := Default()
+	for _, unit := range []struct {
+		link, provider string
+	}{
+		{"https://www.javbus.com/ABP-123", "JavBus"},
+		{"javbus.com/ABP-123", "JavBus"},
+		{"https://www.dmm.co.jp/digital/videoa/-/detail/=/cid=abp00123/", "FANZA"},
+		{"https://video.dmm.co.jp/av/content/?id=abp00123", "FANZA"},
+	} {
+		provider, err := e.GetMovieProviderByURL(unit.link)
+		if assert.NoError(t, err, unit.link) {
+			assert.Equal(t, unit.provider, provider.Name(), unit.link)
+		}
+	}
+
+	_, err := e.GetMovieProviderByURL("https://example.com/ABP-123")
+	assert.ErrorIs(t, err, mt.ErrProviderNotFound)
+	_, err = e.GetMovieProviderByURL("")
+	require.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"github.com/metatube-community/metatube-sdk-go/webhook"
+)
+
+// notify sends a webhook event if the notifier is configured.
+func (e *Engine) notify(typ webhook.EventType, provider, id string, data any) {
+	if e.notifier == nil {
+		return
+	}
+	e.notifier.Notify(&webhook.Event{
+		Type:     typ,
+		Source:   e.name,
+		Provider: provider,
+		ID:       id,
+		Data:     data,
+	})
+}
+
+// existsInDB reports whether the record of the given model exists in DB.
+func (e *Engine) existsInDB(model any, provider, id string) bool {
+	var count int64
+	e.db.Model(model).
+		Where("provider = ?", provider).
+		Where("id = ? COLLATE NOCASE", id).
+		Count(&count) // ignore error
+	return count > 0
+}
@@ -0,0 +1,65 @@
+package imageutil
+
+import (
+	"image"
+	"image/draw"
+)
+
+// minLandscapeRatio is the min width to height ratio of stills to be
+// cropped into backdrops on their own.
+const minLandscapeRatio = 1.3
+
+// Backdrop generates a backdrop of the ratio from preview stills. The
+// largest landscape still is cropped if any, otherwise stills are put
+// side by side in order, and padded if they aren't wide enough.
+func Backdrop(stills []image.Image, ratio float64) image.Image {
+	if len(stills) == 0 {
+		return nil
+	}
+	var (
+		best image.Image
+		area int
+	)
+	for _, still := range stills {
+		b := still.Bounds()
+		if float64(b.Dx()) >= float64(b.Dy())*minLandscapeRatio && b.Dx()*b.Dy() > area {
+			best, area = still, b.Dx()*b.Dy()
+		}
+	}
+	if best != nil {
+		return CropImagePosition(best, ratio, 0.5)
+	}
+	img := composite(stills, ratio)
+	if b := img.Bounds(); float64(b.Dx()) < float64(b.Dy())*ratio {
+		return Letterbox(img, ratio)
+	}
+	return CropImagePosition(img, ratio, 0.5)
+}
+
+// composite puts the stills side by side at the same height, until
+// they are wide enough for the ratio.
+func composite(stills []image.Image, ratio float64) image.Image {
+	height := stills[0].Bounds().Dy()
+	for _, still := range stills[1:] {
+		height = min(height, still.Bounds().Dy())
+	}
+	var (
+		scaled []image.Image
+		width  int
+	)
+	for _, still := range stills {
+		img := Resize(still, 0, height)
+		scaled = append(scaled, img)
+		if width += img.Bounds().Dx(); float64(width) >= float64(height)*ratio {
+			break
+		}
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	x := 0
+	for _, img := range scaled {
+		b := img.Bounds()
+		draw.Draw(dst, image.Rect(x, 0, x+b.Dx(), height), img, b.Min, draw.Src)
+		x += b.Dx()
+	}
+	return dst
+}
@@ -0,0 +1,36 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackdrop(t *testing.T) {
+	ratio := 16.0 / 9.0
+	portrait := image.NewRGBA(image.Rect(0, 0, 300, 450))
+	small := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	large := image.NewRGBA(image.Rect(0, 0, 800, 450))
+
+	assert.Nil(t, Backdrop(nil, ratio))
+
+	// the largest landscape still is cropped.
+	assert.Equal(t, image.Rect(0, 0, 800, 450), Backdrop([]image.Image{portrait, small, large}, ratio).Bounds())
+	assert.Equal(t, image.Rect(0, 38, 400, 263), Backdrop([]image.Image{portrait, small}, ratio).Bounds())
+
+	// portrait stills are put side by side.
+	red := image.NewRGBA(image.Rect(0, 0, 300, 450))
+	for i := 0; i < len(red.Pix); i += 4 {
+		red.Pix[i], red.Pix[i+3] = 255, 255
+	}
+	img := Backdrop([]image.Image{red, red, red, red}, ratio)
+	assert.InDelta(t, 800, img.Bounds().Dx(), 1)
+	assert.Equal(t, 450, img.Bounds().Dy())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, img.At(400, 200))
+
+	// a single portrait still is padded.
+	img = Backdrop([]image.Image{portrait}, ratio)
+	assert.Equal(t, image.Rect(0, 0, 800, 450), img.Bounds())
+}
@@ -0,0 +1,98 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+)
+
+// CoverFrontRatio is the usual width ratio of the front cover in
+// the landscape scans of Japanese DVD covers, i.e. back, spine and
+// front from left to right.
+const CoverFrontRatio = 0.47
+
+const (
+	// spine boundaries are searched within this range of width.
+	spineSearchMin = 0.40
+	spineSearchMax = 0.62
+	// max width ratio of the spine itself.
+	spineMaxWidth = 0.06
+	// min ratio of an edge score to the mean to be a boundary.
+	spineEdgeFactor = 2.5
+	// samples of rows per column.
+	spineSampleRows = 200
+)
+
+// CropCover crops the front cover out of the DVD cover scan by the
+// boundary of the spine, or by CoverFrontRatio if no clear boundary is
+// found, and then to the ratio. Images that don't look like a cover
+// spread are cropped at the right.
+func CropCover(img image.Image, ratio float64) image.Image {
+	width := img.Bounds().Dx()
+	height := img.Bounds().Dy()
+	if float64(width) < float64(height)*1.2 /* not a spread */ {
+		return CropImagePosition(img, ratio, 1.0)
+	}
+	x := detectSpine(img)
+	if float64(width-x) < float64(height)*ratio {
+		// the front is narrower than the ratio, keep the right side.
+		return CropImagePosition(img, ratio, 1.0)
+	}
+	front := CropImage(img, image.Rect(x, 0, width, height).Add(img.Bounds().Min))
+	return CropImagePosition(front, ratio, 0.5)
+}
+
+// detectSpine returns the x offset where the front cover starts, i.e.
+// the right boundary of the spine.
+func detectSpine(img image.Image) int {
+	var (
+		bounds = img.Bounds()
+		width  = bounds.Dx()
+		lo     = int(float64(width) * spineSearchMin)
+		hi     = int(float64(width) * spineSearchMax)
+		prior  = width - int(float64(width)*CoverFrontRatio)
+	)
+	scores := make([]float64, hi-lo+1)
+	var sum float64
+	for x := lo; x <= hi; x++ {
+		scores[x-lo] = edgeScore(img, bounds.Min.X+x)
+		sum += scores[x-lo]
+	}
+	mean := sum / float64(len(scores))
+
+	best := -1
+	for i, score := range scores {
+		if score > mean*spineEdgeFactor && (best < 0 || score > scores[best]) {
+			best = i
+		}
+	}
+	if best < 0 /* no clear boundary */ {
+		return prior
+	}
+	// the strongest edge may be the left boundary of the spine, take
+	// the right boundary if there is a strong edge close to it.
+	limit := min(best+int(float64(width)*spineMaxWidth), len(scores)-1)
+	for i := limit; i > best; i-- {
+		if scores[i] > scores[best]/2 && scores[i] > mean*spineEdgeFactor {
+			return lo + i
+		}
+	}
+	return lo + best
+}
+
+// edgeScore returns the mean luminance difference between the column
+// x and its left one.
+func edgeScore(img image.Image, x int) float64 {
+	bounds := img.Bounds()
+	step := max(bounds.Dy()/spineSampleRows, 1)
+	var (
+		sum float64
+		n   int
+	)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		l := color.GrayModel.Convert(img.At(x-1, y)).(color.Gray).Y
+		r := color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+		sum += float64(max(l, r) - min(l, r))
+		n++
+	}
+	return sum / float64(n)
+}
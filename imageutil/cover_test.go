@@ -0,0 +1,40 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCropCover(t *testing.T) {
+	fill := func(img draw.Image, rect image.Rectangle, y uint8) {
+		draw.Draw(img, rect, image.NewUniform(color.Gray{Y: y}), image.Point{}, draw.Src)
+	}
+
+	// back, spine and front of a cover spread.
+	cover := image.NewRGBA(image.Rect(0, 0, 1000, 600))
+	fill(cover, image.Rect(0, 0, 500, 600), 100)
+	fill(cover, image.Rect(500, 0, 520, 600), 20)
+	fill(cover, image.Rect(520, 0, 1000, 600), 220)
+	assert.Equal(t, 520, detectSpine(cover))
+	assert.Equal(t, image.Rect(550, 0, 970, 600), CropCover(cover, 0.7).Bounds())
+
+	// no clear boundary, the prior is used.
+	plain := image.NewRGBA(image.Rect(0, 0, 1000, 600))
+	assert.Equal(t, 530, detectSpine(plain))
+
+	// front too narrow for the ratio, the right side is kept.
+	narrow := image.NewRGBA(image.Rect(0, 0, 1000, 600))
+	fill(narrow, image.Rect(0, 0, 590, 600), 100)
+	fill(narrow, image.Rect(590, 0, 610, 600), 20)
+	fill(narrow, image.Rect(610, 0, 1000, 600), 220)
+	assert.Equal(t, 610, detectSpine(narrow))
+	assert.Equal(t, image.Rect(580, 0, 1000, 600), CropCover(narrow, 0.7).Bounds())
+
+	// not a spread.
+	poster := image.NewRGBA(image.Rect(0, 0, 600, 600))
+	assert.Equal(t, image.Rect(180, 0, 600, 600), CropCover(poster, 0.7).Bounds())
+}
@@ -0,0 +1,68 @@
+// Package face abstracts face detectors used to position image crops.
+package face
+
+import (
+	"image"
+	"sort"
+)
+
+// Face is a detected face in image coordinates.
+type Face struct {
+	Rect  image.Rectangle
+	Score float64
+}
+
+// Center returns the center point of the face.
+func (f Face) Center() image.Point {
+	return image.Pt((f.Rect.Min.X+f.Rect.Max.X)/2, (f.Rect.Min.Y+f.Rect.Max.Y)/2)
+}
+
+// Detector detects faces in images.
+type Detector interface {
+	Detect(img image.Image) ([]Face, error)
+}
+
+// Position returns the crop position of the image for the ratio, i.e.
+// the relative position of the most prominent face along the cropped
+// axis, pos is returned as is if no face is detected.
+func Position(d Detector, img image.Image, ratio, pos float64) float64 {
+	faces, err := d.Detect(img)
+	if err != nil || len(faces) == 0 {
+		return pos
+	}
+	sort.SliceStable(faces, func(i, j int) bool {
+		return prominence(faces[i]) > prominence(faces[j])
+	})
+	var (
+		bounds = img.Bounds()
+		center = faces[0].Center().Sub(bounds.Min)
+		width  = bounds.Dx()
+		height = bounds.Dy()
+	)
+	if int(float64(height)*ratio) < width {
+		return float64(center.X) / float64(width)
+	}
+	return float64(center.Y) / float64(height)
+}
+
+// prominence weighs the score of the face by its size.
+func prominence(f Face) float64 {
+	return float64(f.Rect.Dx()) * f.Score
+}
+
+// Fallback returns a detector that falls back to the next detectors
+// in order if the previous one fails.
+func Fallback(detectors ...Detector) Detector {
+	return fallback(detectors)
+}
+
+type fallback []Detector
+
+func (ds fallback) Detect(img image.Image) (faces []Face, err error) {
+	for _, d := range ds {
+		if faces, err = d.Detect(img); err == nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,54 @@
+package face
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type detectorFunc func(img image.Image) ([]Face, error)
+
+func (f detectorFunc) Detect(img image.Image) ([]Face, error) { return f(img) }
+
+func TestPosition(t *testing.T) {
+	faces := detectorFunc(func(image.Image) ([]Face, error) {
+		return []Face{
+			{Rect: image.Rect(10, 10, 20, 20), Score: 1},
+			{Rect: image.Rect(60, 40, 100, 80), Score: 0.8},
+		}, nil
+	})
+	none := detectorFunc(func(image.Image) ([]Face, error) { return nil, nil })
+
+	for _, unit := range []struct {
+		d     Detector
+		img   image.Image
+		ratio float64
+		want  float64
+	}{
+		// landscape cropped horizontally.
+		{faces, image.NewRGBA(image.Rect(0, 0, 200, 100)), 0.7, 0.4},
+		// portrait cropped vertically.
+		{faces, image.NewRGBA(image.Rect(0, 0, 100, 200)), 3, 0.3},
+		{none, image.NewRGBA(image.Rect(0, 0, 200, 100)), 0.7, 0.5},
+	} {
+		assert.InDelta(t, unit.want, Position(unit.d, unit.img, unit.ratio, 0.5), 1e-9)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	failed := detectorFunc(func(image.Image) ([]Face, error) {
+		return nil, errors.New("unavailable")
+	})
+	ok := detectorFunc(func(image.Image) ([]Face, error) {
+		return []Face{{Rect: image.Rect(0, 0, 1, 1), Score: 1}}, nil
+	})
+
+	faces, err := Fallback(failed, ok).Detect(image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	assert.NoError(t, err)
+	assert.Len(t, faces, 1)
+
+	_, err = Fallback(failed).Detect(image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Package remote implements a face detector backed by an external
+// detection service over HTTP, e.g. a YOLO or ONNX runtime.
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"net/http"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face"
+)
+
+// DefaultTimeout is the default timeout of detection requests.
+const DefaultTimeout = 10 * time.Second
+
+// Detector posts images as JPEG to the URL of the service, which must
+// respond with the faces in JSON:
+//
+//	{"faces": [{"x": 10, "y": 20, "width": 30, "height": 30, "score": 0.9}]}
+//
+// where coordinates are in pixels of the posted image.
+type Detector struct {
+	URL    string
+	Client *http.Client
+}
+
+// New returns a detector of the service at url.
+func New(url string) *Detector {
+	return &Detector{
+		URL:    url,
+		Client: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+type response struct {
+	Faces []struct {
+		X      int     `json:"x"`
+		Y      int     `json:"y"`
+		Width  int     `json:"width"`
+		Height int     `json:"height"`
+		Score  float64 `json:"score"`
+	} `json:"faces"`
+}
+
+func (d *Detector) Detect(img image.Image) ([]face.Face, error) {
+	buf := &bytes.Buffer{}
+	if err := imageutil.EncodeToJPEG(buf, img, 90); err != nil {
+		return nil, err
+	}
+	resp, err := d.Client.Post(d.URL, "image/jpeg", buf)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("face detector: %s", resp.Status)
+	}
+
+	r := &response{}
+	if err = json.NewDecoder(resp.Body).Decode(r); err != nil {
+		return nil, err
+	}
+	faces := make([]face.Face, 0, len(r.Faces))
+	for _, f := range r.Faces {
+		faces = append(faces, face.Face{
+			Rect:  image.Rect(f.X, f.Y, f.X+f.Width, f.Y+f.Height).Add(img.Bounds().Min),
+			Score: f.Score,
+		})
+	}
+	return faces, nil
+}
+
+var _ face.Detector = (*Detector)(nil)
@@ -0,0 +1,36 @@
+package remote
+
+import (
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "image/jpeg" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"faces":[{"x":10,"y":20,"width":30,"height":40,"score":0.9}]}`))
+	}))
+	defer srv.Close()
+
+	faces, err := New(srv.URL).Detect(image.NewRGBA(image.Rect(5, 5, 105, 105)))
+	require.NoError(t, err)
+	require.Len(t, faces, 1)
+	assert.Equal(t, image.Rect(15, 25, 45, 65), faces[0].Rect)
+	assert.Equal(t, 0.9, faces[0].Score)
+
+	failed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer failed.Close()
+
+	_, err = New(failed.URL).Detect(image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	assert.Error(t, err)
+}
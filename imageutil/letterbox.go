@@ -0,0 +1,41 @@
+package imageutil
+
+import (
+	"image"
+	"image/draw"
+)
+
+// letterboxBlur is the downscale factor of the blurred background.
+const letterboxBlur = 24
+
+// Letterbox pads the image to the ratio instead of cropping it, the
+// padding is filled with a blurred and enlarged copy of the image.
+func Letterbox(img image.Image, ratio float64) image.Image {
+	if ratio < minRatio || ratio > maxRatio {
+		return img // no padding
+	}
+	var (
+		bounds = img.Bounds()
+		width  = bounds.Dx()
+		height = bounds.Dy()
+		w, h   = width, height
+	)
+	if float64(width)/float64(height) > ratio {
+		h = int(float64(width) / ratio)
+	} else {
+		w = int(float64(height) * ratio)
+	}
+	if w == width && h == height {
+		return img
+	}
+
+	// blur by scaling the filled background down and up again.
+	bg := CropImagePosition(img, ratio, 0.5)
+	bg = Resize(Resize(bg, max(w/letterboxBlur, 1), max(h/letterboxBlur, 1)), w, h)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), bg, bg.Bounds().Min, draw.Src)
+	offset := image.Pt((w-width)/2, (h-height)/2)
+	draw.Draw(dst, image.Rect(0, 0, width, height).Add(offset), img, bounds.Min, draw.Src)
+	return dst
+}
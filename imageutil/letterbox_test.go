@@ -0,0 +1,33 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLetterbox(t *testing.T) {
+	src := image.NewRGBA(image.Rect(10, 10, 410, 310))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+
+	for _, unit := range []struct {
+		ratio float64
+		want  image.Rectangle
+		at    image.Point
+	}{
+		// landscape padded vertically.
+		{2.0 / 3.0, image.Rect(0, 0, 400, 600), image.Pt(200, 300)},
+		// padded horizontally.
+		{2.0, image.Rect(0, 0, 600, 300), image.Pt(300, 150)},
+	} {
+		img := Letterbox(src, unit.ratio)
+		assert.Equal(t, unit.want, img.Bounds())
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(img.At(unit.at.X, unit.at.Y)))
+	}
+
+	// same ratio, not padded.
+	assert.Same(t, src, Letterbox(src, 4.0/3.0))
+}
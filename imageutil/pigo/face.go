@@ -2,9 +2,10 @@ package pigo
 
 import (
 	"image"
-	"sort"
 
 	pigo "github.com/esimov/pigo/core"
+
+	"github.com/metatube-community/metatube-sdk-go/imageutil/face"
 )
 
 var classifier *pigo.Pigo
@@ -36,20 +37,23 @@ func DetectFaces(img image.Image) (dets []pigo.Detection) {
 	return
 }
 
-func CalculatePosition(img image.Image, ratio float64, pos float64) float64 {
-	if dets := DetectFaces(img); len(dets) > 0 {
-		sort.SliceStable(dets, func(i, j int) bool {
-			return float32(dets[i].Scale)*dets[i].Q > float32(dets[j].Scale)*dets[j].Q
+// Detector is the built-in face.Detector with the pigo classifier.
+type Detector struct{}
+
+func (Detector) Detect(img image.Image) ([]face.Face, error) {
+	dets := DetectFaces(img)
+	faces := make([]face.Face, 0, len(dets))
+	for _, det := range dets {
+		half := det.Scale / 2
+		faces = append(faces, face.Face{
+			Rect: image.Rect(det.Col-half, det.Row-half, det.Col+half, det.Row+half).
+				Add(img.Bounds().Min),
+			Score: float64(det.Q),
 		})
-		var (
-			width  = img.Bounds().Dx()
-			height = img.Bounds().Dy()
-		)
-		if int(float64(height)*ratio) < width {
-			pos = float64(dets[0].Col) / float64(width)
-		} else {
-			pos = float64(dets[0].Row) / float64(height)
-		}
 	}
-	return pos
+	return faces, nil
+}
+
+func CalculatePosition(img image.Image, ratio float64, pos float64) float64 {
+	return face.Position(Detector{}, img, ratio, pos)
 }
@@ -0,0 +1,261 @@
+// Package pipeline chains image transforms, e.g. crop, resize, sharpen
+// and badge, and encodes the result in the requested format.
+//
+// The compact syntax separates steps with '|', and parameters of each
+// step with ',' after its op and ':', e.g.
+//
+//	crop:ratio=0.7,pos=1|resize:width=400|sharpen:amount=0.5|format:type=png
+//
+// which is the same as the JSON form:
+//
+//	[{"op":"crop","ratio":0.7,"pos":1},{"op":"resize","width":400},
+//	 {"op":"sharpen","amount":0.5},{"op":"format","type":"png"}]
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/badge"
+)
+
+// Limits of pipelines.
+const (
+	MaxSteps     = 16
+	MaxDimension = 4096
+)
+
+// Output formats.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// DefaultQuality is the default JPEG quality.
+const DefaultQuality = 90
+
+// Step is a transform of the pipeline with its parameters.
+type Step struct {
+	Op     string
+	Params map[string]string
+}
+
+// UnmarshalJSON decodes the step from an object of the op and its
+// parameters, e.g. {"op":"resize","width":400}.
+func (s *Step) UnmarshalJSON(data []byte) error {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	op, _ := m["op"].(string)
+	if op == "" {
+		return fmt.Errorf("pipeline: missing op")
+	}
+	delete(m, "op")
+	s.Op, s.Params = op, make(map[string]string, len(m))
+	for key, value := range m {
+		s.Params[key] = fmt.Sprint(value)
+	}
+	return nil
+}
+
+// Pipeline is the compiled steps, see Parse and ParseJSON.
+type Pipeline struct {
+	transforms []transform
+	format     string
+	quality    int
+}
+
+type transform func(img image.Image) (image.Image, error)
+
+// Parse compiles the pipeline of the compact syntax.
+func Parse(s string) (*Pipeline, error) {
+	var steps []Step
+	for _, part := range strings.Split(s, "|") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		op, rest, _ := strings.Cut(part, ":")
+		step := Step{Op: strings.TrimSpace(op), Params: make(map[string]string)}
+		for _, param := range strings.Split(rest, ",") {
+			if param = strings.TrimSpace(param); param == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(param, "=")
+			if !ok {
+				return nil, fmt.Errorf("pipeline: invalid parameter of %s: %s", step.Op, param)
+			}
+			step.Params[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		steps = append(steps, step)
+	}
+	return Compile(steps)
+}
+
+// ParseJSON compiles the pipeline of the JSON array of steps.
+func ParseJSON(data []byte) (*Pipeline, error) {
+	var steps []Step
+	if err := json.Unmarshal(data, &steps); err != nil {
+		return nil, fmt.Errorf("pipeline: %w", err)
+	}
+	return Compile(steps)
+}
+
+// Compile validates the steps and compiles them into a pipeline, the
+// format step, if any, must be the last one.
+func Compile(steps []Step) (*Pipeline, error) {
+	if len(steps) > MaxSteps {
+		return nil, fmt.Errorf("pipeline: too many steps: %d", len(steps))
+	}
+	p := &Pipeline{format: FormatJPEG, quality: DefaultQuality}
+	for i, step := range steps {
+		params := &params{op: step.Op, m: step.Params}
+		var t transform
+		switch step.Op {
+		case "crop":
+			ratio, pos := params.float("ratio", 0), params.float("pos", 0.5)
+			if params.err == nil && ratio <= 0 {
+				params.err = fmt.Errorf("pipeline: crop: invalid ratio")
+			}
+			t = func(img image.Image) (image.Image, error) {
+				return imageutil.CropImagePosition(img, ratio, pos), nil
+			}
+		case "letterbox":
+			ratio := params.float("ratio", 0)
+			if params.err == nil && ratio <= 0 {
+				params.err = fmt.Errorf("pipeline: letterbox: invalid ratio")
+			}
+			t = func(img image.Image) (image.Image, error) {
+				return imageutil.Letterbox(img, ratio), nil
+			}
+		case "resize":
+			width, height := params.int("width", 0), params.int("height", 0)
+			if params.err == nil && (width < 0 || height < 0 ||
+				width > MaxDimension || height > MaxDimension || width+height == 0) {
+				params.err = fmt.Errorf("pipeline: resize: invalid size")
+			}
+			t = func(img image.Image) (image.Image, error) {
+				return imageutil.Resize(img, width, height), nil
+			}
+		case "sharpen":
+			amount := params.float("amount", 1)
+			if params.err == nil && (amount <= 0 || amount > 10) {
+				params.err = fmt.Errorf("pipeline: sharpen: invalid amount")
+			}
+			t = func(img image.Image) (image.Image, error) {
+				return sharpen(img, amount), nil
+			}
+		case "badge":
+			url := params.string("url")
+			if params.err == nil && url == "" {
+				params.err = fmt.Errorf("pipeline: badge: missing url")
+			}
+			t = func(img image.Image) (image.Image, error) {
+				return badge.Badge(img, url)
+			}
+		case "format":
+			if i != len(steps)-1 {
+				return nil, fmt.Errorf("pipeline: format must be the last step")
+			}
+			p.format = params.string("type")
+			p.quality = params.int("quality", DefaultQuality)
+			if params.err == nil && p.format != FormatJPEG && p.format != FormatPNG {
+				params.err = fmt.Errorf("pipeline: format: unsupported type: %s", p.format)
+			}
+			if params.err == nil && (p.quality < 1 || p.quality > 100) {
+				params.err = fmt.Errorf("pipeline: format: invalid quality")
+			}
+		default:
+			return nil, fmt.Errorf("pipeline: unknown op: %s", step.Op)
+		}
+		if err := params.done(); err != nil {
+			return nil, err
+		}
+		if t != nil {
+			p.transforms = append(p.transforms, t)
+		}
+	}
+	return p, nil
+}
+
+// Apply runs the transforms of the pipeline on the image in order.
+func (p *Pipeline) Apply(img image.Image) (_ image.Image, err error) {
+	for _, t := range p.transforms {
+		if img, err = t(img); err != nil {
+			return nil, err
+		}
+	}
+	return img, nil
+}
+
+// ContentType returns the MIME type of the output format.
+func (p *Pipeline) ContentType() string {
+	return "image/" + p.format
+}
+
+// Encode encodes the image in the output format, JPEG by default.
+func (p *Pipeline) Encode(w io.Writer, img image.Image) error {
+	if p.format == FormatPNG {
+		return png.Encode(w, img)
+	}
+	return imageutil.EncodeToJPEG(w, img, p.quality)
+}
+
+// params parses the parameters of a step, the first error is kept
+// and unknown parameters are reported by done.
+type params struct {
+	op   string
+	m    map[string]string
+	used map[string]bool
+	err  error
+}
+
+func (p *params) string(key string) string {
+	if p.used == nil {
+		p.used = make(map[string]bool)
+	}
+	p.used[key] = true
+	return p.m[key]
+}
+
+func (p *params) float(key string, def float64) float64 {
+	s := p.string(key)
+	if s == "" {
+		return def
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("pipeline: %s: invalid %s: %s", p.op, key, s)
+	}
+	return v
+}
+
+func (p *params) int(key string, def int) int {
+	s := p.string(key)
+	if s == "" {
+		return def
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("pipeline: %s: invalid %s: %s", p.op, key, s)
+	}
+	return v
+}
+
+func (p *params) done() error {
+	if p.err != nil {
+		return p.err
+	}
+	for key := range p.m {
+		if !p.used[key] {
+			return fmt.Errorf("pipeline: %s: unknown parameter: %s", p.op, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 538))
+
+	p, err := Parse("crop:ratio=0.7,pos=1 | resize:width=200 | sharpen | format:type=png")
+	require.NoError(t, err)
+	img, err := p.Apply(src)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 200, 286), img.Bounds())
+	assert.Equal(t, "image/png", p.ContentType())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, p.Encode(buf, img))
+	_, err = png.Decode(buf)
+	assert.NoError(t, err)
+
+	p, err = Parse("")
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", p.ContentType())
+
+	for _, s := range []string{
+		"blur",
+		"crop",
+		"crop:ratio=wide",
+		"crop:ratio",
+		"crop:ratio=0.7,size=1",
+		"resize:width=10000",
+		"resize",
+		"format:type=gif",
+		"format:type=jpeg,quality=0",
+		"format:type=png|resize:width=1",
+		"badge",
+	} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	p, err := ParseJSON([]byte(`[{"op":"letterbox","ratio":0.5},{"op":"resize","height":100},{"op":"format","type":"jpeg","quality":80}]`))
+	require.NoError(t, err)
+	img, err := p.Apply(image.NewRGBA(image.Rect(0, 0, 100, 100)))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 50, 100), img.Bounds())
+	assert.Equal(t, 80, p.quality)
+
+	_, err = ParseJSON([]byte(`[{"ratio":0.5}]`))
+	assert.Error(t, err)
+	_, err = ParseJSON([]byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestSharpen(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 3, 3))
+	for i := range src.Pix {
+		src.Pix[i] = 100
+	}
+	src.SetGray(1, 1, color.Gray{Y: 150})
+
+	img := sharpen(src, 1)
+	assert.Equal(t, color.RGBA{R: 200, G: 200, B: 200, A: 255}, img.At(1, 1))
+	assert.Equal(t, color.RGBA{R: 87, G: 87, B: 87, A: 255}, img.At(1, 0))
+}
@@ -0,0 +1,33 @@
+package pipeline
+
+import (
+	"image"
+	"image/draw"
+)
+
+// sharpen applies an unsharp mask of the 4-neighbourhood mean, i.e.
+// each pixel is pushed away from the mean of its neighbours.
+func sharpen(img image.Image, amount float64) image.Image {
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	dst := image.NewRGBA(src.Bounds())
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	at := func(x, y, c int) float64 {
+		x, y = min(max(x, 0), width-1), min(max(y, 0), height-1)
+		return float64(src.Pix[y*src.Stride+x*4+c])
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*src.Stride + x*4
+			for c := 0; c < 3; c++ {
+				v := at(x, y, c)
+				mean := (at(x-1, y, c) + at(x+1, y, c) + at(x, y-1, c) + at(x, y+1, c)) / 4
+				dst.Pix[i+c] = uint8(min(max(v+amount*(v-mean), 0), 255))
+			}
+			dst.Pix[i+3] = src.Pix[i+3]
+		}
+	}
+	return dst
+}
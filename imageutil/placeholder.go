@@ -0,0 +1,59 @@
+package imageutil
+
+import (
+	"image"
+	"sync"
+
+	"github.com/corona10/goimagehash"
+)
+
+// minArtworkSize is the min width and height of real artwork, smaller
+// images are tracking pixels or broken placeholders.
+const minArtworkSize = 64
+
+var (
+	placeholderMu     sync.RWMutex
+	placeholderHashes []*goimagehash.ImageHash
+)
+
+// RegisterPlaceholder registers the perception hash of a known
+// placeholder image, e.g. a "now printing" cover.
+func RegisterPlaceholder(hash uint64) {
+	placeholderMu.Lock()
+	defer placeholderMu.Unlock()
+	placeholderHashes = append(placeholderHashes,
+		goimagehash.NewImageHash(hash, goimagehash.PHash))
+}
+
+// PlaceholderHash returns the perception hash of the image, to be
+// registered as a placeholder.
+func PlaceholderHash(img image.Image) uint64 {
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return 0
+	}
+	return hash.GetHash()
+}
+
+// IsPlaceholder reports whether the image is too small to be real
+// artwork, or similar to any registered placeholder image.
+func IsPlaceholder(img image.Image) bool {
+	if b := img.Bounds(); b.Dx() < minArtworkSize || b.Dy() < minArtworkSize {
+		return true
+	}
+	placeholderMu.RLock()
+	defer placeholderMu.RUnlock()
+	if len(placeholderHashes) == 0 {
+		return false
+	}
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return false
+	}
+	for _, placeholder := range placeholderHashes {
+		if distance, err := hash.Distance(placeholder); err == nil && distance < thPerceptionHash {
+			return true
+		}
+	}
+	return false
+}
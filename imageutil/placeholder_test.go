@@ -0,0 +1,33 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPlaceholder(t *testing.T) {
+	assert.True(t, IsPlaceholder(image.NewRGBA(image.Rect(0, 0, 1, 1))))
+
+	stripes := image.NewRGBA(image.Rect(0, 0, 200, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 200; x++ {
+			if (x/20)%2 == 0 {
+				stripes.Set(x, y, color.White)
+			}
+		}
+	}
+	gradient := image.NewGray(image.Rect(0, 0, 200, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 200; x++ {
+			gradient.SetGray(x, y, color.Gray{Y: uint8(y * 255 / 300)})
+		}
+	}
+	assert.False(t, IsPlaceholder(stripes))
+
+	RegisterPlaceholder(PlaceholderHash(stripes))
+	assert.True(t, IsPlaceholder(stripes))
+	assert.False(t, IsPlaceholder(gradient))
+}
@@ -0,0 +1,56 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+// ScanJob is the job type of scanning a directory in the background.
+const ScanJob = "library_scan"
+
+type scanJobParams struct {
+	Path      string `json:"path"`
+	Overwrite bool   `json:"overwrite"`
+	NoArtwork bool   `json:"no_artwork"`
+}
+
+type scanJobResult struct {
+	Scanned int               `json:"scanned"`
+	Failed  map[string]string `json:"failed,omitempty"`
+}
+
+// ScanJobHandler returns the handler of scan jobs, so that batch scans
+// can be submitted to the job queue of the engine. Paths outside the
+// roots are rejected, see ResolveInRoots.
+func ScanJobHandler(app *engine.Engine, roots []string) engine.JobHandler {
+	return func(ctx context.Context, params json.RawMessage) (any, error) {
+		p := &scanJobParams{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		path, err := ResolveInRoots(p.Path, roots)
+		if err != nil {
+			return nil, err
+		}
+		videos, err := FindVideos(path)
+		if err != nil {
+			return nil, err
+		}
+		s := NewScanner(app.WithContext(ctx))
+		s.Overwrite, s.NoArtwork = p.Overwrite, p.NoArtwork
+
+		result := &scanJobResult{Failed: make(map[string]string)}
+		for _, video := range videos {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+			if r := s.Scan(video); r.Error != nil {
+				result.Failed[r.Path] = r.Error.Error()
+			}
+			result.Scanned++
+		}
+		return result, nil
+	}
+}
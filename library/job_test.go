@@ -0,0 +1,38 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+func TestScanJobHandler_OutsideRoots(t *testing.T) {
+	root, outside := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "abp123.mp4"), nil, 0o644))
+
+	for _, roots := range [][]string{nil, {root}} {
+		h := ScanJobHandler(engine.Default(), roots)
+		params, err := json.Marshal(&scanJobParams{Path: outside})
+		require.NoError(t, err)
+		_, err = h(context.Background(), params)
+		assert.ErrorIs(t, err, ErrOutsideRoots)
+	}
+	// nothing is written next to the video.
+	entries, err := os.ReadDir(outside)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	h := ScanJobHandler(engine.Default(), []string{root})
+	params, err := json.Marshal(&scanJobParams{Path: root})
+	require.NoError(t, err)
+	result, err := h(context.Background(), params)
+	require.NoError(t, err)
+	assert.Zero(t, result.(*scanJobResult).Scanned)
+}
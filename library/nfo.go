@@ -0,0 +1,133 @@
+package library
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/common/romaji"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// NFOUniqueIDType is the uniqueid type written into NFO files.
+const NFOUniqueIDType = "metatube"
+
+// NFO is a Kodi compatible movie NFO document.
+type NFO struct {
+	XMLName       xml.Name      `xml:"movie"`
+	Title         string        `xml:"title"`
+	OriginalTitle string        `xml:"originaltitle,omitempty"`
+	SortTitle     string        `xml:"sorttitle,omitempty"`
+	Plot          string        `xml:"plot,omitempty"`
+	Runtime       int           `xml:"runtime,omitempty"`
+	Premiered     string        `xml:"premiered,omitempty"`
+	Year          int           `xml:"year,omitempty"`
+	Rating        float64       `xml:"rating,omitempty"`
+	Studio        string        `xml:"studio,omitempty"`
+	Label         string        `xml:"label,omitempty"`
+	Director      string        `xml:"director,omitempty"`
+	Set           *nfoSet       `xml:"set,omitempty"`
+	Genres        []string      `xml:"genre"`
+	Actors        []nfoActor    `xml:"actor"`
+	UniqueID      []nfoUniqueID `xml:"uniqueid"`
+	Thumb         []nfoThumb    `xml:"thumb"`
+	Fanart        *nfoFanart    `xml:"fanart,omitempty"`
+	Website       string        `xml:"website,omitempty"`
+}
+
+type nfoSet struct {
+	Name string `xml:"name"`
+}
+
+type nfoActor struct {
+	Name  string `xml:"name"`
+	Role  string `xml:"role,omitempty"`
+	Order int    `xml:"order"`
+}
+
+type nfoUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+type nfoThumb struct {
+	Aspect string `xml:"aspect,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+type nfoFanart struct {
+	Thumb []nfoThumb `xml:"thumb"`
+}
+
+// NewNFO converts the movie info into NFO.
+func NewNFO(info *model.MovieInfo) *NFO {
+	nfo := &NFO{
+		Title:         info.Number + " " + info.Title,
+		OriginalTitle: info.Title,
+		SortTitle:     info.Number,
+		Plot:          info.Summary,
+		Runtime:       info.Runtime,
+		Rating:        info.Score * 2, /* 5-star to 10-point scale */
+		Studio:        info.Maker,
+		Label:         info.Label,
+		Director:      info.Director,
+		Genres:        info.Genres,
+		Website:       info.Homepage,
+		UniqueID: []nfoUniqueID{{
+			Type:    NFOUniqueIDType,
+			Default: true,
+			Value:   info.Provider + ":" + info.ID,
+		}},
+	}
+	if t := time.Time(info.ReleaseDate); !t.IsZero() {
+		nfo.Premiered = t.Format(time.DateOnly)
+		nfo.Year = t.Year()
+	}
+	if info.Series != "" {
+		nfo.Set = &nfoSet{Name: info.Series}
+	}
+	for i, actor := range info.Actors {
+		nfo.Actors = append(nfo.Actors, nfoActor{Name: actor, Order: i})
+	}
+	if thumb := first(info.BigThumbURL, info.ThumbURL); thumb != "" {
+		nfo.Thumb = append(nfo.Thumb, nfoThumb{Aspect: "poster", Value: thumb})
+	}
+	if cover := first(info.BigCoverURL, info.CoverURL); cover != "" {
+		nfo.Fanart = &nfoFanart{Thumb: []nfoThumb{{Value: cover}}}
+	}
+	return nfo
+}
+
+// RomanizeActors writes actor names in romaji, names that cannot
+// be romanized are kept as is.
+func (nfo *NFO) RomanizeActors() {
+	for i, actor := range nfo.Actors {
+		if name, ok := romaji.Romanize(actor.Name); ok {
+			nfo.Actors[i].Name = name
+		}
+	}
+}
+
+// Encode writes the NFO as an indented XML document.
+func (nfo *NFO) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(nfo); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func first(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
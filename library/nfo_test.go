@@ -0,0 +1,74 @@
+package library
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestNFO_Encode(t *testing.T) {
+	info := &model.MovieInfo{
+		ID:          "abp00123",
+		Number:      "ABP-123",
+		Title:       "Title & More",
+		Summary:     "Summary",
+		Provider:    "FANZA",
+		Homepage:    "https://example.com/abp00123",
+		Director:    "Director",
+		Actors:      []string{"Actor A", "Actor B"},
+		ThumbURL:    "https://example.com/thumb.jpg",
+		CoverURL:    "https://example.com/cover.jpg",
+		Maker:       "Maker",
+		Series:      "Series",
+		Genres:      []string{"Genre"},
+		Score:       4.5,
+		Runtime:     120,
+		ReleaseDate: datatypes.Date(time.Date(2014, 1, 2, 0, 0, 0, 0, time.UTC)),
+	}
+
+	buf := &bytes.Buffer{}
+	if assert.NoError(t, NewNFO(info).Encode(buf)) {
+		s := buf.String()
+		for _, want := range []string{
+			`<title>ABP-123 Title &amp; More</title>`,
+			`<premiered>2014-01-02</premiered>`,
+			`<year>2014</year>`,
+			`<rating>9</rating>`,
+			`<set>`,
+			`<name>Actor B</name>`,
+			`<uniqueid type="metatube" default="true">FANZA:abp00123</uniqueid>`,
+			`<thumb aspect="poster">https://example.com/thumb.jpg</thumb>`,
+		} {
+			assert.Contains(t, s, want)
+		}
+	}
+}
+
+func TestNFO_RomanizeActors(t *testing.T) {
+	nfo := NewNFO(&model.MovieInfo{Actors: []string{"三上悠亜", "あいだ ゆあ", "本田瞳"}})
+	nfo.RomanizeActors()
+	var names []string
+	for _, actor := range nfo.Actors {
+		names = append(names, actor.Name)
+	}
+	assert.Equal(t, []string{"Yua Mikami", "Aida Yua", "本田瞳"}, names)
+}
+
+func TestIsVideo(t *testing.T) {
+	for _, unit := range []struct {
+		path string
+		want bool
+	}{
+		{"/a/ABP-123.mp4", true},
+		{"/a/ABP-123.MKV", true},
+		{"/a/ABP-123.nfo", false},
+		{"/a/ABP-123", false},
+	} {
+		assert.Equal(t, unit.want, IsVideo(unit.path), unit.path)
+	}
+}
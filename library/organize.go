@@ -0,0 +1,194 @@
+package library
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// DefaultMaxNameLength is the default max length in bytes of each path
+// element, most filesystems limit filenames to 255 bytes.
+const DefaultMaxNameLength = 240
+
+// CollisionPolicy decides what to do if the target file already exists.
+type CollisionPolicy string
+
+const (
+	CollisionSkip      CollisionPolicy = "skip"
+	CollisionRename    CollisionPolicy = "rename"
+	CollisionOverwrite CollisionPolicy = "overwrite"
+)
+
+// ParseCollisionPolicy parses the policy from string.
+func ParseCollisionPolicy(s string) (CollisionPolicy, error) {
+	switch p := CollisionPolicy(strings.ToLower(s)); p {
+	case CollisionSkip, CollisionRename, CollisionOverwrite:
+		return p, nil
+	case "":
+		return CollisionSkip, nil
+	default:
+		return "", fmt.Errorf("invalid collision policy: %s", s)
+	}
+}
+
+// ErrCollision is returned if the target exists and the policy is skip.
+var ErrCollision = errors.New("target file already exists")
+
+// artworkSuffixes are the suffixes of the sidecar artworks, see Scanner.
+var artworkSuffixes = []string{"-poster.jpg", "-thumb.jpg", "-fanart.jpg"}
+
+// Move is a single planned or performed file move.
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Organizer renames and moves media files with a template.
+type Organizer struct {
+	// Template renders the target path relative to Root without
+	// extension, "/" can be used to create subdirectories.
+	Template *template.Template
+
+	// Root is the destination directory, the directory of
+	// the source file is used if empty.
+	Root string
+
+	// DryRun plans moves without touching any file.
+	DryRun bool
+
+	// Collision is the policy when the target exists.
+	Collision CollisionPolicy
+
+	// MaxNameLength is the max length in bytes of each path element.
+	MaxNameLength int
+}
+
+func NewOrganizer(tmpl *template.Template) *Organizer {
+	return &Organizer{
+		Template:      tmpl,
+		Collision:     CollisionSkip,
+		MaxNameLength: DefaultMaxNameLength,
+	}
+}
+
+// Organize moves the video file and its sidecar files (files sharing the
+// same base name, e.g. NFO, artworks and subtitles) to the target path.
+// The first move is always the video itself. Nothing is moved if any
+// target exists, unless the policy is CollisionOverwrite.
+func (o *Organizer) Organize(path string, info *model.MovieInfo) ([]*Move, error) {
+	target, err := o.Target(path, info)
+	if err != nil {
+		return nil, err
+	}
+	if target == path {
+		return []*Move{{From: path, To: path}}, nil
+	}
+
+	oldBase := strings.TrimSuffix(path, filepath.Ext(path))
+	newBase := strings.TrimSuffix(target, filepath.Ext(target))
+	moves := []*Move{{From: path, To: target}}
+	sidecars, _ := filepath.Glob(globEscape(oldBase) + "*")
+	for _, sidecar := range sidecars {
+		if sidecar == path || IsVideo(sidecar) {
+			continue
+		}
+		// other files with the same prefix, e.g. other parts of the movie,
+		// are not sidecars.
+		if suffix := sidecar[len(oldBase):]; strings.HasPrefix(suffix, ".") || slices.Contains(artworkSuffixes, suffix) {
+			moves = append(moves, &Move{From: sidecar, To: newBase + suffix})
+		}
+	}
+	// never overwrite existing files unless allowed by the policy, the
+	// video target is checked too as it may be created meanwhile.
+	if o.Collision != CollisionOverwrite {
+		for _, move := range moves {
+			if exists(move.To) {
+				return nil, fmt.Errorf("%w: %s", ErrCollision, move.To)
+			}
+		}
+	}
+
+	if o.DryRun {
+		return moves, nil
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return nil, err
+	}
+	for i, move := range moves {
+		if err = os.Rename(move.From, move.To); err != nil {
+			return moves[:i], err
+		}
+	}
+	return moves, nil
+}
+
+// Target returns the target path of the video file, collisions
+// are resolved according to the policy. Parts of a multi-part movie
+// are suffixed with -cdN, so that media servers stack them.
+func (o *Organizer) Target(path string, info *model.MovieInfo) (string, error) {
+	name, err := RenderName(o.Template, info)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("empty name rendered for %s", path)
+	}
+	var suffix string
+	if _, part := number.ParsePart(filepath.Base(path)); part > 0 {
+		suffix = fmt.Sprintf("-cd%d", part)
+	}
+
+	ext := filepath.Ext(path)
+	maxLength := o.MaxNameLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxNameLength
+	}
+	// reserve space for ext and suffixes of the last element.
+	reserved := len(ext) + len(suffix) + len(" (99)")
+	if maxLength <= reserved {
+		return "", fmt.Errorf("max name length %d is too short for %s", maxLength, path)
+	}
+	elems := strings.Split(name, "/")
+	for i := range elems {
+		if i == len(elems)-1 {
+			elems[i] = truncateName(elems[i], maxLength-reserved) + suffix
+			continue
+		}
+		elems[i] = truncateName(elems[i], maxLength)
+	}
+
+	root := o.Root
+	if root == "" {
+		root = filepath.Dir(path)
+	}
+	target := filepath.Join(root, filepath.Join(elems...)) + ext
+	if target == path || !exists(target) {
+		return target, nil
+	}
+
+	switch o.Collision {
+	case CollisionOverwrite:
+		return target, nil
+	case CollisionRename:
+		base := strings.TrimSuffix(target, ext)
+		for i := 1; i < 100; i++ {
+			if candidate := fmt.Sprintf("%s (%d)%s", base, i, ext); candidate == path || !exists(candidate) {
+				return candidate, nil
+			}
+		}
+		fallthrough
+	default:
+		return "", fmt.Errorf("%w: %s", ErrCollision, target)
+	}
+}
+
+func globEscape(s string) string {
+	return strings.NewReplacer(`[`, `\[`, `]`, `\]`, `*`, `\*`, `?`, `\?`).Replace(s)
+}
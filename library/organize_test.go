@@ -0,0 +1,176 @@
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestOrganizer_Organize(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"abp123.mp4", "abp123.nfo", "abp123-poster.jpg", "abp1234.mp4", "abp123-cd2.nfo"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	tmpl, err := ParseNameTemplate("{{.Maker}}/{{.Number}} {{.Title}} [{{.Actors}}]")
+	require.NoError(t, err)
+	info := &model.MovieInfo{
+		Number: "ABP-123",
+		Title:  "A/B: Title?",
+		Maker:  "Maker",
+		Actors: []string{"Actor A", "Actor B"},
+	}
+
+	o := NewOrganizer(tmpl)
+	o.Root = filepath.Join(dir, "out")
+	o.DryRun = true
+	moves, err := o.Organize(filepath.Join(dir, "abp123.mp4"), info)
+	require.NoError(t, err)
+	require.Len(t, moves, 3)
+	want := filepath.Join(dir, "out", "Maker", "ABP-123 A_B_ Title_ [Actor A, Actor B].mp4")
+	assert.Equal(t, want, moves[0].To)
+	assert.FileExists(t, filepath.Join(dir, "abp123.mp4")) // dry-run.
+
+	o.DryRun = false
+	moves, err = o.Organize(filepath.Join(dir, "abp123.mp4"), info)
+	require.NoError(t, err)
+	for _, move := range moves {
+		assert.FileExists(t, move.To)
+		assert.NoFileExists(t, move.From)
+	}
+	assert.FileExists(t, filepath.Join(dir, "abp1234.mp4"))    // untouched.
+	assert.FileExists(t, filepath.Join(dir, "abp123-cd2.nfo")) // other part.
+}
+
+func TestOrganizer_SidecarCollision(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.mp4", "a.nfo", "ABP-123.nfo"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644))
+	}
+	tmpl, err := ParseNameTemplate("{{.Number}}")
+	require.NoError(t, err)
+	info := &model.MovieInfo{Number: "ABP-123"}
+
+	o := NewOrganizer(tmpl)
+	_, err = o.Organize(filepath.Join(dir, "a.mp4"), info)
+	assert.ErrorIs(t, err, ErrCollision)
+	// nothing is moved or overwritten.
+	assert.FileExists(t, filepath.Join(dir, "a.mp4"))
+	data, err := os.ReadFile(filepath.Join(dir, "ABP-123.nfo"))
+	require.NoError(t, err)
+	assert.Equal(t, "ABP-123.nfo", string(data))
+
+	o.Collision = CollisionOverwrite
+	_, err = o.Organize(filepath.Join(dir, "a.mp4"), info)
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(dir, "ABP-123.nfo"))
+	require.NoError(t, err)
+	assert.Equal(t, "a.nfo", string(data))
+}
+
+func TestOrganizer_Collision(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.mp4", "ABP-123.mp4"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	tmpl, err := ParseNameTemplate("{{.Number}}")
+	require.NoError(t, err)
+	info := &model.MovieInfo{Number: "ABP-123"}
+
+	o := NewOrganizer(tmpl)
+	_, err = o.Target(filepath.Join(dir, "a.mp4"), info)
+	assert.ErrorIs(t, err, ErrCollision)
+
+	o.Collision = CollisionRename
+	target, err := o.Target(filepath.Join(dir, "a.mp4"), info)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "ABP-123 (1).mp4"), target)
+}
+
+func TestOrganizer_MaxNameLength(t *testing.T) {
+	tmpl, err := ParseNameTemplate("{{.Title}}")
+	require.NoError(t, err)
+	o := NewOrganizer(tmpl)
+	o.MaxNameLength = 32
+	target, err := o.Target("/tmp/a.mp4", &model.MovieInfo{Title: strings.Repeat("長", 20)})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(filepath.Base(target)), 32)
+	assert.True(t, strings.HasSuffix(target, "長.mp4"))
+}
+
+func TestOrganizer_MultiPart(t *testing.T) {
+	tmpl, err := ParseNameTemplate("{{.Number}}")
+	require.NoError(t, err)
+	o := NewOrganizer(tmpl)
+	info := &model.MovieInfo{Number: "ABP-123"}
+	for name, want := range map[string]string{
+		"abp123-cd1.mp4": "ABP-123-cd1.mp4",
+		"abp123-B.mp4":   "ABP-123-cd2.mp4",
+		"abp123-C.mp4":   "ABP-123.mp4",
+	} {
+		target, err := o.Target(filepath.Join("/tmp", name), info)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/tmp", want), target, name)
+	}
+}
+
+func TestOrganizer_MaxNameLengthTooShort(t *testing.T) {
+	tmpl, err := ParseNameTemplate("{{.Title}}")
+	require.NoError(t, err)
+	o := NewOrganizer(tmpl)
+	for _, n := range []int{1, 9, 10} {
+		o.MaxNameLength = n
+		assert.NotPanics(t, func() {
+			_, err = o.Target("/tmp/a-cd1.mp4", &model.MovieInfo{Title: "Title"})
+			assert.Error(t, err, n)
+		})
+	}
+}
+
+func TestRenderName_Separators(t *testing.T) {
+	tmpl, err := ParseNameTemplate("{{.Maker}}/{{.Number}} {{.Title}}")
+	require.NoError(t, err)
+	name, err := RenderName(tmpl, &model.MovieInfo{
+		Number: "ABP-123",
+		Title:  "../../etc/passwd",
+		Maker:  `A/B\C`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "A_B_C/ABP-123 .._.._etc_passwd", name)
+}
+
+func TestResolveInRoots(t *testing.T) {
+	root := t.TempDir()
+	other := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "a"), 0o755))
+	require.NoError(t, os.Symlink(other, filepath.Join(root, "link")))
+
+	for _, unit := range []struct {
+		path string
+		ok   bool
+	}{
+		{root, true},
+		{filepath.Join(root, "a"), true},
+		{filepath.Join(root, "a", "new", "dir"), true},
+		{filepath.Join(root, "a", "..", ".."), false},
+		{filepath.Join(root, "link"), false},
+		{filepath.Join(root, "link", "new"), false},
+		{other, false},
+		{root + "x", false},
+	} {
+		_, err := ResolveInRoots(unit.path, []string{root})
+		if unit.ok {
+			assert.NoError(t, err, unit.path)
+		} else {
+			assert.ErrorIs(t, err, ErrOutsideRoots, unit.path)
+		}
+	}
+	_, err := ResolveInRoots(root, nil)
+	assert.ErrorIs(t, err, ErrOutsideRoots)
+}
@@ -0,0 +1,100 @@
+package library
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// NameData is the data used to render filename templates.
+type NameData struct {
+	ID          string
+	Number      string
+	Title       string
+	Provider    string
+	Director    string
+	Actors      string
+	Maker       string
+	Label       string
+	Series      string
+	Year        int
+	ReleaseDate string
+}
+
+// newNameData returns the name data of the info, path separators of
+// the values are replaced, so that only the template creates
+// subdirectories.
+func newNameData(info *model.MovieInfo) *NameData {
+	data := &NameData{
+		ID:       valueReplacer.Replace(info.ID),
+		Number:   valueReplacer.Replace(info.Number),
+		Title:    valueReplacer.Replace(info.Title),
+		Provider: valueReplacer.Replace(info.Provider),
+		Director: valueReplacer.Replace(info.Director),
+		Actors:   valueReplacer.Replace(strings.Join(info.Actors, ", ")),
+		Maker:    valueReplacer.Replace(info.Maker),
+		Label:    valueReplacer.Replace(info.Label),
+		Series:   valueReplacer.Replace(info.Series),
+	}
+	if t := time.Time(info.ReleaseDate); !t.IsZero() {
+		data.Year = t.Year()
+		data.ReleaseDate = t.Format(time.DateOnly)
+	}
+	return data
+}
+
+// ParseNameTemplate parses a filename template, e.g.
+// "{{.Number}} {{.Title}} [{{.Actors}}]".
+func ParseNameTemplate(text string) (*template.Template, error) {
+	return template.New("name").Option("missingkey=error").Parse(text)
+}
+
+// RenderName renders the template with the movie info, and returns a
+// slash-separated relative path without extension, each element of the
+// path is sanitized to be safe for most filesystems.
+func RenderName(tmpl *template.Template, info *model.MovieInfo) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, newNameData(info)); err != nil {
+		return "", err
+	}
+	var elems []string
+	for _, elem := range strings.Split(buf.String(), "/") {
+		if elem = sanitizeName(elem); elem != "" && elem != "." && elem != ".." {
+			elems = append(elems, elem)
+		}
+	}
+	return strings.Join(elems, "/"), nil
+}
+
+var valueReplacer = strings.NewReplacer("/", "_", "\\", "_")
+
+var nameReplacer = strings.NewReplacer(
+	"\\", "_", ":", "_", "*", "_", "?", "_",
+	"\"", "_", "<", "_", ">", "_", "|", "_",
+	"\n", " ", "\r", " ", "\t", " ",
+)
+
+func sanitizeName(name string) string {
+	name = nameReplacer.Replace(name)
+	name = strings.Join(strings.Fields(name), " ")
+	return strings.TrimRight(name, ". ")
+}
+
+// truncateName truncates the name to at most n bytes without
+// breaking UTF-8 characters.
+func truncateName(name string, n int) string {
+	if len(name) <= n {
+		return name
+	}
+	if n <= 0 {
+		return ""
+	}
+	for n > 0 && !utf8.RuneStart(name[n]) {
+		n--
+	}
+	return strings.TrimRight(name[:n], ". ")
+}
@@ -0,0 +1,52 @@
+package library
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideRoots is returned if a path is outside all library roots.
+var ErrOutsideRoots = errors.New("path is outside library roots")
+
+// ResolveInRoots returns the absolute path of the path, symlinks of
+// existing paths resolved, if it's one of the roots or within them.
+func ResolveInRoots(path string, roots []string) (string, error) {
+	if len(roots) == 0 {
+		return "", fmt.Errorf("%w: no library roots configured", ErrOutsideRoots)
+	}
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return "", err
+	}
+	for _, root := range roots {
+		if root, err = resolvePath(root); err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(root, resolved); err == nil &&
+			rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrOutsideRoots, path)
+}
+
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	// the longest existing prefix is resolved, so that targets to be
+	// created can't escape by symlinks either.
+	rest := ""
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		if real, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(real, rest), nil
+		}
+		if dir == filepath.Dir(dir) {
+			return abs, nil
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+	}
+}
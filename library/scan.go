@@ -0,0 +1,192 @@
+package library
+
+import (
+	"bytes"
+	"image"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// VideoExtensions are the file extensions recognized as videos.
+var VideoExtensions = []string{
+	".mp4", ".mkv", ".avi", ".wmv", ".mov", ".m4v",
+	".ts", ".m2ts", ".flv", ".rmvb", ".webm", ".iso",
+}
+
+// IsVideo reports whether the file has a video extension.
+func IsVideo(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, v := range VideoExtensions {
+		if ext == v {
+			return true
+		}
+	}
+	return false
+}
+
+// FindVideos walks the directory and returns all video files.
+func FindVideos(dir string) (videos []string, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != dir {
+			return filepath.SkipDir // skip hidden directories.
+		}
+		if !d.IsDir() && IsVideo(path) {
+			videos = append(videos, path)
+		}
+		return nil
+	})
+	return
+}
+
+// Result is the scanning result of a single video file.
+type Result struct {
+	Path   string           `json:"path"`
+	Number string           `json:"number"`
+	Part   int              `json:"part,omitempty"`
+	Info   *model.MovieInfo `json:"info,omitempty"`
+	Error  error            `json:"-"`
+}
+
+// Scanner resolves numbers from video filenames, fetches metadata and
+// writes NFO files and artworks next to the videos.
+type Scanner struct {
+	app *engine.Engine
+
+	// Overwrite existing NFO and artworks.
+	Overwrite bool
+
+	// Skip downloading artworks.
+	NoArtwork bool
+
+	// Write actor names in romaji into NFO files.
+	Romaji bool
+
+	// JPEG quality of artworks.
+	Quality int
+
+	// Organize video files before writing NFO and artworks if
+	// not nil, nothing is written if the organizer is in dry-run.
+	Organizer *Organizer
+}
+
+func NewScanner(app *engine.Engine) *Scanner {
+	return &Scanner{
+		app:     app,
+		Quality: 90,
+	}
+}
+
+// ScanDir scans all video files in the directory.
+func (s *Scanner) ScanDir(dir string) ([]*Result, error) {
+	videos, err := FindVideos(dir)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*Result, 0, len(videos))
+	for _, video := range videos {
+		results = append(results, s.Scan(video))
+	}
+	return results, nil
+}
+
+// Scan scans a single video file.
+func (s *Scanner) Scan(path string) (result *Result) {
+	result = &Result{Path: path}
+	// all parts of a multi-part movie share the same number.
+	result.Number, result.Part = number.ParsePart(filepath.Base(path))
+	nfoPath := s.nfoPath(path)
+	if !s.Overwrite && exists(nfoPath) {
+		return // already scraped.
+	}
+
+	result.Info, result.Error = s.Lookup(result.Number)
+	if result.Error != nil {
+		return
+	}
+	if s.Organizer != nil {
+		var moves []*Move
+		if moves, result.Error = s.Organizer.Organize(path, result.Info); result.Error != nil {
+			return
+		}
+		path = moves[0].To
+		result.Path, nfoPath = path, s.nfoPath(path)
+		if s.Organizer.DryRun {
+			return
+		}
+	}
+	if result.Error = s.writeNFO(nfoPath, result.Info); result.Error != nil {
+		return
+	}
+	if !s.NoArtwork {
+		result.Error = s.writeArtworks(path, result.Info)
+	}
+	return
+}
+
+// Lookup finds the best matched movie info of the number.
+func (s *Scanner) Lookup(num string) (*model.MovieInfo, error) {
+	if num == "" {
+		return nil, mt.ErrInvalidKeyword
+	}
+	return s.app.LookupMovieInfo(num)
+}
+
+func (s *Scanner) nfoPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".nfo"
+}
+
+func (s *Scanner) writeNFO(path string, info *model.MovieInfo) error {
+	nfo := NewNFO(info)
+	if s.Romaji {
+		nfo.RomanizeActors()
+	}
+	buf := &bytes.Buffer{}
+	if err := nfo.Encode(buf); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+func (s *Scanner) writeArtworks(path string, info *model.MovieInfo) error {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	for suffix, get := range map[string]func(string, string) (image.Image, error){
+		"-poster.jpg": func(name, id string) (image.Image, error) {
+			return s.app.GetMoviePrimaryImage(name, id, -1, -1)
+		},
+		"-thumb.jpg":  s.app.GetMovieThumbImage,
+		"-fanart.jpg": s.app.GetMovieBackdropImage,
+	} {
+		target := base + suffix
+		if !s.Overwrite && exists(target) {
+			continue
+		}
+		img, err := get(info.Provider, info.ID)
+		if err != nil {
+			return err
+		}
+		buf := &bytes.Buffer{}
+		if err = imageutil.EncodeToJPEG(buf, img, s.Quality); err != nil {
+			return err
+		}
+		if err = os.WriteFile(target, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
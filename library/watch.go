@@ -0,0 +1,171 @@
+package library
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDelay is the default time a new file must stay
+// unchanged before it is scanned.
+const DefaultWatchDelay = 10 * time.Second
+
+// Watcher monitors directories and scans new video files automatically.
+type Watcher struct {
+	scanner *Scanner
+	dirs    []string
+	logger  *slog.Logger
+
+	// Delay is the time a file must stay unchanged before being
+	// scanned, it avoids scanning files which are still being copied.
+	Delay time.Duration
+
+	// OnResult is called after each file is scanned, if not nil.
+	OnResult func(*Result)
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer
+}
+
+func NewWatcher(scanner *Scanner, dirs ...string) *Watcher {
+	return &Watcher{
+		scanner: scanner,
+		dirs:    dirs,
+		logger:  slog.Default().With(slog.String("component", "watcher")),
+		Delay:   DefaultWatchDelay,
+		pending: make(map[string]*time.Timer),
+	}
+}
+
+// Run watches all directories recursively until the context is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+
+	for _, dir := range w.dirs {
+		if err = w.addRecursive(fw, dir); err != nil {
+			return err
+		}
+	}
+
+	queue := make(chan string, 64)
+	defer w.stopPending()
+	go func() {
+		// scan files one by one to avoid flooding providers.
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case path := <-queue:
+				w.scan(path)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Error("watch", slog.Any("error", err))
+		case event, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			w.handle(ctx, fw, event, queue)
+		}
+	}
+}
+
+func (w *Watcher) handle(ctx context.Context, fw *fsnotify.Watcher, event fsnotify.Event, queue chan<- string) {
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return
+	}
+	if fi, err := os.Stat(event.Name); err == nil && fi.IsDir() {
+		if event.Has(fsnotify.Create) {
+			// watch new directory and scan videos that already exist.
+			if err = w.addRecursive(fw, event.Name); err != nil {
+				w.logger.Warn("watch", slog.String("path", event.Name), slog.Any("error", err))
+			}
+			videos, _ := FindVideos(event.Name)
+			for _, video := range videos {
+				w.schedule(ctx, video, queue)
+			}
+		}
+		return
+	}
+	if IsVideo(event.Name) {
+		w.schedule(ctx, event.Name, queue)
+	}
+}
+
+// schedule (re)starts the delay timer of the file.
+func (w *Watcher) schedule(ctx context.Context, path string, queue chan<- string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if timer, ok := w.pending[path]; ok {
+		timer.Reset(w.Delay)
+		return
+	}
+	w.pending[path] = time.AfterFunc(w.Delay, func() {
+		w.mu.Lock()
+		delete(w.pending, path)
+		w.mu.Unlock()
+		select {
+		case queue <- path:
+		case <-ctx.Done():
+		}
+	})
+}
+
+func (w *Watcher) stopPending() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for path, timer := range w.pending {
+		timer.Stop()
+		delete(w.pending, path)
+	}
+}
+
+func (w *Watcher) scan(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return // file removed.
+	}
+	result := w.scanner.Scan(path)
+	if result.Error != nil {
+		w.logger.Warn("scan", slog.String("path", path), slog.Any("error", result.Error))
+	} else if result.Info != nil {
+		w.logger.Info("scan", slog.String("path", result.Path), slog.String("provider", result.Info.Provider), slog.String("id", result.Info.ID))
+	}
+	if w.OnResult != nil {
+		w.OnResult(result)
+	}
+}
+
+func (w *Watcher) addRecursive(fw *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != dir {
+			return filepath.SkipDir // skip hidden directories.
+		}
+		return fw.Add(path)
+	})
+}
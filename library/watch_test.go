@@ -0,0 +1,43 @@
+package library
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Schedule(t *testing.T) {
+	dir := t.TempDir()
+	w := NewWatcher(nil, dir)
+	w.Delay = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queue := make(chan string, 1)
+	path := filepath.Join(dir, "ABP-123.mp4")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+
+	// repeated writes should only be queued once.
+	for i := 0; i < 3; i++ {
+		w.schedule(ctx, path, queue)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case got := <-queue:
+		assert.Equal(t, path, got)
+	case <-time.After(time.Second):
+		require.FailNow(t, "file not queued")
+	}
+	select {
+	case got := <-queue:
+		assert.FailNow(t, "file queued twice", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "metatube"
+
+// Status label values.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Field extraction result label values.
+const (
+	FieldExtracted = "extracted"
+	FieldEmpty     = "empty"
+)
+
+// Cache result label values.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+var (
+	// ProviderRequests counts upstream requests per provider and operation.
+	ProviderRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "provider_requests_total",
+		Help:      "Total number of provider requests.",
+	}, []string{"provider", "operation", "status"})
+
+	// ProviderRequestDuration observes upstream request latencies.
+	ProviderRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "provider_request_duration_seconds",
+		Help:      "Latency of provider requests.",
+		Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 20, 30, 60},
+	}, []string{"provider", "operation"})
+
+	// ProviderFields counts fields of scraped infos per provider by
+	// whether they are extracted or empty, a sudden rise of empty ones
+	// signals a site redesign breaking the selectors.
+	ProviderFields = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "provider_fields_total",
+		Help:      "Total number of fields extracted by providers.",
+	}, []string{"provider", "type", "field", "result"})
+
+	// CacheLookups counts DB cache lookups by result.
+	CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Total number of metadata cache lookups.",
+	}, []string{"type", "result"})
+
+	// TranslateRequests counts translation requests per engine.
+	TranslateRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "translate_requests_total",
+		Help:      "Total number of translation requests.",
+	}, []string{"engine", "status"})
+
+	// ImageProcessingDuration observes image fetching and processing time.
+	ImageProcessingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "image_processing_duration_seconds",
+		Help:      "Time spent on fetching and processing images.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"stage"})
+
+	// HTTPRequests counts HTTP requests served.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests.",
+	}, []string{"method", "route", "code"})
+
+	// HTTPRequestDuration observes HTTP request latencies.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"method", "route"})
+)
+
+// ObserveProvider records a provider request started at start.
+func ObserveProvider(provider, operation string, start time.Time, err error) {
+	ProviderRequests.WithLabelValues(provider, operation, status(err)).Inc()
+	ProviderRequestDuration.WithLabelValues(provider, operation).Observe(time.Since(start).Seconds())
+}
+
+// ObserveFields records the fields of an info scraped by the provider,
+// fields maps the names of the fields to whether they are empty.
+func ObserveFields(provider, typ string, fields map[string]bool) {
+	for field, empty := range fields {
+		result := FieldExtracted
+		if empty {
+			result = FieldEmpty
+		}
+		ProviderFields.WithLabelValues(provider, typ, field, result).Inc()
+	}
+}
+
+// ObserveCache records a cache lookup.
+func ObserveCache(typ string, hit bool) {
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+	CacheLookups.WithLabelValues(typ, result).Inc()
+}
+
+// ObserveTranslate records a translation request.
+func ObserveTranslate(engine string, err error) {
+	TranslateRequests.WithLabelValues(engine, status(err)).Inc()
+}
+
+// ObserveImage records an image processing stage started at start.
+func ObserveImage(stage string, start time.Time) {
+	ImageProcessingDuration.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+}
+
+func status(err error) string {
+	if err != nil {
+		return StatusError
+	}
+	return StatusSuccess
+}
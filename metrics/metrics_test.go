@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveProvider(t *testing.T) {
+	ObserveProvider("TEST", "search", time.Now(), nil)
+	ObserveProvider("TEST", "search", time.Now(), errors.New("error"))
+	ObserveProvider("TEST", "search", time.Now(), errors.New("error"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ProviderRequests.WithLabelValues("TEST", "search", StatusSuccess)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(ProviderRequests.WithLabelValues("TEST", "search", StatusError)))
+}
+
+func TestObserveCache(t *testing.T) {
+	ObserveCache("test", true)
+	ObserveCache("test", false)
+	ObserveCache("test", false)
+	assert.Equal(t, 1.0, testutil.ToFloat64(CacheLookups.WithLabelValues("test", CacheHit)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(CacheLookups.WithLabelValues("test", CacheMiss)))
+}
+
+func TestObserveFields(t *testing.T) {
+	ObserveFields("TEST", "movie", map[string]bool{"title": false, "actors": true})
+	ObserveFields("TEST", "movie", map[string]bool{"title": false, "actors": false})
+	assert.Equal(t, 2.0, testutil.ToFloat64(ProviderFields.WithLabelValues("TEST", "movie", "title", FieldExtracted)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ProviderFields.WithLabelValues("TEST", "movie", "actors", FieldEmpty)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(ProviderFields.WithLabelValues("TEST", "movie", "title", FieldEmpty)))
+}
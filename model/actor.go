@@ -1,8 +1,11 @@
 package model
 
 import (
+	"time"
+
 	"github.com/lib/pq"
-	"gorm.io/datatypes"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
 )
 
 const ActorMetadataTableName = "actor_metadata"
@@ -39,7 +42,12 @@ type ActorInfo struct {
 	Images       pq.StringArray `json:"images" gorm:"type:text[]"`
 	Birthday     datatypes.Date `json:"birthday"`
 	DebutDate    datatypes.Date `json:"debut_date"`
-	TimeTracker  `json:"-"`
+	// Romaji is the romanized name, if known.
+	Romaji string `json:"romaji,omitempty" gorm:"-"`
+	// Sources maps merged fields to the providers they come from,
+	// only available if the info is merged from multiple providers.
+	Sources     map[string]string `json:"sources,omitempty" gorm:"-"`
+	TimeTracker `json:"-"`
 }
 
 func (*ActorInfo) TableName() string {
@@ -60,3 +68,31 @@ func (a *ActorInfo) ToSearchResult() *ActorSearchResult {
 		Images:   a.Images,
 	}
 }
+
+// AgeAt returns the age of the actor at t, 0 if the birthday is
+// unknown or after t.
+func (a *ActorInfo) AgeAt(t time.Time) int {
+	return age(time.Time(a.Birthday), t)
+}
+
+func age(birthday, t time.Time) int {
+	if birthday.Year() <= 1 || t.Before(birthday) {
+		return 0
+	}
+	years := t.Year() - birthday.Year()
+	if t.Month() < birthday.Month() ||
+		t.Month() == birthday.Month() && t.Day() < birthday.Day() {
+		years--
+	}
+	return years
+}
+
+// ActorBirthday is an upcoming birthday of the actor.
+type ActorBirthday struct {
+	*ActorSearchResult
+	Birthday datatypes.Date `json:"birthday"`
+	// Date is the date of the upcoming birthday.
+	Date datatypes.Date `json:"date"`
+	// Age is the age of the actor on the date.
+	Age int `json:"age"`
+}
@@ -0,0 +1,39 @@
+package model
+
+const CollectionItemsTableName = "collection_items"
+
+type CollectionType string
+
+const (
+	CollectionFavoriteMovies CollectionType = "favorite_movies"
+	CollectionFavoriteActors CollectionType = "favorite_actors"
+	CollectionWatchLater     CollectionType = "watch_later"
+)
+
+func (t CollectionType) Valid() bool {
+	switch t {
+	case CollectionFavoriteMovies, CollectionFavoriteActors, CollectionWatchLater:
+		return true
+	}
+	return false
+}
+
+// IsActor reports whether the collection holds actors instead of movies.
+func (t CollectionType) IsActor() bool {
+	return t == CollectionFavoriteActors
+}
+
+// CollectionItem is a movie or actor in a collection of a user.
+type CollectionItem struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	User        string         `json:"user" gorm:"column:username;uniqueIndex:idx_collection_item"`
+	Collection  CollectionType `json:"collection" gorm:"uniqueIndex:idx_collection_item"`
+	Provider    string         `json:"provider" gorm:"uniqueIndex:idx_collection_item"`
+	ItemID      string         `json:"item_id" gorm:"column:item_id;uniqueIndex:idx_collection_item"`
+	Note        string         `json:"note,omitempty"`
+	TimeTracker `json:"-"`
+}
+
+func (*CollectionItem) TableName() string {
+	return CollectionItemsTableName
+}
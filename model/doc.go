@@ -0,0 +1,16 @@
+// Package model defines the metadata models of the SDK, they are both
+// the database rows and the API documents.
+//
+// # JSON schema
+//
+// Field names of MovieInfo and MovieSearchResult are stable: fields are
+// only ever added, never renamed, retyped or removed, and fields that
+// are always present are never made omitempty. Optional details, e.g.
+// ratings, editions and sources, are omitted if empty.
+//
+// Dates are datatypes.Date, i.e. RFC 3339 timestamps at midnight such
+// as 2006-01-02T00:00:00Z, which the /v2 API serves as ISO 8601 dates
+// such as 2006-01-02, and the zero date is 0001-01-01. Both forms are
+// accepted when decoding. Runtime is integer minutes, which the /v2 API
+// serves as integer seconds.
+package model
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+)
+
+const (
+	FollowsTableName        = "follows"
+	FollowReleasesTableName = "follow_releases"
+)
+
+type FollowType string
+
+const (
+	FollowActor  FollowType = "actor"
+	FollowSeries FollowType = "series"
+	FollowMaker  FollowType = "maker"
+)
+
+func (t FollowType) Valid() bool {
+	switch t {
+	case FollowActor, FollowSeries, FollowMaker:
+		return true
+	}
+	return false
+}
+
+// Follow is an actor, series or maker followed by a user.
+type Follow struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	User        string     `json:"user" gorm:"column:username;uniqueIndex:idx_follow"`
+	Type        FollowType `json:"type" gorm:"uniqueIndex:idx_follow"`
+	Name        string     `json:"name" gorm:"uniqueIndex:idx_follow"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	TimeTracker `json:"-"`
+}
+
+func (*Follow) TableName() string {
+	return FollowsTableName
+}
+
+// FollowRelease is a movie found for a follow.
+type FollowRelease struct {
+	FollowID    uint           `json:"follow_id" gorm:"primaryKey"`
+	Provider    string         `json:"provider" gorm:"primaryKey"`
+	ID          string         `json:"id" gorm:"primaryKey"`
+	Number      string         `json:"number"`
+	Title       string         `json:"title"`
+	Homepage    string         `json:"homepage"`
+	ThumbURL    string         `json:"thumb_url"`
+	ReleaseDate datatypes.Date `json:"release_date"`
+	Seen        bool           `json:"seen" gorm:"index"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+func (*FollowRelease) TableName() string {
+	return FollowReleasesTableName
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+const JobsTableName = "jobs"
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// Job is a background job, persisted so that it survives restarts.
+type Job struct {
+	ID     uint           `json:"id" gorm:"primaryKey"`
+	Type   string         `json:"type" gorm:"index"`
+	Params datatypes.JSON `json:"params,omitempty"`
+	Status JobStatus      `json:"status" gorm:"index"`
+	Result datatypes.JSON `json:"result,omitempty"`
+	Error  string         `json:"error,omitempty"`
+
+	// Owner is the runner holding the lease of a running job, the lease
+	// is renewed while the job runs, and expired ones are reclaimed by
+	// other runners, e.g. after a crash.
+	Owner          string     `json:"owner,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func (*Job) TableName() string {
+	return JobsTableName
+}
+
+// Done reports whether the job is finished, either way.
+func (j *Job) Done() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed
+}
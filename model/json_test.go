@@ -0,0 +1,127 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+)
+
+// Keys of the stable JSON schema, see doc.go before changing them.
+var (
+	movieInfoKeys = []string{
+		"actors", "big_cover_url", "big_thumb_url", "cover_url", "director",
+		"genres", "homepage", "id", "label", "maker", "normalized_score",
+		"number", "physical_date", "preview_images", "preview_video_hls_url",
+		"preview_video_url", "provider", "release_date", "review_count",
+		"runtime", "sales_rank", "score", "series", "streaming_date",
+		"summary", "thumb_url", "title", "wishlist_count",
+	}
+	movieInfoOptionalKeys = []string{
+		"actor_ages", "editions", "match", "preview_image_details", "rating",
+		"ratings", "related_movies", "runtime_mismatch", "sources", "vr",
+	}
+	movieSearchResultKeys = []string{
+		"cover_url", "homepage", "id", "number", "physical_date", "provider",
+		"release_date", "score", "streaming_date", "thumb_url", "title",
+	}
+	movieSearchResultOptionalKeys = []string{
+		"actors", "editions", "owned", "review_count", "sales_rank",
+		"sources", "watched", "wishlist_count",
+	}
+)
+
+func jsonKeys(t *testing.T, v any) map[string]json.RawMessage {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	m := make(map[string]json.RawMessage)
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+func TestMovieInfo_JSONSchema(t *testing.T) {
+	m := jsonKeys(t, &MovieInfo{})
+	for _, key := range movieInfoKeys {
+		assert.Contains(t, m, key, "required key removed or renamed")
+	}
+	for _, key := range movieInfoOptionalKeys {
+		assert.NotContains(t, m, key, "optional key not omitted")
+	}
+	assert.Len(t, m, len(movieInfoKeys), "new keys must be optional")
+	assert.JSONEq(t, `"0001-01-01T00:00:00Z"`, string(m["release_date"]))
+
+	m = jsonKeys(t, &MovieInfo{
+		Editions:            []string{"4k"},
+		PreviewImageDetails: []*PreviewImage{{URL: "u"}},
+		Rating:              &MovieRating{},
+		Ratings:             []*MovieRating{{}},
+		RelatedMovies:       []*RelatedMovie{{}},
+		VR:                  &MovieVRInfo{},
+		Sources:             map[string]string{"title": "FANZA"},
+		ActorAges:           map[string]int{"A": 20},
+		Match:               &MovieMatch{},
+		RuntimeMismatch:     true,
+	})
+	for _, key := range movieInfoOptionalKeys {
+		assert.Contains(t, m, key, "optional key removed or renamed")
+	}
+}
+
+func TestMovieSearchResult_JSONSchema(t *testing.T) {
+	m := jsonKeys(t, MovieSearchResult{})
+	for _, key := range movieSearchResultKeys {
+		assert.Contains(t, m, key, "required key removed or renamed")
+	}
+	assert.Len(t, m, len(movieSearchResultKeys), "new keys must be optional")
+
+	m = jsonKeys(t, MovieSearchResult{
+		Actors:        []string{"A"},
+		Editions:      []string{"vr"},
+		Owned:         true,
+		Watched:       true,
+		ReviewCount:   1,
+		SalesRank:     1,
+		WishlistCount: 1,
+		Sources:       []*MovieSource{{}},
+	})
+	for _, key := range movieSearchResultOptionalKeys {
+		assert.Contains(t, m, key, "optional key removed or renamed")
+	}
+}
+
+func TestMovieInfo_JSONDates(t *testing.T) {
+	info := &MovieInfo{
+		ID:          "abp00030",
+		Runtime:     120,
+		ReleaseDate: datatypes.Date(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+		TimeTracker: TimeTracker{CreatedAt: time.Now()},
+	}
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"release_date":"2021-02-03T00:00:00Z"`)
+	assert.Contains(t, string(data), `"runtime":120`)
+	assert.NotContains(t, string(data), "CreatedAt")
+
+	decoded := &MovieInfo{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, "abp00030", decoded.ID)
+	assert.True(t, time.Time(info.ReleaseDate).Equal(time.Time(decoded.ReleaseDate)))
+
+	// documents of the /v2 API.
+	result := &MovieSearchResult{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "abp00030",
+		"release_date": "2021-02-03",
+		"streaming_date": null,
+		"physical_date": ""
+	}`), result))
+	assert.Equal(t, "2021-02-03", time.Time(result.ReleaseDate).Format(time.DateOnly))
+	assert.True(t, time.Time(result.StreamingDate).IsZero())
+	assert.True(t, time.Time(result.PhysicalDate).IsZero())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"release_date": "03/02/2021"}`), result))
+}
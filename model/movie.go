@@ -2,7 +2,9 @@ package model
 
 import (
 	"github.com/lib/pq"
-	"gorm.io/datatypes"
+	gormtypes "gorm.io/datatypes"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
 )
 
 const (
@@ -22,6 +24,27 @@ type MovieSearchResult struct {
 	Score       float64        `json:"score"`
 	Actors      pq.StringArray `json:"actors,omitempty"`
 	ReleaseDate datatypes.Date `json:"release_date"`
+	// StreamingDate and PhysicalDate are zero if not distinguished.
+	StreamingDate datatypes.Date `json:"streaming_date"`
+	PhysicalDate  datatypes.Date `json:"physical_date"`
+	// Popularity metrics, zero if unknown.
+	ReviewCount   int `json:"review_count,omitempty"`
+	WishlistCount int `json:"wishlist_count,omitempty"`
+	SalesRank     int `json:"sales_rank,omitempty"`
+	// Editions are the edition flags detected from the title.
+	Editions []string `json:"editions,omitempty"`
+	// Sources of the same movie from other providers, if merged.
+	Sources []*MovieSource `json:"sources,omitempty"`
+	// Owned and Watched are the states of the number of the user.
+	Owned   bool `json:"owned,omitempty"`
+	Watched bool `json:"watched,omitempty"`
+}
+
+// MovieSource is a provider entry of a movie.
+type MovieSource struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	Homepage string `json:"homepage"`
 }
 
 func (m *MovieSearchResult) Valid() bool {
@@ -32,7 +55,7 @@ func (m *MovieSearchResult) Valid() bool {
 type MovieReviewInfo struct {
 	ID          string                                   `json:"id" gorm:"primaryKey"`
 	Provider    string                                   `json:"provider" gorm:"primaryKey"`
-	Reviews     datatypes.JSONType[[]*MovieReviewDetail] `json:"reviews"`
+	Reviews     gormtypes.JSONType[[]*MovieReviewDetail] `json:"reviews"`
 	TimeTracker `json:"-"`
 }
 
@@ -83,18 +106,139 @@ type MovieInfo struct {
 	PreviewVideoHLSURL string         `json:"preview_video_hls_url"`
 	PreviewImages      pq.StringArray `json:"preview_images" gorm:"type:text[]"`
 
+	// PreviewImageDetails are the labeled preview images, e.g. scenes or
+	// timestamps, if the provider exposes them. PreviewImages is always
+	// the flattened list of them for backward compatibility.
+	PreviewImageDetails []*PreviewImage `json:"preview_image_details,omitempty" gorm:"serializer:json"`
+
 	Maker  string         `json:"maker"`
 	Label  string         `json:"label"`
 	Series string         `json:"series"`
 	Genres pq.StringArray `json:"genres" gorm:"type:text[]"`
 	Score  float64        `json:"score"`
 
+	// Rating is the raw rating given by the provider, optional if it
+	// is the same as the score out of 5.
+	Rating *MovieRating `json:"rating,omitempty" gorm:"serializer:json"`
+	// Ratings are the raw ratings of sources, with the average of
+	// them in NormalizedScore out of 10.
+	Ratings         []*MovieRating `json:"ratings,omitempty" gorm:"-"`
+	NormalizedScore float64        `json:"normalized_score" gorm:"-"`
+
+	// Popularity metrics, zero if unknown. WishlistCount is the number
+	// of wishlists, e.g. DMM 欲しいものリスト, and SalesRank starts from 1.
+	ReviewCount   int `json:"review_count"`
+	WishlistCount int `json:"wishlist_count"`
+	SalesRank     int `json:"sales_rank"`
+
 	Runtime     int            `json:"runtime"`
 	ReleaseDate datatypes.Date `json:"release_date"`
 
+	// StreamingDate (配信開始日) and PhysicalDate (発売日) are kept apart
+	// if the provider lists them, DMM digital dates often differ from
+	// DVD dates by weeks. ReleaseDate is the first one listed.
+	StreamingDate datatypes.Date `json:"streaming_date"`
+	PhysicalDate  datatypes.Date `json:"physical_date"`
+
+	// Editions are the edition flags detected from the title and
+	// genres, i.e. 4k, vr, remaster and compilation.
+	Editions []string `json:"editions,omitempty" gorm:"-"`
+
+	// RelatedMovies are the movies recommended on the detail page
+	// of the provider, e.g. customers also viewed or same series.
+	RelatedMovies []*RelatedMovie `json:"related_movies,omitempty" gorm:"serializer:json"`
+
+	// VR attributes, nil if not a VR movie.
+	VR *MovieVRInfo `json:"vr,omitempty" gorm:"serializer:json"`
+
+	// Sources maps merged fields to the providers they come from,
+	// only available if the info is merged from multiple providers.
+	Sources map[string]string `json:"sources,omitempty" gorm:"-"`
+
+	// ActorAges are ages of the actors at the release date, keyed by
+	// actor name, only available for actors with known birthdays.
+	ActorAges map[string]int `json:"actor_ages,omitempty" gorm:"-"`
+
+	// Match describes how the movie is matched, if looked up.
+	Match *MovieMatch `json:"match,omitempty" gorm:"-"`
+
+	// RuntimeMismatch is true if the runtime is far off the actual
+	// duration of the movie file, only checked if it is given.
+	RuntimeMismatch bool `json:"runtime_mismatch,omitempty" gorm:"-"`
+
 	TimeTracker `json:"-"`
 }
 
+// PreviewImage is a labeled preview image of a movie.
+type PreviewImage struct {
+	URL string `json:"url"`
+	// Index is the scene or chapter number, starts from 1.
+	Index int `json:"index"`
+	// Caption is the scene title or timestamp, if any.
+	Caption string `json:"caption,omitempty"`
+}
+
+// Relations of related movies.
+const (
+	RelatedAlsoViewed = "also_viewed"
+	RelatedSameSeries = "same_series"
+	RelatedSameActor  = "same_actor"
+)
+
+// RelatedMovie is a movie related to another one of the same provider.
+type RelatedMovie struct {
+	ID       string `json:"id"`
+	Number   string `json:"number,omitempty"`
+	Title    string `json:"title"`
+	Provider string `json:"provider"`
+	Homepage string `json:"homepage"`
+	ThumbURL string `json:"thumb_url,omitempty"`
+	// Relation is why the movie is related, e.g. also_viewed.
+	Relation string `json:"relation"`
+}
+
+// MovieRating is the raw rating of a movie from a provider.
+type MovieRating struct {
+	Provider string  `json:"provider"`
+	Value    float64 `json:"value"`
+	// Scale is the full mark of the value, e.g. 5, 10 or 100.
+	Scale float64 `json:"scale"`
+	// Votes is the number of user ratings, zero if unknown.
+	Votes int `json:"votes,omitempty"`
+	// Normalized is the value converted into the 0-10 scale.
+	Normalized float64 `json:"normalized"`
+}
+
+// MovieVRInfo is the VR-specific attributes of a movie.
+type MovieVRInfo struct {
+	// HighQuality is true for high-quality VR titles.
+	HighQuality bool `json:"high_quality"`
+	// Resolution is the highest resolution, e.g. 8K or 4K.
+	Resolution string `json:"resolution,omitempty"`
+	// Devices is the compatible devices, e.g. Meta Quest.
+	Devices []string `json:"devices,omitempty"`
+}
+
+// MatchType is the way a movie is matched.
+type MatchType string
+
+const (
+	// MatchByID is an exact match of the provider ID.
+	MatchByID MatchType = "id"
+	// MatchByNumber is an exact match of the normalized number.
+	MatchByNumber MatchType = "number"
+	// MatchByTitle is a fuzzy match of the title.
+	MatchByTitle MatchType = "title"
+	// MatchFirstResult is the first search result without a match.
+	MatchFirstResult MatchType = "first_result"
+)
+
+// MovieMatch is the match type and confidence, 0 to 1, of a movie.
+type MovieMatch struct {
+	Type       MatchType `json:"type"`
+	Confidence float64   `json:"confidence"`
+}
+
 func (*MovieInfo) TableName() string {
 	return MovieMetadataTableName
 }
@@ -104,6 +248,33 @@ func (m *MovieInfo) Valid() bool {
 		m.CoverURL != "" && m.Provider != "" && m.Homepage != ""
 }
 
+// AddPreviewImage appends a labeled preview image, index zero means
+// the next one in order. The flattened list is kept in sync.
+func (m *MovieInfo) AddPreviewImage(url string, index int, caption string) {
+	if index <= 0 {
+		index = len(m.PreviewImageDetails) + 1
+	}
+	m.PreviewImages = append(m.PreviewImages, url)
+	m.PreviewImageDetails = append(m.PreviewImageDetails, &PreviewImage{
+		URL:     url,
+		Index:   index,
+		Caption: caption,
+	})
+}
+
+// PreviewImageList returns the labeled preview images, or unlabeled
+// ones built from the flattened list if the provider has no labels.
+func (m *MovieInfo) PreviewImageList() []*PreviewImage {
+	if len(m.PreviewImageDetails) > 0 {
+		return m.PreviewImageDetails
+	}
+	images := make([]*PreviewImage, 0, len(m.PreviewImages))
+	for i, url := range m.PreviewImages {
+		images = append(images, &PreviewImage{URL: url, Index: i + 1})
+	}
+	return images
+}
+
 func (m *MovieInfo) ToSearchResult() *MovieSearchResult {
 	return &MovieSearchResult{
 		ID:          m.ID,
@@ -116,5 +287,12 @@ func (m *MovieInfo) ToSearchResult() *MovieSearchResult {
 		Score:       m.Score,
 		Actors:      m.Actors,
 		ReleaseDate: m.ReleaseDate,
+
+		StreamingDate: m.StreamingDate,
+		PhysicalDate:  m.PhysicalDate,
+
+		ReviewCount:   m.ReviewCount,
+		WishlistCount: m.WishlistCount,
+		SalesRank:     m.SalesRank,
 	}
 }
@@ -0,0 +1,16 @@
+package model
+
+const MovieNumbersTableName = "movie_numbers"
+
+// MovieNumber maps a normalized number to the provider-specific ID of
+// the movie, e.g. ABP123 to 118abp00123 of FANZA, learned from scrapes.
+type MovieNumber struct {
+	Number      string `json:"number" gorm:"primaryKey"`
+	Provider    string `json:"provider" gorm:"primaryKey"`
+	ID          string `json:"id" gorm:"index"`
+	TimeTracker `json:"-"`
+}
+
+func (*MovieNumber) TableName() string {
+	return MovieNumbersTableName
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"gorm.io/datatypes"
+)
+
+const MovieOverridesTableName = "movie_overrides"
+
+// MovieOverride is the user corrections of a movie, stored apart from
+// scraped metadata, so that they survive refreshes.
+type MovieOverride struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	Provider string `json:"provider" gorm:"primaryKey"`
+	// Fields is a JSON object of the overridden MovieInfo fields.
+	Fields      datatypes.JSON `json:"fields"`
+	TimeTracker `json:"-"`
+}
+
+func (*MovieOverride) TableName() string {
+	return MovieOverridesTableName
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"gorm.io/datatypes"
+)
+
+const MoviePayloadsTableName = "movie_payloads"
+
+// MoviePayload is the raw document a movie info is scraped from, kept
+// so that it can be parsed again after parser fixes, without fetching
+// it from the provider again.
+type MoviePayload struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	Provider string `json:"provider" gorm:"primaryKey"`
+	// URL and ContentType of the raw document, e.g. the detail page.
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Body        string `json:"body"`
+	// Snapshot is the movie info as parsed by the provider, before
+	// any processing of the engine, JSONB in Postgres.
+	Snapshot    datatypes.JSON `json:"snapshot"`
+	TimeTracker `json:"-"`
+}
+
+func (*MoviePayload) TableName() string {
+	return MoviePayloadsTableName
+}
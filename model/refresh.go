@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+const RefreshAttemptsTableName = "refresh_attempts"
+
+// RefreshAttempt is the last scheduled refresh of a stored movie or
+// actor, failed refreshes are retried with backoff, so that records
+// failing every time never starve the others.
+type RefreshAttempt struct {
+	Type          string    `json:"type" gorm:"primaryKey"`
+	Provider      string    `json:"provider" gorm:"primaryKey"`
+	ID            string    `json:"id" gorm:"primaryKey"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+	// Failures is the number of consecutive failed attempts.
+	Failures      int       `json:"failures"`
+	NextAttemptAt time.Time `json:"next_attempt_at" gorm:"index"`
+}
+
+func (*RefreshAttempt) TableName() string {
+	return RefreshAttemptsTableName
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+const NumberStatesTableName = "number_states"
+
+// NumberState is the ownership and watched state of a number of a user,
+// the number is stored in the normalized form.
+type NumberState struct {
+	User        string     `json:"user" gorm:"column:username;primaryKey"`
+	Number      string     `json:"number" gorm:"primaryKey"`
+	Owned       bool       `json:"owned"`
+	OwnedAt     *time.Time `json:"owned_at,omitempty"`
+	Watched     bool       `json:"watched"`
+	WatchedAt   *time.Time `json:"watched_at,omitempty"`
+	TimeTracker `json:"-"`
+}
+
+func (*NumberState) TableName() string {
+	return NumberStatesTableName
+}
@@ -251,10 +251,10 @@ func (core *Core) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err e
 						//   this.$set(this.gallery.Rows[c], "FullsizeURL", f),
 						//   this.gallery.Rows[c].Protected && d && i && this.$set(this.gallery.Rows[c], "FullsizeURL", f += "?m=".concat(i))
 						//}
-						for _, row := range galleries.Rows {
+						for i, row := range galleries.Rows {
 							if !row.Protected {
-								info.PreviewImages = append(info.PreviewImages,
-									r.Request.AbsoluteURL(fmt.Sprintf(core.GalleryPath, row.Img)))
+								info.AddPreviewImage(r.Request.AbsoluteURL(
+									fmt.Sprintf(core.GalleryPath, row.Img)), i+1, "")
 							}
 						}
 					}
@@ -271,11 +271,10 @@ func (core *Core) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err e
 						}
 					}{}
 					if json.Unmarshal(r.Body, &galleries) == nil {
-						for _, row := range galleries.Rows {
+						for i, row := range galleries.Rows {
 							if !row.Protected {
-								info.PreviewImages = append(info.PreviewImages,
-									r.Request.AbsoluteURL(fmt.Sprintf(core.LegacyGalleryPath,
-										row.MovieID, row.Filename)))
+								info.AddPreviewImage(r.Request.AbsoluteURL(
+									fmt.Sprintf(core.LegacyGalleryPath, row.MovieID, row.Filename)), i+1, "")
 							}
 						}
 					}
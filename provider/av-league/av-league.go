@@ -9,9 +9,9 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
-	dt "gorm.io/datatypes"
 
 	"github.com/metatube-community/metatube-sdk-go/common/parser"
+	dt "github.com/metatube-community/metatube-sdk-go/datatypes"
 	"github.com/metatube-community/metatube-sdk-go/model"
 	"github.com/metatube-community/metatube-sdk-go/provider"
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+)
+
+// ContextBinder is implemented by scrapers embedded by providers, whose
+// requests can be bound to contexts.
+type ContextBinder interface {
+	// BindContext returns a copy of the binder sharing its state, whose
+	// requests are bound to ctx.
+	BindContext(ctx context.Context) ContextBinder
+}
+
+// WithContext returns a shallow copy of the provider whose requests are
+// bound to ctx, through the ContextBinder it embeds, the provider is
+// returned as is if it embeds none. The copy is meant for single calls.
+func WithContext[P any](p P, ctx context.Context) P {
+	if bound, ok := bindContext(reflect.ValueOf(p), ctx); ok {
+		if v, ok := bound.Interface().(P); ok {
+			return v
+		}
+	}
+	return p
+}
+
+// bindContext binds the first ContextBinder found among the exported
+// embedded pointers of v, the structs on the way are copied.
+func bindContext(v reflect.Value, ctx context.Context) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return v, false
+	}
+	elem := v.Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		if f := elem.Type().Field(i); !f.Anonymous || !f.IsExported() {
+			continue
+		}
+		bound, ok := bindContext(elem.Field(i), ctx)
+		if !ok {
+			continue
+		}
+		c := reflect.New(elem.Type())
+		c.Elem().Set(elem)
+		c.Elem().Field(i).Set(bound)
+		return c, true
+	}
+	// v embeds no binders, it may be one itself, unless promoted from
+	// unexported fields.
+	if b, ok := v.Interface().(ContextBinder); ok {
+		if bound := reflect.ValueOf(b.BindContext(ctx)); bound.Type() == v.Type() {
+			return bound, true
+		}
+	}
+	return v, false
+}
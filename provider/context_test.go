@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestBinder struct {
+	ctx context.Context
+}
+
+func (b *TestBinder) BindContext(ctx context.Context) ContextBinder { return &TestBinder{ctx: ctx} }
+
+type TestCore struct {
+	*TestBinder
+	Name string
+}
+
+type testProvider struct {
+	*TestCore
+	priority float64
+}
+
+func (p *testProvider) Name() string        { return p.TestCore.Name }
+func (p *testProvider) Priority() float64   { return p.priority }
+func (p *testProvider) SetPriority(float64) {}
+func (p *testProvider) URL() *url.URL       { return nil }
+
+type testUnexportedCore struct {
+	*TestBinder
+}
+
+type testUnexportedProvider struct {
+	*testUnexportedCore
+}
+
+func (p *testUnexportedProvider) Name() string        { return "" }
+func (p *testUnexportedProvider) Priority() float64   { return 0 }
+func (p *testUnexportedProvider) SetPriority(float64) {}
+func (p *testUnexportedProvider) URL() *url.URL       { return nil }
+
+func TestWithContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "v")
+
+	p := &testProvider{TestCore: &TestCore{TestBinder: &TestBinder{}, Name: "a"}, priority: 1}
+	bound := WithContext[Provider](p, ctx).(*testProvider)
+	assert.NotSame(t, p, bound)
+	assert.NotSame(t, p.TestCore, bound.TestCore)
+	assert.Equal(t, ctx, bound.ctx)
+	assert.Equal(t, "a", bound.Name())
+	assert.Equal(t, 1.0, bound.Priority())
+	assert.Nil(t, p.ctx) // the original is untouched.
+
+	// unexported embedded fields are never bound.
+	up := &testUnexportedProvider{testUnexportedCore: &testUnexportedCore{TestBinder: &TestBinder{}}}
+	assert.Same(t, up, WithContext[Provider](up, ctx))
+
+	var nilProvider *testProvider
+	assert.Nil(t, WithContext(nilProvider, ctx))
+}
@@ -120,10 +120,12 @@ func (core *Core) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err e
 		case "収録時間":
 			info.Runtime = parser.ParseRuntime(e.ChildText(`.//p`))
 		case "配信開始日":
-			info.ReleaseDate = parser.ParseDate(e.ChildText(`.//p`))
+			info.StreamingDate = parser.ParseDate(e.ChildText(`.//p`))
+			info.ReleaseDate = info.StreamingDate
 		case "発売日":
+			info.PhysicalDate = parser.ParseDate(e.ChildText(`.//p`))
 			if time.Time(info.ReleaseDate).IsZero() {
-				info.ReleaseDate = parser.ParseDate(e.ChildText(`.//p`))
+				info.ReleaseDate = info.PhysicalDate
 			}
 		}
 	})
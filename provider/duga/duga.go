@@ -107,8 +107,14 @@ func (duga *DUGA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err e
 	c.OnXML(`//div[@class="summaryinner"]//table//tr`, func(e *colly.XMLElement) {
 		switch e.ChildText(`.//th`) {
 		case "配信開始日", "発売日":
+			date := parser.ParseDate(e.ChildText(`.//td`))
+			if e.ChildText(`.//th`) == "配信開始日" {
+				info.StreamingDate = date
+			} else {
+				info.PhysicalDate = date
+			}
 			if time.Time(info.ReleaseDate).IsZero() {
-				info.ReleaseDate = parser.ParseDate(e.ChildText(`.//td`))
+				info.ReleaseDate = date
 			}
 		case "メーカー":
 			info.Maker = strings.TrimSpace(e.ChildText(`.//td`))
@@ -189,8 +195,9 @@ func (duga *DUGA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err e
 
 	// Preview Images
 	c.OnXML(`//*[@id="digestthumbbox"]/li`, func(e *colly.XMLElement) {
-		info.PreviewImages = append(info.PreviewImages,
-			e.Request.AbsoluteURL(e.ChildAttr(`.//a`, "href")))
+		// digest thumbs are captioned with scene titles.
+		info.AddPreviewImage(e.Request.AbsoluteURL(e.ChildAttr(`.//a`, "href")),
+			0, strings.TrimSpace(e.ChildAttr(`.//a`, "title")))
 	})
 
 	// Multiple (fallback)
@@ -42,20 +42,20 @@ func Register[T Provider](name string, factory func() T) {
 
 func RangeMovieFactory(f func(string, MovieFactory) bool) {
 	factoryMu.RLock()
+	defer factoryMu.RUnlock()
 	for name, factory := range movieFactories {
 		if !f(name, factory) {
 			return
 		}
 	}
-	factoryMu.RUnlock()
 }
 
 func RangeActorFactory(f func(string, ActorFactory) bool) {
 	factoryMu.RLock()
+	defer factoryMu.RUnlock()
 	for name, factory := range actorFactories {
 		if !f(name, factory) {
 			return
 		}
 	}
-	factoryMu.RUnlock()
 }
@@ -0,0 +1,64 @@
+package fanza
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// GetMovieCalendar lists DVD releases of the month from the release
+// calendar, titles are grouped under day rows like `1日(月)`.
+func (fz *FANZA) GetMovieCalendar(year int, month time.Month) (results []*model.MovieSearchResult, err error) {
+	c := fz.ClonedCollector()
+
+	var day int
+	c.OnXML(`//table[@id="monocal"]//tr`, func(e *colly.XMLElement) {
+		if d := parseCalendarDay(e.ChildText(`.//td[contains(@class,"cal-day")]`)); d > 0 {
+			day = d
+		}
+		homepage := e.Request.AbsoluteURL(e.ChildAttr(`.//td[@class="title-monocal"]//a`, "href"))
+		if day == 0 || homepage == "" {
+			return
+		}
+		id, _ := fz.ParseMovieIDFromURL(homepage) // ignore error.
+		if id == "" {
+			return
+		}
+		results = append(results, &model.MovieSearchResult{
+			ID:          id,
+			Number:      ParseNumber(id),
+			Title:       strings.TrimSpace(e.ChildText(`.//td[@class="title-monocal"]//a`)),
+			Provider:    fz.Name(),
+			Homepage:    homepage,
+			Actors:      e.ChildTexts(`.//td[@class="info-monocal"]//a`),
+			ReleaseDate: datatypes.Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)),
+		})
+	})
+
+	c.OnScraped(func(r *colly.Response) {
+		if isRegionError(r) {
+			err = ErrRegionNotAvailable
+		}
+	})
+
+	if vErr := c.Visit(fmt.Sprintf(calendarURL, month, year)); vErr != nil {
+		err = vErr
+	}
+	return
+}
+
+// parseCalendarDay parses the day of month, e.g., `21日(金)`.
+func parseCalendarDay(s string) int {
+	if ss := regexp.MustCompile(`(\d{1,2})日`).FindStringSubmatch(s); len(ss) == 2 {
+		day, _ := strconv.Atoi(ss[1])
+		return day
+	}
+	return 0
+}
@@ -0,0 +1,21 @@
+package fanza
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCalendarDay(t *testing.T) {
+	for _, unit := range []struct {
+		orig string
+		want int
+	}{
+		{"1日(月)", 1},
+		{"21日（金）", 21},
+		{"", 0},
+		{"発売日", 0},
+	} {
+		assert.Equal(t, unit.want, parseCalendarDay(unit.orig), unit.orig)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/antchfx/htmlquery"
@@ -23,6 +24,7 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/common/comparer"
 	"github.com/metatube-community/metatube-sdk-go/common/number"
 	"github.com/metatube-community/metatube-sdk-go/common/parser"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
 	"github.com/metatube-community/metatube-sdk-go/model"
 	"github.com/metatube-community/metatube-sdk-go/provider"
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/imcmp"
@@ -30,9 +32,13 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*FANZA)(nil)
-	_ provider.MovieSearcher = (*FANZA)(nil)
-	_ provider.MovieReviewer = (*FANZA)(nil)
+	_ provider.MovieProvider       = (*FANZA)(nil)
+	_ provider.MovieSearcher       = (*FANZA)(nil)
+	_ provider.MovieReviewer       = (*FANZA)(nil)
+	_ provider.MovieActorSearcher  = (*FANZA)(nil)
+	_ provider.MovieGenreSearcher  = (*FANZA)(nil)
+	_ provider.MovieCalendarGetter = (*FANZA)(nil)
+	_ provider.BaseURLSetter       = (*FANZA)(nil)
 )
 
 const (
@@ -42,6 +48,7 @@ const (
 
 const (
 	baseURL                 = "https://www.dmm.co.jp/"
+	generalBaseURL          = "https://www.dmm.com/"
 	baseDigitalURL          = "https://www.dmm.co.jp/digital/"
 	baseMonoURL             = "https://www.dmm.co.jp/mono/"
 	searchURL               = "https://www.dmm.co.jp/search/=/searchstr=%s/limit=120/sort=date/"
@@ -51,23 +58,60 @@ const (
 	movieDigitalNikkatsuURL = "https://www.dmm.co.jp/digital/nikkatsu/-/detail/=/cid=%s/"
 	movieMonoDVDURL         = "https://www.dmm.co.jp/mono/dvd/-/detail/=/cid=%s/"
 	movieMonoAnimeURL       = "https://www.dmm.co.jp/mono/anime/-/detail/=/cid=%s/"
+	movieVideoURL           = "https://video.dmm.co.jp/av/content/?id=%s"
+	searchActressURL        = "https://actress.dmm.co.jp/-/search/=/searchstr=%s/"
+	actressListURL          = "https://www.dmm.co.jp/digital/videoa/-/list/=/article=actress/id=%s/page=%d/"
+	genreListURL            = "https://www.dmm.co.jp/digital/videoa/-/genre/"
+	keywordListURL          = "https://www.dmm.co.jp/digital/videoa/-/list/=/article=keyword/id=%s/page=%d/"
+	calendarURL             = "https://www.dmm.co.jp/mono/dvd/-/calendar/=/month=%d/year=%d/day=1-31/"
 )
 
+// maxActressPages is the max number of filmography pages to fetch.
+const maxActressPages = 10
+
 const regionNotAvailable = "not-available-in-your-region"
 
 var ErrRegionNotAvailable = errors.New(regionNotAvailable)
 
+// defaultBaseURLs are the adult domain, and the general-audience one
+// which some releases live on instead.
+var defaultBaseURLs = []string{baseURL, generalBaseURL}
+
 type FANZA struct {
 	*scraper.Scraper
+	baseURLs atomic.Pointer[[]string]
 }
 
 func New() *FANZA {
-	return &FANZA{
+	fz := &FANZA{
 		Scraper: scraper.NewDefaultScraper(Name, baseURL, Priority,
-			scraper.WithCookies(baseURL, []*http.Cookie{
-				{Name: "age_check_done", Value: "1"},
-			})),
+			scraper.WithCookies(baseURL, ageCheckCookies()),
+			scraper.WithCookies(generalBaseURL, ageCheckCookies()),
+			// new React layout.
+			scraper.WithHeadless(`^https://video\.dmm\.co\.jp/`)),
+	}
+	fz.baseURLs.Store(&defaultBaseURLs)
+	return fz
+}
+
+func ageCheckCookies() []*http.Cookie {
+	return []*http.Cookie{{Name: "age_check_done", Value: "1"}}
+}
+
+// SetBaseURLs sets the domains to look up movies in order, the legacy
+// pages of IDs not found on the first one fall back to the rest.
+func (fz *FANZA) SetBaseURLs(urls []*url.URL) {
+	if len(urls) == 0 {
+		fz.baseURLs.Store(&defaultBaseURLs)
+		return
+	}
+	bases := make([]string, 0, len(urls))
+	for _, u := range urls {
+		base := strings.TrimSuffix(u.String(), "/") + "/"
+		_ = fz.SetCookies(base, ageCheckCookies()) // ignore error.
+		bases = append(bases, base)
 	}
+	fz.baseURLs.Store(&bases)
 }
 
 func (fz *FANZA) NormalizeMovieID(id string) string {
@@ -75,7 +119,7 @@ func (fz *FANZA) NormalizeMovieID(id string) string {
 }
 
 func (fz *FANZA) getHomepagesByID(id string) []string {
-	homepages := []string{
+	legacy := []string{
 		fmt.Sprintf(movieMonoDVDURL, id),
 		fmt.Sprintf(movieDigitalVideoAURL, id),
 		fmt.Sprintf(movieDigitalVideoCURL, id),
@@ -85,7 +129,17 @@ func (fz *FANZA) getHomepagesByID(id string) []string {
 	}
 	if regexp.MustCompile(`(?i)[a-z]+00\d{3,}`).MatchString(id) {
 		// might be digital videoa url, try it first.
-		homepages[0], homepages[1] = homepages[1], homepages[0]
+		legacy[0], legacy[1] = legacy[1], legacy[0]
+	}
+	var homepages []string
+	for i, base := range *fz.baseURLs.Load() {
+		for _, homepage := range legacy {
+			homepages = append(homepages, base+strings.TrimPrefix(homepage, baseURL))
+		}
+		if i == 0 {
+			// new layout, in case legacy pages are gone.
+			homepages = append(homepages, fmt.Sprintf(movieVideoURL, id))
+		}
 	}
 	return homepages
 }
@@ -110,6 +164,8 @@ func (fz *FANZA) ParseMovieIDFromURL(rawURL string) (id string, err error) {
 	if sub := regexp.MustCompile(`/cid=(.*?)/`).
 		FindStringSubmatch(homepage.Path); len(sub) == 2 {
 		id = fz.NormalizeMovieID(sub[1])
+	} else if v := homepage.Query().Get("id"); v != "" /* new layout */ {
+		id = fz.NormalizeMovieID(v)
 	}
 	return
 }
@@ -150,6 +206,12 @@ func (fz *FANZA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err er
 		info.CoverURL = e.Request.AbsoluteURL(PreviewSrc(e.Attr("href")))
 	})
 
+	// VR attributes
+	var (
+		isVR      bool
+		vrDevices string
+	)
+
 	// Fields
 	c.OnXML(`//tr`, func(e *colly.XMLElement) {
 		switch e.ChildText(`.//td[1]`) {
@@ -172,9 +234,18 @@ func (fz *FANZA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err er
 			info.Runtime = parser.ParseRuntime(e.ChildText(`.//td[2]`))
 		case "監督：":
 			info.Director = strings.Trim(e.ChildText(`.//td[2]`), "-")
+		case "対応デバイス：":
+			vrDevices = e.ChildText(`.//td[2]`)
 		case "配信開始日：", "商品発売日：", "発売日：", "貸出開始日：":
+			date := parser.ParseDate(e.ChildText(`.//td[2]`))
+			switch e.ChildText(`.//td[1]`) {
+			case "配信開始日：":
+				info.StreamingDate = date
+			case "商品発売日：", "発売日：":
+				info.PhysicalDate = date
+			}
 			if time.Time(info.ReleaseDate).IsZero() {
-				info.ReleaseDate = parser.ParseDate(e.ChildText(`.//td[2]`))
+				info.ReleaseDate = date
 			}
 		}
 	})
@@ -229,6 +300,11 @@ func (fz *FANZA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err er
 		}
 	})
 
+	// New layout (video.dmm.co.jp), legacy pages may redirect to it.
+	c.OnXML(`//script[@id="__NEXT_DATA__"]`, func(e *colly.XMLElement) {
+		parseNextData(e.Text, info, e.Request.AbsoluteURL)
+	})
+
 	// Title (fallback)
 	c.OnXML(`//meta[@property="og:title"]`, func(e *colly.XMLElement) {
 		if info.Title != "" {
@@ -320,6 +396,7 @@ func (fz *FANZA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err er
 
 	// Preview Video (VR)
 	c.OnXML(`//*[@id="detail-sample-vr-movie"]/div/a`, func(e *colly.XMLElement) {
+		isVR = true
 		d := c.Clone()
 		d.OnResponse(func(r *colly.Response) {
 			sub := regexp.MustCompile(`var sampleUrl = "(.+?)";`).FindSubmatch(r.Body)
@@ -359,12 +436,20 @@ func (fz *FANZA) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err er
 		info.PreviewImages = previewImageSet.Slice()
 	})
 
+	// Final (VR)
+	c.OnScraped(func(_ *colly.Response) {
+		if info.VR = parseVRInfo(isVR, info.Title, info.Genres, vrDevices); info.VR != nil {
+			info.Genres = tagVR(info.Genres)
+		}
+	})
+
 	// Final (images)
 	c.OnScraped(func(_ *colly.Response) {
 		if info.CoverURL == "" {
 			// try to convert thumb url to cover url.
 			info.CoverURL = PreviewSrc(info.ThumbURL)
 		}
+		dropPlaceholderImages(info)
 	})
 
 	// Find big thumb/cover images (awsimgsrc.dmm.co.jp)
@@ -508,34 +593,9 @@ func (fz *FANZA) searchMovie(keyword string) (results []*model.MovieSearchResult
 	c := fz.ClonedCollector()
 
 	c.OnXML(`//*[@id="list"]/li`, func(e *colly.XMLElement) {
-		homepage := e.Request.AbsoluteURL(e.ChildAttr(`.//p[@class="tmb"]/a`, "href"))
-		if !strings.HasPrefix(homepage, baseDigitalURL) && !strings.HasPrefix(homepage, baseMonoURL) {
-			return // ignore other contents.
-		}
-		id, _ := fz.ParseMovieIDFromURL(homepage) // ignore error.
-
-		thumb := e.ChildAttr(`.//p[@class="tmb"]/a/span[1]/img`, "src")
-		if re := regexp.MustCompile(`(p[a-z]\.)jpg`); re.MatchString(thumb) {
-			thumb = re.ReplaceAllString(thumb, "ps.jpg")
-		}
-
-		var releaseDate string
-		rate := e.ChildText(`.//p[@class="rate"]`)
-		if re := regexp.MustCompile(`(配信日|発売日|貸出日)：\s*`); re.MatchString(rate) {
-			releaseDate = re.ReplaceAllString(rate, "")
-			rate = "" // reset rate.
-		}
-		results = append(results, &model.MovieSearchResult{
-			ID:          id,
-			Number:      ParseNumber(id),
-			Title:       e.ChildAttr(`.//p[@class="tmb"]/a/span[1]/img`, "alt"),
-			Provider:    fz.Name(),
-			Homepage:    homepage,
-			ThumbURL:    e.Request.AbsoluteURL(thumb),
-			CoverURL:    e.Request.AbsoluteURL(PreviewSrc(thumb)),
-			Score:       parser.ParseScore(rate /* float or a dash (-) */),
-			ReleaseDate: parser.ParseDate(releaseDate /* 発売日：2022/07/21 */),
-		})
+		if result := fz.parseListItem(e); result != nil {
+			results = append(results, result)
+		}
 	})
 
 	c.OnScraped(func(r *colly.Response) {
@@ -550,6 +610,130 @@ func (fz *FANZA) searchMovie(keyword string) (results []*model.MovieSearchResult
 	return
 }
 
+func (fz *FANZA) parseListItem(e *colly.XMLElement) *model.MovieSearchResult {
+	homepage := e.Request.AbsoluteURL(e.ChildAttr(`.//p[@class="tmb"]/a`, "href"))
+	if !strings.HasPrefix(homepage, baseDigitalURL) && !strings.HasPrefix(homepage, baseMonoURL) {
+		return nil // ignore other contents.
+	}
+	id, _ := fz.ParseMovieIDFromURL(homepage) // ignore error.
+
+	thumb := e.ChildAttr(`.//p[@class="tmb"]/a/span[1]/img`, "src")
+	if re := regexp.MustCompile(`(p[a-z]\.)jpg`); re.MatchString(thumb) {
+		thumb = re.ReplaceAllString(thumb, "ps.jpg")
+	}
+
+	var (
+		releaseDate   string
+		streamingDate datatypes.Date
+		physicalDate  datatypes.Date
+	)
+	rate := e.ChildText(`.//p[@class="rate"]`)
+	if re := regexp.MustCompile(`(配信日|発売日|貸出日)：\s*`); re.MatchString(rate) {
+		releaseDate = re.ReplaceAllString(rate, "")
+		switch re.FindStringSubmatch(rate)[1] {
+		case "配信日":
+			streamingDate = parser.ParseDate(releaseDate)
+		case "発売日":
+			physicalDate = parser.ParseDate(releaseDate)
+		}
+		rate = "" // reset rate.
+	}
+	return &model.MovieSearchResult{
+		ID:          id,
+		Number:      ParseNumber(id),
+		Title:       e.ChildAttr(`.//p[@class="tmb"]/a/span[1]/img`, "alt"),
+		Provider:    fz.Name(),
+		Homepage:    homepage,
+		ThumbURL:    e.Request.AbsoluteURL(thumb),
+		CoverURL:    e.Request.AbsoluteURL(PreviewSrc(thumb)),
+		Score:       parser.ParseScore(rate /* float or a dash (-) */),
+		ReleaseDate: parser.ParseDate(releaseDate /* 発売日：2022/07/21 */),
+
+		StreamingDate: streamingDate,
+		PhysicalDate:  physicalDate,
+	}
+}
+
+func (fz *FANZA) SearchMovieByActor(name string) (results []*model.MovieSearchResult, err error) {
+	// Find the actress ID first, exact match is preferred.
+	var actressID string
+	c := fz.ClonedCollector()
+	c.OnXML(`//a[contains(@href,"actress_id=")]`, func(e *colly.XMLElement) {
+		if actressID != "" && strings.TrimSpace(e.Text) != name {
+			return
+		}
+		if ss := regexp.MustCompile(`actress_id=(\d+)`).FindStringSubmatch(e.Attr("href")); len(ss) == 2 {
+			actressID = ss[1]
+		}
+	})
+	if err = c.Visit(fmt.Sprintf(searchActressURL, url.QueryEscape(name))); err != nil {
+		return nil, err
+	}
+	if actressID == "" {
+		return nil, provider.ErrInfoNotFound
+	}
+
+	// Then the paginated filmography.
+	for page := 1; page <= maxActressPages; page++ {
+		var found int
+		d := fz.ClonedCollector()
+		d.OnXML(`//*[@id="list"]/li`, func(e *colly.XMLElement) {
+			if result := fz.parseListItem(e); result != nil {
+				results = append(results, result)
+				found++
+			}
+		})
+		d.OnScraped(func(r *colly.Response) {
+			if isRegionError(r) {
+				err = ErrRegionNotAvailable
+			}
+		})
+		if vErr := d.Visit(fmt.Sprintf(actressListURL, actressID, page)); vErr != nil && page == 1 {
+			return nil, vErr
+		}
+		if err != nil || found == 0 {
+			break
+		}
+	}
+	return
+}
+
+func (fz *FANZA) SearchMovieByGenre(genre string, page int) (results []*model.MovieSearchResult, err error) {
+	// Find the genre (keyword) ID from the genre list.
+	var genreID string
+	c := fz.ClonedCollector()
+	c.OnXML(`//a[contains(@href,"article=keyword/id=")]`, func(e *colly.XMLElement) {
+		if genreID != "" || strings.TrimSpace(e.Text) != genre {
+			return
+		}
+		if ss := regexp.MustCompile(`article=keyword/id=(\d+)`).FindStringSubmatch(e.Attr("href")); len(ss) == 2 {
+			genreID = ss[1]
+		}
+	})
+	if err = c.Visit(genreListURL); err != nil {
+		return nil, err
+	}
+	if genreID == "" {
+		return nil, provider.ErrInfoNotFound
+	}
+
+	d := fz.ClonedCollector()
+	d.OnXML(`//*[@id="list"]/li`, func(e *colly.XMLElement) {
+		if result := fz.parseListItem(e); result != nil {
+			results = append(results, result)
+		}
+	})
+	d.OnScraped(func(r *colly.Response) {
+		if isRegionError(r) {
+			err = ErrRegionNotAvailable
+		}
+	})
+	if vErr := d.Visit(fmt.Sprintf(keywordListURL, genreID, page)); vErr != nil {
+		return nil, vErr
+	}
+	return
+}
+
 func (fz *FANZA) GetMovieReviewsByID(id string) (reviews []*model.MovieReviewDetail, err error) {
 	for _, homepage := range fz.getHomepagesByID(id) {
 		if reviews, err = fz.GetMovieReviewsByURL(homepage); err == nil && len(reviews) > 0 {
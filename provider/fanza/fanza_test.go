@@ -1,6 +1,7 @@
 package fanza
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -131,3 +132,19 @@ func TestPreviewSrc(t *testing.T) {
 		assert.Equal(t, unit.want, PreviewSrc(unit.src))
 	}
 }
+
+func TestFANZA_SetBaseURLs(t *testing.T) {
+	fz := New()
+	homepages := fz.getHomepagesByID("abc123")
+	assert.Equal(t, "https://www.dmm.co.jp/mono/dvd/-/detail/=/cid=abc123/", homepages[0])
+	assert.Contains(t, homepages, "https://video.dmm.co.jp/av/content/?id=abc123")
+	assert.Equal(t, "https://www.dmm.com/digital/nikkatsu/-/detail/=/cid=abc123/", homepages[len(homepages)-1])
+
+	fz.SetBaseURLs([]*url.URL{{Scheme: "https", Host: "www.dmm.com"}})
+	homepages = fz.getHomepagesByID("abc123")
+	assert.Equal(t, "https://www.dmm.com/mono/dvd/-/detail/=/cid=abc123/", homepages[0])
+	assert.Len(t, homepages, 7)
+
+	fz.SetBaseURLs(nil)
+	assert.Len(t, fz.getHomepagesByID("abc123"), 13)
+}
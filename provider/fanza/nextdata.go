@@ -0,0 +1,134 @@
+package fanza
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/parser"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// nextData is the data embedded in the new React layout pages of
+// video.dmm.co.jp, i.e. <script id="__NEXT_DATA__">.
+type nextData struct {
+	Props struct {
+		PageProps struct {
+			Content *nextContent `json:"content"`
+		} `json:"pageProps"`
+	} `json:"props"`
+}
+
+type nextName struct {
+	Name string `json:"name"`
+}
+
+type nextContent struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	PackageImage struct {
+		LargeURL  string `json:"largeUrl"`
+		MediumURL string `json:"mediumUrl"`
+	} `json:"packageImage"`
+	SampleImages []struct {
+		ImageURL      string `json:"imageUrl"`
+		LargeImageURL string `json:"largeImageUrl"`
+	} `json:"sampleImages"`
+	SampleMovie struct {
+		HighestMovieURL string `json:"highestMovieUrl"`
+	} `json:"sample2DMovie"`
+	Duration          int        `json:"duration"` // seconds
+	DeliveryStartDate string     `json:"deliveryStartDate"`
+	MakerReleasedAt   string     `json:"makerReleasedAt"`
+	Actresses         []nextName `json:"actresses"`
+	Directors         []nextName `json:"directors"`
+	Series            *nextName  `json:"series"`
+	Maker             *nextName  `json:"maker"`
+	Label             *nextName  `json:"label"`
+	Genres            []nextName `json:"genres"`
+	Review            struct {
+		Average float64 `json:"average"`
+		Count   int     `json:"count"`
+	} `json:"review"`
+	WishlistCount int `json:"wishlistCount"`
+}
+
+// parseNextData fills info with the __NEXT_DATA__ JSON, it reports
+// whether the JSON contains any content.
+func parseNextData(data string, info *model.MovieInfo, absURL func(string) string) bool {
+	next := &nextData{}
+	if err := json.Unmarshal([]byte(data), next); err != nil {
+		return false
+	}
+	content := next.Props.PageProps.Content
+	if content == nil || content.ID == "" {
+		return false
+	}
+
+	info.ID = content.ID
+	info.Number = ParseNumber(content.ID)
+	info.Title = strings.TrimSpace(content.Title)
+	info.Summary = strings.TrimSpace(content.Description)
+	if content.PackageImage.MediumURL != "" {
+		info.ThumbURL = absURL(content.PackageImage.MediumURL)
+	}
+	if content.PackageImage.LargeURL != "" {
+		info.CoverURL = absURL(content.PackageImage.LargeURL)
+	}
+	info.PreviewImages = info.PreviewImages[:0]
+	for _, img := range content.SampleImages {
+		src := img.LargeImageURL
+		if src == "" {
+			src = PreviewSrc(img.ImageURL)
+		}
+		if src != "" {
+			info.PreviewImages = append(info.PreviewImages, absURL(src))
+		}
+	}
+	if content.SampleMovie.HighestMovieURL != "" {
+		info.PreviewVideoURL = absURL(content.SampleMovie.HighestMovieURL)
+	}
+	if content.Duration > 0 {
+		info.Runtime = (content.Duration + 59) / 60
+	}
+	if content.DeliveryStartDate != "" {
+		info.StreamingDate = parser.ParseDate(content.DeliveryStartDate)
+	}
+	if content.MakerReleasedAt != "" {
+		info.PhysicalDate = parser.ParseDate(content.MakerReleasedAt)
+	}
+	for _, date := range []string{content.DeliveryStartDate, content.MakerReleasedAt} {
+		if date != "" {
+			info.ReleaseDate = parser.ParseDate(date)
+			break
+		}
+	}
+	info.Actors = names(content.Actresses)
+	info.Genres = names(content.Genres)
+	if directors := names(content.Directors); len(directors) > 0 {
+		info.Director = directors[0]
+	}
+	if content.Series != nil {
+		info.Series = content.Series.Name
+	}
+	if content.Maker != nil {
+		info.Maker = content.Maker.Name
+	}
+	if content.Label != nil {
+		info.Label = content.Label.Name
+	}
+	info.Score = content.Review.Average
+	info.ReviewCount = content.Review.Count
+	info.WishlistCount = content.WishlistCount
+	return true
+}
+
+func names(v []nextName) []string {
+	out := make([]string, 0, len(v))
+	for _, n := range v {
+		if name := strings.TrimSpace(n.Name); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
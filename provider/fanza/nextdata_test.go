@@ -0,0 +1,65 @@
+package fanza
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestParseNextData(t *testing.T) {
+	const data = `{"props":{"pageProps":{"content":{
+		"id":"midv00047",
+		"title":" Title ",
+		"description":"Summary",
+		"packageImage":{"largeUrl":"https://pics.dmm.co.jp/digital/video/midv00047/midv00047pl.jpg","mediumUrl":"https://pics.dmm.co.jp/digital/video/midv00047/midv00047ps.jpg"},
+		"sampleImages":[{"imageUrl":"https://pics.dmm.co.jp/digital/video/midv00047/midv00047-1.jpg"}],
+		"sample2DMovie":{"highestMovieUrl":"https://cc3001.dmm.co.jp/litevideo/freepv/m/mid/midv00047/midv00047_mhb_w.mp4"},
+		"duration":7205,
+		"deliveryStartDate":"2022-02-15T10:00:00+09:00",
+		"actresses":[{"name":"Actress"}],
+		"directors":[{"name":"Director"}],
+		"maker":{"name":"Maker"},
+		"genres":[{"name":"Genre"},{"name":" "}],
+		"review":{"average":4.5,"count":12},
+		"wishlistCount":345
+	}}}}`
+
+	info := &model.MovieInfo{}
+	require.True(t, parseNextData(data, info, func(s string) string { return s }))
+	assert.Equal(t, "midv00047", info.ID)
+	assert.Equal(t, "MIDV-047", info.Number)
+	assert.Equal(t, "Title", info.Title)
+	assert.Equal(t, "https://pics.dmm.co.jp/digital/video/midv00047/midv00047pl.jpg", info.CoverURL)
+	assert.Equal(t, []string{"https://pics.dmm.co.jp/digital/video/midv00047/midv00047jp-1.jpg"}, []string(info.PreviewImages))
+	assert.Equal(t, 121, info.Runtime)
+	assert.Equal(t, 2022, time.Time(info.ReleaseDate).Year())
+	assert.Equal(t, info.ReleaseDate, info.StreamingDate)
+	assert.True(t, time.Time(info.PhysicalDate).IsZero())
+	assert.Equal(t, []string{"Actress"}, []string(info.Actors))
+	assert.Equal(t, []string{"Genre"}, []string(info.Genres))
+	assert.Equal(t, "Director", info.Director)
+	assert.Equal(t, "Maker", info.Maker)
+	assert.Equal(t, 4.5, info.Score)
+	assert.Equal(t, 12, info.ReviewCount)
+	assert.Equal(t, 345, info.WishlistCount)
+
+	// legacy pages have no content.
+	assert.False(t, parseNextData(`{"props":{"pageProps":{}}}`, &model.MovieInfo{}, nil))
+	assert.False(t, parseNextData(`invalid`, &model.MovieInfo{}, nil))
+}
+
+func TestFANZA_ParseMovieIDFromURL(t *testing.T) {
+	fz := &FANZA{}
+	for rawURL, want := range map[string]string{
+		"https://www.dmm.co.jp/digital/videoa/-/detail/=/cid=midv00047/": "midv00047",
+		"https://video.dmm.co.jp/av/content/?id=MIDV00047":               "midv00047",
+	} {
+		id, err := fz.ParseMovieIDFromURL(rawURL)
+		require.NoError(t, err)
+		assert.Equal(t, want, id)
+	}
+}
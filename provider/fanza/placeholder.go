@@ -0,0 +1,51 @@
+package fanza
+
+import (
+	"strings"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/provider/internal/imcmp"
+)
+
+// placeholderPatterns are the path patterns of DMM's placeholder images.
+var placeholderPatterns = []string{
+	"/now_printing/",
+	"/noimage/",
+	"now_printing.jpg",
+}
+
+// placeholderPeriod is the period around the release date, during which
+// the cover images might still be placeholders.
+const placeholderPeriod = 30 * 24 * time.Hour
+
+// isPlaceholderURL reports whether the url is a known placeholder image.
+func isPlaceholderURL(s string) bool {
+	for _, pattern := range placeholderPatterns {
+		if strings.Contains(s, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropPlaceholderImages empties the placeholder cover and thumb images,
+// so that real ones can be pulled from other providers instead.
+func dropPlaceholderImages(info *model.MovieInfo) {
+	if isPlaceholderURL(info.ThumbURL) {
+		info.ThumbURL = ""
+	}
+	if isPlaceholderURL(info.CoverURL) {
+		info.CoverURL = ""
+	}
+	if info.CoverURL == "" {
+		return
+	}
+	// only recent releases are checked, since the images
+	// must be fetched to be compared.
+	if release := time.Time(info.ReleaseDate); !release.IsZero() &&
+		time.Since(release) < placeholderPeriod &&
+		imcmp.Placeholder(info.CoverURL, nil) {
+		info.CoverURL = ""
+	}
+}
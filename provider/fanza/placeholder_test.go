@@ -0,0 +1,27 @@
+package fanza
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestDropPlaceholderImages(t *testing.T) {
+	info := &model.MovieInfo{
+		ThumbURL: "https://pics.dmm.co.jp/mono/movie/adult/now_printing/now_printing.jpg",
+		CoverURL: "https://pics.dmm.co.jp/mono/noimage/movie/adult_pl.jpg",
+	}
+	dropPlaceholderImages(info)
+	assert.Empty(t, info.ThumbURL)
+	assert.Empty(t, info.CoverURL)
+
+	info = &model.MovieInfo{
+		ThumbURL: "https://pics.dmm.co.jp/digital/video/midv00047/midv00047ps.jpg",
+		CoverURL: "https://pics.dmm.co.jp/digital/video/midv00047/midv00047pl.jpg",
+	}
+	dropPlaceholderImages(info) // released long ago, not fetched.
+	assert.NotEmpty(t, info.ThumbURL)
+	assert.NotEmpty(t, info.CoverURL)
+}
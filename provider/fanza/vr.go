@@ -0,0 +1,52 @@
+package fanza
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// vrGenre is the genre added to VR movies, it's also the
+// genre FANZA uses for VR-only titles.
+const vrGenre = "VR専用"
+
+var (
+	vrResolutionRe = regexp.MustCompile(`(?i)(8K|6K|5K|4K)`)
+	vrDeviceSepRe  = regexp.MustCompile(`\s*[/／、,，]\s*`)
+)
+
+// parseVRInfo returns the VR attributes of a movie, or nil if it's
+// not a VR movie. The devices are text of the 対応デバイス field.
+func parseVRInfo(isVR bool, title string, genres []string, devices string) *model.MovieVRInfo {
+	text := title + " " + strings.Join(genres, " ")
+	if !isVR &&
+		!strings.Contains(title, "【VR】") &&
+		!strings.Contains(text, vrGenre) {
+		return nil
+	}
+	vr := &model.MovieVRInfo{
+		HighQuality: strings.Contains(text, "ハイクオリティVR") ||
+			strings.Contains(text, "高画質VR") ||
+			strings.Contains(text, "高画質版"),
+	}
+	if m := vrResolutionRe.FindStringSubmatch(text); len(m) == 2 {
+		vr.Resolution = strings.ToUpper(m[1])
+	}
+	for _, device := range vrDeviceSepRe.Split(strings.TrimSpace(devices), -1) {
+		if device = strings.Trim(device, "- "); device != "" {
+			vr.Devices = append(vr.Devices, device)
+		}
+	}
+	return vr
+}
+
+// tagVR adds the VR genre to genres, if absent.
+func tagVR(genres []string) []string {
+	for _, genre := range genres {
+		if genre == vrGenre {
+			return genres
+		}
+	}
+	return append(genres, vrGenre)
+}
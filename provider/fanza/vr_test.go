@@ -0,0 +1,26 @@
+package fanza
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVRInfo(t *testing.T) {
+	assert.Nil(t, parseVRInfo(false, "Title", []string{"単体作品"}, ""))
+
+	vr := parseVRInfo(false, "【VR】【8KVR】Title", []string{"ハイクオリティVR"}, "Meta Quest / PlayStation VR2、PICO")
+	require.NotNil(t, vr)
+	assert.True(t, vr.HighQuality)
+	assert.Equal(t, "8K", vr.Resolution)
+	assert.Equal(t, []string{"Meta Quest", "PlayStation VR2", "PICO"}, vr.Devices)
+
+	vr = parseVRInfo(true, "Title", nil, "")
+	require.NotNil(t, vr)
+	assert.False(t, vr.HighQuality)
+	assert.Empty(t, vr.Resolution)
+
+	assert.Equal(t, []string{"単体作品", vrGenre}, tagVR([]string{"単体作品"}))
+	assert.Equal(t, []string{vrGenre}, tagVR([]string{vrGenre}))
+}
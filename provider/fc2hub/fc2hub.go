@@ -39,7 +39,8 @@ type FC2HUB struct {
 }
 
 func New() *FC2HUB {
-	return &FC2HUB{scraper.NewDefaultScraper(Name, baseURL, Priority)}
+	return &FC2HUB{scraper.NewDefaultScraper(Name, baseURL, Priority,
+		scraper.WithHeaderProfile(""))}
 }
 
 func (fc2hub *FC2HUB) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
@@ -176,6 +177,14 @@ func (fc2hub *FC2HUB) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, e
 			case "CreativeWorkSeries":
 				// Average rating score.
 				info.Score = data.AggregateRating.RatingValue
+				info.ReviewCount = data.AggregateRating.RatingCount
+				if rating := data.AggregateRating; rating.BestRating > 0 {
+					info.Rating = &model.MovieRating{
+						Value: rating.RatingValue,
+						Scale: rating.BestRating,
+						Votes: rating.RatingCount,
+					}
+				}
 			case "WebPage":
 				//if data.URL != "" {
 				//	// Update homepage URL.
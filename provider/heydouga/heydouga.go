@@ -179,6 +179,12 @@ func (hey *HeyDouga) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, er
 					}{}
 					if json.Unmarshal(r.Body, &data) == nil {
 						info.Score = parser.ParseScore(data.MovieRatingAverage)
+						info.Rating = &model.MovieRating{
+							Value: info.Score,
+							Scale: 5,
+							Votes: parser.ParseInt(data.MovieRatingCount),
+						}
+						info.ReviewCount = info.Rating.Votes
 					}
 				})
 				d.Visit(r.Request.AbsoluteURL(ratingURL))
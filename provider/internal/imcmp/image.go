@@ -79,3 +79,13 @@ func Similar(imageUrlA, imageUrlB string, fetcher provider.Fetcher) bool {
 
 	return imageutil.Similar(imgA, imgB)
 }
+
+// Placeholder reports whether the image of the url is a placeholder,
+// e.g. a "now printing" cover. Unavailable images are not reported.
+func Placeholder(imageUrl string, fetcher provider.Fetcher) bool {
+	img, err := getImageByURL(imageUrl, fetcher)
+	if err != nil {
+		return false
+	}
+	return imageutil.IsPlaceholder(img)
+}
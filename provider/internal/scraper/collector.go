@@ -0,0 +1,120 @@
+package scraper
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gocolly/colly/v2"
+
+	"github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// Collector is a colly.Collector of the scraper, XPath callbacks
+// registered by OnXML are traced if tracing is enabled, and their
+// selectors are replaced by the overrides, if any.
+type Collector struct {
+	*colly.Collector
+	collectorConfig
+
+	mu sync.Mutex
+	// XPath selectors registered by OnXML.
+	queries []string
+	// matched selectors by request ID.
+	matched map[uint32]map[string]struct{}
+}
+
+// collectorConfig is the config of a collector and its clones.
+type collectorConfig struct {
+	// trace of scrapes, nil if tracing is disabled.
+	trace *provider.Trace
+	// XPath selectors overriding the built-in ones.
+	xpaths map[string]string
+	// record is called with fetched documents, optional.
+	record func(*provider.Payload)
+}
+
+func newCollector(c *colly.Collector, cfg collectorConfig) *Collector {
+	cc := &Collector{Collector: c, collectorConfig: cfg}
+	if record := cfg.record; record != nil {
+		c.OnResponse(func(r *colly.Response) {
+			if len(r.Body) <= provider.MaxPayloadSize {
+				record(&provider.Payload{
+					URL:         r.Request.URL.String(),
+					ContentType: r.Headers.Get("Content-Type"),
+					Body:        r.Body,
+				})
+			}
+		})
+	}
+	trace := cfg.trace
+	if trace == nil {
+		return cc
+	}
+	cc.matched = make(map[uint32]map[string]struct{})
+	c.OnResponse(func(r *colly.Response) {
+		trace.AddPage(&provider.TracePage{
+			URL:         r.Request.URL.String(),
+			Status:      r.StatusCode,
+			ContentType: r.Headers.Get("Content-Type"),
+			Size:        len(r.Body),
+		})
+	})
+	c.OnScraped(func(r *colly.Response) {
+		cc.mu.Lock()
+		defer cc.mu.Unlock()
+		matched := cc.matched[r.Request.ID]
+		delete(cc.matched, r.Request.ID)
+		for _, query := range cc.queries {
+			if _, ok := matched[query]; !ok {
+				trace.AddMiss(r.Request.URL.String(), query)
+			}
+		}
+	})
+	return cc
+}
+
+// Clone returns a clone of the collector, registered callbacks are not
+// cloned, the same as colly.Collector.Clone.
+func (c *Collector) Clone() *Collector {
+	return newCollector(c.Collector.Clone(), c.collectorConfig)
+}
+
+// OnXML registers the callback of the XPath query, the same as
+// colly.Collector.OnXML, except that the query is replaced by its
+// override if set.
+func (c *Collector) OnXML(query string, f colly.XMLCallback) {
+	if override, ok := c.xpaths[query]; ok {
+		query = override
+	}
+	if c.trace == nil {
+		c.Collector.OnXML(query, f)
+		return
+	}
+	c.mu.Lock()
+	c.queries = append(c.queries, query)
+	c.mu.Unlock()
+	c.Collector.OnXML(query, func(e *colly.XMLElement) {
+		c.mu.Lock()
+		if c.matched[e.Request.ID] == nil {
+			c.matched[e.Request.ID] = make(map[string]struct{})
+		}
+		c.matched[e.Request.ID][query] = struct{}{}
+		c.mu.Unlock()
+		c.trace.AddMatch(e.Request.URL.String(), query, rawValue(e))
+		f(e)
+	})
+}
+
+// rawValue returns the text of the element, or the value of its
+// first link-like attribute if the text is empty.
+func rawValue(e *colly.XMLElement) string {
+	if text := strings.TrimSpace(e.Text); text != "" {
+		return text
+	}
+	for _, key := range []string{"src", "href", "content", "value", "title"} {
+		if v := e.Attr(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
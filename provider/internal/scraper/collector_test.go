@@ -0,0 +1,113 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+func TestCollector_Trace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><body><h1> Title </h1><img class="cover" src="/cover.jpg"></body></html>`))
+	}))
+	defer srv.Close()
+
+	s := NewScraper("TEST", srv.URL, 0)
+	trace := &provider.Trace{}
+	s.SetTrace(trace)
+
+	var title string
+	c := s.ClonedCollector()
+	c.OnXML(`//h1`, func(e *colly.XMLElement) { title = e.Text })
+	c.OnXML(`//img[@class="cover"]`, func(*colly.XMLElement) {})
+	c.OnXML(`//div[@class="missing"]`, func(*colly.XMLElement) {})
+	require.NoError(t, c.Visit(srv.URL))
+
+	assert.Equal(t, " Title ", title)
+	if assert.Len(t, trace.Pages, 1) {
+		assert.Equal(t, http.StatusOK, trace.Pages[0].Status)
+	}
+	if assert.Len(t, trace.Matches, 2) {
+		assert.Equal(t, "Title", trace.Matches[0].Raw)
+		assert.Equal(t, "/cover.jpg", trace.Matches[1].Raw)
+	}
+	if assert.Len(t, trace.Misses, 1) {
+		assert.Equal(t, `//div[@class="missing"]`, trace.Misses[0].XPath)
+	}
+
+	// clones are traced as well.
+	d := c.Clone()
+	d.OnXML(`//h1`, func(*colly.XMLElement) {})
+	require.NoError(t, d.Visit(srv.URL+"/?page=2"))
+	assert.Len(t, trace.Pages, 2)
+	assert.Len(t, trace.Matches, 3)
+
+	s.SetTrace(nil)
+	require.NoError(t, s.ClonedCollector().Visit(srv.URL+"/?page=3"))
+	assert.Len(t, trace.Pages, 2)
+}
+
+func TestCollector_XPathOverrides(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><body><h2 class="title">Title</h2></body></html>`))
+	}))
+	defer srv.Close()
+
+	s := NewScraper("TEST", srv.URL, 0)
+	s.SetXPathOverrides(map[string]string{`//h1`: `//h2[@class="title"]`})
+	assert.Equal(t, `//h2[@class="title"]`, s.XPathOverrides()[`//h1`])
+
+	var title string
+	c := s.ClonedCollector()
+	c.OnXML(`//h1`, func(e *colly.XMLElement) { title = e.Text })
+	require.NoError(t, c.Visit(srv.URL))
+	assert.Equal(t, "Title", title)
+
+	// clones keep the overrides.
+	title = ""
+	d := c.Clone()
+	d.OnXML(`//h1`, func(e *colly.XMLElement) { title = e.Text })
+	require.NoError(t, d.Visit(srv.URL+"/?page=2"))
+	assert.Equal(t, "Title", title)
+
+	s.SetXPathOverrides(nil)
+	assert.Nil(t, s.XPathOverrides())
+	title = ""
+	c = s.ClonedCollector()
+	c.OnXML(`//h1`, func(e *colly.XMLElement) { title = e.Text })
+	require.NoError(t, c.Visit(srv.URL+"/?page=3"))
+	assert.Empty(t, title)
+}
+
+func TestCollector_PayloadRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><body><h1>Title</h1></body></html>`))
+	}))
+	defer srv.Close()
+
+	s := NewScraper("TEST", srv.URL, 0)
+	var payloads []*provider.Payload
+	s.SetPayloadRecorder(func(p *provider.Payload) { payloads = append(payloads, p) })
+
+	c := s.ClonedCollector()
+	require.NoError(t, c.Visit(srv.URL))
+	require.NoError(t, c.Clone().Visit(srv.URL+"/?page=2"))
+	if assert.Len(t, payloads, 2) {
+		assert.Equal(t, srv.URL+"/?page=2", payloads[1].URL)
+		assert.Equal(t, "text/html; charset=utf-8", payloads[0].ContentType)
+		assert.Contains(t, string(payloads[0].Body), "<h1>Title</h1>")
+	}
+
+	s.SetPayloadRecorder(nil)
+	require.NoError(t, s.ClonedCollector().Visit(srv.URL+"/?page=3"))
+	assert.Len(t, payloads, 2)
+}
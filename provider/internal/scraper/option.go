@@ -2,11 +2,13 @@ package scraper
 
 import (
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/gocolly/colly/v2"
 	"github.com/gocolly/colly/v2/debug"
 
+	"github.com/metatube-community/metatube-sdk-go/common/profile"
 	"github.com/metatube-community/metatube-sdk-go/common/random"
 )
 
@@ -58,6 +60,31 @@ func WithRandomUserAgent() Option {
 	return WithUserAgent(random.UserAgent())
 }
 
+// WithHeaderProfile sends rotated realistic browser headers, such as
+// User-Agent, Sec-CH-UA and Accept-Language, with all requests, it
+// reduces the chance of being challenged by anti-bot services.
+func WithHeaderProfile(language string) Option {
+	return func(s *Scraper) error {
+		s.rotator = profile.NewRotator(language)
+		return nil
+	}
+}
+
+// WithHeadless renders pages whose URLs match any of the patterns
+// with the headless browser, if it's enabled, e.g. JS-rendered pages.
+func WithHeadless(patterns ...string) Option {
+	return func(s *Scraper) error {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return err
+			}
+			s.headless = append(s.headless, re)
+		}
+		return nil
+	}
+}
+
 func WithCookies(url string, cookies []*http.Cookie) Option {
 	return func(s *Scraper) error {
 		return s.c.SetCookies(url, cookies)
@@ -74,6 +101,7 @@ func WithRequestTimeout(timeout time.Duration) Option {
 func WithDisableCookies() Option {
 	return func(s *Scraper) error {
 		s.c.DisableCookies()
+		s.jar = nil
 		return nil
 	}
 }
@@ -90,7 +118,7 @@ func WithDisableRedirects() Option {
 
 func WithTransport(transport http.RoundTripper) Option {
 	return func(s *Scraper) error {
-		s.c.WithTransport(transport)
+		s.transport = transport
 		return nil
 	}
 }
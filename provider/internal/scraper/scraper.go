@@ -1,26 +1,72 @@
 package scraper
 
 import (
+	"context"
+	"net/http"
 	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/gocolly/colly/v2"
 	"go.uber.org/atomic"
 
+	"github.com/metatube-community/metatube-sdk-go/common/cookiejar"
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/common/profile"
+	"github.com/metatube-community/metatube-sdk-go/common/tracing"
 	"github.com/metatube-community/metatube-sdk-go/provider"
 )
 
 var (
-	_ provider.Provider             = (*Scraper)(nil)
-	_ provider.RequestTimeoutSetter = (*Scraper)(nil)
+	_ provider.Provider              = (*Scraper)(nil)
+	_ provider.RequestTimeoutSetter  = (*Scraper)(nil)
+	_ provider.ProxySetter           = (*Scraper)(nil)
+	_ provider.RateLimitSetter       = (*Scraper)(nil)
+	_ provider.CookieManager         = (*Scraper)(nil)
+	_ provider.TransportSetter       = (*Scraper)(nil)
+	_ provider.CacheSetter           = (*Scraper)(nil)
+	_ provider.SharedRateLimitSetter = (*Scraper)(nil)
+	_ provider.Tracer                = (*Scraper)(nil)
+	_ provider.XPathOverrider        = (*Scraper)(nil)
+	_ provider.PayloadRecorder       = (*Scraper)(nil)
+	_ provider.ContextBinder         = (*Scraper)(nil)
 )
 
 // Scraper implements basic Provider interface.
 type Scraper struct {
+	*scraperState
+	// context of requests, nil if unbound.
+	ctx context.Context
+}
+
+// scraperState is the state of a scraper, shared by its copies bound
+// to contexts.
+type scraperState struct {
 	name     string
 	baseURL  *url.URL
 	priority *atomic.Float64
 	c        *colly.Collector
+	// transport of the collector.
+	transport http.RoundTripper
+	// innermost transport, replaceable at runtime.
+	base swapTransport
+	// outermost transport, caching if enabled at runtime.
+	cache swapTransport
+	// runtime adjustable proxy and rate limit.
+	proxy   atomic.Pointer[url.URL]
+	limiter limiter
+	// browser header profiles, optional.
+	rotator *profile.Rotator
+	// exportable cookie jar, nil if cookies are disabled.
+	jar *cookiejar.Jar
+	// URL patterns to render with the headless browser, if enabled.
+	headless []*regexp.Regexp
+	// trace of scrapes, nil if tracing is disabled.
+	trace atomic.Pointer[provider.Trace]
+	// XPath selectors overriding the built-in ones, optional.
+	xpaths atomic.Pointer[map[string]string]
+	// recorder of fetched documents, nil if recording is disabled.
+	recorder atomic.Pointer[func(*provider.Payload)]
 }
 
 // NewScraper returns a *Scraper that implements provider.Provider .
@@ -29,18 +75,22 @@ func NewScraper(name, base string, priority float64, opts ...Option) *Scraper {
 	if err != nil {
 		panic(err)
 	}
-	s := &Scraper{
+	s := &Scraper{scraperState: &scraperState{
 		name:     name,
 		baseURL:  baseURL,
 		priority: atomic.NewFloat64(priority),
 		c:        colly.NewCollector(),
-	}
+		jar:      cookiejar.New(),
+	}}
+	s.c.SetCookieJar(s.jar)
 	for _, opt := range opts {
 		// Apply options.
 		if err := opt(s); err != nil {
 			panic(err)
 		}
 	}
+	// Log and trace all upstream requests made by the collector.
+	s.c.WithTransport(tracing.NewTransport(logger.NewTransport(s.baseTransport())))
 	return s
 }
 
@@ -69,8 +119,83 @@ func (s *Scraper) NormalizeActorID(id string) string { return id /* AS IS */ }
 
 func (s *Scraper) ParseActorIDFromURL(string) (string, error) { panic("unimplemented") }
 
-// ClonedCollector returns cloned internal collector.
-func (s *Scraper) ClonedCollector() *colly.Collector { return s.c.Clone() }
+// ClonedCollector returns cloned internal collector, its requests are
+// bound to the context of the scraper, if any.
+func (s *Scraper) ClonedCollector() *Collector {
+	cfg := collectorConfig{
+		trace:  s.trace.Load(),
+		xpaths: s.XPathOverrides(),
+	}
+	if f := s.recorder.Load(); f != nil {
+		cfg.record = *f
+	}
+	c := s.c.Clone()
+	if s.ctx != nil {
+		c.Context = s.ctx
+	}
+	return newCollector(c, cfg)
+}
+
+// BindContext returns a copy of the scraper sharing its state, whose
+// requests are bound to ctx, i.e. canceled with it and logged with the
+// request ID of it.
+func (s *Scraper) BindContext(ctx context.Context) provider.ContextBinder {
+	return &Scraper{scraperState: s.scraperState, ctx: ctx}
+}
+
+// SetTrace records the pages and XPath matches of following scrapes to
+// the trace, nil disables tracing.
+func (s *Scraper) SetTrace(t *provider.Trace) { s.trace.Store(t) }
+
+// SetPayloadRecorder calls f with every document fetched by collectors
+// cloned afterwards, nil stops recording.
+func (s *Scraper) SetPayloadRecorder(f func(p *provider.Payload)) {
+	if f == nil {
+		s.recorder.Store(nil)
+		return
+	}
+	s.recorder.Store(&f)
+}
+
+// XPathOverrides returns the XPath selectors that replace the built-in
+// ones, keyed by the built-in selectors.
+func (s *Scraper) XPathOverrides() map[string]string {
+	if m := s.xpaths.Load(); m != nil {
+		return *m
+	}
+	return nil
+}
+
+// SetXPathOverrides replaces the built-in XPath selectors of the keys
+// with the values in collectors cloned afterwards, empty restores the
+// built-in ones.
+func (s *Scraper) SetXPathOverrides(m map[string]string) {
+	if len(m) == 0 {
+		s.xpaths.Store(nil)
+		return
+	}
+	overrides := make(map[string]string, len(m))
+	for k, v := range m {
+		overrides[k] = v
+	}
+	s.xpaths.Store(&overrides)
+}
 
 // SetRequestTimeout sets timeout for HTTP requests.
 func (s *Scraper) SetRequestTimeout(timeout time.Duration) { s.c.SetRequestTimeout(timeout) }
+
+// Cookies returns all cookies keyed by the URLs they were set for.
+func (s *Scraper) Cookies() map[string][]*http.Cookie {
+	if s.jar == nil {
+		return nil
+	}
+	return s.jar.Export()
+}
+
+// SetCookies sets cookies for the URL, empty means the base URL.
+func (s *Scraper) SetCookies(rawURL string, cookies []*http.Cookie) error {
+	if rawURL == "" {
+		rawURL = s.baseURL.String()
+	}
+	return s.c.SetCookies(rawURL, cookies)
+}
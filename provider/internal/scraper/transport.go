@@ -0,0 +1,280 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"go.uber.org/atomic"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cloudflare"
+	"github.com/metatube-community/metatube-sdk-go/common/headless"
+	"github.com/metatube-community/metatube-sdk-go/common/priority"
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+// sharedTransport is the pooled transport shared by all scrapers, so
+// that connections are kept alive and reused across providers and
+// collector clones instead of being dialed for every request.
+var sharedTransport = newSharedTransport()
+
+func newSharedTransport() *http.Transport {
+	t := cleanhttp.DefaultPooledTransport()
+	t.Proxy = contextProxy
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = 256
+	t.MaxIdleConnsPerHost = 16
+	t.MaxConnsPerHost = 32
+	return t
+}
+
+type proxyFuncKey struct{}
+
+// contextProxy resolves the proxy of the scraper that sent the request,
+// idle connections are pooled by proxy, so they are never mixed up.
+func contextProxy(req *http.Request) (*url.URL, error) {
+	if proxyFunc, ok := req.Context().Value(proxyFuncKey{}).(func(*http.Request) (*url.URL, error)); ok {
+		return proxyFunc(req)
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// proxyTransport passes the proxy function of the scraper to the
+// shared transport through the request context.
+type proxyTransport struct {
+	base  http.RoundTripper
+	proxy func(*http.Request) (*url.URL, error)
+}
+
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(context.WithValue(req.Context(), proxyFuncKey{}, t.proxy)))
+}
+
+// proxyFunc returns the proxy set by SetProxy, or the proxy
+// from environment variables if not set.
+func (s *Scraper) proxyFunc(req *http.Request) (*url.URL, error) {
+	if proxy := s.proxy.Load(); proxy != nil {
+		return proxy, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// SetProxy sets the proxy for HTTP requests, nil restores
+// the proxy from environment variables.
+func (s *Scraper) SetProxy(proxy *url.URL) { s.proxy.Store(proxy) }
+
+// SetRateLimit sets the minimum interval between HTTP requests,
+// zero disables rate limiting.
+func (s *Scraper) SetRateLimit(interval time.Duration) { s.limiter.interval.Store(interval) }
+
+// SetTransport replaces the innermost transport of HTTP requests, e.g.
+// for mTLS or recording, nil restores the built-in one. The proxy set
+// by SetProxy only applies to *http.Transport, which is cloned.
+func (s *Scraper) SetTransport(t http.RoundTripper) {
+	if ht, ok := t.(*http.Transport); ok {
+		ht = ht.Clone()
+		ht.Proxy = s.proxyFunc
+		t = ht
+	}
+	s.base.set(t)
+}
+
+// baseTransport returns the base transport of the collector
+// with proxy, Cloudflare challenge and headless browser support.
+func (s *Scraper) baseTransport() http.RoundTripper {
+	t := s.transport
+	if ht, ok := t.(*http.Transport); ok {
+		// cloned, as the transport of the caller may be shared.
+		ht = ht.Clone()
+		ht.Proxy = s.proxyFunc
+		t = ht
+	}
+	if t == nil {
+		t = &proxyTransport{base: sharedTransport, proxy: s.proxyFunc}
+	}
+	s.base.def = t
+	// inside the rotator, as clearances override the User-Agent.
+	t = cloudflare.NewTransport(&s.base)
+	if len(s.headless) > 0 {
+		t = headless.NewTransport(t, s.headless...)
+	}
+	if s.rotator != nil {
+		t = s.rotator.Transport(t)
+	}
+	// outside the limiter, as cached responses are not rate limited.
+	s.cache.def = &limitTransport{base: t, limiter: &s.limiter}
+	return &s.cache
+}
+
+// SetCache caches responses of GET requests in the store for ttl,
+// nil store or zero ttl disables caching.
+func (s *Scraper) SetCache(st store.Store, ttl time.Duration) {
+	if st == nil || ttl <= 0 {
+		s.cache.set(nil)
+		return
+	}
+	s.cache.set(store.NewTransport(st, ttl, s.cache.def))
+}
+
+// swapTransport delegates to the transport set at runtime,
+// or the default one if not set.
+type swapTransport struct {
+	def http.RoundTripper
+	mu  sync.RWMutex
+	rt  http.RoundTripper
+}
+
+func (t *swapTransport) set(rt http.RoundTripper) {
+	t.mu.Lock()
+	t.rt = rt
+	t.mu.Unlock()
+}
+
+func (t *swapTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	rt := t.rt
+	t.mu.RUnlock()
+	if rt == nil {
+		rt = t.def
+	}
+	return rt.RoundTrip(req)
+}
+
+// limiter spaces out requests by a minimum interval. Requests are of
+// the priorities carried by their contexts, background requests only
+// take due slots while no interactive requests are waiting, so that
+// they never queue ahead of interactive ones.
+type limiter struct {
+	interval atomic.Duration
+	mu       sync.Mutex
+	next     time.Time
+	// number of interactive requests waiting for slots.
+	interactive int
+	// shared among replicas, optional.
+	shared atomic.Pointer[sharedLimiter]
+}
+
+// sharedLimiter reserves request slots of the key in a store.
+type sharedLimiter struct {
+	reserver store.Reserver
+	key      string
+}
+
+// reserve reserves the next local slot, and returns the wait for it.
+func (l *limiter) reserve(interval time.Duration) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(interval)
+	return at.Sub(now)
+}
+
+// reserveDue reserves the next local slot for a background request if
+// it's due and no interactive requests are waiting, or else returns how
+// long to wait before retrying.
+func (l *limiter) reserveDue(interval time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if l.interactive == 0 && !l.next.After(now) {
+		l.next = now.Add(interval)
+		return 0, true
+	}
+	if d := l.next.Sub(now); d > 0 {
+		return d, false
+	}
+	return interval, false
+}
+
+// reserveShared reserves the next shared slot, and reports whether
+// it's reserved, errors of the store are ignored.
+func (l *limiter) reserveShared(interval time.Duration) (time.Duration, bool) {
+	shared := l.shared.Load()
+	if shared == nil {
+		return 0, false
+	}
+	d, err := shared.reserver.Reserve(shared.key, interval)
+	return d, err == nil
+}
+
+// wait blocks until the next request is allowed or req is canceled,
+// the local slots are used if the shared ones are unavailable.
+func (l *limiter) wait(req *http.Request) error {
+	interval := l.interval.Load()
+	if interval <= 0 {
+		return nil
+	}
+	ctx := req.Context()
+	if priority.FromContext(ctx) == priority.Background {
+		for {
+			d, ok := l.reserveDue(interval)
+			if ok {
+				break
+			}
+			if err := sleep(ctx, d); err != nil {
+				return err
+			}
+		}
+		// the local slot is reserved already.
+		d, _ := l.reserveShared(interval)
+		return sleep(ctx, d)
+	}
+	l.mu.Lock()
+	l.interactive++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.interactive--
+		l.mu.Unlock()
+	}()
+	d, ok := l.reserveShared(interval)
+	if !ok {
+		d = l.reserve(interval)
+	}
+	return sleep(ctx, d)
+}
+
+// sleep blocks for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetSharedRateLimit shares the rate limit of the scraper through the
+// store, e.g. among replicas, so that their combined request rate stays
+// within the limit. Nil store restores the local rate limit.
+func (s *Scraper) SetSharedRateLimit(r store.Reserver) {
+	if r == nil {
+		s.limiter.shared.Store(nil)
+		return
+	}
+	s.limiter.shared.Store(&sharedLimiter{reserver: r, key: "ratelimit:" + s.name})
+}
+
+type limitTransport struct {
+	base    http.RoundTripper
+	limiter *limiter
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,189 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/common/priority"
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+func TestScraper_SetRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := NewDefaultScraper("TEST", srv.URL, 0)
+	s.SetRateLimit(100 * time.Millisecond)
+
+	c := s.ClonedCollector()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.Visit(srv.URL))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestScraper_SetProxy(t *testing.T) {
+	s := NewDefaultScraper("TEST", "https://example.com", 0)
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+
+	proxy, _ := url.Parse("http://127.0.0.1:1080")
+	s.SetProxy(proxy)
+	got, err := s.proxyFunc(req)
+	require.NoError(t, err)
+	assert.Equal(t, proxy, got)
+
+	s.SetProxy(nil)
+	got, err = s.proxyFunc(req)
+	require.NoError(t, err)
+	want, _ := http.ProxyFromEnvironment(req)
+	assert.Equal(t, want, got)
+}
+
+type countingTransport struct {
+	http.RoundTripper
+	n int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n++
+	return t.RoundTripper.RoundTrip(req)
+}
+
+func TestScraper_SetTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := NewDefaultScraper("TEST", srv.URL, 0)
+	rt := &countingTransport{RoundTripper: http.DefaultTransport}
+	s.SetTransport(rt)
+
+	c := s.ClonedCollector()
+	require.NoError(t, c.Visit(srv.URL))
+	assert.Equal(t, 1, rt.n)
+
+	s.SetTransport(nil)
+	c = s.ClonedCollector()
+	require.NoError(t, c.Visit(srv.URL+"/?restored"))
+	assert.Equal(t, 1, rt.n)
+}
+
+func TestScraper_SharedTransport(t *testing.T) {
+	conns := atomic.NewInt32(0)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Inc()
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	for _, name := range []string{"A", "B"} {
+		s := NewDefaultScraper(name, srv.URL, 0)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, s.ClonedCollector().Visit(fmt.Sprintf("%s/%s/%d", srv.URL, name, i)))
+		}
+	}
+	assert.EqualValues(t, 1, conns.Load())
+}
+
+func TestLimiter_Priority(t *testing.T) {
+	l := &limiter{}
+	l.interval.Store(50 * time.Millisecond)
+	request := func(p priority.Priority) *http.Request {
+		return httptest.NewRequest(http.MethodGet, "/", nil).
+			WithContext(priority.WithContext(context.Background(), p))
+	}
+
+	order := make(chan priority.Priority, 4)
+	visit := func(p priority.Priority) {
+		assert.NoError(t, l.wait(request(p)))
+		order <- p
+	}
+	visit(priority.Interactive)
+	for i := 0; i < 2; i++ {
+		go visit(priority.Background)
+	}
+	time.Sleep(10 * time.Millisecond)
+	// interactive requests take slots ahead of waiting background ones.
+	go visit(priority.Interactive)
+
+	var got []priority.Priority
+	for i := 0; i < 4; i++ {
+		select {
+		case p := <-order:
+			got = append(got, p)
+		case <-time.After(time.Second):
+			t.Fatal("request is not allowed")
+		}
+	}
+	assert.Equal(t, []priority.Priority{
+		priority.Interactive, priority.Interactive,
+		priority.Background, priority.Background,
+	}, got)
+}
+
+func TestScraper_SetSharedRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// two replicas of the same provider.
+	shared := store.NewMemory(0)
+	a, b := NewDefaultScraper("TEST", srv.URL, 0), NewDefaultScraper("TEST", srv.URL, 0)
+	for _, s := range []*Scraper{a, b} {
+		s.SetRateLimit(100 * time.Millisecond)
+		s.SetSharedRateLimit(shared)
+	}
+
+	ca, cb := a.ClonedCollector(), b.ClonedCollector()
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, ca.Visit(srv.URL))
+		require.NoError(t, cb.Visit(srv.URL))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestScraper_BindContext(t *testing.T) {
+	ids := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids <- r.Header.Get(logger.RequestIDHeader)
+	}))
+	defer srv.Close()
+
+	s := NewDefaultScraper("TEST", srv.URL, 0)
+	require.NoError(t, s.ClonedCollector().Visit(srv.URL))
+	assert.Empty(t, <-ids)
+
+	ctx, cancel := context.WithCancel(logger.WithRequestID(context.Background(), "abc"))
+	bound := s.BindContext(ctx).(*Scraper)
+	assert.Equal(t, s.Name(), bound.Name())
+	require.NoError(t, bound.ClonedCollector().Visit(srv.URL))
+	assert.Equal(t, "abc", <-ids)
+
+	cancel()
+	assert.ErrorIs(t, bound.ClonedCollector().Visit(srv.URL), context.Canceled)
+	// the scraper itself is not bound.
+	require.NoError(t, s.ClonedCollector().Visit(srv.URL))
+	<-ids
+}
+
+func TestScraper_WithTransport(t *testing.T) {
+	ht := &http.Transport{}
+	s := NewDefaultScraper("TEST", "https://example.com", 0, WithTransport(ht))
+	s.SetProxy(&url.URL{Scheme: "http", Host: "127.0.0.1:1080"})
+	// the transport of the caller is never changed.
+	assert.Nil(t, ht.Proxy)
+}
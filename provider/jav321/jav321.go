@@ -143,6 +143,7 @@ func (jav *JAV321) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err
 	c.OnXML(`//b[contains(text(),"配信開始日")]/following-sibling::node()[1]`, func(e *colly.XMLElement) {
 		info.ReleaseDate = parser.ParseDate(
 			strings.TrimLeft(e.DOM.(*html.Node).Data, ":"))
+		info.StreamingDate = info.ReleaseDate
 	})
 
 	// Runtime
@@ -187,6 +188,7 @@ func (jav *JAV321) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err
 	c.OnXML(`//b[contains(text(),"平均評価")]/following-sibling::img/@data-original`, func(e *colly.XMLElement) {
 		if ss := regexp.MustCompile(`(\d+)\.gif`).FindStringSubmatch(e.Text); len(ss) == 2 {
 			info.Score = parser.ParseScore(ss[1]) / 10
+			info.Rating = &model.MovieRating{Value: parser.ParseScore(ss[1]), Scale: 50}
 		}
 	})
 
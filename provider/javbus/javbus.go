@@ -20,9 +20,11 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*JavBus)(nil)
-	_ provider.MovieSearcher = (*JavBus)(nil)
-	_ provider.Fetcher       = (*JavBus)(nil)
+	_ provider.MovieProvider      = (*JavBus)(nil)
+	_ provider.MovieSearcher      = (*JavBus)(nil)
+	_ provider.MovieActorSearcher = (*JavBus)(nil)
+	_ provider.MovieGenreSearcher = (*JavBus)(nil)
+	_ provider.Fetcher            = (*JavBus)(nil)
 )
 
 const (
@@ -35,8 +37,14 @@ const (
 	movieURL            = "https://www.javbus.com/ja/%s"
 	searchURL           = "https://www.javbus.com/ja/search/%s"
 	searchUncensoredURL = "https://www.javbus.com/ja/uncensored/search/%s"
+	searchStarURL       = "https://www.javbus.com/ja/searchstar/%s"
+	genreListURL        = "https://www.javbus.com/ja/genre"
+	genreURL            = "https://www.javbus.com/ja/genre/%s/%d"
 )
 
+// maxStarPages is the max number of filmography pages to fetch.
+const maxStarPages = 10
+
 type JavBus struct {
 	*fetch.Fetcher
 	*scraper.Scraper
@@ -47,6 +55,7 @@ func New() *JavBus {
 		Fetcher: fetch.Default(&fetch.Config{Referer: baseURL}),
 		Scraper: scraper.NewDefaultScraper(Name, baseURL, Priority,
 			scraper.WithDisableRedirects(),
+			scraper.WithHeaderProfile(""),
 			scraper.WithHeaders(map[string]string{
 				"Referer": baseURL,
 			}),
@@ -130,6 +139,24 @@ func (bus *JavBus) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err
 		info.PreviewImages = append(info.PreviewImages, e.Request.AbsoluteURL(e.Attr("href")))
 	})
 
+	// Related (recommended by viewers)
+	c.OnXML(`//*[@id="related-waterfall"]/a`, func(e *colly.XMLElement) {
+		href := e.Request.AbsoluteURL(e.Attr("href"))
+		id, err := bus.ParseMovieIDFromURL(href)
+		if err != nil || id == "" {
+			return
+		}
+		info.RelatedMovies = append(info.RelatedMovies, &model.RelatedMovie{
+			ID:       id,
+			Number:   id,
+			Title:    strings.TrimSpace(e.Attr("title")),
+			Provider: bus.Name(),
+			Homepage: href,
+			ThumbURL: e.Request.AbsoluteURL(e.ChildAttr(`.//img`, "src")),
+			Relation: model.RelatedAlsoViewed,
+		})
+	})
+
 	// Actors
 	c.OnXML(`//div[@class="star-name"]`, func(e *colly.XMLElement) {
 		info.Actors = append(info.Actors, e.ChildAttr(`.//a`, "title"))
@@ -177,25 +204,7 @@ func (bus *JavBus) SearchMovie(keyword string) (results []*model.MovieSearchResu
 	c.OnXML(`//a[@class="movie-box"]`, func(e *colly.XMLElement) {
 		mu.Lock()
 		defer mu.Unlock()
-
-		var thumb, cover string
-		thumb = e.Request.AbsoluteURL(e.ChildAttr(`.//div[1]/img`, "src"))
-		if re := regexp.MustCompile(`(?i)/thumbs?/([a-z\d]+)(?:_b)?\.(jpg|png)`); re.MatchString(thumb) {
-			cover = re.ReplaceAllString(thumb, "/cover/${1}_b.${2}") // guess
-		}
-
-		homepage := e.Request.AbsoluteURL(e.Attr("href"))
-		id, _ := bus.ParseMovieIDFromURL(homepage)
-		results = append(results, &model.MovieSearchResult{
-			ID:          id,
-			Number:      e.ChildText(`.//div[2]/span/date[1]`),
-			Title:       strings.SplitN(e.ChildText(`.//div[2]/span`), "\n", 2)[0],
-			Provider:    bus.Name(),
-			Homepage:    homepage,
-			ThumbURL:    thumb,
-			CoverURL:    cover,
-			ReleaseDate: parser.ParseDate(e.ChildText(`.//div[2]/span/date[2]`)),
-		})
+		results = append(results, bus.parseMovieBox(e))
 	})
 
 	for _, u := range []string{
@@ -210,6 +219,86 @@ func (bus *JavBus) SearchMovie(keyword string) (results []*model.MovieSearchResu
 	return
 }
 
+func (bus *JavBus) parseMovieBox(e *colly.XMLElement) *model.MovieSearchResult {
+	var thumb, cover string
+	thumb = e.Request.AbsoluteURL(e.ChildAttr(`.//div[1]/img`, "src"))
+	if re := regexp.MustCompile(`(?i)/thumbs?/([a-z\d]+)(?:_b)?\.(jpg|png)`); re.MatchString(thumb) {
+		cover = re.ReplaceAllString(thumb, "/cover/${1}_b.${2}") // guess
+	}
+
+	homepage := e.Request.AbsoluteURL(e.Attr("href"))
+	id, _ := bus.ParseMovieIDFromURL(homepage)
+	return &model.MovieSearchResult{
+		ID:          id,
+		Number:      e.ChildText(`.//div[2]/span/date[1]`),
+		Title:       strings.SplitN(e.ChildText(`.//div[2]/span`), "\n", 2)[0],
+		Provider:    bus.Name(),
+		Homepage:    homepage,
+		ThumbURL:    thumb,
+		CoverURL:    cover,
+		ReleaseDate: parser.ParseDate(e.ChildText(`.//div[2]/span/date[2]`)),
+	}
+}
+
+func (bus *JavBus) SearchMovieByActor(name string) (results []*model.MovieSearchResult, err error) {
+	// Find the star page first, exact match is preferred.
+	var starURL string
+	c := bus.ClonedCollector()
+	c.OnXML(`//a[@class="avatar-box"]`, func(e *colly.XMLElement) {
+		if starURL == "" || strings.TrimSpace(e.ChildText(`.//div[@class="photo-info"]/span`)) == name {
+			starURL = e.Request.AbsoluteURL(e.Attr("href"))
+		}
+	})
+	if err = c.Visit(fmt.Sprintf(searchStarURL, url.PathEscape(name))); err != nil {
+		return nil, err
+	}
+	if starURL == "" {
+		return nil, provider.ErrInfoNotFound
+	}
+
+	// Then the paginated filmography.
+	pages := 0
+	d := bus.ClonedCollector()
+	d.OnXML(`//a[@class="movie-box"]`, func(e *colly.XMLElement) {
+		results = append(results, bus.parseMovieBox(e))
+	})
+	d.OnXML(`//a[@id="next"]`, func(e *colly.XMLElement) {
+		if pages++; pages < maxStarPages {
+			d.Visit(e.Request.AbsoluteURL(e.Attr("href")))
+		}
+	})
+	if err = d.Visit(starURL); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (bus *JavBus) SearchMovieByGenre(genre string, page int) (results []*model.MovieSearchResult, err error) {
+	// Find the genre ID from the genre list.
+	var genreID string
+	c := bus.ClonedCollector()
+	c.OnXML(`//div[contains(@class,"genre-box")]/a`, func(e *colly.XMLElement) {
+		if genreID == "" && strings.TrimSpace(e.Text) == genre {
+			genreID = path.Base(e.Attr("href"))
+		}
+	})
+	if err = c.Visit(genreListURL); err != nil {
+		return nil, err
+	}
+	if genreID == "" {
+		return nil, provider.ErrInfoNotFound
+	}
+
+	d := bus.ClonedCollector()
+	d.OnXML(`//a[@class="movie-box"]`, func(e *colly.XMLElement) {
+		results = append(results, bus.parseMovieBox(e))
+	})
+	if err = d.Visit(fmt.Sprintf(genreURL, genreID, page)); err != nil {
+		return nil, err
+	}
+	return
+}
+
 func init() {
 	provider.Register(Name, New)
 }
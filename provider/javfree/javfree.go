@@ -41,7 +41,8 @@ type JAVFREE struct {
 func New() *JAVFREE {
 	return &JAVFREE{
 		Fetcher: fetch.Default(&fetch.Config{Referer: baseURL}),
-		Scraper: scraper.NewDefaultScraper(Name, baseURL, Priority),
+		Scraper: scraper.NewDefaultScraper(Name, baseURL, Priority,
+			scraper.WithHeaderProfile("")),
 	}
 }
 
@@ -184,8 +184,14 @@ func (mgs *MGS) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, err err
 		case "品番：":
 			info.Number = e.ChildText(`.//td`)
 		case "配信開始日：", "商品発売日：":
+			date := parser.ParseDate(e.ChildText(`.//td`))
+			if e.ChildText(`.//th`) == "配信開始日：" {
+				info.StreamingDate = date
+			} else {
+				info.PhysicalDate = date
+			}
 			if time.Time(info.ReleaseDate).IsZero() {
-				info.ReleaseDate = parser.ParseDate(e.ChildText(`.//td`))
+				info.ReleaseDate = date
 			}
 		case "シリーズ：":
 			info.Series = e.ChildText(`.//td`)
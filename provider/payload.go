@@ -0,0 +1,13 @@
+package provider
+
+// MaxPayloadSize is the maximum size of recorded payloads, larger
+// documents are not recorded.
+const MaxPayloadSize = 4 << 20
+
+// Payload is a raw document fetched while scraping, e.g. the HTML of
+// a detail page.
+type Payload struct {
+	URL         string
+	ContentType string
+	Body        []byte
+}
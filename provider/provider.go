@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/store"
 )
 
 type Provider interface {
@@ -30,6 +31,36 @@ type MovieSearcher interface {
 	NormalizeMovieKeyword(Keyword string) string
 }
 
+type MovieActorSearcher interface {
+	// SearchMovieByActor searches movies of the actor, i.e. the
+	// filmography listed on the actor page of the provider.
+	SearchMovieByActor(name string) ([]*model.MovieSearchResult, error)
+}
+
+type MovieSeriesSearcher interface {
+	// SearchMovieBySeries searches movies of the series, i.e. the
+	// movies listed on the series page of the provider.
+	SearchMovieBySeries(name string) ([]*model.MovieSearchResult, error)
+}
+
+type MovieMakerSearcher interface {
+	// SearchMovieByMaker searches movies of the maker, i.e. the
+	// movies listed on the maker page of the provider.
+	SearchMovieByMaker(name string) ([]*model.MovieSearchResult, error)
+}
+
+type MovieGenreSearcher interface {
+	// SearchMovieByGenre lists movies of the genre page by page,
+	// page starts from 1.
+	SearchMovieByGenre(genre string, page int) ([]*model.MovieSearchResult, error)
+}
+
+type MovieCalendarGetter interface {
+	// GetMovieCalendar lists movies released, or to be released,
+	// in the given month.
+	GetMovieCalendar(year int, month time.Month) ([]*model.MovieSearchResult, error)
+}
+
 type MovieReviewer interface {
 	// GetMovieReviewsByID gets the user reviews of given movie id.
 	GetMovieReviewsByID(id string) ([]*model.MovieReviewDetail, error)
@@ -38,6 +69,12 @@ type MovieReviewer interface {
 	GetMovieReviewsByURL(rawURL string) ([]*model.MovieReviewDetail, error)
 }
 
+type ScoreNormalizer interface {
+	// NormalizeScore converts the raw rating of the provider into
+	// the 0-10 scale, ratings are scaled linearly if not implemented.
+	NormalizeScore(rating *model.MovieRating) float64
+}
+
 type MovieProvider interface {
 	// Provider should be implemented.
 	Provider
@@ -86,3 +123,67 @@ type RequestTimeoutSetter interface {
 	// SetRequestTimeout sets timeout for HTTP requests.
 	SetRequestTimeout(timeout time.Duration)
 }
+
+type ProxySetter interface {
+	// SetProxy sets the proxy for HTTP requests, nil means
+	// using the proxy from environment variables.
+	SetProxy(proxy *url.URL)
+}
+
+type TransportSetter interface {
+	// SetTransport replaces the transport of HTTP requests,
+	// nil restores the built-in one.
+	SetTransport(t http.RoundTripper)
+}
+
+type RateLimitSetter interface {
+	// SetRateLimit sets the minimum interval between HTTP
+	// requests, zero disables rate limiting.
+	SetRateLimit(interval time.Duration)
+}
+
+type BaseURLSetter interface {
+	// SetBaseURLs sets the base URLs of the provider in lookup order,
+	// e.g. regional domains, empty restores the built-in ones.
+	SetBaseURLs(urls []*url.URL)
+}
+
+type SharedRateLimitSetter interface {
+	// SetSharedRateLimit coordinates the rate limit through the
+	// store, e.g. among replicas, nil restores the local one.
+	SetSharedRateLimit(r store.Reserver)
+}
+
+type Tracer interface {
+	// SetTrace records the extraction of following scrapes to the
+	// trace, nil disables tracing.
+	SetTrace(t *Trace)
+}
+
+type PayloadRecorder interface {
+	// SetPayloadRecorder calls f with every document fetched
+	// afterwards, nil stops recording.
+	SetPayloadRecorder(f func(p *Payload))
+}
+
+type XPathOverrider interface {
+	// XPathOverrides returns the XPath selectors that replace the
+	// built-in ones, keyed by the built-in selectors.
+	XPathOverrides() map[string]string
+	// SetXPathOverrides replaces the built-in XPath selectors of the
+	// keys with the values, empty restores the built-in ones.
+	SetXPathOverrides(m map[string]string)
+}
+
+type CacheSetter interface {
+	// SetCache caches HTTP responses in the store for ttl,
+	// nil store or zero ttl disables caching.
+	SetCache(s store.Store, ttl time.Duration)
+}
+
+type CookieManager interface {
+	// Cookies returns all cookies keyed by the URLs they were set for.
+	Cookies() map[string][]*http.Cookie
+	// SetCookies sets cookies for the URL, empty means the base URL.
+	SetCookies(rawURL string, cookies []*http.Cookie) error
+}
@@ -166,6 +166,7 @@ func (tht *TokyoHot) GetMovieInfoByURL(rawURL string) (info *model.MovieInfo, er
 				info.Label = e.ChildText(dda)
 			case "配信開始日":
 				info.ReleaseDate = parser.ParseDate(e.ChildText(dd))
+				info.StreamingDate = info.ReleaseDate
 			case "収録時間":
 				info.Runtime = parser.ParseRuntime(e.ChildText(dd))
 			case "作品番号":
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// MaxTraceValueLength is the maximum length of traced raw values.
+const MaxTraceValueLength = 256
+
+// Trace records the pages fetched and the XPath selectors matched or
+// missed while scraping, it's safe for concurrent use.
+type Trace struct {
+	mu      sync.Mutex
+	Pages   []*TracePage  `json:"pages"`
+	Matches []*TraceMatch `json:"matches"`
+	Misses  []*TraceMiss  `json:"misses"`
+}
+
+// TracePage is a page fetched while scraping.
+type TracePage struct {
+	URL         string `json:"url"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size"`
+}
+
+// TraceMatch is an element matched by an XPath selector.
+type TraceMatch struct {
+	URL   string `json:"url"`
+	XPath string `json:"xpath"`
+	Raw   string `json:"raw"`
+}
+
+// TraceMiss is an XPath selector that matched nothing on a page.
+type TraceMiss struct {
+	URL   string `json:"url"`
+	XPath string `json:"xpath"`
+}
+
+func (t *Trace) AddPage(page *TracePage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Pages = append(t.Pages, page)
+}
+
+func (t *Trace) AddMatch(url, xpath, raw string) {
+	raw = strings.Join(strings.Fields(raw), " ")
+	if len(raw) > MaxTraceValueLength {
+		raw = raw[:MaxTraceValueLength]
+		for !utf8.ValidString(raw) {
+			raw = raw[:len(raw)-1]
+		}
+		raw += "…"
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Matches = append(t.Matches, &TraceMatch{URL: url, XPath: xpath, Raw: raw})
+}
+
+func (t *Trace) AddMiss(url, xpath string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Misses = append(t.Misses, &TraceMiss{URL: url, XPath: xpath})
+}
@@ -0,0 +1,196 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+func postReload(reload func() error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := reload(); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"reloaded": true}})
+	}
+}
+
+func deleteCache(app *engine.Engine, typ infoType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		var err error
+		switch typ {
+		case actorInfoType:
+			err = app.PurgeActorInfo(uri.Provider, uri.ID)
+		case movieInfoType:
+			err = app.PurgeMovieInfo(uri.Provider, uri.ID)
+		default:
+			panic("invalid info/metadata type")
+		}
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"purged": true}})
+	}
+}
+
+func postRefresh(app *engine.Engine, typ infoType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		var (
+			info any
+			err  error
+		)
+		switch typ {
+		case actorInfoType:
+			info, err = app.GetActorInfoByProviderID(uri.Provider, uri.ID, false)
+		case movieInfoType:
+			info, err = app.GetMovieInfoByProviderID(uri.Provider, uri.ID, false)
+		default:
+			panic("invalid info/metadata type")
+		}
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &responseMessage{Data: info})
+	}
+}
+
+func getDBStats(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stats, err := app.DBStats()
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: stats})
+	}
+}
+
+func postDBVacuum(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := app.DBVacuum(); err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"vacuumed": true}})
+	}
+}
+
+type cookieUri struct {
+	Provider string `uri:"provider" binding:"required"`
+}
+
+type cookieBody struct {
+	// URL the cookies are set for, defaults to the provider's base URL.
+	URL     string         `json:"url"`
+	Cookies []*http.Cookie `json:"cookies" binding:"required"`
+}
+
+func getCookies(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &cookieUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		cookies, err := app.GetProviderCookies(uri.Provider)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: cookies})
+	}
+}
+
+func putCookies(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &cookieUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		body := &cookieBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := app.SetProviderCookies(uri.Provider, body.URL, body.Cookies); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"updated": true}})
+	}
+}
+
+func getMovieOverride(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		override, err := app.GetMovieOverride(uri.Provider, uri.ID)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: override})
+	}
+}
+
+func patchMovieOverride(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		var fields map[string]json.RawMessage
+		if err := c.ShouldBindJSON(&fields); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		info, err := app.PatchMovieOverride(uri.Provider, uri.ID, fields)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: info})
+	}
+}
+
+func deleteMovieOverride(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := app.DeleteMovieOverride(uri.Provider, uri.ID); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"deleted": true}})
+	}
+}
@@ -1,7 +1,9 @@
 package route
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -22,7 +24,60 @@ func authentication(v auth.Validator) gin.HandlerFunc {
 				abortWithError(c, errors.FromCode(http.StatusUnauthorized))
 				return
 			}
+			if i, ok := v.(auth.Identifier); ok {
+				if name, ok := i.Identify(token); ok && name != "" {
+					c.Set(userContextKey, name)
+				}
+			}
+			if l, ok := v.(auth.Limiter); ok {
+				if wait, ok := l.Allow(token); !ok {
+					c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+					abortWithError(c, errors.FromCode(http.StatusTooManyRequests))
+					return
+				}
+			}
 		}
 		c.Next()
 	}
 }
+
+// hasValidToken reports whether the request carries a valid bearer
+// token, for public endpoints that serve authenticated callers more.
+func hasValidToken(c *gin.Context, v auth.Validator) bool {
+	bearer, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	return bearer == "Bearer" && found && v.Valid(token)
+}
+
+// denyAdmin denies all requests to admin endpoints.
+func denyAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		abortWithStatusMessage(c, http.StatusForbidden, "admin endpoints are disabled without admin token or keys")
+	}
+}
+
+// userContextKey is the gin context key of the authenticated user.
+const userContextKey = "metatube.user"
+
+// defaultUser is the user of requests without an identity, i.e. auth
+// is disabled or the token names no one.
+const defaultUser = "default"
+
+// requestUser returns the user of the request, user-scoped data, e.g.
+// follows and collections, are of the identity of the key, so that no
+// keys can access the data of others.
+func requestUser(c *gin.Context) string {
+	if user := c.GetString(userContextKey); user != "" {
+		return user
+	}
+	return defaultUser
+}
+
+func getAPIKeyUsage(v auth.Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		usages := []*auth.Usage{}
+		if r, ok := v.(auth.UsageReporter); ok {
+			usages = r.Usage()
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: usages})
+	}
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Role is the role of an API key.
+type Role string
+
+const (
+	// RoleUser is the default role, of the regular endpoints only.
+	RoleUser Role = "user"
+	// RoleAdmin is of the admin endpoints as well.
+	RoleAdmin Role = "admin"
+)
+
+// Key is an API key with optional quotas, zero quotas are unlimited.
+type Key struct {
+	// Name is the identity of the key, keys without names are named
+	// after the hash of their tokens, so that they share no data.
+	Name  string
+	Token string
+	// Role is RoleUser if empty.
+	Role Role
+	// RateLimit is the maximum requests per minute.
+	RateLimit int
+	// Quota is the maximum requests per day in UTC.
+	Quota int
+}
+
+// Usage is the usage accounting of a key.
+type Usage struct {
+	Name      string    `json:"name"`
+	RateLimit int       `json:"rate_limit,omitempty"`
+	Quota     int       `json:"quota,omitempty"`
+	Requests  int64     `json:"requests"`
+	Today     int       `json:"today"`
+	Rejected  int64     `json:"rejected"`
+	LastUsed  time.Time `json:"last_used,omitzero"`
+}
+
+type keyState struct {
+	*Key
+	usage       Usage
+	windowStart time.Time
+	window      int
+	day         time.Time
+}
+
+// KeyStore validates multiple API keys and enforces their quotas.
+type KeyStore struct {
+	mu   sync.Mutex
+	keys []*keyState
+	now  func() time.Time
+}
+
+func NewKeyStore(keys ...*Key) *KeyStore {
+	store := &KeyStore{
+		keys: make([]*keyState, 0, len(keys)),
+		now:  time.Now,
+	}
+	for _, key := range keys {
+		name := key.Name
+		if name == "" {
+			sum := sha256.Sum256([]byte(key.Token))
+			name = "key-" + hex.EncodeToString(sum[:4])
+		}
+		store.keys = append(store.keys, &keyState{
+			Key: key,
+			usage: Usage{
+				Name:      name,
+				RateLimit: key.RateLimit,
+				Quota:     key.Quota,
+			},
+		})
+	}
+	return store
+}
+
+// lookup returns the key of the token, or nil if there is none. All
+// tokens are compared in constant time, so that the timing of requests
+// reveals nothing of them.
+func (store *KeyStore) lookup(token string) (found *keyState) {
+	for _, k := range store.keys {
+		if subtle.ConstantTimeCompare([]byte(k.Token), []byte(token)) == 1 {
+			found = k
+		}
+	}
+	return
+}
+
+func (store *KeyStore) Valid(token string) bool {
+	return store.lookup(token) != nil
+}
+
+// Admins returns the validator of the admin keys of the store, which
+// shares the quotas and usage of the keys, or nil if there is none.
+func (store *KeyStore) Admins() Validator {
+	for _, k := range store.keys {
+		if k.Role == RoleAdmin {
+			return adminKeys{store}
+		}
+	}
+	return nil
+}
+
+// adminKeys validates only the admin keys of the store.
+type adminKeys struct {
+	*KeyStore
+}
+
+func (a adminKeys) Valid(token string) bool {
+	k := a.lookup(token)
+	return k != nil && k.Role == RoleAdmin
+}
+
+// Identify returns the name of the key of the token.
+func (store *KeyStore) Identify(token string) (string, bool) {
+	k := store.lookup(token)
+	if k == nil {
+		return "", false
+	}
+	return k.usage.Name, true
+}
+
+// Allow accounts a request of the key of the token, and returns the
+// duration to wait if the request exceeds the quotas of the key.
+func (store *KeyStore) Allow(token string) (time.Duration, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	k := store.lookup(token)
+	if k == nil {
+		return 0, false
+	}
+	now := store.now().UTC()
+	if day := now.Truncate(24 * time.Hour); !day.Equal(k.day) {
+		k.day, k.usage.Today = day, 0
+	}
+	if now.Sub(k.windowStart) >= time.Minute {
+		k.windowStart, k.window = now, 0
+	}
+	switch {
+	case k.Quota > 0 && k.usage.Today >= k.Quota:
+		k.usage.Rejected++
+		return k.day.Add(24 * time.Hour).Sub(now), false
+	case k.RateLimit > 0 && k.window >= k.RateLimit:
+		k.usage.Rejected++
+		return k.windowStart.Add(time.Minute).Sub(now), false
+	}
+	k.window++
+	k.usage.Today++
+	k.usage.Requests++
+	k.usage.LastUsed = now
+	return 0, true
+}
+
+// Usage returns the usage of all keys, ordered by name.
+func (store *KeyStore) Usage() []*Usage {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	usages := make([]*Usage, 0, len(store.keys))
+	for _, k := range store.keys {
+		usage := k.usage
+		if !k.day.Equal(store.now().UTC().Truncate(24 * time.Hour)) {
+			usage.Today = 0
+		}
+		usages = append(usages, &usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+	return usages
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC)
+	store := NewKeyStore(
+		&Key{Name: "alice", Token: "a", RateLimit: 2},
+		&Key{Name: "bob", Token: "b", Quota: 3},
+	)
+	store.now = func() time.Time { return now }
+
+	assert.True(t, store.Valid("a"))
+	assert.False(t, store.Valid("c"))
+	_, ok := store.Allow("c")
+	assert.False(t, ok)
+
+	for i := 0; i < 2; i++ {
+		_, ok = store.Allow("a")
+		require.True(t, ok)
+	}
+	wait, ok := store.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	for i := 0; i < 3; i++ {
+		_, ok = store.Allow("b")
+		require.True(t, ok)
+	}
+	wait, ok = store.Allow("b")
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	now = now.Add(time.Minute)
+	_, ok = store.Allow("a")
+	assert.True(t, ok)
+	_, ok = store.Allow("b")
+	assert.True(t, ok, "quota is reset the next day")
+
+	usages := store.Usage()
+	require.Len(t, usages, 2)
+	assert.Equal(t, Usage{Name: "alice", RateLimit: 2, Requests: 3, Today: 1, Rejected: 1, LastUsed: now}, *usages[0])
+	assert.Equal(t, Usage{Name: "bob", Quota: 3, Requests: 4, Today: 1, Rejected: 1, LastUsed: now}, *usages[1])
+}
+
+func TestKeyStore_Identify(t *testing.T) {
+	store := NewKeyStore(&Key{Name: "alice", Token: "a"})
+	name, ok := store.Identify("a")
+	assert.True(t, ok)
+	assert.Equal(t, "alice", name)
+	_, ok = store.Identify("b")
+	assert.False(t, ok)
+
+	// keys without names never share the identity of each other.
+	store = NewKeyStore(&Key{Token: "a"}, &Key{Token: "b"})
+	a, ok := store.Identify("a")
+	require.True(t, ok)
+	b, ok := store.Identify("b")
+	require.True(t, ok)
+	assert.NotEmpty(t, a)
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, "default", a)
+}
+
+func TestKeyStore_Admins(t *testing.T) {
+	assert.Nil(t, NewKeyStore(&Key{Name: "alice", Token: "a"}).Admins())
+
+	store := NewKeyStore(
+		&Key{Name: "alice", Token: "a", Role: RoleAdmin},
+		&Key{Name: "bob", Token: "b", Role: RoleUser},
+		&Key{Name: "carol", Token: "c"},
+	)
+	admins := store.Admins()
+	require.NotNil(t, admins)
+	assert.True(t, admins.Valid("a"))
+	assert.False(t, admins.Valid("b"))
+	assert.False(t, admins.Valid("c"))
+	// quotas and usage are shared with the store.
+	_, ok := admins.(Limiter).Allow("a")
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, store.Usage()[0].Requests)
+}
@@ -1,9 +1,11 @@
 package auth
 
+import "crypto/subtle"
+
 type Token string
 
 func (token Token) Valid(t string) bool {
-	return string(token) == t
+	return subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1
 }
 
 type TokenStore map[string]struct{}
@@ -1,5 +1,23 @@
 package auth
 
+import "time"
+
 type Validator interface {
 	Valid(string) bool
 }
+
+// Limiter is implemented by validators that enforce quotas of tokens.
+type Limiter interface {
+	Allow(string) (time.Duration, bool)
+}
+
+// Identifier is implemented by validators that tell the identities of
+// tokens, e.g. the names of API keys.
+type Identifier interface {
+	Identify(string) (string, bool)
+}
+
+// UsageReporter is implemented by validators that account usage.
+type UsageReporter interface {
+	Usage() []*Usage
+}
@@ -0,0 +1,47 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keys := auth.NewKeyStore(
+		&auth.Key{Name: "admin", Token: "a", Role: auth.RoleAdmin},
+		&auth.Key{Name: "user", Token: "u"},
+	)
+
+	for _, unit := range []struct {
+		v, admin auth.Validator
+		token    string
+		code     int
+	}{
+		// denied without admin validators.
+		{nil, nil, "", http.StatusForbidden},
+		{auth.Token("u"), nil, "u", http.StatusForbidden},
+		{keys, nil, "a", http.StatusForbidden},
+		{keys, keys.Admins(), "u", http.StatusUnauthorized},
+		{keys, keys.Admins(), "a", http.StatusOK},
+		{auth.Token("u"), auth.Token("a"), "u", http.StatusUnauthorized},
+		{auth.Token("u"), auth.Token("a"), "a", http.StatusOK},
+	} {
+		var opts []Option
+		if unit.admin != nil {
+			opts = append(opts, WithAdminValidator(unit.admin))
+		}
+		r := New(engine.Default(), unit.v, opts...)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/keys", nil)
+		req.Header.Set("Authorization", "Bearer "+unit.token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, unit.code, w.Code, unit.token)
+	}
+}
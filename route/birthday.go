@@ -0,0 +1,49 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+// defaultBirthdayDays is the date range when `days` is omitted.
+const defaultBirthdayDays = 7
+
+type birthdayQuery struct {
+	// From is the first date, defaults to today.
+	From string `form:"from"`
+	Days int    `form:"days"`
+}
+
+// getActorBirthdays lists actors with birthdays within the days.
+func getActorBirthdays(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &birthdayQuery{Days: defaultBirthdayDays}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		from := time.Now()
+		if query.From != "" {
+			var err error
+			if from, err = time.Parse(time.DateOnly, query.From); err != nil {
+				abortWithStatusMessage(c, http.StatusBadRequest, err)
+				return
+			}
+		}
+		if query.Days <= 0 || query.Days > engine.MaxBirthdayDays {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid days")
+			return
+		}
+
+		birthdays, err := app.WithContext(c.Request.Context()).GetActorBirthdays(from, query.Days)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: birthdays})
+	}
+}
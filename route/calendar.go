@@ -0,0 +1,81 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// defaultCalendarDays is the date range when `to` is omitted.
+const defaultCalendarDays = 30
+
+type calendarQuery struct {
+	From   string   `form:"from"`
+	To     string   `form:"to"`
+	Actors []string `form:"actor"`
+}
+
+type calendarDay struct {
+	Date   string                     `json:"date"`
+	Movies []*model.MovieSearchResult `json:"movies"`
+}
+
+// dates parses the date range, from defaults to today.
+func (q *calendarQuery) dates() (from, to time.Time, err error) {
+	from = time.Now()
+	if q.From != "" {
+		if from, err = time.Parse(time.DateOnly, q.From); err != nil {
+			return
+		}
+	}
+	to = from.AddDate(0, 0, defaultCalendarDays)
+	if q.To != "" {
+		if to, err = time.Parse(time.DateOnly, q.To); err != nil {
+			return
+		}
+	}
+	if to.Before(from) || to.Sub(from) > engine.MaxCalendarDays*24*time.Hour {
+		err = fmt.Errorf("invalid date range: %s - %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	return
+}
+
+func getCalendar(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &calendarQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		from, to, err := query.dates()
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		results, err := app.WithContext(c.Request.Context()).GetMovieCalendar(from, to, query.Actors...)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: groupByDate(results)})
+	}
+}
+
+// groupByDate indexes sorted results by release date.
+func groupByDate(results []*model.MovieSearchResult) []*calendarDay {
+	days := make([]*calendarDay, 0)
+	for _, result := range results {
+		date := time.Time(result.ReleaseDate).Format(time.DateOnly)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, &calendarDay{Date: date})
+		}
+		days[len(days)-1].Movies = append(days[len(days)-1].Movies, result)
+	}
+	return days
+}
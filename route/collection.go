@@ -0,0 +1,70 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+type collectionQuery struct {
+	Collection model.CollectionType `form:"collection"`
+}
+
+type collectionBody struct {
+	Collection model.CollectionType `json:"collection" binding:"required"`
+	Provider   string               `json:"provider" binding:"required"`
+	ID         string               `json:"id" binding:"required"`
+	Note       string               `json:"note"`
+}
+
+func getCollections(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &collectionQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		items, err := app.GetCollection(requestUser(c), query.Collection)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: items})
+	}
+}
+
+func postCollectionItem(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		body := &collectionBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		item, err := app.AddCollectionItem(user, body.Collection, body.Provider, body.ID, body.Note)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: item})
+	}
+}
+
+func deleteCollectionItem(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		uri := &followUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := app.RemoveCollectionItem(user, uri.ID); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"deleted": true}})
+	}
+}
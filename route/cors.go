@@ -0,0 +1,52 @@
+package route
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// corsMaxAge is the seconds that preflight results can be cached.
+const corsMaxAge = 600
+
+var (
+	corsAllowMethods  = "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsExposeHeaders = "Content-Length, Content-Range, ETag, Retry-After, X-Request-Id"
+)
+
+// cors allows browsers of the origins, or of any origins if one of
+// them is *, to access the API, preflight requests are answered here.
+func cors(origins []string) gin.HandlerFunc {
+	wildcard := slices.ContainsFunc(origins, func(o string) bool {
+		return strings.TrimSpace(o) == "*"
+	})
+	allowed := func(origin string) bool {
+		return wildcard || slices.ContainsFunc(origins, func(o string) bool {
+			return strings.EqualFold(strings.TrimSpace(o), origin)
+		})
+	}
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin == "" || !allowed(origin) {
+			c.Next()
+			return
+		}
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", corsExposeHeaders)
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", corsAllowMethods)
+			if headers := c.GetHeader("Access-Control-Request-Headers"); headers != "" {
+				c.Header("Access-Control-Allow-Headers", headers)
+			}
+			c.Header("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
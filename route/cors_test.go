@@ -0,0 +1,54 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(cors([]string{"https://app.example.com", " https://web.example.com"}))
+	r.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	do := func(method, origin string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/ok", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			req.Header.Set("Access-Control-Request-Headers", "Authorization")
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "https://app.example.com")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+
+	w = do(http.MethodOptions, "https://web.example.com")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://web.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.NotEmpty(t, w.Header().Get("Access-Control-Allow-Methods"))
+
+	for _, origin := range []string{"", "https://evil.example.com"} {
+		w = do(http.MethodGet, origin)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"), origin)
+	}
+
+	r = gin.New()
+	r.Use(cors([]string{"*"}))
+	r.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	w = do(http.MethodGet, "https://any.example.com")
+	assert.Equal(t, "https://any.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+}
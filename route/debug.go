@@ -0,0 +1,85 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+type debugScrapeQuery struct {
+	Provider string `form:"provider" binding:"required"`
+	ID       string `form:"id" binding:"required"`
+	// Type is movie or actor, defaults to movie.
+	Type string `form:"type"`
+}
+
+func getDebugScrape(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &debugScrapeQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		var (
+			result *engine.ScrapeDebug
+			err    error
+		)
+		switch query.Type {
+		case "", "movie":
+			result, err = app.DebugMovieScrape(query.Provider, query.ID)
+		case "actor":
+			result, err = app.DebugActorScrape(query.Provider, query.ID)
+		default:
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid type: "+query.Type)
+			return
+		}
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: result})
+	}
+}
+
+// maxDryRunBodySize is the maximum size of dry run requests.
+const maxDryRunBodySize = 16 << 20
+
+type dryRunBody struct {
+	Provider string `json:"provider" binding:"required"`
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	// Type is movie or actor, defaults to movie.
+	Type string `json:"type"`
+	// HTML is parsed instead of the live pages if not empty.
+	HTML string `json:"html"`
+}
+
+func postDryRunParse(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxDryRunBodySize)
+		body := &dryRunBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if body.Type != "" && body.Type != "movie" && body.Type != "actor" {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid type: "+body.Type)
+			return
+		}
+		result, err := app.DryRunParse(&engine.DryRun{
+			Provider: body.Provider,
+			ID:       body.ID,
+			URL:      body.URL,
+			HTML:     []byte(body.HTML),
+			Actor:    body.Type == "actor",
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: result})
+	}
+}
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestDryRunParse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := New(engine.Default(), auth.Token("u"), WithAdminValidator(auth.Token("a")))
+
+	for _, unit := range []struct {
+		path, token, body string
+		code              int
+	}{
+		// admin only.
+		{"/v1/debug/parse", "u", `{"provider":"javbus","id":"abc-001"}`, http.StatusNotFound},
+		{"/v1/admin/debug/parse", "u", `{"provider":"javbus","id":"abc-001"}`, http.StatusUnauthorized},
+		// never fetches URLs of other sites.
+		{"/v1/admin/debug/parse", "a", `{"provider":"javbus","url":"http://127.0.0.1:6379/"}`, http.StatusBadRequest},
+		{"/v1/admin/debug/parse", "a", `{"provider":"javbus","type":"unknown","id":"abc-001"}`, http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, unit.path, strings.NewReader(unit.body))
+		req.Header.Set("Authorization", "Bearer "+unit.token)
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, unit.code, w.Code, unit.body)
+	}
+}
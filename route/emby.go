@@ -0,0 +1,234 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// embyProviderName is the provider key used in Jellyfin/Emby ProviderIds.
+const embyProviderName = "MetaTube"
+
+// ticksPerMinute is the number of Jellyfin/Emby ticks (100ns) in a minute.
+const ticksPerMinute = int64(time.Minute / 100)
+
+type embyProviderIDs map[string]string
+
+func newEmbyProviderIDs(provider, id string) embyProviderIDs {
+	return embyProviderIDs{embyProviderName: provider + ":" + id}
+}
+
+type embyRemoteSearchResult struct {
+	Name               string          `json:"Name"`
+	ProviderIDs        embyProviderIDs `json:"ProviderIds"`
+	ProductionYear     int             `json:"ProductionYear,omitempty"`
+	PremiereDate       *time.Time      `json:"PremiereDate,omitempty"`
+	ImageURL           string          `json:"ImageUrl,omitempty"`
+	SearchProviderName string          `json:"SearchProviderName"`
+}
+
+type embyPersonInfo struct {
+	Name string `json:"Name"`
+	Type string `json:"Type"`
+}
+
+type embyMovieItem struct {
+	Name            string           `json:"Name"`
+	OriginalTitle   string           `json:"OriginalTitle"`
+	Overview        string           `json:"Overview"`
+	Genres          []string         `json:"Genres"`
+	Studios         []string         `json:"Studios"`
+	Tags            []string         `json:"Tags"`
+	People          []embyPersonInfo `json:"People"`
+	CommunityRating float64          `json:"CommunityRating,omitempty"`
+	PremiereDate    *time.Time       `json:"PremiereDate,omitempty"`
+	ProductionYear  int              `json:"ProductionYear,omitempty"`
+	RunTimeTicks    int64            `json:"RunTimeTicks,omitempty"`
+	HomePageURL     string           `json:"HomePageUrl"`
+	ProviderIDs     embyProviderIDs  `json:"ProviderIds"`
+}
+
+type embyPersonItem struct {
+	Name                string          `json:"Name"`
+	OriginalTitle       string          `json:"OriginalTitle,omitempty"`
+	Overview            string          `json:"Overview"`
+	PremiereDate        *time.Time      `json:"PremiereDate,omitempty"`
+	ProductionLocations []string        `json:"ProductionLocations"`
+	ImageURL            string          `json:"ImageUrl,omitempty"`
+	HomePageURL         string          `json:"HomePageUrl"`
+	ProviderIDs         embyProviderIDs `json:"ProviderIds"`
+}
+
+type embySearchQuery struct {
+	Name     string `form:"name" binding:"required"`
+	Provider string `form:"provider"`
+	Fallback bool   `form:"fallback"`
+}
+
+func getEmbySearch(app *engine.Engine, typ searchType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &embySearchQuery{
+			Fallback: true, // enable fallback by default.
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		results, err := search(app.WithContext(c.Request.Context()), typ, &searchQuery{
+			Q:        query.Name,
+			Provider: query.Provider,
+			Fallback: query.Fallback,
+		})
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		data := []*embyRemoteSearchResult{}
+		switch v := results.(type) {
+		case []*model.ActorSearchResult:
+			for _, result := range v {
+				r := &embyRemoteSearchResult{
+					Name:               result.Name,
+					ProviderIDs:        newEmbyProviderIDs(result.Provider, result.ID),
+					SearchProviderName: embyProviderName,
+				}
+				if len(result.Images) > 0 {
+					r.ImageURL = result.Images[0]
+				}
+				data = append(data, r)
+			}
+		case []*model.MovieSearchResult:
+			for _, result := range v {
+				data = append(data, &embyRemoteSearchResult{
+					Name:               result.Number + " " + result.Title,
+					ProviderIDs:        newEmbyProviderIDs(result.Provider, result.ID),
+					ProductionYear:     embyYear(result.ReleaseDate),
+					PremiereDate:       embyDate(result.ReleaseDate),
+					ImageURL:           result.ThumbURL,
+					SearchProviderName: embyProviderName,
+				})
+			}
+		}
+
+		// Emby/Jellyfin plugins decode the DTOs as is, without the envelope.
+		c.JSON(http.StatusOK, data)
+	}
+}
+
+func getEmbyInfo(app *engine.Engine, typ infoType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &infoQuery{
+			Lazy: true, // enable lazy by default.
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		var (
+			data any
+			err  error
+		)
+		switch typ {
+		case actorInfoType:
+			var info *model.ActorInfo
+			if info, err = app.GetActorInfoByProviderID(uri.Provider, uri.ID, query.Lazy); err == nil {
+				data = newEmbyPersonItem(info)
+			}
+		case movieInfoType:
+			var info *model.MovieInfo
+			if info, err = app.GetMovieInfoByProviderID(uri.Provider, uri.ID, query.Lazy); err == nil {
+				data = newEmbyMovieItem(info)
+			}
+		default:
+			panic("invalid info/metadata type")
+		}
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, data)
+	}
+}
+
+func newEmbyMovieItem(info *model.MovieInfo) *embyMovieItem {
+	item := &embyMovieItem{
+		Name:            info.Number + " " + info.Title,
+		OriginalTitle:   info.Title,
+		Overview:        info.Summary,
+		Genres:          append([]string{}, info.Genres...),
+		Studios:         []string{},
+		Tags:            []string{},
+		People:          []embyPersonInfo{},
+		CommunityRating: info.Score * 2, /* 5-star to 10-point scale */
+		PremiereDate:    embyDate(info.ReleaseDate),
+		ProductionYear:  embyYear(info.ReleaseDate),
+		RunTimeTicks:    int64(info.Runtime) * ticksPerMinute,
+		HomePageURL:     info.Homepage,
+		ProviderIDs:     newEmbyProviderIDs(info.Provider, info.ID),
+	}
+	if info.Maker != "" {
+		item.Studios = append(item.Studios, info.Maker)
+	}
+	for _, tag := range []string{info.Label, info.Series} {
+		if tag != "" {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+	if info.Director != "" {
+		item.People = append(item.People, embyPersonInfo{Name: info.Director, Type: "Director"})
+	}
+	for _, actor := range info.Actors {
+		item.People = append(item.People, embyPersonInfo{Name: actor, Type: "Actor"})
+	}
+	return item
+}
+
+func newEmbyPersonItem(info *model.ActorInfo) *embyPersonItem {
+	item := &embyPersonItem{
+		Name:                info.Name,
+		Overview:            info.Summary,
+		PremiereDate:        embyDate(info.Birthday),
+		ProductionLocations: []string{},
+		HomePageURL:         info.Homepage,
+		ProviderIDs:         newEmbyProviderIDs(info.Provider, info.ID),
+	}
+	if len(info.Aliases) > 0 {
+		item.OriginalTitle = info.Aliases[0]
+	}
+	if info.Nationality != "" {
+		item.ProductionLocations = append(item.ProductionLocations, info.Nationality)
+	}
+	if len(info.Images) > 0 {
+		item.ImageURL = info.Images[0]
+	}
+	return item
+}
+
+func embyDate(d datatypes.Date) *time.Time {
+	if t := time.Time(d); !t.IsZero() {
+		return &t
+	}
+	return nil
+}
+
+func embyYear(d datatypes.Date) int {
+	if t := time.Time(d); !t.IsZero() {
+		return t.Year()
+	}
+	return 0
+}
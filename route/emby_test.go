@@ -0,0 +1,73 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// embyTestEngine returns an engine of its own in-memory DB with a movie
+// info of FANZA:emby00001.
+func embyTestEngine(t *testing.T) *engine.Engine {
+	db, err := database.Open(&database.Config{DSN: "file:emby_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	app := engine.New(engine.WithDB(db))
+	require.NoError(t, app.DBAutoMigrate(true))
+	require.NoError(t, db.Save(&model.MovieInfo{
+		ID: "emby00001", Number: "EMBY-001", Title: "Title", Provider: "FANZA",
+		Homepage: "https://example.com/emby00001", CoverURL: "https://example.com/emby00001.jpg",
+		Maker: "Maker", Director: "Director", Actors: []string{"Actor"}, Runtime: 120,
+		ReleaseDate: datatypes.Date(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+	}).Error)
+	return app
+}
+
+func TestGetEmbyInfo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	app := embyTestEngine(t)
+
+	r := gin.New()
+	r.GET("/emby/movies/:provider/:id", getEmbyInfo(app, movieInfoType))
+	r.GET("/emby/movies/search", getEmbySearch(app, movieSearchType))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/emby/movies/FANZA/emby00001", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	// DTOs are written as is, without the envelope.
+	item := map[string]any{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+	assert.NotContains(t, item, "data")
+	assert.Equal(t, "EMBY-001 Title", item["Name"])
+	assert.Equal(t, []any{"Maker"}, item["Studios"])
+	assert.EqualValues(t, 2021, item["ProductionYear"])
+	assert.EqualValues(t, 120*ticksPerMinute, item["RunTimeTicks"])
+	assert.Equal(t, map[string]any{embyProviderName: "FANZA:emby00001"}, item["ProviderIds"])
+	assert.Len(t, item["People"], 2)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/emby/movies/unknown/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/emby/movies/search", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNewEmbyPersonItem(t *testing.T) {
+	item := newEmbyPersonItem(&model.ActorInfo{ID: "1", Name: "Name", Provider: "GFRIENDS"})
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"Name"`)
+	assert.Contains(t, string(data), `"ProviderIds":{"MetaTube":"GFRIENDS:1"}`)
+}
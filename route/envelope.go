@@ -0,0 +1,207 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// API versions of the response envelope.
+const (
+	apiVersion1 = "1"
+	apiVersion2 = "2"
+)
+
+// apiVersionHeader is the response header of the API version.
+const apiVersionHeader = "X-API-Version"
+
+// apiVersion sets the API version header of responses.
+func apiVersion(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(apiVersionHeader, version)
+		c.Next()
+	}
+}
+
+// envelopeV2 is the response envelope of /v2, pagination and other
+// response metadata are kept in meta, so that new metadata doesn't add
+// top-level fields. It is stable, fields are only ever added to it.
+type envelopeV2 struct {
+	APIVersion string          `json:"api_version"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Error      json.RawMessage `json:"error,omitempty"`
+	Meta       *metaV2         `json:"meta,omitempty"`
+}
+
+type metaV2 struct {
+	Page json.RawMessage `json:"page,omitempty"`
+}
+
+// envelope rewrites JSON responses of the frozen /v1 handlers into the
+// envelope of the API version, so that /v1 never changes along with
+// newer versions. Responses without the /v1 envelope, e.g. modules,
+// become the data of the new envelope, dates become ISO 8601 dates and
+// runtimes become seconds.
+func envelope(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		w := newBufferWriter(c.Writer)
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		body := w.buf.Bytes()
+		if w.status != http.StatusNotModified &&
+			strings.HasPrefix(c.Writer.Header().Get("Content-Type"), gin.MIMEJSON) {
+			if wrapped, err := wrapResponse(body, version); err == nil {
+				body = wrapped
+				c.Writer.Header().Del("Content-Length")
+			}
+		}
+		c.Writer.WriteHeader(w.status)
+		if len(body) > 0 {
+			_, _ = c.Writer.Write(body)
+		} else {
+			c.Writer.WriteHeaderNow()
+		}
+	}
+}
+
+func wrapResponse(body []byte, version string) ([]byte, error) {
+	body, err := rewriteFields(body)
+	if err != nil {
+		return nil, err
+	}
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	v2 := &envelopeV2{APIVersion: version}
+	if isEnvelopeV1(resp) {
+		v2.Data, v2.Error = resp["data"], resp["error"]
+		if page, ok := resp["page"]; ok {
+			v2.Meta = &metaV2{Page: page}
+		}
+	} else {
+		v2.Data = body
+	}
+	return json.Marshal(v2)
+}
+
+// isEnvelopeV1 reports whether the response is a /v1 responseMessage.
+func isEnvelopeV1(resp map[string]json.RawMessage) bool {
+	for key := range resp {
+		switch key {
+		case "data", "page", "error":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// dateKeys are the JSON keys of the datatypes.Date fields of models,
+// which are RFC 3339 timestamps in /v1 and ISO 8601 dates in /v2.
+var dateKeys = jsonDateKeys(
+	model.MovieInfo{}, model.MovieSearchResult{}, model.MovieReviewDetail{},
+	model.ActorInfo{}, model.ActorSearchResult{}, model.ActorBirthday{},
+	model.FollowRelease{},
+)
+
+// jsonDateKeys returns the JSON keys of the datatypes.Date fields of
+// the values, nested ones included.
+func jsonDateKeys(values ...any) map[string]bool {
+	var (
+		keys    = make(map[string]bool)
+		visited = make(map[reflect.Type]bool)
+		dateTyp = reflect.TypeOf(datatypes.Date{})
+		walk    func(reflect.Type)
+	)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice ||
+			t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t == dateTyp || visited[t] {
+			return
+		}
+		visited[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			switch {
+			case name == "-" || !f.IsExported():
+			case f.Type == dateTyp && name != "":
+				keys[name] = true
+			default:
+				walk(f.Type)
+			}
+		}
+	}
+	for _, v := range values {
+		walk(reflect.TypeOf(v))
+	}
+	return keys
+}
+
+// runtimeKey is the JSON key of the runtime of movies, which is integer
+// minutes in /v1 and integer seconds in /v2.
+const runtimeKey = "runtime"
+
+// rewriteFields rewrites the dates of the JSON body into ISO 8601 dates
+// and the runtimes into seconds, the body is returned as is if it has
+// neither of them.
+func rewriteFields(body []byte) ([]byte, error) {
+	if !hasDateKeys(body) && !bytes.Contains(body, []byte(`"`+runtimeKey+`":`)) {
+		return body, nil
+	}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(rewriteValues(v))
+}
+
+func hasDateKeys(body []byte) bool {
+	for key := range dateKeys {
+		if bytes.Contains(body, []byte(`"`+key+`":`)) {
+			return true
+		}
+	}
+	return false
+}
+
+func rewriteValues(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && dateKeys[key] {
+				if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+					v[key] = t.Format(time.DateOnly)
+				}
+				continue
+			}
+			if n, ok := value.(json.Number); ok && key == runtimeKey {
+				if minutes, err := n.Int64(); err == nil {
+					v[key] = datatypes.Duration(time.Duration(minutes) * time.Minute)
+				}
+				continue
+			}
+			v[key] = rewriteValues(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = rewriteValues(value)
+		}
+	}
+	return v
+}
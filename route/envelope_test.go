@@ -0,0 +1,97 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	handlers := func(g *gin.RouterGroup) {
+		g.GET("/search", func(c *gin.Context) {
+			c.JSON(http.StatusOK, &responseMessage{
+				Data: []gin.H{{"id": "1"}},
+				Page: &pageInfo{Page: 1, Limit: 1, Total: 3},
+			})
+		})
+		g.GET("/error", func(c *gin.Context) {
+			abortWithStatusMessage(c, http.StatusNotFound, "not found")
+		})
+		g.GET("/modules", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"modules": []string{"a"}})
+		})
+		g.GET("/movie", func(c *gin.Context) {
+			c.JSON(http.StatusOK, &responseMessage{
+				Data: []*model.MovieSearchResult{{
+					ID:          "1",
+					ReleaseDate: datatypes.NewDate(2021, 2, 3),
+				}},
+			})
+		})
+		g.GET("/text", func(c *gin.Context) {
+			c.String(http.StatusOK, "plain")
+		})
+	}
+	handlers(r.Group("/v1", apiVersion(apiVersion1)))
+	handlers(r.Group("/v2", apiVersion(apiVersion2), envelope(apiVersion2)))
+
+	for _, unit := range []struct {
+		path    string
+		code    int
+		version string
+		want    string
+	}{
+		// v1 is frozen.
+		{"/v1/search", http.StatusOK, "1", `{"data":[{"id":"1"}],"page":{"page":1,"limit":1,"total":3}}`},
+		{"/v1/error", http.StatusNotFound, "1", `{"error":{"code":404,"message":"not found"}}`},
+		{"/v1/modules", http.StatusOK, "1", `{"modules":["a"]}`},
+		{"/v2/search", http.StatusOK, "2", `{"api_version":"2","data":[{"id":"1"}],"meta":{"page":{"page":1,"limit":1,"total":3}}}`},
+		{"/v2/error", http.StatusNotFound, "2", `{"api_version":"2","error":{"code":404,"message":"not found"}}`},
+		{"/v2/modules", http.StatusOK, "2", `{"api_version":"2","data":{"modules":["a"]}}`},
+		// dates are RFC 3339 in v1, and ISO 8601 in v2.
+		{"/v1/movie", http.StatusOK, "1", `{"data":[{"id":"1","number":"","title":"","provider":"","homepage":"","thumb_url":"","cover_url":"","score":0,"release_date":"2021-02-03T00:00:00Z","streaming_date":"0001-01-01T00:00:00Z","physical_date":"0001-01-01T00:00:00Z"}]}`},
+		{"/v2/movie", http.StatusOK, "2", `{"api_version":"2","data":[{"id":"1","number":"","title":"","provider":"","homepage":"","thumb_url":"","cover_url":"","score":0,"release_date":"2021-02-03","streaming_date":"0001-01-01","physical_date":"0001-01-01"}]}`},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, unit.path, nil))
+		assert.Equal(t, unit.code, w.Code, unit.path)
+		assert.Equal(t, unit.version, w.Header().Get(apiVersionHeader), unit.path)
+		assert.JSONEq(t, unit.want, w.Body.String(), unit.path)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/text", nil))
+	assert.Equal(t, "plain", w.Body.String())
+}
+
+func TestRewriteFields(t *testing.T) {
+	for _, key := range []string{"release_date", "streaming_date", "physical_date", "birthday", "debut_date", "date"} {
+		assert.True(t, dateKeys[key], key)
+	}
+	assert.False(t, dateKeys["created_at"])
+
+	for _, unit := range []struct {
+		body, want string
+	}{
+		// runtimes are minutes in v1, and seconds in v2.
+		{`{"data":{"title":"a","runtime":120}}`, `{"data":{"title":"a","runtime":7200}}`},
+		{`{"data":{"runtime":0,"sources":{"runtime":"FANZA"}}}`, `{"data":{"runtime":0,"sources":{"runtime":"FANZA"}}}`},
+		{`{"data":{"birthday":"1990-05-06T00:00:00+09:00","score":4.25}}`, `{"data":{"birthday":"1990-05-06","score":4.25}}`},
+		// not a date of models.
+		{`{"data":{"date":"2021-02-03","started_at":"2021-02-03T04:05:06Z"}}`, `{"data":{"date":"2021-02-03","started_at":"2021-02-03T04:05:06Z"}}`},
+		{`{"data":[{"release_date":"2021-02-03T00:00:00Z","id":12345678901234567890}]}`, `{"data":[{"release_date":"2021-02-03","id":12345678901234567890}]}`},
+	} {
+		body, err := rewriteFields([]byte(unit.body))
+		if assert.NoError(t, err, unit.body) {
+			assert.JSONEq(t, unit.want, string(body), unit.body)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package route
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag computes a strong ETag from the body of successful GET responses
+// and answers conditional requests with 304 Not Modified, so that clients
+// refreshing metadata periodically don't re-transfer unchanged payloads.
+func etag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := newBufferWriter(c.Writer)
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.status == http.StatusOK {
+			tag := computeETag(w.buf.Bytes())
+			c.Header("ETag", tag)
+			if matchETag(c.GetHeader("If-None-Match"), tag) {
+				c.Writer.Header().Del("Content-Length")
+				c.Writer.Header().Del("Content-Type")
+				c.Writer.WriteHeader(http.StatusNotModified)
+				c.Writer.WriteHeaderNow()
+				return
+			}
+		}
+		c.Writer.WriteHeader(w.status)
+		if w.buf.Len() > 0 {
+			_, _ = c.Writer.Write(w.buf.Bytes())
+		} else {
+			c.Writer.WriteHeaderNow()
+		}
+	}
+}
+
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matchETag reports whether the If-None-Match header matches tag,
+// using the weak comparison as required by RFC 9110.
+func matchETag(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferWriter buffers the response so that it can be
+// inspected, e.g. for the ETag, before it's written.
+type bufferWriter struct {
+	gin.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func newBufferWriter(w gin.ResponseWriter) *bufferWriter {
+	return &bufferWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (w *bufferWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferWriter) WriteHeaderNow() {}
+
+func (w *bufferWriter) Write(data []byte) (int, error) { return w.buf.Write(data) }
+
+func (w *bufferWriter) WriteString(s string) (int, error) { return w.buf.WriteString(s) }
+
+func (w *bufferWriter) Status() int { return w.status }
+
+func (w *bufferWriter) Size() int { return w.buf.Len() }
+
+func (w *bufferWriter) Written() bool { return w.buf.Len() > 0 }
@@ -0,0 +1,50 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(etag())
+	r.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"id": "abc123"}) })
+	r.GET("/error", func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"error": "not found"}) })
+
+	do := func(path, inm string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if inm != "" {
+			req.Header.Set("If-None-Match", inm)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("/ok", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"abc123"}`, w.Body.String())
+	tag := w.Header().Get("ETag")
+	assert.NotEmpty(t, tag)
+
+	w = do("/ok", tag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = do("/ok", `"other", W/`+tag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = do("/ok", `"other"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, tag, w.Header().Get("ETag"))
+
+	w = do("/error", "*")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.JSONEq(t, `{"error":"not found"}`, w.Body.String())
+}
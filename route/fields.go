@@ -0,0 +1,139 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// selectFields shapes successful JSON responses to the fields listed
+// in the fields query, e.g. ?fields=title,cover_url,actors, so that
+// list views don't transfer full summaries and preview arrays. Fields
+// of nested objects are selected with dots, e.g. ?fields=reviews.title,
+// and fields unknown to the response are ignored. It applies to each
+// item if the data is an array, and the error and page info are kept.
+func selectFields() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := parseFields(c.Query("fields"))
+		if len(fields) == 0 {
+			c.Next()
+			return
+		}
+
+		w := newBufferWriter(c.Writer)
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		body := w.buf.Bytes()
+		if w.status == http.StatusOK &&
+			strings.HasPrefix(c.Writer.Header().Get("Content-Type"), gin.MIMEJSON) {
+			if shaped, err := shapeResponse(body, fields); err == nil {
+				body = shaped
+				c.Writer.Header().Del("Content-Length")
+			}
+		}
+		c.Writer.WriteHeader(w.status)
+		if len(body) > 0 {
+			_, _ = c.Writer.Write(body)
+		} else {
+			c.Writer.WriteHeaderNow()
+		}
+	}
+}
+
+// fieldSet is a tree of selected fields, nil means the whole value.
+type fieldSet map[string]fieldSet
+
+func parseFields(query string) fieldSet {
+	var fields fieldSet
+	for _, path := range strings.Split(query, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if fields == nil {
+			fields = make(fieldSet)
+		}
+		fields.add(strings.Split(path, "."))
+	}
+	return fields
+}
+
+func (f fieldSet) add(path []string) {
+	name := path[0]
+	sub, ok := f[name]
+	if ok && sub == nil {
+		return // the whole value is selected.
+	}
+	if len(path) == 1 {
+		f[name] = nil
+		return
+	}
+	if sub == nil {
+		sub = make(fieldSet)
+		f[name] = sub
+	}
+	sub.add(path[1:])
+}
+
+func shapeResponse(body []byte, fields fieldSet) ([]byte, error) {
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	data, ok := resp["data"]
+	if !ok {
+		return body, nil
+	}
+	data, err := fields.pick(data)
+	if err != nil {
+		return nil, err
+	}
+	resp["data"] = data
+	return json.Marshal(resp)
+}
+
+// pick selects the fields of the object, or of each object of the
+// array, other values are kept as is.
+func (f fieldSet) pick(raw json.RawMessage) (json.RawMessage, error) {
+	switch trimmed := bytes.TrimSpace(raw); {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			picked, err := f.pick(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = picked
+		}
+		return json.Marshal(items)
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return nil, err
+		}
+		picked := make(map[string]json.RawMessage, len(f))
+		for name, sub := range f {
+			value, ok := obj[name]
+			if !ok {
+				continue
+			}
+			if sub != nil {
+				var err error
+				if value, err = sub.pick(value); err != nil {
+					return nil, err
+				}
+			}
+			picked[name] = value
+		}
+		return json.Marshal(picked)
+	default:
+		return raw, nil
+	}
+}
@@ -0,0 +1,56 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(selectFields())
+	r.GET("/info", func(c *gin.Context) {
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{
+			"title":   "Title",
+			"summary": "Long summary",
+			"actors":  []string{"A", "B"},
+			"reviews": []gin.H{{"title": "Good", "comment": "Long comment"}},
+		}})
+	})
+	r.GET("/search", func(c *gin.Context) {
+		c.JSON(http.StatusOK, &responseMessage{
+			Data: []gin.H{{"id": "1", "title": "One"}, {"id": "2", "title": "Two"}},
+			Page: &pageInfo{Page: 1, Limit: 2, Total: 2},
+		})
+	})
+	r.GET("/error", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": gin.H{"code": 404, "message": "not found"}})
+	})
+
+	do := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	for _, unit := range []struct {
+		path string
+		code int
+		want string
+	}{
+		{"/info?fields=title,actors,unknown", http.StatusOK, `{"data":{"title":"Title","actors":["A","B"]}}`},
+		{"/info?fields=reviews.title", http.StatusOK, `{"data":{"reviews":[{"title":"Good"}]}}`},
+		{"/info?fields=reviews.title,reviews", http.StatusOK, `{"data":{"reviews":[{"title":"Good","comment":"Long comment"}]}}`},
+		{"/search?fields=id", http.StatusOK, `{"data":[{"id":"1"},{"id":"2"}],"page":{"page":1,"limit":2,"total":2}}`},
+		{"/search?fields=+,", http.StatusOK, `{"data":[{"id":"1","title":"One"},{"id":"2","title":"Two"}],"page":{"page":1,"limit":2,"total":2}}`},
+		{"/error?fields=id", http.StatusNotFound, `{"error":{"code":404,"message":"not found"}}`},
+	} {
+		w := do(unit.path)
+		assert.Equal(t, unit.code, w.Code, unit.path)
+		assert.JSONEq(t, unit.want, w.Body.String(), unit.path)
+	}
+}
@@ -0,0 +1,61 @@
+package route
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/library"
+)
+
+type filmographyRequest struct {
+	Actor string `json:"actor" binding:"required"`
+	// Numbers are movie numbers or video filenames of the library.
+	Numbers []string `json:"numbers"`
+	// Path is a library directory to scan for videos, if any, it must
+	// be within the library roots.
+	Path string `json:"path"`
+}
+
+// postFilmography reports movies of the actor missing in the library,
+// paths are confined to the library roots.
+func postFilmography(app *engine.Engine, roots []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := &filmographyRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		items := req.Numbers
+		if req.Path != "" {
+			path, err := library.ResolveInRoots(req.Path, roots)
+			if err != nil {
+				abortWithStatusMessage(c, http.StatusForbidden, err)
+				return
+			}
+			videos, err := library.FindVideos(path)
+			if err != nil {
+				abortWithStatusMessage(c, http.StatusBadRequest, err)
+				return
+			}
+			items = append(items, videos...)
+		}
+		numbers := make([]string, 0, len(items))
+		for _, item := range items {
+			if num, _ := number.ParsePart(filepath.Base(item)); num != "" {
+				numbers = append(numbers, num)
+			}
+		}
+
+		report, err := app.WithContext(c.Request.Context()).GetFilmographyReport(req.Actor, numbers)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: report})
+	}
+}
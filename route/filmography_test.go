@@ -0,0 +1,41 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestPostFilmographyRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	root, other := t.TempDir(), t.TempDir()
+
+	for _, unit := range []struct {
+		opts  []Option
+		token string
+		path  string
+		code  int
+	}{
+		{[]Option{WithLibraryRoots(root)}, "user", root, http.StatusUnauthorized},
+		{[]Option{WithLibraryRoots(root)}, "admin", other, http.StatusForbidden},
+		{[]Option{WithLibraryRoots(root)}, "admin", "/", http.StatusForbidden},
+		// no paths are allowed without library roots.
+		{nil, "admin", root, http.StatusForbidden},
+	} {
+		opts := append(unit.opts, WithAdminValidator(auth.Token("admin")))
+		r := New(engine.Default(), auth.Token("user"), opts...)
+		w := httptest.NewRecorder()
+		body := `{"actor": "Actor", "path": "` + unit.path + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/library/filmography", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+unit.token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, unit.code, w.Code, body)
+	}
+}
@@ -0,0 +1,87 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+type followUri struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+type followBody struct {
+	Type model.FollowType `json:"type" binding:"required"`
+	Name string           `json:"name" binding:"required"`
+}
+
+func getFollows(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		follows, err := app.GetFollows(user)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: follows})
+	}
+}
+
+func postFollow(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		body := &followBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		follow, err := app.AddFollow(user, body.Type, body.Name)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: follow})
+	}
+}
+
+func deleteFollow(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		uri := &followUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := app.RemoveFollow(user, uri.ID); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"deleted": true}})
+	}
+}
+
+func getUnseenReleases(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		releases, err := app.GetUnseenReleases(user)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: releases})
+	}
+}
+
+func postReleasesSeen(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		if err := app.MarkReleasesSeen(user); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"seen": true}})
+	}
+}
@@ -0,0 +1,85 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestFollowsOfKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, err := database.Open(&database.Config{DSN: "file:follow_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	app := engine.New(engine.WithDB(db))
+	require.NoError(t, app.DBAutoMigrate(true))
+	r := New(app, auth.NewKeyStore(
+		&auth.Key{Name: "alice", Token: "a"},
+		&auth.Key{Name: "bob", Token: "b"},
+	))
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		return w
+	}
+	count := func(path, token string) int {
+		w := serve(http.MethodGet, path, token, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &struct{ Data []any }{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		return len(resp.Data)
+	}
+
+	w := serve(http.MethodPost, "/v1/follows", "a", `{"type": "actor", "name": "Name"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve(http.MethodPost, "/v1/collections", "a", `{"collection": "watch_later", "provider": "FANZA", "id": "abc00001"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// the user is of the key, and can't be named by the query.
+	assert.Equal(t, 1, count("/v1/follows", "a"))
+	assert.Equal(t, 0, count("/v1/follows", "b"))
+	assert.Equal(t, 0, count("/v1/follows?user=alice", "b"))
+	assert.Equal(t, 1, count("/v1/collections", "a"))
+	assert.Equal(t, 0, count("/v1/collections?user=alice", "b"))
+}
+
+func TestNumberStatesOfKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, err := database.Open(&database.Config{DSN: "file:state_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	app := engine.New(engine.WithDB(db))
+	require.NoError(t, app.DBAutoMigrate(true))
+	r := New(app, auth.NewKeyStore(
+		&auth.Key{Name: "alice", Token: "a"},
+		&auth.Key{Name: "bob", Token: "b"},
+	))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/states", strings.NewReader(`{"numbers": ["ABC-001"], "owned": true}`))
+	req.Header.Set("Authorization", "Bearer a")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	for token, n := range map[string]int{"a": 1, "b": 0} {
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/v1/states?user=alice", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &struct{ Data []any }{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		assert.Len(t, resp.Data, n, token)
+	}
+}
@@ -0,0 +1,84 @@
+package route
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+// getHealthz reports the server is alive, it never checks dependencies
+// so that the process is not restarted when they're down.
+func getHealthz() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{"status": "ok"}})
+	}
+}
+
+// getReadyz reports whether the server is ready to serve requests, it
+// responds 503 with the failed checks if not.
+func getReadyz(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checks, ready := app.WithContext(c.Request.Context()).CheckReadiness()
+		code := http.StatusOK
+		if !ready {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, &responseMessage{Data: gin.H{
+			"ready":  ready,
+			"checks": checks,
+		}})
+	}
+}
+
+type providerHealth struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func getProviderHealth(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		failed := app.WithContext(c.Request.Context()).CheckProviderHealth()
+		health := func(name, typ string) *providerHealth {
+			h := &providerHealth{Name: name, Type: typ, OK: true}
+			if err, ok := failed[strings.ToUpper(name)]; ok {
+				h.OK, h.Error = false, err.Error()
+			}
+			return h
+		}
+		data := make([]*providerHealth, 0, len(app.GetActorProviders())+len(app.GetMovieProviders()))
+		for _, provider := range app.GetActorProviders() {
+			data = append(data, health(provider.Name(), "actor"))
+		}
+		for _, provider := range app.GetMovieProviders() {
+			data = append(data, health(provider.Name(), "movie"))
+		}
+		sort.Slice(data, func(i, j int) bool {
+			if data[i].Type != data[j].Type {
+				return data[i].Type < data[j].Type
+			}
+			return data[i].Name < data[j].Name
+		})
+		c.JSON(http.StatusOK, &responseMessage{Data: data})
+	}
+}
+
+type fieldStatsQuery struct {
+	Provider string `form:"provider"`
+}
+
+func getProviderFieldStats(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &fieldStatsQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: app.GetFieldStats(query.Provider)})
+	}
+}
@@ -3,6 +3,7 @@ package route
 import (
 	"bytes"
 	"image"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/engine"
 	"github.com/metatube-community/metatube-sdk-go/imageutil"
 	"github.com/metatube-community/metatube-sdk-go/imageutil/badge"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/pipeline"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 )
 
@@ -35,8 +37,20 @@ type imageQuery struct {
 	Auto     bool    `form:"auto"`
 	Badge    string  `form:"badge"`
 	Quality  int     `form:"quality"`
+	// Fit of primary images of movies, either crop or letterbox.
+	Fit string `form:"fit"`
+	// Pipeline of transforms applied to the image, see the pipeline
+	// package for the syntax, e.g. resize:width=400|format:type=png.
+	// POST requests take the JSON form of the pipeline as the body.
+	Pipeline string `form:"pipeline"`
 }
 
+// Fits of primary images.
+const (
+	cropImageFit      = "crop"
+	letterboxImageFit = "letterbox"
+)
+
 func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 	var ratio float64
 	switch typ {
@@ -51,6 +65,7 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
 		uri := &imageUri{}
 		if err := c.ShouldBindUri(uri); err != nil {
 			abortWithStatusMessage(c, http.StatusBadRequest, err)
@@ -66,6 +81,24 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			return
 		}
 
+		p, err := bindPipeline(c, query.Pipeline)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		switch query.Fit {
+		case "", cropImageFit:
+		case letterboxImageFit:
+			if typ != primaryImageType {
+				abortWithStatusMessage(c, http.StatusBadRequest, "letterbox is only supported for primary images")
+				return
+			}
+		default:
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid fit")
+			return
+		}
+
 		var isActorProvider bool
 		switch {
 		case app.IsActorProvider(uri.Provider):
@@ -77,10 +110,7 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			return
 		}
 
-		var (
-			img image.Image
-			err error
-		)
+		var img image.Image
 		if query.URL != "" /* specified URL */ {
 			var provider mt.Provider
 			if isActorProvider {
@@ -92,7 +122,11 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			if typ != primaryImageType || query.Ratio < 0 {
 				query.Ratio = ratio
 			}
-			img, err = app.GetImageByURL(provider, query.URL, query.Ratio, query.Position, query.Auto)
+			if query.Fit == letterboxImageFit {
+				img, err = app.GetLetterboxImageByURL(provider, query.URL, query.Ratio)
+			} else {
+				img, err = app.GetImageByURL(provider, query.URL, query.Ratio, query.Position, query.Auto)
+			}
 		} else if isActorProvider /* actor */ {
 			switch typ {
 			case primaryImageType:
@@ -104,7 +138,11 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 		} else /* movie */ {
 			switch typ {
 			case primaryImageType:
-				img, err = app.GetMoviePrimaryImage(uri.Provider, uri.ID, query.Ratio, query.Position)
+				if query.Fit == letterboxImageFit {
+					img, err = app.GetMovieLetterboxImage(uri.Provider, uri.ID, query.Ratio)
+				} else {
+					img, err = app.GetMoviePrimaryImage(uri.Provider, uri.ID, query.Ratio, query.Position)
+				}
 			case thumbImageType:
 				img, err = app.GetMovieThumbImage(uri.Provider, uri.ID)
 			case backdropImageType:
@@ -123,20 +161,143 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			}
 		}
 
-		c.Header("X-MetaTube-Image-Width", strconv.Itoa(img.Bounds().Dx()))
-		c.Header("X-MetaTube-Image-Height", strconv.Itoa(img.Bounds().Dy()))
+		if p != nil {
+			if img, err = p.Apply(img); err != nil {
+				abortWithError(c, err)
+				return
+			}
+			renderPipelineImage(c, img, p)
+			return
+		}
+
+		renderImage(c, img, query.Quality)
+	}
+}
+
+// bindPipeline compiles the pipeline of the query, or of the JSON body
+// of POST requests, nil if there is none.
+func bindPipeline(c *gin.Context, query string) (*pipeline.Pipeline, error) {
+	if c.Request.Method == http.MethodPost {
+		data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPipelineSize))
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.ParseJSON(data)
+	}
+	if query == "" {
+		return nil, nil
+	}
+	return pipeline.Parse(query)
+}
+
+// maxPipelineSize is the max size of JSON pipelines.
+const maxPipelineSize = 64 << 10
+
+type actorFaceImageQuery struct {
+	// Ratio is the width to height ratio, e.g. 1 for a square,
+	// defaults to the primary ratio 2:3.
+	Ratio   float64 `form:"ratio"`
+	Quality int     `form:"quality"`
+}
+
+// getActorFaceImage serves the actor image cropped to the ratio and
+// centered on the detected face, as person images of Jellyfin expect.
+func getActorFaceImage(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &actorFaceImageQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if query.Ratio < 0 {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid ratio")
+			return
+		}
+		if !app.IsActorProvider(uri.Provider) {
+			abortWithError(c, mt.ErrProviderNotFound)
+			return
+		}
+		img, err := app.GetActorFaceImage(uri.Provider, uri.ID, query.Ratio)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		renderImage(c, img, query.Quality)
+	}
+}
+
+type previewQuery struct {
+	// Format is either webp or gif, defaults to webp.
+	Format string `form:"format"`
+}
 
-		buf := &bytes.Buffer{}
-		if err = imageutil.EncodeToJPEG(buf, img, query.Quality); err != nil {
-			panic(err)
+// getPreview serves the animated preview sampled from the movie
+// trailer, for hover animations of media centers.
+func getPreview(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
 		}
+		query := &previewQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, mt.ErrProviderNotFound)
+			return
+		}
+		path, err := app.GetMoviePreview(uri.Provider, uri.ID, query.Format)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.File(path)
+	}
+}
+
+// renderImage responds with the image encoded as JPEG.
+func renderImage(c *gin.Context, img image.Image, quality int) {
+	c.Header("X-MetaTube-Image-Width", strconv.Itoa(img.Bounds().Dx()))
+	c.Header("X-MetaTube-Image-Height", strconv.Itoa(img.Bounds().Dy()))
+
+	buf := &bytes.Buffer{}
+	if err := imageutil.EncodeToJPEG(buf, img, quality); err != nil {
+		panic(err)
+	}
+
+	c.Render(http.StatusOK, render.Reader{
+		ContentType:   jpegImageMIMEType,
+		ContentLength: int64(buf.Len()),
+		Reader:        buf,
+	})
+}
 
-		c.Render(http.StatusOK, render.Reader{
-			ContentType:   jpegImageMIMEType,
-			ContentLength: int64(buf.Len()),
-			Reader:        buf,
-		})
+// renderPipelineImage responds with the image encoded in the output
+// format of the pipeline.
+func renderPipelineImage(c *gin.Context, img image.Image, p *pipeline.Pipeline) {
+	c.Header("X-MetaTube-Image-Width", strconv.Itoa(img.Bounds().Dx()))
+	c.Header("X-MetaTube-Image-Height", strconv.Itoa(img.Bounds().Dy()))
+
+	buf := &bytes.Buffer{}
+	if err := p.Encode(buf, img); err != nil {
+		panic(err)
 	}
+
+	c.Render(http.StatusOK, render.Reader{
+		ContentType:   p.ContentType(),
+		ContentLength: int64(buf.Len()),
+		Reader:        buf,
+	})
 }
 
 const jpegImageMIMEType = "image/jpeg"
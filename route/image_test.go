@@ -0,0 +1,87 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+func TestGetActorFaceImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/actors/:provider/:id/primary", getActorFaceImage(engine.Default()))
+
+	for _, unit := range []struct {
+		path string
+		code int
+	}{
+		{"/actors/unknown/1/primary", http.StatusNotFound},
+		{"/actors/unknown/1/primary?ratio=-1", http.StatusBadRequest},
+		{"/actors/unknown/1/primary?ratio=square", http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, unit.path, nil))
+		assert.Equal(t, unit.code, w.Code, unit.path)
+	}
+}
+
+func TestGetPreview(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/images/preview/:provider/:id", getPreview(engine.Default()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/images/preview/unknown/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetImage_Fit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	app := engine.Default()
+	r.GET("/images/primary/:provider/:id", getImage(app, primaryImageType))
+	r.GET("/images/thumb/:provider/:id", getImage(app, thumbImageType))
+
+	for _, unit := range []struct {
+		path string
+		code int
+	}{
+		{"/images/primary/unknown/1?fit=stretch", http.StatusBadRequest},
+		{"/images/thumb/unknown/1?fit=letterbox", http.StatusBadRequest},
+		{"/images/primary/unknown/1?fit=letterbox", http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, unit.path, nil))
+		assert.Equal(t, unit.code, w.Code, unit.path)
+	}
+}
+
+func TestGetImage_Pipeline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	app := engine.Default()
+	r.GET("/images/primary/:provider/:id", getImage(app, primaryImageType))
+	r.POST("/images/primary/:provider/:id", getImage(app, primaryImageType))
+
+	for _, unit := range []struct {
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{http.MethodGet, "/images/primary/unknown/1?pipeline=blur", "", http.StatusBadRequest},
+		{http.MethodGet, "/images/primary/unknown/1?pipeline=resize:width%3D100", "", http.StatusNotFound},
+		{http.MethodPost, "/images/primary/unknown/1", `[{"op":"blur"}]`, http.StatusBadRequest},
+		{http.MethodPost, "/images/primary/unknown/1", `[{"op":"resize","width":100}]`, http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(unit.method, unit.path, strings.NewReader(unit.body)))
+		assert.Equal(t, unit.code, w.Code, unit.path+unit.body)
+	}
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
 	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
 )
 
 type infoType uint8
@@ -22,10 +24,21 @@ type infoUri struct {
 
 type infoQuery struct {
 	Lazy bool `form:"lazy"`
+	// Merge fills in missing movie fields from other providers, or
+	// merges the same actor of other providers into one record, with
+	// sources of fields attached.
+	Merge bool `form:"merge"`
+	// Lang translates movie titles and summaries, curated
+	// translations take precedence over machine translation.
+	Lang string `form:"lang"`
+	// Duration is the actual duration of the movie file in seconds,
+	// the runtime closest to it is preferred among providers.
+	Duration datatypes.Duration `form:"duration"`
 }
 
 func getInfo(app *engine.Engine, typ infoType) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
 		uri := &infoUri{}
 		if err := c.ShouldBindUri(uri); err != nil {
 			abortWithStatusMessage(c, http.StatusBadRequest, err)
@@ -45,9 +58,28 @@ func getInfo(app *engine.Engine, typ infoType) gin.HandlerFunc {
 		)
 		switch typ {
 		case actorInfoType:
-			info, err = app.GetActorInfoByProviderID(uri.Provider, uri.ID, query.Lazy)
+			if query.Merge {
+				info, err = app.GetMergedActorInfo(uri.Provider, uri.ID, query.Lazy)
+			} else {
+				info, err = app.GetActorInfoByProviderID(uri.Provider, uri.ID, query.Lazy)
+			}
 		case movieInfoType:
-			info, err = app.GetMovieInfoByProviderID(uri.Provider, uri.ID, query.Lazy)
+			var movie *model.MovieInfo
+			if query.Merge {
+				movie, err = app.GetMergedMovieInfo(uri.Provider, uri.ID, query.Lazy)
+			} else {
+				movie, err = app.GetMovieInfoByProviderID(uri.Provider, uri.ID, query.Lazy)
+			}
+			if err == nil && query.Duration > 0 {
+				app.SelectRuntime(movie, query.Duration.Std())
+			}
+			if err == nil && query.Lang != "" {
+				err = app.TranslateMovieInfo(movie, query.Lang)
+			}
+			if err == nil {
+				err = app.FillActorAges(movie)
+			}
+			info = movie
 		default:
 			panic("invalid info/metadata type")
 		}
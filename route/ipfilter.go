@@ -0,0 +1,36 @@
+package route
+
+import (
+	"net/http"
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+)
+
+// ipFilter rejects clients in the denylist, or not in the allowlist if
+// it's not empty. The denylist takes precedence over the allowlist.
+func ipFilter(allow, deny []netip.Prefix) gin.HandlerFunc {
+	contains := func(prefixes []netip.Prefix, addr netip.Addr) bool {
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(c *gin.Context) {
+		addr, err := netip.ParseAddr(c.ClientIP())
+		if err != nil {
+			abortWithError(c, errors.FromCode(http.StatusForbidden))
+			return
+		}
+		addr = addr.Unmap()
+		if contains(deny, addr) || (len(allow) > 0 && !contains(allow, addr)) {
+			abortWithError(c, errors.FromCode(http.StatusForbidden))
+			return
+		}
+		c.Next()
+	}
+}
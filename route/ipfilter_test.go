@@ -0,0 +1,45 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	_ = r.SetTrustedProxies(nil)
+	r.Use(ipFilter(
+		[]netip.Prefix{netip.MustParsePrefix("192.168.1.0/24"), netip.MustParsePrefix("::1/128")},
+		[]netip.Prefix{netip.MustParsePrefix("192.168.1.100/32")},
+	))
+	r.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	for _, unit := range []struct {
+		remoteAddr string
+		forwarded  string
+		code       int
+	}{
+		{"192.168.1.2:1234", "", http.StatusOK},
+		{"[::1]:1234", "", http.StatusOK},
+		{"[::ffff:192.168.1.2]:1234", "", http.StatusOK},
+		{"192.168.1.100:1234", "", http.StatusForbidden},
+		{"10.0.0.1:1234", "", http.StatusForbidden},
+		// forwarded IPs of untrusted proxies are ignored.
+		{"10.0.0.1:1234", "192.168.1.2", http.StatusForbidden},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+		req.RemoteAddr = unit.remoteAddr
+		if unit.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", unit.forwarded)
+		}
+		r.ServeHTTP(w, req)
+		assert.Equal(t, unit.code, w.Code, unit.remoteAddr)
+	}
+}
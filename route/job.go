@@ -0,0 +1,79 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// defaultJobLimit is the max number of jobs listed if not specified.
+const defaultJobLimit = 100
+
+type jobUri struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+type jobQuery struct {
+	Status model.JobStatus `form:"status"`
+	Limit  int             `form:"limit"`
+}
+
+type jobBody struct {
+	Type   string          `json:"type" binding:"required"`
+	Params json.RawMessage `json:"params"`
+}
+
+func getJobs(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &jobQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if query.Limit <= 0 {
+			query.Limit = defaultJobLimit
+		}
+		jobs, err := app.GetJobs(query.Status, query.Limit)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: jobs})
+	}
+}
+
+func getJob(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &jobUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		job, err := app.GetJob(uri.ID)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: job})
+	}
+}
+
+func postJob(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body := &jobBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		job, err := app.SubmitJob(body.Type, body.Params)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, &responseMessage{Data: job})
+	}
+}
@@ -0,0 +1,62 @@
+package route
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/logger"
+	"github.com/metatube-community/metatube-sdk-go/common/profile"
+)
+
+const requestIDHeader = logger.RequestIDHeader
+
+// requestID attaches a request ID to the request context, the ID
+// given by the client is reused if present.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" || len(id) > 64 {
+			id = logger.NewRequestID()
+		}
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), id))
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+// acceptLanguage passes the Accept-Language header of the client through
+// the request context to the providers, see profile.WithLanguage.
+func acceptLanguage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if language := c.GetHeader("Accept-Language"); language != "" && len(language) <= 256 {
+			c.Request = c.Request.WithContext(profile.WithLanguage(c.Request.Context(), language))
+		}
+		c.Next()
+	}
+}
+
+func requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		attrs := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		l := logger.FromContext(c.Request.Context(), nil)
+		if c.Writer.Status() >= 500 {
+			l.Error("request", attrs...)
+			return
+		}
+		l.Info("request", attrs...)
+	}
+}
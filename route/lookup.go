@@ -0,0 +1,64 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+type lookupQuery struct {
+	Q string `form:"q" binding:"required"`
+}
+
+// getLookup returns the best matched movie info of the keyword, with
+// the match type and confidence, for automated pipelines.
+func getLookup(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &lookupQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		info, err := app.WithContext(c.Request.Context()).LookupMovieInfo(query.Q)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: info})
+	}
+}
+
+type numberUri struct {
+	Number string `uri:"number" binding:"required"`
+}
+
+type numberQuery struct {
+	Lazy bool `form:"lazy"`
+}
+
+// getMovieByNumber returns the movie info of the exact number, without
+// knowing the provider-specific ID.
+func getMovieByNumber(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &numberUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &numberQuery{
+			Lazy: true, // enable lazy by default.
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		info, err := app.WithContext(c.Request.Context()).GetMovieInfoByNumber(uri.Number, query.Lazy)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: info})
+	}
+}
@@ -0,0 +1,35 @@
+package route
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/metatube-community/metatube-sdk-go/metrics"
+)
+
+func instrument() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// Use the route pattern instead of the raw path to
+		// keep label cardinality bounded.
+		path := c.FullPath()
+		if path == "" {
+			path = "unmatched"
+		}
+		metrics.HTTPRequests.
+			WithLabelValues(c.Request.Method, path, strconv.Itoa(c.Writer.Status())).
+			Inc()
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, path).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+func getMetrics() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
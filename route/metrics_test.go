@@ -0,0 +1,28 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/metrics"
+)
+
+func TestInstrumentRecoveredPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(instrument(), recovery())
+	r.GET("/panic", func(*gin.Context) { panic("boom") })
+
+	counter := metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/panic", "500")
+	before := testutil.ToFloat64(counter)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+}
@@ -0,0 +1,393 @@
+package route
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	V "github.com/metatube-community/metatube-sdk-go/internal/version"
+)
+
+// Paths of the OpenAPI document and its Swagger UI.
+const (
+	openAPIPath   = "/openapi.json"
+	swaggerUIPath = "/docs"
+)
+
+// apiDoc describes a route for the OpenAPI document, routes are keyed
+// by their method and path without the API version prefix.
+type apiDoc struct {
+	summary string
+	// query is the struct bound from the query, if any.
+	query any
+	// public routes don't require the bearer token.
+	public bool
+	// image routes respond with images, or other media, rather than
+	// JSON, in JPEG unless the media types are given.
+	image      bool
+	mediaTypes []string
+}
+
+// pipelineMediaTypes are output formats of image pipelines.
+var pipelineMediaTypes = []string{jpegImageMIMEType, "image/png"}
+
+var apiDocs = map[string]apiDoc{
+	"GET /":                           {summary: "Get server info", public: true},
+	"GET /metrics":                    {summary: "Get Prometheus metrics", public: true},
+	"GET /healthz":                    {summary: "Check server liveness", public: true},
+	"GET " + webUIPath + "/*filepath": {summary: "Get web UI", public: true},
+	"GET /readyz":                     {summary: "Check server readiness", public: true},
+	"GET /modules":                    {summary: "List build modules", public: true},
+	"GET /providers":                  {summary: "List providers", public: true},
+	"GET /translate":                  {summary: "Translate text", query: translateQuery{}, public: true},
+
+	"GET /images/primary/:provider/:id":   {summary: "Get primary image", query: imageQuery{}, public: true, image: true},
+	"GET /images/thumb/:provider/:id":     {summary: "Get thumb image", query: imageQuery{}, public: true, image: true},
+	"GET /images/backdrop/:provider/:id":  {summary: "Get backdrop image", query: imageQuery{}, public: true, image: true},
+	"POST /images/primary/:provider/:id":  {summary: "Process primary image with JSON pipeline", query: imageQuery{}, public: true, image: true, mediaTypes: pipelineMediaTypes},
+	"POST /images/thumb/:provider/:id":    {summary: "Process thumb image with JSON pipeline", query: imageQuery{}, public: true, image: true, mediaTypes: pipelineMediaTypes},
+	"POST /images/backdrop/:provider/:id": {summary: "Process backdrop image with JSON pipeline", query: imageQuery{}, public: true, image: true, mediaTypes: pipelineMediaTypes},
+	"GET /actors/:provider/:id/primary":   {summary: "Get actor image centered on face", query: actorFaceImageQuery{}, public: true, image: true},
+	"GET /movies/:provider/:id/trailer":   {summary: "Stream movie trailer", public: true, image: true, mediaTypes: []string{"video/mp4"}},
+	"GET /images/preview/:provider/:id":   {summary: "Get animated preview of trailer", query: previewQuery{}, image: true, mediaTypes: []string{"image/webp", "image/gif"}},
+
+	"GET /db/version":                              {summary: "Get database version"},
+	"GET /actors/:provider/:id":                    {summary: "Get actor info", query: infoQuery{}},
+	"GET /actors/search":                           {summary: "Search actors", query: searchQuery{}},
+	"GET /actors/birthdays":                        {summary: "List upcoming actor birthdays", query: birthdayQuery{}},
+	"GET /movies/:provider/:id":                    {summary: "Get movie info", query: infoQuery{}},
+	"GET /movies/:provider/:id/related":            {summary: "Get related movies", query: relatedQuery{}},
+	"GET /movies/search":                           {summary: "Search movies", query: searchQuery{}},
+	"GET /movies/lookup":                           {summary: "Look up movie by file name", query: lookupQuery{}},
+	"GET /movies/number/:number":                   {summary: "Get movie by number", query: numberQuery{}},
+	"GET /calendar":                                {summary: "Get release calendar", query: calendarQuery{}},
+	"GET /follows":                                 {summary: "List follows"},
+	"POST /follows":                                {summary: "Follow actor or series"},
+	"DELETE /follows/:id":                          {summary: "Unfollow"},
+	"GET /follows/releases":                        {summary: "List unseen releases of follows"},
+	"POST /follows/releases/seen":                  {summary: "Mark releases of follows seen"},
+	"GET /collections":                             {summary: "List collection items", query: collectionQuery{}},
+	"POST /collections":                            {summary: "Add movie or actor to collection"},
+	"DELETE /collections/:id":                      {summary: "Remove item from collection"},
+	"GET /states":                                  {summary: "List owned and watched states of numbers", query: stateQuery{}},
+	"POST /states":                                 {summary: "Mark numbers owned or watched"},
+	"GET /reviews/:provider/:id":                   {summary: "Get movie reviews", query: reviewQuery{}},
+	"GET /subtitles/search":                        {summary: "Search subtitles", query: subtitleSearchQuery{}},
+	"GET /subtitles/:provider/:id":                 {summary: "Get subtitles of movie"},
+	"GET /admin/cache/stats":                       {summary: "Get cache stats"},
+	"DELETE /admin/cache/actors/:provider/:id":     {summary: "Delete cached actor info"},
+	"DELETE /admin/cache/movies/:provider/:id":     {summary: "Delete cached movie info"},
+	"POST /admin/refresh/actors/:provider/:id":     {summary: "Refresh actor info"},
+	"POST /admin/refresh/movies/:provider/:id":     {summary: "Refresh movie info"},
+	"GET /admin/overrides/movies/:provider/:id":    {summary: "Get movie override"},
+	"PATCH /admin/overrides/movies/:provider/:id":  {summary: "Update movie override"},
+	"DELETE /admin/overrides/movies/:provider/:id": {summary: "Delete movie override"},
+	"POST /admin/db/vacuum":                        {summary: "Vacuum database"},
+	"GET /admin/providers/health":                  {summary: "Check health of providers"},
+	"GET /admin/providers/fields":                  {summary: "Get empty rates of fields extracted by providers", query: fieldStatsQuery{}},
+	"GET /admin/debug/scrape":                      {summary: "Trace the extraction of a fresh scrape", query: debugScrapeQuery{}},
+	"POST /admin/debug/parse":                      {summary: "Dry-run a provider parser against HTML or a URL of its site"},
+	"GET /admin/keys":                              {summary: "Get usage of API keys"},
+	"GET /admin/translations/export":               {summary: "Export translation memory", query: translationMemoryQuery{}},
+	"POST /admin/translations/import":              {summary: "Import translation memory", query: translationMemoryQuery{}},
+	"GET /admin/jobs":                              {summary: "List jobs", query: jobQuery{}},
+	"POST /admin/jobs":                             {summary: "Submit job"},
+	"GET /admin/jobs/:id":                          {summary: "Get job"},
+	"GET /admin/cookies/:provider":                 {summary: "Get provider cookies"},
+	"PUT /admin/cookies/:provider":                 {summary: "Set provider cookies"},
+	"POST /admin/reload":                           {summary: "Reload config"},
+	"POST /admin/library/filmography":              {summary: "Report missing movies of an actor in the library"},
+	"POST /admin/library/organize":                 {summary: "Organize library files"},
+
+	"GET /emby/images/primary/:provider/:id":  {summary: "Get primary image", query: imageQuery{}, public: true, image: true},
+	"GET /emby/images/thumb/:provider/:id":    {summary: "Get thumb image", query: imageQuery{}, public: true, image: true},
+	"GET /emby/images/backdrop/:provider/:id": {summary: "Get backdrop image", query: imageQuery{}, public: true, image: true},
+	"GET /emby/actors/:provider/:id":          {summary: "Get actor info in Jellyfin/Emby format"},
+	"GET /emby/actors/search":                 {summary: "Search actors in Jellyfin/Emby format", query: embySearchQuery{}},
+	"GET /emby/movies/:provider/:id":          {summary: "Get movie info in Jellyfin/Emby format"},
+	"GET /emby/movies/search":                 {summary: "Search movies in Jellyfin/Emby format", query: embySearchQuery{}},
+
+	"GET /plex/library/metadata/matches":    {summary: "Match metadata for Plex agents"},
+	"POST /plex/library/metadata/matches":   {summary: "Match metadata for Plex agents"},
+	"GET /plex/library/metadata/:ratingKey": {summary: "Get metadata for Plex agents"},
+
+	"GET /graphql":  {summary: "Query stash-box compatible GraphQL"},
+	"POST /graphql": {summary: "Query stash-box compatible GraphQL"},
+
+	"GET " + openAPIPath:   {summary: "Get OpenAPI document", public: true},
+	"GET " + swaggerUIPath: {summary: "Get Swagger UI", public: true},
+}
+
+// getOpenAPI serves the OpenAPI 3 document generated from the routes
+// of r, it's generated on the first request, after all routes are set.
+func getOpenAPI(r *gin.Engine) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  gin.H
+	)
+	return func(c *gin.Context) {
+		once.Do(func() { doc = openAPIDocument(r.Routes()) })
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>MetaTube API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "` + openAPIPath + `", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+// getSwaggerUI serves the Swagger UI of the OpenAPI document.
+func getSwaggerUI() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+	}
+}
+
+func openAPIDocument(routes gin.RoutesInfo) gin.H {
+	paths := make(map[string]gin.H)
+	for _, route := range routes {
+		if route.Method == http.MethodHead || route.Method == http.MethodOptions {
+			continue
+		}
+		path, params := openAPIPathParams(route.Path)
+		if paths[path] == nil {
+			paths[path] = gin.H{}
+		}
+		paths[path][strings.ToLower(route.Method)] = openAPIOperation(route, params)
+	}
+	return gin.H{
+		"openapi": "3.0.3",
+		"info": gin.H{
+			"title":   "MetaTube API",
+			"version": V.Version,
+		},
+		"paths": paths,
+		"components": gin.H{
+			"securitySchemes": gin.H{
+				"bearerAuth": gin.H{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": gin.H{
+				"Error": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"code":    gin.H{"type": "integer"},
+						"message": gin.H{"type": "string"},
+					},
+				},
+				"Page": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"page":  gin.H{"type": "integer"},
+						"limit": gin.H{"type": "integer"},
+						"total": gin.H{"type": "integer"},
+					},
+				},
+				"Response": gin.H{
+					"type": "object",
+					"properties": gin.H{
+						"data":  gin.H{},
+						"page":  gin.H{"$ref": "#/components/schemas/Page"},
+						"error": gin.H{"$ref": "#/components/schemas/Error"},
+					},
+				},
+				"EnvelopeV2": gin.H{
+					"type":     "object",
+					"required": []string{"api_version"},
+					"properties": gin.H{
+						"api_version": gin.H{"type": "string"},
+						"data":        gin.H{},
+						"error":       gin.H{"$ref": "#/components/schemas/Error"},
+						"meta": gin.H{
+							"type": "object",
+							"properties": gin.H{
+								"page": gin.H{"$ref": "#/components/schemas/Page"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// openAPIPathParams converts the gin path to the OpenAPI one, and
+// returns the names of its parameters.
+func openAPIPathParams(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// apiDocKey returns the apiDocs key of the route, the API version
+// prefix is trimmed, e.g. GET /v1/movies/search is GET /movies/search.
+func apiDocKey(method, path string) (key, version string) {
+	for _, v := range []string{apiVersion1, apiVersion2} {
+		prefix := "/v" + v
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			path, version = strings.TrimPrefix(path, prefix), v
+			break
+		}
+	}
+	if path == "" {
+		path = "/"
+	}
+	return method + " " + path, version
+}
+
+func openAPIOperation(route gin.RouteInfo, params []string) gin.H {
+	key, version := apiDocKey(route.Method, route.Path)
+	doc := apiDocs[key]
+
+	var parameters []gin.H
+	for _, name := range params {
+		parameters = append(parameters, gin.H{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   gin.H{"type": "string"},
+		})
+	}
+	if doc.query != nil {
+		parameters = append(parameters, openAPIQueryParams(reflect.TypeOf(doc.query))...)
+	}
+	// fields selection applies to the private API routes.
+	if version != "" && !doc.public && openAPITag(key) != "admin" && route.Method == http.MethodGet {
+		parameters = append(parameters, gin.H{
+			"name":        "fields",
+			"in":          "query",
+			"description": "Comma-separated fields of the data to return",
+			"schema":      gin.H{"type": "string"},
+		})
+	}
+
+	content := gin.H{"application/json": gin.H{"schema": gin.H{"$ref": "#/components/schemas/Response"}}}
+	switch {
+	case doc.image:
+		mediaTypes := doc.mediaTypes
+		if len(mediaTypes) == 0 {
+			mediaTypes = []string{jpegImageMIMEType}
+		}
+		content = gin.H{}
+		for _, typ := range mediaTypes {
+			content[typ] = gin.H{"schema": gin.H{"type": "string", "format": "binary"}}
+		}
+	case version == apiVersion2:
+		content = gin.H{"application/json": gin.H{"schema": gin.H{"$ref": "#/components/schemas/EnvelopeV2"}}}
+	}
+
+	op := gin.H{
+		"operationId": openAPIOperationID(route.Method, route.Path),
+		"responses": gin.H{
+			"200": gin.H{"description": http.StatusText(http.StatusOK), "content": content},
+		},
+	}
+	if doc.summary != "" {
+		op["summary"] = doc.summary
+	}
+	if tag := openAPITag(key); tag != "" {
+		op["tags"] = []string{tag}
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+	if !doc.public {
+		op["security"] = []gin.H{{"bearerAuth": []string{}}}
+	}
+	return op
+}
+
+// openAPIOperationID returns a unique ID of the route, e.g.
+// getV1MoviesProviderId for GET /v1/movies/:provider/:id.
+func openAPIOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, word := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == ':' || r == '*' || r == '_' || r == '-' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// openAPITag returns the first path segment of the key, e.g. movies.
+func openAPITag(key string) string {
+	_, path, _ := strings.Cut(key, " ")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if segments[0] == "admin" && len(segments) > 1 {
+		return "admin"
+	}
+	return segments[0]
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// openAPIQueryParams returns the query parameters of the fields with
+// form tags of the struct, including embedded ones.
+func openAPIQueryParams(typ reflect.Type) []gin.H {
+	var parameters []gin.H
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			parameters = append(parameters, openAPIQueryParams(field.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		param := gin.H{
+			"name":   name,
+			"in":     "query",
+			"schema": openAPISchema(field.Type),
+		}
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			param["required"] = true
+		}
+		parameters = append(parameters, param)
+	}
+	sort.SliceStable(parameters, func(i, j int) bool {
+		return parameters[i]["name"].(string) < parameters[j]["name"].(string)
+	})
+	return parameters
+}
+
+func openAPISchema(typ reflect.Type) gin.H {
+	if typ == timeType {
+		return gin.H{"type": "string", "format": "date-time"}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return gin.H{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return gin.H{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return gin.H{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return gin.H{"type": "array", "items": openAPISchema(typ.Elem())}
+	case reflect.Pointer:
+		return openAPISchema(typ.Elem())
+	default:
+		return gin.H{"type": "string"}
+	}
+}
@@ -0,0 +1,58 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+func TestOpenAPI(t *testing.T) {
+	r := New(engine.Default(), nil, WithStashBox(), WithWebUI(), WithSubtitleSources(), WithReload(func() error { return nil }))
+
+	// all routes must be documented.
+	for _, route := range r.Routes() {
+		key, _ := apiDocKey(route.Method, route.Path)
+		_, ok := apiDocs[key]
+		assert.True(t, ok, "undocumented route: %s", key)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string           `json:"operationId"`
+			Parameters  []map[string]any `json:"parameters"`
+			Security    []map[string]any `json:"security"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	op, ok := doc.Paths["/v1/movies/{provider}/{id}"]["get"]
+	require.True(t, ok)
+	assert.Equal(t, "getV1MoviesProviderId", op.OperationID)
+	assert.NotEmpty(t, op.Security)
+	var names []string
+	for _, param := range op.Parameters {
+		names = append(names, param["name"].(string))
+	}
+	assert.Equal(t, []string{"provider", "id", "duration", "lang", "lazy", "merge", "fields"}, names)
+
+	_, ok = doc.Paths["/v2/movies/search"]["get"]
+	assert.True(t, ok)
+	assert.Empty(t, doc.Paths["/v1/translate"]["get"].Security)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, swaggerUIPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), openAPIPath)
+}
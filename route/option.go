@@ -0,0 +1,98 @@
+package route
+
+import (
+	"net/netip"
+
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/subtitle"
+)
+
+// Option configures optional route features.
+type Option func(*options)
+
+type options struct {
+	enableStashBox  bool
+	enableWebUI     bool
+	subtitleSources []string
+	reload          func() error
+	adminValidator  auth.Validator
+	corsOrigins     []string
+	ipAllowlist     []netip.Prefix
+	ipDenylist      []netip.Prefix
+	trustedProxies  []netip.Prefix
+	libraryRoots    []string
+}
+
+// WithStashBox enables the stash-box compatible GraphQL endpoint.
+func WithStashBox() Option {
+	return func(o *options) {
+		o.enableStashBox = true
+	}
+}
+
+// WithWebUI enables the embedded web UI to search and inspect metadata.
+func WithWebUI() Option {
+	return func(o *options) {
+		o.enableWebUI = true
+	}
+}
+
+// WithSubtitleSources enables the subtitle endpoints with the given
+// sources, all registered sources are used if names are empty.
+func WithSubtitleSources(names ...string) Option {
+	return func(o *options) {
+		if len(names) == 0 {
+			names = subtitle.Sources()
+		}
+		o.subtitleSources = names
+	}
+}
+
+// WithReload enables the admin endpoint to reload the config
+// at runtime with the given function.
+func WithReload(reload func() error) Option {
+	return func(o *options) {
+		o.reload = reload
+	}
+}
+
+// WithAdminValidator protects the admin endpoints with the given
+// validator, which are denied without one.
+func WithAdminValidator(v auth.Validator) Option {
+	return func(o *options) {
+		o.adminValidator = v
+	}
+}
+
+// WithCORS allows browser-based front-ends of the origins to access
+// the server, * allows any origins.
+func WithCORS(origins ...string) Option {
+	return func(o *options) {
+		o.corsOrigins = origins
+	}
+}
+
+// WithIPFilter only allows clients in the allowlist, if not empty, and
+// rejects clients in the denylist.
+func WithIPFilter(allow, deny []netip.Prefix) Option {
+	return func(o *options) {
+		o.ipAllowlist, o.ipDenylist = allow, deny
+	}
+}
+
+// WithTrustedProxies trusts the forwarded client IPs and hosts of the
+// proxies of the addresses or CIDRs, no proxies are trusted without them.
+// Proxies of multiple options are all trusted.
+func WithTrustedProxies(proxies ...netip.Prefix) Option {
+	return func(o *options) {
+		o.trustedProxies = append(o.trustedProxies, proxies...)
+	}
+}
+
+// WithLibraryRoots enables the library admin endpoints to access files
+// within the directories.
+func WithLibraryRoots(roots ...string) Option {
+	return func(o *options) {
+		o.libraryRoots = roots
+	}
+}
@@ -0,0 +1,93 @@
+package route
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/library"
+)
+
+type organizeRequest struct {
+	Path          string `json:"path" binding:"required"`
+	Template      string `json:"template" binding:"required"`
+	Root          string `json:"root"`
+	DryRun        bool   `json:"dry_run"`
+	Collision     string `json:"collision"`
+	MaxNameLength int    `json:"max_name_length"`
+	// Number overrides the number parsed from the filename.
+	Number string `json:"number"`
+}
+
+type organizeResult struct {
+	Path  string          `json:"path"`
+	Part  int             `json:"part,omitempty"`
+	Moves []*library.Move `json:"moves,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// postOrganize organizes the files of the request, paths are confined
+// to the library roots.
+func postOrganize(app *engine.Engine, roots []string) gin.HandlerFunc {
+	scanner := library.NewScanner(app)
+	return func(c *gin.Context) {
+		req := &organizeRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		tmpl, err := library.ParseNameTemplate(req.Template)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		path, err := library.ResolveInRoots(req.Path, roots)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusForbidden, err)
+			return
+		}
+		organizer := library.NewOrganizer(tmpl)
+		if req.Root != "" {
+			if organizer.Root, err = library.ResolveInRoots(req.Root, roots); err != nil {
+				abortWithStatusMessage(c, http.StatusForbidden, err)
+				return
+			}
+		}
+		organizer.DryRun = req.DryRun
+		if req.MaxNameLength > 0 {
+			organizer.MaxNameLength = req.MaxNameLength
+		}
+		if organizer.Collision, err = library.ParseCollisionPolicy(req.Collision); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		videos, err := library.FindVideos(path)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		results := make([]*organizeResult, 0, len(videos))
+		for _, video := range videos {
+			result := &organizeResult{Path: video}
+			num, part := number.ParsePart(filepath.Base(video))
+			if req.Number != "" {
+				num = req.Number
+			}
+			result.Part = part
+			if info, err := scanner.Lookup(num); err != nil {
+				result.Error = err.Error()
+			} else if result.Moves, err = organizer.Organize(video, info); err != nil {
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+		}
+
+		c.JSON(http.StatusOK, &responseMessage{Data: results})
+	}
+}
@@ -0,0 +1,68 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+func TestPostOrganize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	root, other := t.TempDir(), t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "videos"), 0o755))
+
+	r := gin.New()
+	r.POST("/organize", postOrganize(engine.Default(), []string{root}))
+
+	for _, unit := range []struct {
+		path, root string
+		code       int
+	}{
+		{filepath.Join(root, "videos"), "", http.StatusOK},
+		{filepath.Join(root, "videos"), filepath.Join(root, "out"), http.StatusOK},
+		{filepath.Join(root, "videos", "..", ".."), "", http.StatusForbidden},
+		{other, "", http.StatusForbidden},
+		{"/", "", http.StatusForbidden},
+		{filepath.Join(root, "videos"), other, http.StatusForbidden},
+	} {
+		w := httptest.NewRecorder()
+		body := `{"path": "` + unit.path + `", "root": "` + unit.root + `", "template": "{{.Number}}", "dry_run": true}`
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/organize", strings.NewReader(body)))
+		assert.Equal(t, unit.code, w.Code, body)
+	}
+}
+
+func TestOrganizeRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	root := t.TempDir()
+	body := `{"path": "` + root + `", "template": "{{.Number}}", "dry_run": true}`
+
+	for _, unit := range []struct {
+		opts  []Option
+		token string
+		code  int
+	}{
+		// disabled without library roots.
+		{nil, "admin", http.StatusNotFound},
+		{[]Option{WithLibraryRoots(root)}, "user", http.StatusUnauthorized},
+		{[]Option{WithLibraryRoots(root)}, "admin", http.StatusOK},
+	} {
+		opts := append(unit.opts, WithAdminValidator(auth.Token("admin")))
+		r := New(engine.Default(), auth.Token("user"), opts...)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/library/organize", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+unit.token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, unit.code, w.Code, unit.token)
+	}
+}
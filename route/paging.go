@@ -0,0 +1,182 @@
+package route
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// maxPageLimit is the maximum number of items per page.
+const maxPageLimit = 100
+
+// Sort keys of search results.
+const (
+	sortByRelevance   = "relevance"
+	sortByScore       = "score"
+	sortByReleaseDate = "release_date"
+	sortByName        = "name"
+
+	// Streaming or physical release dates, release date if unknown.
+	sortByStreamingDate = "streaming_date"
+	sortByPhysicalDate  = "physical_date"
+
+	// Popularity, sales rank sorts best first by default.
+	sortByReviewCount   = "review_count"
+	sortByWishlistCount = "wishlist_count"
+	sortBySalesRank     = "sales_rank"
+)
+
+type pageQuery struct {
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	Sort  string `form:"sort"`
+	Order string `form:"order"`
+}
+
+type pageInfo struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+// validate checks the query and fills in defaults.
+func (q *pageQuery) validate(typ searchType) error {
+	if q.Page < 0 || q.Limit < 0 {
+		return fmt.Errorf("invalid page or limit")
+	}
+	if q.Limit > maxPageLimit {
+		q.Limit = maxPageLimit
+	}
+	if q.Page > 0 && q.Limit == 0 {
+		q.Limit = maxPageLimit
+	}
+	if q.Limit > 0 && q.Page == 0 {
+		q.Page = 1
+	}
+
+	q.Sort = strings.ToLower(q.Sort)
+	switch q.Sort {
+	case "", sortByRelevance:
+	case sortByScore, sortByReleaseDate, sortByStreamingDate, sortByPhysicalDate,
+		sortByReviewCount, sortByWishlistCount, sortBySalesRank:
+		if typ != movieSearchType {
+			return fmt.Errorf("unsupported sort for actors: %s", q.Sort)
+		}
+	case sortByName:
+		if typ != actorSearchType {
+			return fmt.Errorf("unsupported sort for movies: %s", q.Sort)
+		}
+	default:
+		return fmt.Errorf("invalid sort: %s", q.Sort)
+	}
+
+	q.Order = strings.ToLower(q.Order)
+	switch q.Order {
+	case "":
+		// name and rank sort ascending by default, others descending.
+		if q.Sort == sortByName || q.Sort == sortBySalesRank {
+			q.Order = "asc"
+		} else {
+			q.Order = "desc"
+		}
+	case "asc", "desc":
+	default:
+		return fmt.Errorf("invalid order: %s", q.Order)
+	}
+	return nil
+}
+
+// enabled reports whether pagination is requested.
+func (q *pageQuery) enabled() bool { return q.Limit > 0 }
+
+// apply sorts and slices the search results, the relevance order
+// given by the engine is kept for equal items.
+func (q *pageQuery) apply(results any) (any, *pageInfo) {
+	switch v := results.(type) {
+	case []*model.MovieSearchResult:
+		q.sortMovies(v)
+		return paginate(q, v)
+	case []*model.ActorSearchResult:
+		q.sortActors(v)
+		return paginate(q, v)
+	default:
+		return results, nil
+	}
+}
+
+func (q *pageQuery) sortMovies(results []*model.MovieSearchResult) {
+	var less func(a, b *model.MovieSearchResult) bool
+	switch q.Sort {
+	case sortByScore:
+		less = func(a, b *model.MovieSearchResult) bool { return a.Score < b.Score }
+	case sortByReleaseDate, sortByStreamingDate, sortByPhysicalDate:
+		less = func(a, b *model.MovieSearchResult) bool {
+			return q.sortDate(a).Before(q.sortDate(b))
+		}
+	case sortByReviewCount:
+		less = func(a, b *model.MovieSearchResult) bool { return a.ReviewCount < b.ReviewCount }
+	case sortByWishlistCount:
+		less = func(a, b *model.MovieSearchResult) bool { return a.WishlistCount < b.WishlistCount }
+	case sortBySalesRank:
+		// unranked movies come after ranked ones.
+		less = func(a, b *model.MovieSearchResult) bool {
+			return a.SalesRank != 0 && (b.SalesRank == 0 || a.SalesRank < b.SalesRank)
+		}
+	default:
+		return // keep relevance order.
+	}
+	sortStable(results, less, q.Order == "desc")
+}
+
+// sortDate returns the date to sort the movie by, and falls back
+// to the release date if the chosen date is unknown.
+func (q *pageQuery) sortDate(result *model.MovieSearchResult) time.Time {
+	var date time.Time
+	switch q.Sort {
+	case sortByStreamingDate:
+		date = time.Time(result.StreamingDate)
+	case sortByPhysicalDate:
+		date = time.Time(result.PhysicalDate)
+	}
+	if date.IsZero() {
+		date = time.Time(result.ReleaseDate)
+	}
+	return date
+}
+
+func (q *pageQuery) sortActors(results []*model.ActorSearchResult) {
+	if q.Sort != sortByName {
+		return // keep relevance order.
+	}
+	sortStable(results, func(a, b *model.ActorSearchResult) bool {
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}, q.Order == "desc")
+}
+
+func sortStable[T any](s []T, less func(a, b T) bool, desc bool) {
+	sort.SliceStable(s, func(i, j int) bool {
+		if desc {
+			return less(s[j], s[i])
+		}
+		return less(s[i], s[j])
+	})
+}
+
+func paginate[T any](q *pageQuery, s []T) ([]T, *pageInfo) {
+	if !q.enabled() {
+		return s, nil
+	}
+	info := &pageInfo{Page: q.Page, Limit: q.Limit, Total: len(s)}
+	start := (q.Page - 1) * q.Limit
+	if start >= len(s) {
+		return []T{}, info
+	}
+	end := start + q.Limit
+	if end > len(s) {
+		end = len(s)
+	}
+	return s[start:end], info
+}
@@ -0,0 +1,78 @@
+package route
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestPageQuery(t *testing.T) {
+	date := func(s string) datatypes.Date {
+		d, _ := time.Parse(time.DateOnly, s)
+		return datatypes.Date(d)
+	}
+	movies := func() []*model.MovieSearchResult {
+		return []*model.MovieSearchResult{
+			{ID: "a", Score: 3.5, ReleaseDate: date("2020-01-01"), PhysicalDate: date("2023-01-01"), ReviewCount: 5},
+			{ID: "b", Score: 4.5, ReleaseDate: date("2022-01-01"), StreamingDate: date("2019-01-01"), SalesRank: 3},
+			{ID: "c", Score: 1.0, ReleaseDate: date("2021-01-01"), ReviewCount: 9, SalesRank: 1},
+		}
+	}
+	ids := func(v any) (ids []string) {
+		for _, m := range v.([]*model.MovieSearchResult) {
+			ids = append(ids, m.ID)
+		}
+		return
+	}
+
+	for _, unit := range []struct {
+		query *pageQuery
+		ids   []string
+		info  *pageInfo
+	}{
+		{&pageQuery{}, []string{"a", "b", "c"}, nil},
+		{&pageQuery{Sort: "score"}, []string{"b", "a", "c"}, nil},
+		{&pageQuery{Sort: "release_date", Order: "asc"}, []string{"a", "c", "b"}, nil},
+		{&pageQuery{Sort: "streaming_date", Order: "asc"}, []string{"b", "a", "c"}, nil},
+		{&pageQuery{Sort: "physical_date", Order: "asc"}, []string{"c", "b", "a"}, nil},
+		{&pageQuery{Sort: "review_count"}, []string{"c", "a", "b"}, nil},
+		{&pageQuery{Sort: "sales_rank"}, []string{"c", "b", "a"}, nil},
+		{&pageQuery{Limit: 2}, []string{"a", "b"}, &pageInfo{Page: 1, Limit: 2, Total: 3}},
+		{&pageQuery{Page: 2, Limit: 2, Sort: "score"}, []string{"c"}, &pageInfo{Page: 2, Limit: 2, Total: 3}},
+		{&pageQuery{Page: 3, Limit: 2}, nil, &pageInfo{Page: 3, Limit: 2, Total: 3}},
+	} {
+		require.NoError(t, unit.query.validate(movieSearchType))
+		results, info := unit.query.apply(movies())
+		assert.Equal(t, unit.ids, ids(results), unit.query)
+		assert.Equal(t, unit.info, info, unit.query)
+	}
+
+	for _, query := range []*pageQuery{
+		{Page: -1},
+		{Sort: "name"},
+		{Sort: "unknown"},
+		{Order: "up"},
+	} {
+		assert.Error(t, query.validate(movieSearchType), query)
+	}
+	assert.Error(t, (&pageQuery{Sort: "score"}).validate(actorSearchType))
+	assert.NoError(t, (&pageQuery{Sort: "name"}).validate(actorSearchType))
+}
+
+func TestSearchQuery_Validate(t *testing.T) {
+	query := &searchQuery{By: "Genre", pageQuery: pageQuery{Page: 2}}
+	require.NoError(t, query.validate(movieSearchType))
+	assert.Equal(t, searchByGenre, query.By)
+	assert.Equal(t, 2, query.genrePage)
+	assert.False(t, query.enabled())
+
+	assert.NoError(t, (&searchQuery{By: "actor"}).validate(movieSearchType))
+	assert.Error(t, (&searchQuery{By: "actor"}).validate(actorSearchType))
+	assert.Error(t, (&searchQuery{By: "genre", pageQuery: pageQuery{Limit: 10}}).validate(movieSearchType))
+	assert.Error(t, (&searchQuery{By: "unknown"}).validate(movieSearchType))
+}
@@ -0,0 +1,217 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/comparer"
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// plexGUIDScheme is the scheme used to build Plex metadata GUIDs.
+const plexGUIDScheme = "metatube"
+
+type plexTag struct {
+	Tag   string `json:"tag"`
+	Thumb string `json:"thumb,omitempty"`
+}
+
+type plexMetadata struct {
+	GUID                  string    `json:"guid"`
+	RatingKey             string    `json:"ratingKey"`
+	Key                   string    `json:"key"`
+	Type                  string    `json:"type"`
+	Title                 string    `json:"title"`
+	OriginalTitle         string    `json:"originalTitle,omitempty"`
+	Summary               string    `json:"summary,omitempty"`
+	Studio                string    `json:"studio,omitempty"`
+	Year                  int       `json:"year,omitempty"`
+	OriginallyAvailableAt string    `json:"originallyAvailableAt,omitempty"`
+	Duration              int64     `json:"duration,omitempty"`
+	Rating                float64   `json:"rating,omitempty"`
+	Score                 int       `json:"score,omitempty"`
+	Thumb                 string    `json:"thumb,omitempty"`
+	Art                   string    `json:"art,omitempty"`
+	Genre                 []plexTag `json:"Genre,omitempty"`
+	Director              []plexTag `json:"Director,omitempty"`
+	Role                  []plexTag `json:"Role,omitempty"`
+	Collection            []plexTag `json:"Collection,omitempty"`
+}
+
+type plexMediaContainer struct {
+	Offset    int             `json:"offset"`
+	TotalSize int             `json:"totalSize"`
+	Size      int             `json:"size"`
+	Metadata  []*plexMetadata `json:"Metadata"`
+}
+
+type plexMatchQuery struct {
+	Title    string `form:"title" json:"title"`
+	Filename string `form:"filename" json:"filename"`
+	Year     int    `form:"year" json:"year"`
+	Manual   bool   `form:"manual" json:"manual"`
+}
+
+func postPlexMatch(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		query := &plexMatchQuery{}
+		if err := c.ShouldBind(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		keyword := query.Title
+		if query.Filename != "" /* filename is more accurate */ {
+			keyword = number.Trim(query.Filename)
+		}
+		if keyword == "" {
+			abortWithStatusMessage(c, http.StatusBadRequest, "title or filename is required")
+			return
+		}
+
+		results, err := app.SearchMovieAll(keyword, true)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		results = filterPlexMatches(results, query.Year, query.Manual)
+
+		base := plexBaseURL(c)
+		container := &plexMediaContainer{Metadata: make([]*plexMetadata, 0, len(results))}
+		for _, result := range results {
+			m := newPlexMetadata(base, result.Provider, result.ID)
+			m.Title = result.Number + " " + result.Title
+			m.OriginalTitle = result.Title
+			m.Score = int(comparer.Compare(keyword, result.Number) * 100)
+			if t := time.Time(result.ReleaseDate); !t.IsZero() {
+				m.Year = t.Year()
+				m.OriginallyAvailableAt = t.Format(time.DateOnly)
+			}
+			container.Metadata = append(container.Metadata, m)
+		}
+		if len(container.Metadata) == 0 {
+			abortWithError(c, errors.FromCode(http.StatusNotFound))
+			return
+		}
+		container.Size = len(container.Metadata)
+		container.TotalSize = len(container.Metadata)
+
+		c.JSON(http.StatusOK, gin.H{"MediaContainer": container})
+	}
+}
+
+// filterPlexMatches drops the results of other years than the year,
+// if any, and keeps only the best one of the rest unless manual.
+func filterPlexMatches(results []*model.MovieSearchResult, year int, manual bool) []*model.MovieSearchResult {
+	filtered := make([]*model.MovieSearchResult, 0, len(results))
+	for _, result := range results {
+		if year > 0 &&
+			!time.Time(result.ReleaseDate).IsZero() &&
+			time.Time(result.ReleaseDate).Year() != year {
+			continue // year mismatched.
+		}
+		filtered = append(filtered, result)
+	}
+	if !manual && len(filtered) > 1 /* auto match */ {
+		filtered = filtered[:1]
+	}
+	return filtered
+}
+
+func getPlexMetadata(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		provider, id, found := strings.Cut(c.Param("ratingKey"), ":")
+		if !found || provider == "" || id == "" {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid rating key")
+			return
+		}
+
+		info, err := app.GetMovieInfoByProviderID(provider, id, true)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"MediaContainer": &plexMediaContainer{
+			TotalSize: 1,
+			Size:      1,
+			Metadata:  []*plexMetadata{newPlexMovieMetadata(plexBaseURL(c), info)},
+		}})
+	}
+}
+
+func newPlexMetadata(base, provider, id string) *plexMetadata {
+	ratingKey := provider + ":" + id
+	return &plexMetadata{
+		GUID:      fmt.Sprintf("%s://movie/%s", plexGUIDScheme, ratingKey),
+		RatingKey: ratingKey,
+		Key:       "/plex/library/metadata/" + url.PathEscape(ratingKey),
+		Type:      "movie",
+		Thumb:     fmt.Sprintf("%s/v1/images/primary/%s/%s", base, provider, url.PathEscape(id)),
+		Art:       fmt.Sprintf("%s/v1/images/backdrop/%s/%s", base, provider, url.PathEscape(id)),
+	}
+}
+
+func newPlexMovieMetadata(base string, info *model.MovieInfo) *plexMetadata {
+	m := newPlexMetadata(base, info.Provider, info.ID)
+	m.Title = info.Number + " " + info.Title
+	m.OriginalTitle = info.Title
+	m.Summary = info.Summary
+	m.Studio = info.Maker
+	m.Duration = (time.Duration(info.Runtime) * time.Minute).Milliseconds()
+	m.Rating = info.Score * 2 /* 5-star to 10-point scale */
+	if t := time.Time(info.ReleaseDate); !t.IsZero() {
+		m.Year = t.Year()
+		m.OriginallyAvailableAt = t.Format(time.DateOnly)
+	}
+	for _, genre := range info.Genres {
+		m.Genre = append(m.Genre, plexTag{Tag: genre})
+	}
+	if info.Director != "" {
+		m.Director = append(m.Director, plexTag{Tag: info.Director})
+	}
+	for _, actor := range info.Actors {
+		m.Role = append(m.Role, plexTag{Tag: actor})
+	}
+	if info.Series != "" {
+		m.Collection = append(m.Collection, plexTag{Tag: info.Series})
+	}
+	return m
+}
+
+// plexBaseURL returns the external base URL of the server, Plex
+// requires absolute URLs for artworks. Forwarded headers are honored
+// only of trusted proxies, the same as the client IPs of gin.
+func plexBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	host := c.Request.Host
+	if forwardedByTrustedProxy(c) {
+		if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if fwd := c.GetHeader("X-Forwarded-Host"); fwd != "" {
+			host = fwd
+		}
+	}
+	return scheme + "://" + host
+}
+
+// forwardedByTrustedProxy reports whether the request is forwarded by
+// a proxy trusted by gin, whose client IP then differs from the peer.
+func forwardedByTrustedProxy(c *gin.Context) bool {
+	return c.ClientIP() != c.RemoteIP()
+}
@@ -0,0 +1,104 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestFilterPlexMatches(t *testing.T) {
+	date := func(year int) datatypes.Date {
+		return datatypes.Date(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	results := []*model.MovieSearchResult{
+		{ID: "1", ReleaseDate: date(2020)},
+		{ID: "2", ReleaseDate: date(2021)},
+		{ID: "3"},
+		{ID: "4", ReleaseDate: date(2021)},
+	}
+	ids := func(results []*model.MovieSearchResult) (ids []string) {
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		return
+	}
+
+	for _, unit := range []struct {
+		year   int
+		manual bool
+		want   []string
+	}{
+		{0, false, []string{"1"}},
+		{0, true, []string{"1", "2", "3", "4"}},
+		// the best match of the year, not the best match dropped.
+		{2021, false, []string{"2"}},
+		{2021, true, []string{"2", "3", "4"}},
+		{2019, false, []string{"3"}},
+	} {
+		assert.Equal(t, unit.want, ids(filterPlexMatches(results, unit.year, unit.manual)), unit)
+	}
+	assert.Empty(t, filterPlexMatches(nil, 2021, false))
+}
+
+func TestPlexBaseURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, unit := range []struct {
+		trusted    []string
+		remoteAddr string
+		forwarded  bool
+		want       string
+	}{
+		{nil, "10.0.0.1:1234", false, "http://example.com"},
+		// forwarded headers of untrusted proxies are ignored.
+		{nil, "10.0.0.1:1234", true, "http://example.com"},
+		{[]string{"192.168.1.0/24"}, "10.0.0.1:1234", true, "http://example.com"},
+		{[]string{"10.0.0.0/8"}, "10.0.0.1:1234", true, "https://metatube.example.org"},
+		{[]string{"10.0.0.0/8"}, "10.0.0.1:1234", false, "http://example.com"},
+	} {
+		r := gin.New()
+		_ = r.SetTrustedProxies(unit.trusted)
+		var got string
+		r.GET("/", func(c *gin.Context) { got = plexBaseURL(c) })
+
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+		req.RemoteAddr = unit.remoteAddr
+		if unit.forwarded {
+			req.Header.Set("X-Forwarded-For", "203.0.113.1")
+			req.Header.Set("X-Forwarded-Proto", "https")
+			req.Header.Set("X-Forwarded-Host", "metatube.example.org")
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, unit.want, got, unit)
+	}
+}
+
+func TestGetPlexMetadata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/plex/library/metadata/:ratingKey", getPlexMetadata(embyTestEngine(t)))
+
+	for _, unit := range []struct {
+		ratingKey string
+		code      int
+	}{
+		{"FANZA:emby00001", http.StatusOK},
+		{"invalid", http.StatusBadRequest},
+		{"unknown:1", http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/plex/library/metadata/"+unit.ratingKey, nil))
+		assert.Equal(t, unit.code, w.Code, unit.ratingKey)
+		if unit.code == http.StatusOK {
+			assert.Contains(t, w.Body.String(), `"guid":"metatube://movie/FANZA:emby00001"`)
+			assert.Contains(t, w.Body.String(), `"title":"EMBY-001 Title"`)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+type relatedQuery struct {
+	Lazy bool `form:"lazy"`
+}
+
+func getRelatedMovies(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &relatedQuery{
+			Lazy: true, // enable lazy by default.
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		related, err := app.GetRelatedMovies(uri.Provider, uri.ID, query.Lazy)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &responseMessage{Data: related})
+	}
+}
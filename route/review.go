@@ -17,6 +17,7 @@ type reviewQuery struct {
 
 func getReview(app *engine.Engine) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
 		uri := &infoUri{}
 		if err := c.ShouldBindUri(uri); err != nil {
 			abortWithStatusMessage(c, http.StatusBadRequest, err)
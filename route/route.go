@@ -14,11 +14,33 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/route/auth"
 )
 
-func New(app *engine.Engine, v auth.Validator) *gin.Engine {
+func New(app *engine.Engine, v auth.Validator, opts ...Option) *gin.Engine {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	r := gin.New()
+	{
+		// forwarded IPs and hosts can be spoofed unless proxies are trusted.
+		proxies := make([]string, 0, len(o.trustedProxies))
+		for _, prefix := range o.trustedProxies {
+			proxies = append(proxies, prefix.String())
+		}
+		if err := r.SetTrustedProxies(proxies); err != nil {
+			panic(err) // unreachable for valid prefixes.
+		}
+	}
 	{
 		// register middleware
-		r.Use(logger(), recovery())
+		// instrument precedes recovery to count recovered panics as 500s.
+		r.Use(requestID(), acceptLanguage(), tracer(), requestLogger(), instrument(), recovery())
+		if len(o.ipAllowlist) > 0 || len(o.ipDenylist) > 0 {
+			r.Use(ipFilter(o.ipAllowlist, o.ipDenylist))
+		}
+		if len(o.corsOrigins) > 0 {
+			r.Use(cors(o.corsOrigins))
+		}
 		// fallback behavior
 		r.NoRoute(notFound())
 		r.NoMethod(notAllowed())
@@ -30,28 +52,182 @@ func New(app *engine.Engine, v auth.Validator) *gin.Engine {
 	// index page
 	r.GET("/", getIndex(app))
 
-	system := r.Group("/v1", cacheNoStore())
+	// Liveness and readiness probes.
+	r.GET("/healthz", cacheNoStore(), getHealthz())
+	r.GET("/readyz", cacheNoStore(), getReadyz(app))
+
+	// Prometheus metrics endpoint.
+	r.GET("/metrics", cacheNoStore(), getMetrics())
+
+	// OpenAPI document and its Swagger UI.
+	r.GET(openAPIPath, cacheNoStore(), getOpenAPI(r))
+	r.GET(swaggerUIPath, cacheNoStore(), getSwaggerUI())
+
+	// API endpoints, /v1 is frozen, and /v2 derives its
+	// responses from it with the versioned envelope.
+	apiRoutes(r.Group("/v1", apiVersion(apiVersion1)), app, v, o)
+	apiRoutes(r.Group("/v2", apiVersion(apiVersion2), envelope(apiVersion2)), app, v, o)
+
+	// Trailers are streamed as received, outside the middlewares of API
+	// endpoints buffering whole responses, i.e. ETags and envelopes.
+	for _, version := range []string{apiVersion1, apiVersion2} {
+		r.GET("/v"+version+"/movies/:provider/:id/trailer", apiVersion(version), getTrailer(app))
+	}
+
+	// Admin endpoints are protected by the admin validator, and
+	// denied if none is configured, as regular tokens never grant
+	// admin access.
+	adminAuth := denyAdmin()
+	if o.adminValidator != nil {
+		adminAuth = authentication(o.adminValidator)
+	}
+	admin := r.Group("/v1/admin", adminAuth, cacheNoStore())
+	{
+		cache := admin.Group("/cache")
+		{
+			cache.GET("/stats", getDBStats(app))
+			cache.DELETE("/actors/:provider/:id", deleteCache(app, actorInfoType))
+			cache.DELETE("/movies/:provider/:id", deleteCache(app, movieInfoType))
+		}
+
+		refresh := admin.Group("/refresh")
+		{
+			refresh.POST("/actors/:provider/:id", postRefresh(app, actorInfoType))
+			refresh.POST("/movies/:provider/:id", postRefresh(app, movieInfoType))
+		}
+
+		overrides := admin.Group("/overrides")
+		{
+			overrides.GET("/movies/:provider/:id", getMovieOverride(app))
+			overrides.PATCH("/movies/:provider/:id", patchMovieOverride(app))
+			overrides.DELETE("/movies/:provider/:id", deleteMovieOverride(app))
+		}
+
+		admin.POST("/db/vacuum", postDBVacuum(app))
+		admin.GET("/keys", getAPIKeyUsage(v))
+		admin.GET("/providers/health", getProviderHealth(app))
+		admin.GET("/providers/fields", getProviderFieldStats(app))
+
+		// Debug scrapes fetch live pages bypassing all caches.
+		debug := admin.Group("/debug")
+		{
+			debug.GET("/scrape", getDebugScrape(app))
+			debug.POST("/parse", postDryRunParse(app))
+		}
+
+		translations := admin.Group("/translations")
+		{
+			translations.GET("/export", getTranslationMemory())
+			translations.POST("/import", postTranslationMemory())
+		}
+
+		jobs := admin.Group("/jobs")
+		{
+			jobs.GET("", getJobs(app))
+			jobs.POST("", postJob(app))
+			jobs.GET("/:id", getJob(app))
+		}
+
+		cookies := admin.Group("/cookies")
+		{
+			cookies.GET("/:provider", getCookies(app))
+			cookies.PUT("/:provider", putCookies(app))
+		}
+
+		if o.reload != nil {
+			admin.POST("/reload", postReload(o.reload))
+		}
+
+		library := admin.Group("/library")
+		{
+			library.POST("/filmography", postFilmography(app, o.libraryRoots))
+			if len(o.libraryRoots) > 0 {
+				library.POST("/organize", postOrganize(app, o.libraryRoots))
+			}
+		}
+	}
+
+	// Jellyfin/Emby compatible endpoints.
+	emby := r.Group("/emby", etag())
+	{
+		images := emby.Group("/images", cachePublicSMaxAge(180*24*time.Hour))
+		{
+			images.GET("/primary/:provider/:id", getImage(app, primaryImageType))
+			images.GET("/thumb/:provider/:id", getImage(app, thumbImageType))
+			images.GET("/backdrop/:provider/:id", getImage(app, backdropImageType))
+		}
+
+		private := emby.Group("", authentication(v))
+		{
+			private.GET("/actors/:provider/:id", getEmbyInfo(app, actorInfoType))
+			private.GET("/actors/search", getEmbySearch(app, actorSearchType))
+			private.GET("/movies/:provider/:id", getEmbyInfo(app, movieInfoType))
+			private.GET("/movies/search", getEmbySearch(app, movieSearchType))
+		}
+	}
+
+	// Plex custom metadata agent endpoints.
+	plex := r.Group("/plex/library/metadata", authentication(v), etag())
+	{
+		plex.GET("/matches", postPlexMatch(app))
+		plex.POST("/matches", postPlexMatch(app))
+		plex.GET("/:ratingKey", getPlexMetadata(app))
+	}
+
+	// Embedded web UI.
+	if o.enableWebUI {
+		r.GET(webUIPath+"/*filepath", cacheNoStore(), getWebUI())
+	}
+
+	// Stash-box compatible GraphQL endpoint.
+	if o.enableStashBox {
+		graphql := r.Group("/graphql", authentication(v))
+		{
+			graphql.GET("", postStashBox(app))
+			graphql.POST("", postStashBox(app))
+		}
+	}
+
+	return r
+}
+
+// apiRoutes registers the API endpoints of a version to g.
+func apiRoutes(g *gin.RouterGroup, app *engine.Engine, v auth.Validator, o *options) {
+	system := g.Group("", cacheNoStore())
 	{
 		system.GET("/modules", getModules())
 		system.GET("/providers", getProviders(app))
 	}
 
-	public := r.Group("/v1",
+	public := g.Group("",
 		// It's planned to cache public data for
 		// a long time, especially behind a CDN.
-		cachePublicSMaxAge(180*24*time.Hour))
+		cachePublicSMaxAge(180*24*time.Hour), etag())
 	{
-		public.GET("/translate", getTranslate())
+		public.GET("/translate", getTranslate(v))
+
+		public.GET("/actors/:provider/:id/primary", getActorFaceImage(app))
 
 		images := public.Group("/images")
 		{
 			images.GET("/primary/:provider/:id", getImage(app, primaryImageType))
 			images.GET("/thumb/:provider/:id", getImage(app, thumbImageType))
 			images.GET("/backdrop/:provider/:id", getImage(app, backdropImageType))
+			// JSON pipelines.
+			images.POST("/primary/:provider/:id", getImage(app, primaryImageType))
+			images.POST("/thumb/:provider/:id", getImage(app, thumbImageType))
+			images.POST("/backdrop/:provider/:id", getImage(app, backdropImageType))
 		}
 	}
 
-	private := r.Group("/v1", authentication(v))
+	// Previews are encoded by ffmpeg on demand, which is too costly to
+	// be open to anyone.
+	previews := g.Group("/images", authentication(v), etag())
+	{
+		previews.GET("/preview/:provider/:id", getPreview(app))
+	}
+
+	private := g.Group("", authentication(v), etag(), selectFields())
 	{
 		db := private.Group("/db")
 		{
@@ -62,25 +238,55 @@ func New(app *engine.Engine, v auth.Validator) *gin.Engine {
 		{
 			actors.GET("/:provider/:id", getInfo(app, actorInfoType))
 			actors.GET("/search", getSearch(app, actorSearchType))
+			actors.GET("/birthdays", getActorBirthdays(app))
 		}
 
 		movies := private.Group("/movies")
 		{
 			movies.GET("/:provider/:id", getInfo(app, movieInfoType))
+			movies.GET("/:provider/:id/related", getRelatedMovies(app))
 			movies.GET("/search", getSearch(app, movieSearchType))
+			movies.GET("/lookup", getLookup(app))
+			movies.GET("/number/:number", getMovieByNumber(app))
+		}
+
+		private.GET("/calendar", getCalendar(app))
+
+		follows := private.Group("/follows")
+		{
+			follows.GET("", getFollows(app))
+			follows.POST("", postFollow(app))
+			follows.DELETE("/:id", deleteFollow(app))
+			follows.GET("/releases", getUnseenReleases(app))
+			follows.POST("/releases/seen", postReleasesSeen(app))
+		}
+
+		collections := private.Group("/collections")
+		{
+			collections.GET("", getCollections(app))
+			collections.POST("", postCollectionItem(app))
+			collections.DELETE("/:id", deleteCollectionItem(app))
+		}
+
+		states := private.Group("/states")
+		{
+			states.GET("", getNumberStates(app))
+			states.POST("", postNumberStates(app))
 		}
 
 		reviews := private.Group("/reviews")
 		{
 			reviews.GET("/:provider/:id", getReview(app))
 		}
-	}
 
-	return r
-}
-
-func logger() gin.HandlerFunc {
-	return gin.LoggerWithConfig(gin.LoggerConfig{})
+		if len(o.subtitleSources) > 0 {
+			subtitles := private.Group("/subtitles")
+			{
+				subtitles.GET("/search", getSubtitleSearch(o.subtitleSources))
+				subtitles.GET("/:provider/:id", getSubtitles(app, o.subtitleSources))
+			}
+		}
+	}
 }
 
 func recovery() gin.HandlerFunc {
@@ -161,6 +367,7 @@ func abortWithStatusMessage(c *gin.Context, code int, message any) {
 }
 
 type responseMessage struct {
-	Data  any   `json:"data,omitempty"`
-	Error error `json:"error,omitempty"`
+	Data  any       `json:"data,omitempty"`
+	Page  *pageInfo `json:"page,omitempty"`
+	Error error     `json:"error,omitempty"`
 }
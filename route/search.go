@@ -1,8 +1,10 @@
 package route
 
 import (
+	"fmt"
 	"net/http"
 	pkgurl "net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -18,82 +20,157 @@ const (
 	movieSearchType
 )
 
+// Search modes other than the default keyword search.
+const (
+	searchByActor = "actor"
+	searchByGenre = "genre"
+)
+
 type searchQuery struct {
 	Q        string `form:"q" binding:"required"`
 	Provider string `form:"provider"`
 	Fallback bool   `form:"fallback"`
+	By       string `form:"by"`
+	// Dedup collapses results of the same movie from all providers.
+	Dedup bool `form:"dedup"`
+	// Compilation is include, demote or exclude for compilations.
+	Compilation string `form:"compilation"`
+	pageQuery
+	// upstream page of genre listings.
+	genrePage int
+}
+
+// validate checks the search mode and the paging query.
+func (q *searchQuery) validate(typ searchType) error {
+	q.By = strings.ToLower(q.By)
+	switch q.By {
+	case "":
+	case searchByActor, searchByGenre:
+		if typ != movieSearchType {
+			return fmt.Errorf("unsupported search mode for actors: %s", q.By)
+		}
+	default:
+		return fmt.Errorf("invalid search mode: %s", q.By)
+	}
+	switch policy := engine.CompilationPolicy(strings.ToLower(q.Compilation)); policy {
+	case "":
+	case engine.CompilationInclude, engine.CompilationDemote, engine.CompilationExclude:
+		if typ != movieSearchType {
+			return fmt.Errorf("unsupported compilation policy for actors")
+		}
+		q.Compilation = string(policy)
+	default:
+		return fmt.Errorf("invalid compilation policy: %s", q.Compilation)
+	}
+	if q.By == searchByGenre {
+		// genre listings are paginated by providers, so the page
+		// selects the upstream page instead of slicing results.
+		if q.Page < 0 || q.Limit > 0 {
+			return fmt.Errorf("invalid page or limit for genre search")
+		}
+		q.genrePage, q.Page = max(q.Page, 1), 0
+	}
+	return q.pageQuery.validate(typ)
 }
 
 func getSearch(app *engine.Engine, typ searchType) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		query := &searchQuery{
 			Fallback: true, // enable fallback by default.
+			Dedup:    true, // enable dedup by default.
 		}
 		if err := c.ShouldBindQuery(query); err != nil {
 			abortWithStatusMessage(c, http.StatusBadRequest, err)
 			return
 		}
 
-		isValidURL := true
-		if _, err := pkgurl.ParseRequestURI(query.Q); err != nil {
-			isValidURL = false
-		}
-
-		searchAll := true
-		if query.Provider != "" {
-			searchAll = false
+		if err := query.validate(typ); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
 		}
 
-		var (
-			results any
-			err     error
-		)
-		switch typ {
-		case actorSearchType:
-			if isValidURL {
-				results, err = app.GetActorInfoByURL(query.Q, true /* always lazy */)
-			} else if searchAll {
-				results, err = app.SearchActorAll(query.Q, query.Fallback)
-			} else {
-				results, err = app.SearchActor(query.Q, query.Provider, query.Fallback)
-			}
-		case movieSearchType:
-			if isValidURL {
-				results, err = app.GetMovieInfoByURL(query.Q, true /* always lazy */)
-			} else if searchAll {
-				results, err = app.SearchMovieAll(query.Q, query.Fallback)
-			} else {
-				results, err = app.SearchMovie(query.Q, query.Provider, query.Fallback)
-			}
-		default:
-			panic("invalid search type")
-		}
+		results, err := search(app.WithContext(c.Request.Context()), typ, query)
 		if err != nil {
 			abortWithError(c, err)
 			return
 		}
 
-		// length is at least 1.
-		resultsLength := 1
-
-		// convert to search results.
-		switch v := results.(type) {
-		case *model.ActorInfo:
-			results = []*model.ActorSearchResult{v.ToSearchResult()}
-		case *model.MovieInfo:
-			results = []*model.MovieSearchResult{v.ToSearchResult()}
-		case []*model.ActorSearchResult:
-			resultsLength = len(v)
-		case []*model.MovieSearchResult:
-			resultsLength = len(v)
-		default:
-			panic("unexpected search results type")
+		if movies, ok := results.([]*model.MovieSearchResult); ok {
+			// owned and watched states are of the user of the key.
+			if err = app.AnnotateNumberStates(requestUser(c), movies); err != nil {
+				abortWithError(c, err)
+				return
+			}
 		}
-		if resultsLength == 0 {
-			abortWithError(c, errors.FromCode(http.StatusNotFound))
-			return
+
+		results, page := query.apply(results)
+		c.JSON(http.StatusOK, &responseMessage{Data: results, Page: page})
+	}
+}
+
+// search performs the actual searching and always returns search
+// results, it returns a not found error when no results are found.
+func search(app *engine.Engine, typ searchType, query *searchQuery) (results any, err error) {
+	isValidURL := true
+	if _, err := pkgurl.ParseRequestURI(query.Q); err != nil {
+		isValidURL = false
+	}
+
+	searchAll := true
+	if query.Provider != "" {
+		searchAll = false
+	}
+
+	switch typ {
+	case actorSearchType:
+		if isValidURL {
+			results, err = app.GetActorInfoByURL(query.Q, true /* always lazy */)
+		} else if searchAll {
+			results, err = app.SearchActorAll(query.Q, query.Fallback)
+		} else {
+			results, err = app.SearchActor(query.Q, query.Provider, query.Fallback)
+		}
+	case movieSearchType:
+		if query.By == searchByActor {
+			results, err = app.SearchMovieByActor(query.Q)
+		} else if query.By == searchByGenre {
+			results, err = app.SearchMovieByGenre(query.Q, query.genrePage)
+		} else if isValidURL {
+			results, err = app.GetMovieInfoByURL(query.Q, true /* always lazy */)
+		} else if searchAll {
+			results, err = app.SearchMovieAll(query.Q, query.Fallback)
+			if err == nil && query.Dedup {
+				results = engine.DedupMovieSearchResults(results.([]*model.MovieSearchResult))
+			}
+		} else {
+			results, err = app.SearchMovie(query.Q, query.Provider, query.Fallback)
 		}
+	default:
+		panic("invalid search type")
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		c.JSON(http.StatusOK, &responseMessage{Data: results})
+	// length is at least 1.
+	resultsLength := 1
+
+	// convert to search results.
+	switch v := results.(type) {
+	case *model.ActorInfo:
+		results = []*model.ActorSearchResult{v.ToSearchResult()}
+	case *model.MovieInfo:
+		results = []*model.MovieSearchResult{v.ToSearchResult()}
+	case []*model.ActorSearchResult:
+		resultsLength = len(v)
+	case []*model.MovieSearchResult:
+		v = engine.ApplyCompilationPolicy(v, engine.CompilationPolicy(query.Compilation))
+		results, resultsLength = v, len(v)
+	default:
+		panic("unexpected search results type")
+	}
+	if resultsLength == 0 {
+		return nil, errors.FromCode(http.StatusNotFound)
 	}
+	return results, nil
 }
@@ -0,0 +1,601 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// stashBoxRequest is a standard GraphQL-over-HTTP request.
+type stashBoxRequest struct {
+	Query         string         `json:"query" form:"query" binding:"required"`
+	OperationName string         `json:"operationName" form:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type stashBoxError struct {
+	Message string   `json:"message"`
+	Path    []string `json:"path,omitempty"`
+}
+
+type stashBoxResponse struct {
+	Data   map[string]any   `json:"data"`
+	Errors []*stashBoxError `json:"errors,omitempty"`
+}
+
+type stashBoxURL struct {
+	URL  string          `json:"url"`
+	Type string          `json:"type"`
+	Site stashBoxURLSite `json:"site"`
+}
+
+type stashBoxURLSite struct {
+	Name string `json:"name"`
+}
+
+type stashBoxImage struct {
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type stashBoxTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type stashBoxStudio struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type stashBoxPerformerAppearance struct {
+	As        *string            `json:"as"`
+	Performer *stashBoxPerformer `json:"performer"`
+}
+
+type stashBoxScene struct {
+	ID         string                         `json:"id"`
+	Code       string                         `json:"code"`
+	Title      string                         `json:"title"`
+	Details    string                         `json:"details"`
+	Date       *string                        `json:"date"`
+	Duration   *int                           `json:"duration"`
+	Director   string                         `json:"director"`
+	URLs       []stashBoxURL                  `json:"urls"`
+	Images     []stashBoxImage                `json:"images"`
+	Studio     *stashBoxStudio                `json:"studio"`
+	Tags       []stashBoxTag                  `json:"tags"`
+	Performers []*stashBoxPerformerAppearance `json:"performers"`
+}
+
+type stashBoxPerformer struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Disambiguation string          `json:"disambiguation"`
+	Aliases        []string        `json:"aliases"`
+	Gender         *string         `json:"gender"`
+	BirthDate      *string         `json:"birth_date"`
+	Country        *string         `json:"country"`
+	Height         *int            `json:"height"`
+	CupSize        *string         `json:"cup_size"`
+	URLs           []stashBoxURL   `json:"urls"`
+	Images         []stashBoxImage `json:"images"`
+}
+
+func postStashBox(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := &stashBoxRequest{}
+		if err := c.ShouldBind(req); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if c.Request.Method == http.MethodGet && req.Variables == nil {
+			if v := c.Query("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					abortWithStatusMessage(c, http.StatusBadRequest, err)
+					return
+				}
+			}
+		}
+
+		fields, err := parseStashBoxQuery(req.Query, req.OperationName, req.Variables)
+		if err != nil {
+			c.JSON(http.StatusOK, &stashBoxResponse{
+				Errors: []*stashBoxError{{Message: err.Error()}},
+			})
+			return
+		}
+
+		app := app.WithContext(c.Request.Context())
+		resp := &stashBoxResponse{Data: make(map[string]any, len(fields))}
+		for _, field := range fields {
+			data, err := resolveStashBoxField(app, field)
+			if err != nil {
+				resp.Errors = append(resp.Errors, &stashBoxError{
+					Message: err.Error(),
+					Path:    []string{field.alias},
+				})
+			}
+			resp.Data[field.alias] = data
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func resolveStashBoxField(app *engine.Engine, field *stashBoxField) (any, error) {
+	switch field.name {
+	case "__typename":
+		return "Query", nil
+	case "searchScene":
+		results, err := app.SearchMovieAll(field.stringArg("term"), true)
+		if err != nil {
+			return []*stashBoxScene{}, err
+		}
+		scenes := make([]*stashBoxScene, 0, len(results))
+		for _, result := range results {
+			scenes = append(scenes, newStashBoxSceneFromSearchResult(result))
+		}
+		return scenes, nil
+	case "findScene":
+		provider, id, ok := strings.Cut(field.stringArg("id"), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid scene id: %s", field.stringArg("id"))
+		}
+		info, err := app.GetMovieInfoByProviderID(provider, id, true)
+		if err != nil {
+			return nil, err
+		}
+		return newStashBoxScene(info), nil
+	case "searchPerformer":
+		results, err := app.SearchActorAll(field.stringArg("term"), true)
+		if err != nil {
+			return []*stashBoxPerformer{}, err
+		}
+		performers := make([]*stashBoxPerformer, 0, len(results))
+		for _, result := range results {
+			performers = append(performers, newStashBoxPerformerFromSearchResult(result))
+		}
+		return performers, nil
+	case "findPerformer":
+		provider, id, ok := strings.Cut(field.stringArg("id"), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid performer id: %s", field.stringArg("id"))
+		}
+		info, err := app.GetActorInfoByProviderID(provider, id, true)
+		if err != nil {
+			return nil, err
+		}
+		return newStashBoxPerformer(info), nil
+	case "findSceneByFingerprint", "findScenesByFingerprints", "findScenesByFullFingerprints":
+		// scenes are matched by numbers, no fingerprints are indexed.
+		return []*stashBoxScene{}, nil
+	case "findScenesBySceneFingerprints":
+		// a list of matched scenes per queried scene.
+		fingerprints, _ := field.args["fingerprints"].([]any)
+		scenes := make([][]*stashBoxScene, len(fingerprints))
+		for i := range scenes {
+			scenes[i] = []*stashBoxScene{}
+		}
+		return scenes, nil
+	default:
+		return nil, fmt.Errorf("unsupported query field: %s", field.name)
+	}
+}
+
+func newStashBoxSceneFromSearchResult(result *model.MovieSearchResult) *stashBoxScene {
+	scene := &stashBoxScene{
+		ID:         result.Provider + ":" + result.ID,
+		Code:       result.Number,
+		Title:      result.Title,
+		Date:       stashBoxDate(result.ReleaseDate),
+		URLs:       []stashBoxURL{newStashBoxURL(result.Provider, result.Homepage)},
+		Images:     newStashBoxImages(result.CoverURL, result.ThumbURL),
+		Tags:       []stashBoxTag{},
+		Performers: []*stashBoxPerformerAppearance{},
+	}
+	for _, actor := range result.Actors {
+		scene.Performers = append(scene.Performers, &stashBoxPerformerAppearance{
+			Performer: &stashBoxPerformer{Name: actor, Aliases: []string{}},
+		})
+	}
+	return scene
+}
+
+func newStashBoxScene(info *model.MovieInfo) *stashBoxScene {
+	scene := &stashBoxScene{
+		ID:         info.Provider + ":" + info.ID,
+		Code:       info.Number,
+		Title:      info.Title,
+		Details:    info.Summary,
+		Date:       stashBoxDate(info.ReleaseDate),
+		Director:   info.Director,
+		URLs:       []stashBoxURL{newStashBoxURL(info.Provider, info.Homepage)},
+		Images:     newStashBoxImages(info.BigCoverURL, info.CoverURL, info.BigThumbURL, info.ThumbURL),
+		Tags:       []stashBoxTag{},
+		Performers: []*stashBoxPerformerAppearance{},
+	}
+	if info.Runtime > 0 {
+		duration := int((time.Duration(info.Runtime) * time.Minute).Seconds())
+		scene.Duration = &duration
+	}
+	if info.Maker != "" {
+		scene.Studio = &stashBoxStudio{ID: info.Maker, Name: info.Maker}
+	}
+	for _, genre := range info.Genres {
+		scene.Tags = append(scene.Tags, stashBoxTag{ID: genre, Name: genre})
+	}
+	for _, actor := range info.Actors {
+		scene.Performers = append(scene.Performers, &stashBoxPerformerAppearance{
+			Performer: &stashBoxPerformer{Name: actor, Aliases: []string{}},
+		})
+	}
+	return scene
+}
+
+func newStashBoxPerformerFromSearchResult(result *model.ActorSearchResult) *stashBoxPerformer {
+	return &stashBoxPerformer{
+		ID:      result.Provider + ":" + result.ID,
+		Name:    result.Name,
+		Aliases: append([]string{}, result.Aliases...),
+		URLs:    []stashBoxURL{newStashBoxURL(result.Provider, result.Homepage)},
+		Images:  newStashBoxImages(result.Images...),
+	}
+}
+
+func newStashBoxPerformer(info *model.ActorInfo) *stashBoxPerformer {
+	female := "FEMALE" // almost all actors are female.
+	performer := &stashBoxPerformer{
+		ID:        info.Provider + ":" + info.ID,
+		Name:      info.Name,
+		Aliases:   append([]string{}, info.Aliases...),
+		Gender:    &female,
+		BirthDate: stashBoxDate(info.Birthday),
+		URLs:      []stashBoxURL{newStashBoxURL(info.Provider, info.Homepage)},
+		Images:    newStashBoxImages(info.Images...),
+	}
+	if info.Nationality != "" {
+		performer.Country = &info.Nationality
+	}
+	if info.Height > 0 {
+		performer.Height = &info.Height
+	}
+	if info.CupSize != "" {
+		performer.CupSize = &info.CupSize
+	}
+	return performer
+}
+
+func newStashBoxURL(provider, homepage string) stashBoxURL {
+	return stashBoxURL{URL: homepage, Type: "HOME", Site: stashBoxURLSite{Name: provider}}
+}
+
+func newStashBoxImages(urls ...string) []stashBoxImage {
+	images := make([]stashBoxImage, 0, len(urls))
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+		images = append(images, stashBoxImage{ID: url, URL: url})
+	}
+	return images
+}
+
+func stashBoxDate(d datatypes.Date) *string {
+	if t := time.Time(d); !t.IsZero() {
+		s := t.Format(time.DateOnly)
+		return &s
+	}
+	return nil
+}
+
+// stashBoxField is a top-level field of a GraphQL query operation.
+type stashBoxField struct {
+	alias string
+	name  string
+	args  map[string]any
+}
+
+func (f *stashBoxField) stringArg(name string) string {
+	switch v := f.args[name].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// parseStashBoxQuery is a tiny GraphQL parser that only extracts the
+// top-level fields and their arguments of the selected operation, the
+// selection sets are ignored as full objects are always returned.
+func parseStashBoxQuery(query, operationName string, variables map[string]any) ([]*stashBoxField, error) {
+	p := &stashBoxParser{src: query, vars: variables}
+	for {
+		p.skipIgnored()
+		if p.eof() {
+			break
+		}
+		if p.peek() == '{' /* anonymous query shorthand */ {
+			return p.parseSelectionSet()
+		}
+		keyword := p.name()
+		switch keyword {
+		case "query":
+			p.skipIgnored()
+			name := p.name()
+			p.skipIgnored()
+			if p.peek() == '(' {
+				p.skipBalanced('(', ')')
+			}
+			p.skipDirectives()
+			if p.peek() != '{' {
+				return nil, fmt.Errorf("syntax error at position %d", p.pos)
+			}
+			if operationName == "" || operationName == name {
+				return p.parseSelectionSet()
+			}
+			p.skipBalanced('{', '}')
+		case "fragment":
+			for !p.eof() && p.peek() != '{' {
+				p.pos++
+			}
+			p.skipBalanced('{', '}')
+		case "mutation", "subscription":
+			return nil, fmt.Errorf("unsupported operation: %s", keyword)
+		default:
+			return nil, fmt.Errorf("syntax error at position %d", p.pos)
+		}
+	}
+	return nil, fmt.Errorf("unknown operation named %q", operationName)
+}
+
+type stashBoxParser struct {
+	src  string
+	pos  int
+	vars map[string]any
+}
+
+func (p *stashBoxParser) eof() bool { return p.pos >= len(p.src) }
+
+// peek returns the current byte, or 0 at the end of the query.
+func (p *stashBoxParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *stashBoxParser) skipIgnored() {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == '#':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		case c == ',' || unicode.IsSpace(rune(c)):
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *stashBoxParser) name() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *stashBoxParser) skipDirectives() {
+	for p.skipIgnored(); !p.eof() && p.peek() == '@'; p.skipIgnored() {
+		p.pos++
+		p.name()
+		p.skipIgnored()
+		if !p.eof() && p.peek() == '(' {
+			p.skipBalanced('(', ')')
+		}
+	}
+}
+
+// skipBalanced skips a balanced block, string literals are respected.
+func (p *stashBoxParser) skipBalanced(open, close byte) {
+	depth := 0
+	for !p.eof() {
+		switch c := p.peek(); c {
+		case '"':
+			_, _ = p.string()
+			continue
+		case open:
+			depth++
+		case close:
+			depth--
+		}
+		p.pos++
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+func (p *stashBoxParser) parseSelectionSet() (fields []*stashBoxField, err error) {
+	p.pos++ // skip '{'
+	for {
+		p.skipIgnored()
+		if p.eof() {
+			return nil, fmt.Errorf("unexpected end of query")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return fields, nil
+		}
+		if strings.HasPrefix(p.src[p.pos:], "...") {
+			return nil, fmt.Errorf("fragment spreads are not supported at the top level")
+		}
+
+		field := &stashBoxField{name: p.name(), args: make(map[string]any)}
+		if field.name == "" {
+			return nil, fmt.Errorf("syntax error at position %d", p.pos)
+		}
+		field.alias = field.name
+		p.skipIgnored()
+		if !p.eof() && p.peek() == ':' {
+			p.pos++
+			p.skipIgnored()
+			field.name = p.name()
+			p.skipIgnored()
+		}
+		if !p.eof() && p.peek() == '(' {
+			if err = p.parseArguments(field.args); err != nil {
+				return nil, err
+			}
+		}
+		p.skipDirectives()
+		if !p.eof() && p.peek() == '{' {
+			p.skipBalanced('{', '}')
+		}
+		fields = append(fields, field)
+	}
+}
+
+func (p *stashBoxParser) parseArguments(args map[string]any) (err error) {
+	p.pos++ // skip '('
+	for {
+		p.skipIgnored()
+		if p.eof() {
+			return fmt.Errorf("unexpected end of query")
+		}
+		if p.peek() == ')' {
+			p.pos++
+			return nil
+		}
+		name := p.name()
+		p.skipIgnored()
+		if p.eof() || p.peek() != ':' {
+			return fmt.Errorf("syntax error at position %d", p.pos)
+		}
+		p.pos++
+		p.skipIgnored()
+		if args[name], err = p.value(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *stashBoxParser) value() (any, error) {
+	if p.eof() {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		return p.vars[p.name()], nil
+	case c == '"':
+		return p.string()
+	case c == '[':
+		return p.list()
+	case c == '{':
+		return p.object()
+	default:
+		start := p.pos
+		for !p.eof() && strings.IndexByte(" \t\r\n,)]}", p.peek()) < 0 {
+			p.pos++
+		}
+		raw := p.src[start:p.pos]
+		if raw == "" {
+			return nil, fmt.Errorf("syntax error at position %d", p.pos)
+		}
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n, nil
+		}
+		switch raw {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return raw, nil // enum value.
+	}
+}
+
+func (p *stashBoxParser) list() (list []any, err error) {
+	list = []any{}
+	p.pos++ // skip '['
+	for {
+		p.skipIgnored()
+		if p.eof() {
+			return nil, fmt.Errorf("unexpected end of query")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return list, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+}
+
+func (p *stashBoxParser) object() (map[string]any, error) {
+	obj := make(map[string]any)
+	p.pos++ // skip '{'
+	for {
+		p.skipIgnored()
+		if p.eof() {
+			return nil, fmt.Errorf("unexpected end of query")
+		}
+		if p.peek() == '}' {
+			p.pos++
+			return obj, nil
+		}
+		name := p.name()
+		p.skipIgnored()
+		if name == "" || p.peek() != ':' {
+			return nil, fmt.Errorf("syntax error at position %d", p.pos)
+		}
+		p.pos++
+		p.skipIgnored()
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+}
+
+func (p *stashBoxParser) string() (string, error) {
+	start := p.pos
+	for p.pos++; !p.eof(); p.pos++ {
+		switch p.peek() {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			return strconv.Unquote(p.src[start:p.pos])
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
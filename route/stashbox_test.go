@@ -0,0 +1,109 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStashBoxQuery(t *testing.T) {
+	for _, unit := range []struct {
+		query, operationName string
+		variables            map[string]any
+		want                 []*stashBoxField
+	}{
+		{
+			query: `{ findScene(id: "FANZA:1") { id title } }`,
+			want:  []*stashBoxField{{alias: "findScene", name: "findScene", args: map[string]any{"id": "FANZA:1"}}},
+		},
+		{
+			query:     `query Find($id: ID!) { scene: findScene(id: $id) @include(if: true) { id } __typename }`,
+			variables: map[string]any{"id": "FANZA:1"},
+			want: []*stashBoxField{
+				{alias: "scene", name: "findScene", args: map[string]any{"id": "FANZA:1"}},
+				{alias: "__typename", name: "__typename", args: map[string]any{}},
+			},
+		},
+		{
+			query: `# comment
+				fragment F on Scene { id }
+				query A { searchScene(term: "a") { ...F } }
+				query B { searchPerformer(term: "b", limit: 10, all: true) { id } }`,
+			operationName: "B",
+			want: []*stashBoxField{{alias: "searchPerformer", name: "searchPerformer",
+				args: map[string]any{"term": "b", "limit": float64(10), "all": true}}},
+		},
+		{
+			query: `{ findScenesBySceneFingerprints(fingerprints: [[{hash: "abc", algorithm: MD5}], []]) { id } }`,
+			want: []*stashBoxField{{alias: "findScenesBySceneFingerprints", name: "findScenesBySceneFingerprints",
+				args: map[string]any{"fingerprints": []any{
+					[]any{map[string]any{"hash": "abc", "algorithm": "MD5"}},
+					[]any{},
+				}}}},
+		},
+	} {
+		fields, err := parseStashBoxQuery(unit.query, unit.operationName, unit.variables)
+		if assert.NoError(t, err, unit.query) {
+			assert.Equal(t, unit.want, fields, unit.query)
+		}
+	}
+}
+
+func TestParseStashBoxQueryMalformed(t *testing.T) {
+	for _, query := range []string{
+		``,
+		`{`,
+		`{ query`,
+		`query`,
+		`query Find`,
+		`query Find(`,
+		`query Find @`,
+		`{ findScene(`,
+		`{ findScene(id`,
+		`{ findScene(id:`,
+		`{ findScene(id: "1`,
+		`{ findScene(id: [1, {a: }]) }`,
+		`{ findScene(id: [}) }`,
+		`{ findScene(id: {a 1}) }`,
+		`{ findScene { id `,
+		`{ ...F }`,
+		`}`,
+		`mutation { submitScene }`,
+		`query A { findScene }`,
+	} {
+		assert.NotPanics(t, func() {
+			_, err := parseStashBoxQuery(query, "B", nil)
+			assert.Error(t, err, query)
+		}, query)
+	}
+}
+
+func TestPostStashBox(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/graphql", postStashBox(embyTestEngine(t)))
+
+	for _, unit := range []struct {
+		body string
+		want string
+	}{
+		{`{"query": "{ findScene(id: \"FANZA:emby00001\") { id } }"}`, `"code":"EMBY-001"`},
+		{`{"query": "{ findSceneByFingerprint(fingerprint: {hash: \"abc\", algorithm: MD5}) { id } }"}`,
+			`{"data":{"findSceneByFingerprint":[]}}`},
+		{`{"query": "{ findScenesBySceneFingerprints(fingerprints: $fps) { id } }", "variables": {"fps": [[], []]}}`,
+			`{"data":{"findScenesBySceneFingerprints":[[],[]]}}`},
+		{`{"query": "{ query"}`, `"errors":[{"message":"unexpected end of query"}]`},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(unit.body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), unit.want, unit.body)
+	}
+}
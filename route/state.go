@@ -0,0 +1,55 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+type stateQuery struct {
+	Owned   *bool `form:"owned"`
+	Watched *bool `form:"watched"`
+}
+
+type stateBody struct {
+	Numbers []string  `json:"numbers" binding:"required"`
+	Owned   *bool     `json:"owned"`
+	Watched *bool     `json:"watched"`
+	At      time.Time `json:"at"`
+}
+
+func getNumberStates(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &stateQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		states, err := app.GetNumberStates(requestUser(c), query.Owned, query.Watched)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: states})
+	}
+}
+
+func postNumberStates(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := requestUser(c)
+		body := &stateBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		states, err := app.SetNumberStates(user, body.Numbers, body.Owned, body.Watched, body.At)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: states})
+	}
+}
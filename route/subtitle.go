@@ -0,0 +1,73 @@
+package route
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/subtitle"
+	_ "github.com/metatube-community/metatube-sdk-go/subtitle/subtitlecat"
+)
+
+type subtitleSearchQuery struct {
+	Q      string `form:"q" binding:"required"`
+	Source string `form:"source"`
+}
+
+func getSubtitleSearch(sources []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &subtitleSearchQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		searchSubtitles(c, number.Trim(query.Q), query.Source, sources)
+	}
+}
+
+func getSubtitles(app *engine.Engine, sources []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		info, err := app.GetMovieInfoByProviderID(uri.Provider, uri.ID, true)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		searchSubtitles(c, info.Number, c.Query("source"), sources)
+	}
+}
+
+func searchSubtitles(c *gin.Context, num, source string, sources []string) {
+	if num == "" {
+		abortWithStatusMessage(c, http.StatusBadRequest, "invalid number")
+		return
+	}
+	if source != "" /* specified source */ {
+		// only the sources enabled by the operator.
+		if !slices.ContainsFunc(sources, func(name string) bool { return strings.EqualFold(name, source) }) {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid source: "+source)
+			return
+		}
+		sources = []string{source}
+	}
+	results, err := subtitle.Search(num, sources...)
+	if err != nil {
+		abortWithStatusMessage(c, http.StatusBadGateway, err)
+		return
+	}
+	if len(results) == 0 {
+		abortWithError(c, errors.FromCode(http.StatusNotFound))
+		return
+	}
+	c.JSON(http.StatusOK, &responseMessage{Data: results})
+}
@@ -0,0 +1,44 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/subtitle"
+)
+
+// stubSource finds a subtitle of every number.
+type stubSource struct{ name string }
+
+func (s *stubSource) Name() string { return s.name }
+
+func (s *stubSource) Search(number string) ([]*subtitle.Subtitle, error) {
+	return []*subtitle.Subtitle{{Number: number, Language: "en", URL: "https://example.com/" + number, Source: s.name}}, nil
+}
+
+func TestSubtitleSearchSources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	subtitle.Register(&stubSource{"StubEnabled"})
+	subtitle.Register(&stubSource{"StubDisabled"})
+	r := New(engine.Default(), nil, WithSubtitleSources("StubEnabled"))
+
+	for _, unit := range []struct {
+		source string
+		code   int
+	}{
+		{"", http.StatusOK},
+		{"stubenabled", http.StatusOK},
+		// registered, but not enabled by the operator.
+		{"StubDisabled", http.StatusBadRequest},
+		{"unknown", http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/subtitles/search?q=ABC-123&source="+unit.source, nil))
+		assert.Equal(t, unit.code, w.Code, unit.source)
+	}
+}
@@ -0,0 +1,67 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// trailerResponseHeaders are headers of the upstream response passed
+// to the client, so that players can seek with range requests.
+var trailerResponseHeaders = []string{
+	"Accept-Ranges",
+	"Content-Range",
+	"ETag",
+	"Last-Modified",
+}
+
+// Only full trailers are cached, as partial contents of range requests
+// would be served by shared caches to requests of other ranges.
+var (
+	cacheTrailer        = cachePublicSMaxAge(180 * 24 * time.Hour)
+	cachePartialTrailer = cacheNoStore()
+)
+
+// getTrailer proxies the preview video of the movie, as video hosts
+// often reject players without the Referer of the provider.
+func getTrailer(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		app := app.WithContext(c.Request.Context())
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, mt.ErrProviderNotFound)
+			return
+		}
+		resp, err := app.OpenMovieTrailer(uri.Provider, uri.ID, c.Request.Header)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		headers := make(map[string]string)
+		for _, key := range trailerResponseHeaders {
+			if value := resp.Header.Get(key); value != "" {
+				headers[key] = value
+			}
+		}
+		if resp.StatusCode == http.StatusOK {
+			cacheTrailer(c)
+		} else {
+			cachePartialTrailer(c)
+		}
+		contentType := resp.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "video/mp4"
+		}
+		c.DataFromReader(resp.StatusCode, resp.ContentLength, contentType, resp.Body, headers)
+	}
+}
@@ -0,0 +1,71 @@
+package route
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestGetTrailer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/movies/:provider/:id/trailer", getTrailer(engine.Default()))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/movies/unknown/1/trailer", nil)
+	req.Header.Set("Range", "bytes=0-")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetTrailerStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	video := bytes.Repeat([]byte("0123456789"), 100)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "video/mp4")
+		http.ServeContent(w, r, "trailer.mp4", time.Time{}, bytes.NewReader(video))
+	}))
+	defer upstream.Close()
+
+	db, err := database.Open(&database.Config{DSN: "file:trailer_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	app := engine.New(engine.WithDB(db))
+	require.NoError(t, app.DBAutoMigrate(true))
+	require.NoError(t, db.Save(&model.MovieInfo{
+		ID: "trailer00001", Number: "TRAILER-001", Title: "Title", Provider: "FANZA",
+		Homepage: "https://example.com/trailer00001", CoverURL: "https://example.com/trailer00001.jpg",
+		PreviewVideoURL: upstream.URL + "/trailer.mp4",
+	}).Error)
+	r := New(app, nil)
+
+	for _, version := range []string{apiVersion1, apiVersion2} {
+		path := "/v" + version + "/movies/FANZA/trailer00001/trailer"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code, path)
+		// streamed as is, neither tagged nor enveloped.
+		assert.Equal(t, video, w.Body.Bytes(), path)
+		assert.Empty(t, w.Header().Get("ETag"), path)
+		assert.Equal(t, version, w.Header().Get(apiVersionHeader), path)
+		assert.Contains(t, w.Header().Get("Cache-Control"), "public", path)
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Range", "bytes=10-19")
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusPartialContent, w.Code, path)
+		assert.Equal(t, video[10:20], w.Body.Bytes(), path)
+		assert.Equal(t, "bytes 10-19/1000", w.Header().Get("Content-Range"), path)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"), path)
+	}
+}
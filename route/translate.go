@@ -1,11 +1,15 @@
 package route
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/schema"
 
+	"github.com/metatube-community/metatube-sdk-go/metrics"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
 	"github.com/metatube-community/metatube-sdk-go/translate"
 	_ "github.com/metatube-community/metatube-sdk-go/translate/baidu"
 	_ "github.com/metatube-community/metatube-sdk-go/translate/deepl"
@@ -27,7 +31,10 @@ type translateResponse struct {
 	Text string `json:"translated_text"`
 }
 
-func getTranslate() gin.HandlerFunc {
+// getTranslate translates the text, the endpoint is public, but the
+// default parameters of translators, e.g. API keys of the operator,
+// are only used for authenticated callers.
+func getTranslate(v auth.Validator) gin.HandlerFunc {
 	decoder := schema.NewDecoder()
 	decoder.SetAliasTag("json")
 	decoder.IgnoreUnknownKeys(true)
@@ -41,13 +48,22 @@ func getTranslate() gin.HandlerFunc {
 			return
 		}
 
+		params := c.Request.URL.Query()
+		if v == nil /* auth disabled */ || hasValidToken(c, v) {
+			params = translate.WithDefaults(query.Engine, params)
+			if v != nil {
+				// never shared with anonymous callers.
+				c.Header("Cache-Control", "private")
+			}
+		}
 		decode := func(v any) error {
-			return decoder.Decode(v, c.Request.URL.Query())
+			return decoder.Decode(v, params)
 		}
 
 		result, err := translate.
-			New(query.Engine, decode).
+			Cached(translate.New(query.Engine, decode), translate.DefaultMemory).
 			Translate(query.Q, query.From, query.To)
+		metrics.ObserveTranslate(query.Engine, err)
 		if err != nil {
 			abortWithError(c, err)
 			return
@@ -62,3 +78,47 @@ func getTranslate() gin.HandlerFunc {
 		})
 	}
 }
+
+type translationMemoryQuery struct {
+	Format string `form:"format"`
+}
+
+func getTranslationMemory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &translationMemoryQuery{Format: translate.FormatTMX}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		buf := &bytes.Buffer{}
+		if err := translate.DefaultMemory.Export(buf, query.Format); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		contentType := "text/csv; charset=utf-8"
+		if query.Format == translate.FormatTMX {
+			contentType = "application/x-tmx+xml; charset=utf-8"
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="translations.%s"`, query.Format))
+		c.Data(http.StatusOK, contentType, buf.Bytes())
+	}
+}
+
+func postTranslationMemory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &translationMemoryQuery{Format: translate.FormatTMX}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		n, err := translate.DefaultMemory.Import(c.Request.Body, query.Format)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: gin.H{
+			"imported": n,
+			"total":    translate.DefaultMemory.Len(),
+		}})
+	}
+}
@@ -0,0 +1,65 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+// keyTranslator translates text into the key it's given.
+type keyTranslator struct {
+	Key string `json:"key"`
+}
+
+func (t *keyTranslator) Translate(text, _, _ string) (string, error) {
+	return text + ":" + t.Key, nil
+}
+
+func init() {
+	translate.Register(&keyTranslator{})
+}
+
+func TestGetTranslateDefaults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	translate.SetDefaults(map[string]map[string]string{"keyTranslator": {"key": "secret"}})
+	t.Cleanup(func() { translate.SetDefaults(nil) })
+
+	for _, unit := range []struct {
+		v     auth.Validator
+		q     string
+		token string
+		want  string
+	}{
+		// anonymous callers never spend the keys of the operator.
+		{auth.Token("u"), "anonymous", "", "anonymous:"},
+		{auth.Token("u"), "invalid", "x", "invalid:"},
+		{auth.Token("u"), "authenticated", "u", "authenticated:secret"},
+		{nil, "disabled", "", "disabled:secret"},
+	} {
+		r := New(engine.Default(), unit.v)
+		query := url.Values{"q": {unit.q}, "to": {"en"}, "engine": {"keyTranslator"}}
+		req := httptest.NewRequest(http.MethodGet, "/v1/translate?"+query.Encode(), nil)
+		if unit.token != "" {
+			req.Header.Set("Authorization", "Bearer "+unit.token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, unit.q)
+
+		var resp struct {
+			Data translateResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, unit.want, resp.Data.Text, unit.q)
+	}
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const webUIPath = "/ui"
+
+//go:embed ui
+var webUIAssets embed.FS
+
+// getWebUI serves the embedded web UI to search and inspect metadata.
+func getWebUI() gin.HandlerFunc {
+	assets, _ := fs.Sub(webUIAssets, "ui")
+	fileServer := http.StripPrefix(webUIPath, http.FileServerFS(assets))
+	return func(c *gin.Context) {
+		if strings.HasSuffix(c.Param("filepath"), ".html") {
+			// index.html is redirected to the directory by the file server.
+			c.Redirect(http.StatusMovedPermanently, webUIPath+"/")
+			return
+		}
+		fileServer.ServeHTTP(c.Writer, c.Request)
+	}
+}
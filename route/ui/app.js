@@ -0,0 +1,161 @@
+'use strict';
+
+const $ = (id) => document.getElementById(id);
+const tokenInput = $('token');
+tokenInput.value = localStorage.getItem('token') || '';
+tokenInput.addEventListener('change', () => localStorage.setItem('token', tokenInput.value));
+
+function showError(err) {
+  const el = $('error');
+  el.textContent = err ? String(err.message || err) : '';
+  el.hidden = !err;
+}
+
+async function api(path, params) {
+  const url = new URL('/v1' + path, location.origin);
+  for (const [k, v] of Object.entries(params || {})) {
+    if (v !== '' && v != null) url.searchParams.set(k, v);
+  }
+  const headers = {};
+  if (tokenInput.value) headers.Authorization = 'Bearer ' + tokenInput.value;
+  const resp = await fetch(url, {headers});
+  const body = await resp.json();
+  if (!resp.ok) throw new Error((body.error && body.error.message) || resp.statusText);
+  return body.data;
+}
+
+function imageURL(type, provider, id, params) {
+  const url = new URL(`/v1/images/${type}/${encodeURIComponent(provider)}/${encodeURIComponent(id)}`, location.origin);
+  for (const [k, v] of Object.entries(params || {})) url.searchParams.set(k, v);
+  return url.toString();
+}
+
+function el(tag, props, ...children) {
+  const e = Object.assign(document.createElement(tag), props);
+  e.append(...children);
+  return e;
+}
+
+// tabs
+document.querySelectorAll('nav button').forEach((btn) => btn.addEventListener('click', () => {
+  document.querySelectorAll('nav button, .tab').forEach((e) => e.classList.remove('active'));
+  btn.classList.add('active');
+  $(btn.dataset.tab).classList.add('active');
+  if (btn.dataset.tab === 'providers') loadProviders().catch(showError);
+}));
+
+// search
+let providers = null;
+
+async function getProviders() {
+  if (!providers) providers = await api('/providers');
+  return providers;
+}
+
+async function fillProviderSelect() {
+  const type = $('search-type').value;
+  const data = await getProviders();
+  const names = Object.keys(type === 'movies' ? data.movie_providers : data.actor_providers).sort();
+  const select = $('search-provider');
+  select.replaceChildren(el('option', {value: '', textContent: 'All providers'}),
+    ...names.map((name) => el('option', {value: name, textContent: name})));
+}
+
+$('search-type').addEventListener('change', () => fillProviderSelect().catch(showError));
+
+$('search-form').addEventListener('submit', async (ev) => {
+  ev.preventDefault();
+  showError(null);
+  const type = $('search-type').value;
+  const results = $('search-results');
+  results.replaceChildren();
+  $('detail').hidden = true;
+  try {
+    const data = await api(`/${type}/search`, {q: $('search-q').value, provider: $('search-provider').value});
+    results.append(...data.map((r) => {
+      const thumb = type === 'movies' ? r.thumb_url : (r.images || [])[0];
+      const card = el('div', {className: 'card'},
+        el('img', {src: thumb || '', loading: 'lazy', alt: ''}),
+        el('div', {textContent: type === 'movies' ? `${r.number} ${r.title}` : r.name}),
+        el('small', {textContent: `${r.provider} / ${r.id}`}));
+      card.addEventListener('click', () => showDetail(type, r).catch(showError));
+      return card;
+    }));
+  } catch (err) {
+    showError(err);
+  }
+});
+
+let current = null;
+
+async function showDetail(type, result) {
+  showError(null);
+  const info = await api(`/${type}/${encodeURIComponent(result.provider)}/${encodeURIComponent(result.id)}`);
+  current = {type, info};
+  $('detail').hidden = false;
+  $('detail-title').textContent = type === 'movies' ? `${info.number} ${info.title}` : info.name;
+  $('detail-json').textContent = JSON.stringify(info, null, 2);
+  $('crop').hidden = type !== 'movies';
+  updateCrop();
+  $('detail').scrollIntoView({behavior: 'smooth'});
+}
+
+function updateCrop() {
+  if (!current || current.type !== 'movies') return;
+  const {provider, id} = current.info;
+  const params = {pos: $('crop-pos').value, auto: $('crop-auto').checked};
+  $('crop-primary').src = imageURL('primary', provider, id, {...params, fit: 'crop'});
+  $('crop-letterbox').src = imageURL('primary', provider, id, {fit: 'letterbox'});
+  $('crop-backdrop').src = imageURL('backdrop', provider, id, {});
+}
+
+$('crop-pos').addEventListener('change', updateCrop);
+$('crop-auto').addEventListener('change', updateCrop);
+
+// translate
+$('translate-form').addEventListener('submit', async (ev) => {
+  ev.preventDefault();
+  showError(null);
+  const params = Object.fromEntries(new URLSearchParams($('translate-params').value));
+  try {
+    const data = await api('/translate', {
+      ...params,
+      q: $('translate-q').value,
+      engine: $('translate-engine').value,
+      from: $('translate-from').value,
+      to: $('translate-to').value,
+    });
+    $('translate-result').textContent = data.translated_text;
+  } catch (err) {
+    showError(err);
+  }
+});
+
+// providers
+async function loadProviders(health) {
+  const data = await getProviders();
+  const rows = [];
+  for (const [type, list] of [['movie', data.movie_providers], ['actor', data.actor_providers]]) {
+    for (const name of Object.keys(list).sort()) {
+      const status = health ? health[name] : null;
+      rows.push(el('tr', {},
+        el('td', {textContent: name}),
+        el('td', {textContent: type}),
+        el('td', {}, el('a', {href: list[name], textContent: list[name], target: '_blank', rel: 'noreferrer'})),
+        el('td', status ? {className: status.ok ? 'ok' : 'failed', textContent: status.ok ? 'OK' : status.error} : {textContent: '-'})));
+    }
+  }
+  $('providers-list').replaceChildren(...rows);
+}
+
+$('providers-check').addEventListener('click', async () => {
+  showError(null);
+  try {
+    const data = await api('/admin/providers/health');
+    loadProviders(Object.fromEntries(data.map((h) => [h.name, h]))).catch(showError);
+  } catch (err) {
+    showError(err);
+  }
+});
+
+fillProviderSelect().catch(showError);
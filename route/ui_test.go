@@ -0,0 +1,38 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+)
+
+func TestWebUI(t *testing.T) {
+	r := New(engine.Default(), nil, WithWebUI())
+
+	for _, unit := range []struct {
+		path        string
+		code        int
+		contentType string
+	}{
+		{"/ui/", http.StatusOK, "text/html; charset=utf-8"},
+		{"/ui/app.js", http.StatusOK, "text/javascript; charset=utf-8"},
+		{"/ui/style.css", http.StatusOK, "text/css; charset=utf-8"},
+		{"/ui/index.html", http.StatusMovedPermanently, ""},
+		{"/ui/unknown.js", http.StatusNotFound, ""},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, unit.path, nil))
+		assert.Equal(t, unit.code, w.Code, unit.path)
+		if unit.contentType != "" {
+			assert.Equal(t, unit.contentType, w.Header().Get("Content-Type"), unit.path)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	New(engine.Default(), nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ui/", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
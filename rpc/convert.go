@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/metatube-community/metatube-sdk-go/datatypes"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/rpc/pb"
+)
+
+func toMovieSearchResult(result *model.MovieSearchResult) *pb.MovieSearchResult {
+	return &pb.MovieSearchResult{
+		Id:          result.ID,
+		Number:      result.Number,
+		Title:       result.Title,
+		Provider:    result.Provider,
+		Homepage:    result.Homepage,
+		ThumbUrl:    result.ThumbURL,
+		CoverUrl:    result.CoverURL,
+		Score:       result.Score,
+		Actors:      result.Actors,
+		ReleaseDate: formatDate(result.ReleaseDate),
+	}
+}
+
+func toActorSearchResult(result *model.ActorSearchResult) *pb.ActorSearchResult {
+	return &pb.ActorSearchResult{
+		Id:       result.ID,
+		Name:     result.Name,
+		Provider: result.Provider,
+		Homepage: result.Homepage,
+		Aliases:  result.Aliases,
+		Images:   result.Images,
+	}
+}
+
+func toMovieInfo(info *model.MovieInfo) *pb.MovieInfo {
+	return &pb.MovieInfo{
+		Id:                 info.ID,
+		Number:             info.Number,
+		Title:              info.Title,
+		Summary:            info.Summary,
+		Provider:           info.Provider,
+		Homepage:           info.Homepage,
+		Director:           info.Director,
+		Actors:             info.Actors,
+		ThumbUrl:           info.ThumbURL,
+		BigThumbUrl:        info.BigThumbURL,
+		CoverUrl:           info.CoverURL,
+		BigCoverUrl:        info.BigCoverURL,
+		PreviewVideoUrl:    info.PreviewVideoURL,
+		PreviewVideoHlsUrl: info.PreviewVideoHLSURL,
+		PreviewImages:      info.PreviewImages,
+		Maker:              info.Maker,
+		Label:              info.Label,
+		Series:             info.Series,
+		Genres:             info.Genres,
+		Score:              info.Score,
+		Runtime:            int32(info.Runtime),
+		ReleaseDate:        formatDate(info.ReleaseDate),
+		Vr:                 toMovieVRInfo(info.VR),
+	}
+}
+
+func toMovieVRInfo(vr *model.MovieVRInfo) *pb.MovieVRInfo {
+	if vr == nil {
+		return nil
+	}
+	return &pb.MovieVRInfo{
+		HighQuality: vr.HighQuality,
+		Resolution:  vr.Resolution,
+		Devices:     vr.Devices,
+	}
+}
+
+func toActorInfo(info *model.ActorInfo) *pb.ActorInfo {
+	return &pb.ActorInfo{
+		Id:           info.ID,
+		Name:         info.Name,
+		Provider:     info.Provider,
+		Homepage:     info.Homepage,
+		Summary:      info.Summary,
+		Hobby:        info.Hobby,
+		Skill:        info.Skill,
+		BloodType:    info.BloodType,
+		CupSize:      info.CupSize,
+		Measurements: info.Measurements,
+		Nationality:  info.Nationality,
+		Height:       int32(info.Height),
+		Aliases:      info.Aliases,
+		Images:       info.Images,
+		Birthday:     formatDate(info.Birthday),
+		DebutDate:    formatDate(info.DebutDate),
+	}
+}
+
+func formatDate(d datatypes.Date) string {
+	if t := time.Time(d); !t.IsZero() {
+		return t.Format(time.DateOnly)
+	}
+	return ""
+}
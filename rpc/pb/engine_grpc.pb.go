@@ -0,0 +1,360 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: engine.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Engine_Search_FullMethodName        = "/metatube.v1.Engine/Search"
+	Engine_BatchSearch_FullMethodName   = "/metatube.v1.Engine/BatchSearch"
+	Engine_GetMovie_FullMethodName      = "/metatube.v1.Engine/GetMovie"
+	Engine_BatchGetMovie_FullMethodName = "/metatube.v1.Engine/BatchGetMovie"
+	Engine_GetActor_FullMethodName      = "/metatube.v1.Engine/GetActor"
+	Engine_Translate_FullMethodName     = "/metatube.v1.Engine/Translate"
+	Engine_ProcessImage_FullMethodName  = "/metatube.v1.Engine/ProcessImage"
+)
+
+// EngineClient is the client API for Engine service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Engine exposes the metadata engine to non-HTTP consumers.
+type EngineClient interface {
+	// Search searches actors or movies by keyword.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// BatchSearch searches multiple keywords, results are streamed
+	// back as soon as each search completes.
+	BatchSearch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SearchRequest, SearchResponse], error)
+	// GetMovie gets movie info by provider and id.
+	GetMovie(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*MovieInfo, error)
+	// BatchGetMovie gets multiple movie infos in a stream.
+	BatchGetMovie(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GetInfoRequest, MovieInfo], error)
+	// GetActor gets actor info by provider and id.
+	GetActor(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*ActorInfo, error)
+	// Translate translates text with the given engine.
+	Translate(ctx context.Context, in *TranslateRequest, opts ...grpc.CallOption) (*TranslateResponse, error)
+	// ProcessImage returns the processed primary, thumb or backdrop
+	// image of a movie or actor, encoded as JPEG.
+	ProcessImage(ctx context.Context, in *ProcessImageRequest, opts ...grpc.CallOption) (*ProcessImageResponse, error)
+}
+
+type engineClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEngineClient(cc grpc.ClientConnInterface) EngineClient {
+	return &engineClient{cc}
+}
+
+func (c *engineClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, Engine_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) BatchSearch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SearchRequest, SearchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Engine_ServiceDesc.Streams[0], Engine_BatchSearch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchRequest, SearchResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Engine_BatchSearchClient = grpc.BidiStreamingClient[SearchRequest, SearchResponse]
+
+func (c *engineClient) GetMovie(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*MovieInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MovieInfo)
+	err := c.cc.Invoke(ctx, Engine_GetMovie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) BatchGetMovie(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GetInfoRequest, MovieInfo], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Engine_ServiceDesc.Streams[1], Engine_BatchGetMovie_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetInfoRequest, MovieInfo]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Engine_BatchGetMovieClient = grpc.BidiStreamingClient[GetInfoRequest, MovieInfo]
+
+func (c *engineClient) GetActor(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*ActorInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActorInfo)
+	err := c.cc.Invoke(ctx, Engine_GetActor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) Translate(ctx context.Context, in *TranslateRequest, opts ...grpc.CallOption) (*TranslateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslateResponse)
+	err := c.cc.Invoke(ctx, Engine_Translate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) ProcessImage(ctx context.Context, in *ProcessImageRequest, opts ...grpc.CallOption) (*ProcessImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessImageResponse)
+	err := c.cc.Invoke(ctx, Engine_ProcessImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EngineServer is the server API for Engine service.
+// All implementations must embed UnimplementedEngineServer
+// for forward compatibility.
+//
+// Engine exposes the metadata engine to non-HTTP consumers.
+type EngineServer interface {
+	// Search searches actors or movies by keyword.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// BatchSearch searches multiple keywords, results are streamed
+	// back as soon as each search completes.
+	BatchSearch(grpc.BidiStreamingServer[SearchRequest, SearchResponse]) error
+	// GetMovie gets movie info by provider and id.
+	GetMovie(context.Context, *GetInfoRequest) (*MovieInfo, error)
+	// BatchGetMovie gets multiple movie infos in a stream.
+	BatchGetMovie(grpc.BidiStreamingServer[GetInfoRequest, MovieInfo]) error
+	// GetActor gets actor info by provider and id.
+	GetActor(context.Context, *GetInfoRequest) (*ActorInfo, error)
+	// Translate translates text with the given engine.
+	Translate(context.Context, *TranslateRequest) (*TranslateResponse, error)
+	// ProcessImage returns the processed primary, thumb or backdrop
+	// image of a movie or actor, encoded as JPEG.
+	ProcessImage(context.Context, *ProcessImageRequest) (*ProcessImageResponse, error)
+	mustEmbedUnimplementedEngineServer()
+}
+
+// UnimplementedEngineServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEngineServer struct{}
+
+func (UnimplementedEngineServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedEngineServer) BatchSearch(grpc.BidiStreamingServer[SearchRequest, SearchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchSearch not implemented")
+}
+func (UnimplementedEngineServer) GetMovie(context.Context, *GetInfoRequest) (*MovieInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMovie not implemented")
+}
+func (UnimplementedEngineServer) BatchGetMovie(grpc.BidiStreamingServer[GetInfoRequest, MovieInfo]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchGetMovie not implemented")
+}
+func (UnimplementedEngineServer) GetActor(context.Context, *GetInfoRequest) (*ActorInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActor not implemented")
+}
+func (UnimplementedEngineServer) Translate(context.Context, *TranslateRequest) (*TranslateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Translate not implemented")
+}
+func (UnimplementedEngineServer) ProcessImage(context.Context, *ProcessImageRequest) (*ProcessImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessImage not implemented")
+}
+func (UnimplementedEngineServer) mustEmbedUnimplementedEngineServer() {}
+func (UnimplementedEngineServer) testEmbeddedByValue()                {}
+
+// UnsafeEngineServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EngineServer will
+// result in compilation errors.
+type UnsafeEngineServer interface {
+	mustEmbedUnimplementedEngineServer()
+}
+
+func RegisterEngineServer(s grpc.ServiceRegistrar, srv EngineServer) {
+	// If the following call pancis, it indicates UnimplementedEngineServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Engine_ServiceDesc, srv)
+}
+
+func _Engine_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_BatchSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EngineServer).BatchSearch(&grpc.GenericServerStream[SearchRequest, SearchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Engine_BatchSearchServer = grpc.BidiStreamingServer[SearchRequest, SearchResponse]
+
+func _Engine_GetMovie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).GetMovie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_GetMovie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).GetMovie(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_BatchGetMovie_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EngineServer).BatchGetMovie(&grpc.GenericServerStream[GetInfoRequest, MovieInfo]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Engine_BatchGetMovieServer = grpc.BidiStreamingServer[GetInfoRequest, MovieInfo]
+
+func _Engine_GetActor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).GetActor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_GetActor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).GetActor(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_Translate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).Translate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_Translate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).Translate(ctx, req.(*TranslateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_ProcessImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).ProcessImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Engine_ProcessImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).ProcessImage(ctx, req.(*ProcessImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Engine_ServiceDesc is the grpc.ServiceDesc for Engine service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Engine_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "metatube.v1.Engine",
+	HandlerType: (*EngineServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _Engine_Search_Handler,
+		},
+		{
+			MethodName: "GetMovie",
+			Handler:    _Engine_GetMovie_Handler,
+		},
+		{
+			MethodName: "GetActor",
+			Handler:    _Engine_GetActor_Handler,
+		},
+		{
+			MethodName: "Translate",
+			Handler:    _Engine_Translate_Handler,
+		},
+		{
+			MethodName: "ProcessImage",
+			Handler:    _Engine_ProcessImage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchSearch",
+			Handler:       _Engine_BatchSearch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BatchGetMovie",
+			Handler:       _Engine_BatchGetMovie_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "engine.proto",
+}
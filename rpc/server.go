@@ -0,0 +1,333 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	goerr "errors"
+	"image"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/badge"
+	"github.com/metatube-community/metatube-sdk-go/metrics"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/rpc/pb"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+const jpegImageMIMEType = "image/jpeg"
+
+var _ pb.EngineServer = (*Server)(nil)
+
+// Server implements the gRPC Engine service.
+type Server struct {
+	pb.UnimplementedEngineServer
+	app *engine.Engine
+}
+
+// New returns a gRPC server with the Engine service registered, token
+// authentication is enabled if the validator is not nil.
+func New(app *engine.Engine, v auth.Validator, opts ...grpc.ServerOption) *grpc.Server {
+	if v != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(unaryAuthentication(v)),
+			grpc.ChainStreamInterceptor(streamAuthentication(v)))
+	}
+	s := grpc.NewServer(opts...)
+	pb.RegisterEngineServer(s, &Server{app: app})
+	return s
+}
+
+func (s *Server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	resp, err := s.search(s.app.WithContext(ctx), req)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return resp, nil
+}
+
+func (s *Server) BatchSearch(stream grpc.BidiStreamingServer[pb.SearchRequest, pb.SearchResponse]) error {
+	app := s.app.WithContext(stream.Context())
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp, err := s.search(app, req)
+		if err != nil {
+			resp = &pb.SearchResponse{Keyword: req.GetKeyword(), Error: err.Error()}
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) search(app *engine.Engine, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	resp := &pb.SearchResponse{Keyword: req.GetKeyword()}
+	switch req.GetType() {
+	case pb.SearchType_SEARCH_TYPE_ACTOR:
+		var (
+			results []*model.ActorSearchResult
+			err     error
+		)
+		if req.GetProvider() != "" {
+			results, err = app.SearchActor(req.GetKeyword(), req.GetProvider(), req.GetFallback())
+		} else {
+			results, err = app.SearchActorAll(req.GetKeyword(), req.GetFallback())
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			resp.Actors = append(resp.Actors, toActorSearchResult(result))
+		}
+	default:
+		var (
+			results []*model.MovieSearchResult
+			err     error
+		)
+		if req.GetProvider() != "" {
+			results, err = app.SearchMovie(req.GetKeyword(), req.GetProvider(), req.GetFallback())
+		} else {
+			results, err = app.SearchMovieAll(req.GetKeyword(), req.GetFallback())
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			resp.Movies = append(resp.Movies, toMovieSearchResult(result))
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) GetMovie(ctx context.Context, req *pb.GetInfoRequest) (*pb.MovieInfo, error) {
+	info, err := s.app.WithContext(ctx).GetMovieInfoByProviderID(req.GetProvider(), req.GetId(), req.GetLazy())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toMovieInfo(info), nil
+}
+
+func (s *Server) BatchGetMovie(stream grpc.BidiStreamingServer[pb.GetInfoRequest, pb.MovieInfo]) error {
+	app := s.app.WithContext(stream.Context())
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var resp *pb.MovieInfo
+		if info, err := app.GetMovieInfoByProviderID(req.GetProvider(), req.GetId(), req.GetLazy()); err != nil {
+			resp = &pb.MovieInfo{Provider: req.GetProvider(), Id: req.GetId(), Error: err.Error()}
+		} else {
+			resp = toMovieInfo(info)
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) GetActor(ctx context.Context, req *pb.GetInfoRequest) (*pb.ActorInfo, error) {
+	info, err := s.app.WithContext(ctx).GetActorInfoByProviderID(req.GetProvider(), req.GetId(), req.GetLazy())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toActorInfo(info), nil
+}
+
+func (s *Server) Translate(_ context.Context, req *pb.TranslateRequest) (*pb.TranslateResponse, error) {
+	if req.GetQ() == "" || req.GetTo() == "" || req.GetEngine() == "" {
+		return nil, status.Error(codes.InvalidArgument, "q, to and engine are required")
+	}
+	from := req.GetFrom()
+	if from == "" {
+		from = "auto"
+	}
+
+	decoder := schema.NewDecoder()
+	decoder.SetAliasTag("json")
+	decoder.IgnoreUnknownKeys(true)
+	values := make(map[string][]string, len(req.GetConfig()))
+	for k, v := range req.GetConfig() {
+		values[k] = []string{v}
+	}
+
+	result, err := translate.
+		Cached(translate.New(req.GetEngine(), func(v any) error { return decoder.Decode(v, translate.WithDefaults(req.GetEngine(), values)) }), translate.DefaultMemory).
+		Translate(req.GetQ(), from, req.GetTo())
+	metrics.ObserveTranslate(req.GetEngine(), err)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.TranslateResponse{Result: result, From: from, To: req.GetTo()}, nil
+}
+
+func (s *Server) ProcessImage(ctx context.Context, req *pb.ProcessImageRequest) (*pb.ProcessImageResponse, error) {
+	app := s.app.WithContext(ctx)
+	var (
+		img image.Image
+		err error
+	)
+	switch {
+	case app.IsActorProvider(req.GetProvider()):
+		if req.GetType() != pb.ImageType_IMAGE_TYPE_PRIMARY {
+			return nil, status.Error(codes.InvalidArgument, "unsupported image type")
+		}
+		img, err = app.GetActorPrimaryImage(req.GetProvider(), req.GetId())
+	case app.IsMovieProvider(req.GetProvider()):
+		switch req.GetType() {
+		case pb.ImageType_IMAGE_TYPE_PRIMARY:
+			ratio, pos := req.GetRatio(), req.GetPosition()
+			if ratio == 0 {
+				ratio = -1
+			}
+			if pos == 0 {
+				pos = -1
+			}
+			img, err = app.GetMoviePrimaryImage(req.GetProvider(), req.GetId(), ratio, pos)
+		case pb.ImageType_IMAGE_TYPE_THUMB:
+			img, err = app.GetMovieThumbImage(req.GetProvider(), req.GetId())
+		case pb.ImageType_IMAGE_TYPE_BACKDROP:
+			img, err = app.GetMovieBackdropImage(req.GetProvider(), req.GetId())
+		default:
+			return nil, status.Error(codes.InvalidArgument, "unsupported image type")
+		}
+	default:
+		return nil, toStatusError(mt.ErrProviderNotFound)
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if req.GetBadge() != "" {
+		if img, err = badge.Badge(img, req.GetBadge()); err != nil {
+			return nil, toStatusError(err)
+		}
+	}
+
+	quality := int(req.GetQuality())
+	if quality <= 0 {
+		quality = 90
+	}
+	buf := &bytes.Buffer{}
+	if err = imageutil.EncodeToJPEG(buf, img, quality); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.ProcessImageResponse{
+		Data:        buf.Bytes(),
+		ContentType: jpegImageMIMEType,
+		Width:       int32(img.Bounds().Dx()),
+		Height:      int32(img.Bounds().Dy()),
+	}, nil
+}
+
+func unaryAuthentication(v auth.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		token, err := authenticate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		if err = allow(v, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthentication(v auth.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		token, err := authenticate(ss.Context(), v)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &limitedStream{ServerStream: ss, validator: v, token: token})
+	}
+}
+
+// limitedStream accounts every received message as a request, so that
+// batch streams never bypass the quotas of the key.
+type limitedStream struct {
+	grpc.ServerStream
+	validator auth.Validator
+	token     string
+}
+
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return allow(s.validator, s.token)
+}
+
+// authenticate validates the bearer token in the authorization metadata,
+// the same as the HTTP authentication, and returns the token.
+func authenticate(ctx context.Context, v auth.Validator) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		if bearer, token, found := strings.Cut(header, " "); bearer == "Bearer" && found && v.Valid(token) {
+			return token, nil
+		}
+	}
+	return "", status.Error(codes.Unauthenticated, http.StatusText(http.StatusUnauthorized))
+}
+
+// allow accounts a request of the token if the validator enforces quotas.
+func allow(v auth.Validator, token string) error {
+	if l, ok := v.(auth.Limiter); ok {
+		if _, ok := l.Allow(token); !ok {
+			return status.Error(codes.ResourceExhausted, http.StatusText(http.StatusTooManyRequests))
+		}
+	}
+	return nil
+}
+
+// toStatusError converts HTTP errors to gRPC status errors.
+func toStatusError(err error) error {
+	statusCode := errors.StatusCode(err)
+	var e *errors.HTTPError
+	if goerr.As(err, &e) {
+		statusCode = e.Code
+	}
+	code := codes.Unknown
+	switch statusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		code = codes.Internal
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/rpc/pb"
+)
+
+const testToken = "secret"
+
+func newTestClient(t *testing.T) pb.EngineClient {
+	return newTestClientWithValidator(t, auth.Token(testToken))
+}
+
+func newTestClientWithValidator(t *testing.T, v auth.Validator) pb.EngineClient {
+	db, err := database.Open(&database.Config{DSN: "file:rpc_test?mode=memory&cache=shared"})
+	require.NoError(t, err)
+	app := engine.New(engine.WithDB(db))
+	require.NoError(t, app.DBAutoMigrate(true))
+	require.NoError(t, db.Save(&model.MovieInfo{
+		ID: "rpc00001", Number: "RPC-001", Title: "Title", Provider: "FANZA",
+		Homepage: "https://example.com/rpc00001", CoverURL: "https://example.com/rpc00001.jpg",
+	}).Error)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := New(app, v)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewEngineClient(conn)
+}
+
+func authorized(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+testToken)
+}
+
+func TestServer_Authentication(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.GetMovie(context.Background(), &pb.GetInfoRequest{Provider: "FANZA", Id: "rpc00001", Lazy: true})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = client.GetMovie(ctx, &pb.GetInfoRequest{Provider: "FANZA", Id: "rpc00001", Lazy: true})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.BatchGetMovie(context.Background())
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_GetMovie(t *testing.T) {
+	client := newTestClient(t)
+	ctx := authorized(context.Background())
+
+	info, err := client.GetMovie(ctx, &pb.GetInfoRequest{Provider: "FANZA", Id: "rpc00001", Lazy: true})
+	require.NoError(t, err)
+	assert.Equal(t, "RPC-001", info.GetNumber())
+	assert.Equal(t, "Title", info.GetTitle())
+
+	_, err = client.GetMovie(ctx, &pb.GetInfoRequest{Provider: "unknown", Id: "1", Lazy: true})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetActor(ctx, &pb.GetInfoRequest{Provider: "unknown", Id: "1", Lazy: true})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_BatchGetMovie(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.BatchGetMovie(authorized(context.Background()))
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.GetInfoRequest{Provider: "FANZA", Id: "rpc00001", Lazy: true}))
+	require.NoError(t, stream.Send(&pb.GetInfoRequest{Provider: "unknown", Id: "1", Lazy: true}))
+	require.NoError(t, stream.CloseSend())
+
+	info, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "RPC-001", info.GetNumber())
+	assert.Empty(t, info.GetError())
+
+	// errors of single requests never break the stream.
+	info, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", info.GetProvider())
+	assert.NotEmpty(t, info.GetError())
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestServer_BatchGetMovieQuota(t *testing.T) {
+	client := newTestClientWithValidator(t, auth.NewKeyStore(&auth.Key{Name: "alice", Token: testToken, Quota: 2}))
+
+	stream, err := client.BatchGetMovie(authorized(context.Background()))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, stream.Send(&pb.GetInfoRequest{Provider: "FANZA", Id: "rpc00001", Lazy: true}))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	// every message is accounted, the stream ends once the quota runs out.
+	for i := 0; i < 2; i++ {
+		info, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "RPC-001", info.GetNumber())
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestServer_Translate(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.Translate(authorized(context.Background()), &pb.TranslateRequest{Q: "q"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ProcessImage(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.ProcessImage(authorized(context.Background()), &pb.ProcessImageRequest{Provider: "unknown", Id: "1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	_ Store   = (*Bolt)(nil)
+	_ Sweeper = (*Bolt)(nil)
+)
+
+var boltBucket = []byte("cache")
+
+// Bolt is an embedded store in a BoltDB file, entries are encoded the
+// same as File. It survives restarts of single instances, and keeps
+// many small entries in one file.
+type Bolt struct {
+	db *bbolt.DB
+}
+
+// NewBolt opens the BoltDB store of the file, which is created along
+// with its directory if it does not exist.
+func NewBolt(path string) (*Bolt, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	// the file is locked by one process only.
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Bolt{db: db}, nil
+}
+
+func (b *Bolt) Get(key string) (value []byte, err error) {
+	var expired bool
+	err = b.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(boltBucket).Get([]byte(key))
+		if data == nil {
+			return ErrNotFound
+		}
+		v, ok := decodeEntry(data, time.Now())
+		if !ok {
+			expired = true
+			return ErrNotFound
+		}
+		// data is only valid in the transaction.
+		value = append([]byte(nil), v...)
+		return nil
+	})
+	if expired {
+		_ = b.Delete(key) // ignore error.
+	}
+	return
+}
+
+func (b *Bolt) Set(key string, value []byte, ttl time.Duration) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Put([]byte(key), encodeEntry(value, ttl))
+	})
+}
+
+func (b *Bolt) Delete(key string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete([]byte(key))
+	})
+}
+
+// DeleteExpired deletes all expired and corrupted entries.
+func (b *Bolt) DeleteExpired() error {
+	now := time.Now()
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		var expired [][]byte
+		if err := bucket.ForEach(func(k, v []byte) error {
+			if _, ok := decodeEntry(v, now); !ok {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close closes the BoltDB file.
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
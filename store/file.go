@@ -0,0 +1,145 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	_ Store   = (*File)(nil)
+	_ Sweeper = (*File)(nil)
+)
+
+// File is an on-disk store, each entry is a file named by the hash of
+// its key, prefixed with its expiration time. It needs no extra
+// dependencies, and survives restarts of single instances.
+type File struct {
+	dir string
+}
+
+// NewFile returns a file store in the directory, which is created if
+// it does not exist.
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir}, nil
+}
+
+func (f *File) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(f.dir, name[:2], name)
+}
+
+func (f *File) Get(key string) ([]byte, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	value, ok := decodeEntry(data, time.Now())
+	if !ok {
+		_ = os.Remove(f.path(key)) // ignore error.
+		return nil, ErrNotFound
+	}
+	return value, nil
+}
+
+func (f *File) Set(key string, value []byte, ttl time.Duration) error {
+	path := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data := encodeEntry(value, ttl)
+
+	// write atomically, so that readers never see partial entries.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *File) Delete(key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeleteExpired deletes all expired and corrupted entries, only their
+// headers are read.
+func (f *File) DeleteExpired() error {
+	now := time.Now()
+	return filepath.WalkDir(f.dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil // deleted meanwhile.
+		}
+		if err != nil || d.IsDir() || len(d.Name()) != sha256.Size*2 {
+			return err // temporary files are removed by their writers.
+		}
+		file, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		header := make([]byte, entryHeaderSize)
+		_, err = io.ReadFull(file, header)
+		file.Close()
+		if err == nil && !entryExpired(header, now) {
+			return nil
+		}
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	})
+}
+
+// entryHeaderSize is the size of the expiration time of an entry,
+// Unix nanoseconds in big endian, zero if it never expires.
+const entryHeaderSize = 8
+
+func encodeEntry(value []byte, ttl time.Duration) []byte {
+	var expiry int64
+	if ttl > 0 {
+		expiry = time.Now().Add(ttl).UnixNano()
+	}
+	data := make([]byte, entryHeaderSize, entryHeaderSize+len(value))
+	binary.BigEndian.PutUint64(data, uint64(expiry))
+	return append(data, value...)
+}
+
+// decodeEntry returns the value of the entry, ok is false if the entry
+// is expired or corrupted.
+func decodeEntry(data []byte, now time.Time) (value []byte, ok bool) {
+	if len(data) < entryHeaderSize || entryExpired(data, now) {
+		return nil, false
+	}
+	return data[entryHeaderSize:], true
+}
+
+func entryExpired(header []byte, now time.Time) bool {
+	expiry := int64(binary.BigEndian.Uint64(header))
+	return expiry > 0 && now.UnixNano() > expiry
+}
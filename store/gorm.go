@@ -0,0 +1,70 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	_ Store   = (*GORM)(nil)
+	_ Sweeper = (*GORM)(nil)
+)
+
+// cacheEntry is a row of the GORM store.
+type cacheEntry struct {
+	Key       string `gorm:"primaryKey"`
+	Value     []byte
+	ExpiresAt *time.Time `gorm:"index"`
+}
+
+func (*cacheEntry) TableName() string { return "cache_entries" }
+
+// GORM is a store in the database, e.g. the one of the engine.
+type GORM struct {
+	db *gorm.DB
+}
+
+// NewGORM returns a GORM store, its table is migrated automatically.
+func NewGORM(db *gorm.DB) (*GORM, error) {
+	if err := db.AutoMigrate(&cacheEntry{}); err != nil {
+		return nil, err
+	}
+	return &GORM{db: db}, nil
+}
+
+func (g *GORM) Get(key string) ([]byte, error) {
+	entry := &cacheEntry{}
+	err := g.db.Where(map[string]any{"key": key}).First(entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.ExpiresAt != nil && time.Now().After(*entry.ExpiresAt) {
+		_ = g.Delete(key) // ignore error.
+		return nil, ErrNotFound
+	}
+	return entry.Value, nil
+}
+
+func (g *GORM) Set(key string, value []byte, ttl time.Duration) error {
+	entry := &cacheEntry{Key: key, Value: value}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+	return g.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(entry).Error
+}
+
+func (g *GORM) Delete(key string) error {
+	return g.db.Where(map[string]any{"key": key}).Delete(&cacheEntry{}).Error
+}
+
+// DeleteExpired deletes all expired entries.
+func (g *GORM) DeleteExpired() error {
+	return g.db.Where("expires_at < ?", time.Now()).Delete(&cacheEntry{}).Error
+}
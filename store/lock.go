@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// LockPollInterval is the first interval of polling locks held by
+// others, it is doubled by each poll up to MaxLockPollInterval.
+var (
+	LockPollInterval    = 200 * time.Millisecond
+	MaxLockPollInterval = 5 * time.Second
+)
+
+// Locker is implemented by stores that support locks, e.g. Redis, so
+// that replicas sharing the store never do the same work twice.
+type Locker interface {
+	// TryLock acquires the lock of the key for at most ttl without
+	// blocking, and reports whether it is acquired.
+	TryLock(key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// Acquire acquires the lock of the key, or waits until done reports the
+// work was done by the holder, the lock expires or ctx is done. The
+// returned unlock is nil if the lock is not acquired, errors of the
+// locker are ignored, i.e. the work is done without the lock.
+func Acquire(ctx context.Context, l Locker, key string, ttl time.Duration, done func() bool) (unlock func()) {
+	deadline := time.Now().Add(ttl)
+	interval := LockPollInterval
+	for {
+		unlock, ok, err := l.TryLock(key, ttl)
+		if err != nil {
+			return nil
+		}
+		if ok {
+			return unlock
+		}
+		if done() || time.Now().After(deadline) {
+			return nil
+		}
+		timer := time.NewTimer(min(interval, time.Until(deadline)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		interval = min(2*interval, MaxLockPollInterval)
+	}
+}
+
+func lockToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+// DefaultMemoryCapacity is the default max number of entries of
+// the memory store.
+const DefaultMemoryCapacity = 10000
+
+var (
+	_ Store    = (*Memory)(nil)
+	_ Locker   = (*Memory)(nil)
+	_ Reserver = (*Memory)(nil)
+)
+
+// Memory is an in-memory store, the least recently used entries
+// are evicted once its capacity is reached.
+type Memory struct {
+	cache *ttlcache.Cache[string, []byte]
+	locks *ttlcache.Cache[string, string]
+	slots reservations
+}
+
+// NewMemory returns a memory store, zero capacity means unlimited.
+func NewMemory(capacity uint64) *Memory {
+	return &Memory{
+		cache: ttlcache.New[string, []byte](
+			ttlcache.WithCapacity[string, []byte](capacity),
+			ttlcache.WithDisableTouchOnHit[string, []byte]()),
+		locks: ttlcache.New[string, string](
+			ttlcache.WithDisableTouchOnHit[string, string]()),
+	}
+}
+
+func (m *Memory) Get(key string) ([]byte, error) {
+	item := m.cache.Get(key)
+	if item == nil || item.IsExpired() {
+		return nil, ErrNotFound
+	}
+	return item.Value(), nil
+}
+
+func (m *Memory) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = ttlcache.NoTTL
+	}
+	m.cache.Set(key, value, ttl)
+	return nil
+}
+
+func (m *Memory) Delete(key string) error {
+	m.cache.Delete(key)
+	return nil
+}
+
+func (m *Memory) TryLock(key string, ttl time.Duration) (func(), bool, error) {
+	token := lockToken()
+	if _, loaded := m.locks.GetOrSet(key, token, ttlcache.WithTTL[string, string](ttl)); loaded {
+		return nil, false, nil // expired ones are replaced.
+	}
+	return func() {
+		if item := m.locks.Get(key); item != nil && item.Value() == token {
+			m.locks.Delete(key)
+		}
+	}, true, nil
+}
+
+func (m *Memory) Reserve(key string, interval time.Duration) (time.Duration, error) {
+	return m.slots.reserve(key, interval), nil
+}
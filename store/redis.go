@@ -0,0 +1,289 @@
+package store
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRedisTimeout is the default timeout of Redis commands.
+const DefaultRedisTimeout = 5 * time.Second
+
+// maxRedisIdleConns is the max number of pooled idle connections.
+const maxRedisIdleConns = 16
+
+var (
+	_ Store    = (*Redis)(nil)
+	_ Locker   = (*Redis)(nil)
+	_ Reserver = (*Redis)(nil)
+)
+
+// unlockScript deletes the lock only if it's still held by the token.
+const unlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`
+
+// Redis is a Redis store, shared by all replicas of the server. It
+// speaks the RESP protocol directly, only simple commands are used.
+// It talks to a single node, i.e. neither Redis Cluster nor Sentinel
+// is supported, and connections are encrypted by TLS only if the URL
+// is of the rediss scheme.
+type Redis struct {
+	addr     string
+	username string
+	password string
+	db       int
+	timeout  time.Duration
+	// TLS config of connections, nil if unencrypted.
+	tls  *tls.Config
+	idle chan *redisConn
+}
+
+// NewRedis returns a Redis store of the URL, i.e.
+// redis[s]://[[user]:password@]host[:port][/db], URL options are
+// rejected as none is supported.
+func NewRedis(rawURL string) (*Redis, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "redis" && u.Scheme != "rediss") || u.Hostname() == "" {
+		return nil, fmt.Errorf("store: invalid redis url: %s", rawURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("store: unsupported redis url options: %s", u.RawQuery+u.Fragment)
+	}
+	r := &Redis{
+		addr:    u.Host,
+		timeout: DefaultRedisTimeout,
+		idle:    make(chan *redisConn, maxRedisIdleConns),
+	}
+	if u.Scheme == "rediss" {
+		r.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	if u.Port() == "" {
+		r.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		r.username = u.User.Username()
+		r.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if r.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("store: invalid redis db: %s", db)
+		}
+	}
+	return r, nil
+}
+
+func (r *Redis) Get(key string) ([]byte, error) {
+	v, err := r.Do("GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	data, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: invalid reply: %v", v)
+	}
+	return data, nil
+}
+
+func (r *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := r.Do(args...)
+	return err
+}
+
+func (r *Redis) Delete(key string) error {
+	_, err := r.Do("DEL", key)
+	return err
+}
+
+func (r *Redis) TryLock(key string, ttl time.Duration) (func(), bool, error) {
+	token := lockToken()
+	v, err := r.Do("SET", key, token, "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil || v == nil /* held by others */ {
+		return nil, false, err
+	}
+	return func() {
+		_, _ = r.Do("EVAL", unlockScript, "1", key, token) // ignore error.
+	}, true, nil
+}
+
+// reserveScript reserves the next slot by the clock of Redis, so that
+// clocks of replicas never matter, and returns the wait in milliseconds.
+const reserveScript = `if redis.replicate_commands then redis.replicate_commands() end
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local at = tonumber(redis.call("GET", KEYS[1])) or now
+if at < now then at = now end
+local interval = tonumber(ARGV[1])
+redis.call("SET", KEYS[1], at + interval, "PX", at + interval - now + 1000)
+return at - now`
+
+func (r *Redis) Reserve(key string, interval time.Duration) (time.Duration, error) {
+	v, err := r.Do("EVAL", reserveScript, "1", key, strconv.FormatInt(interval.Milliseconds(), 10))
+	if err != nil {
+		return 0, err
+	}
+	ms, ok := v.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: invalid reply: %v", v)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// Close closes all idle connections.
+func (r *Redis) Close() error {
+	for {
+		select {
+		case c := <-r.idle:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// RedisError is an error reply of Redis.
+type RedisError string
+
+func (e RedisError) Error() string { return "redis: " + string(e) }
+
+// Do sends the command and returns its reply, i.e. nil, string for
+// simple strings, int64, []byte for bulk strings, or []any.
+func (r *Redis) Do(args ...string) (any, error) {
+	c, err := r.conn()
+	if err != nil {
+		return nil, err
+	}
+	_ = c.SetDeadline(time.Now().Add(r.timeout))
+	v, err := c.do(args...)
+	var re RedisError
+	if err != nil && !errors.As(err, &re) {
+		// the connection is broken.
+		c.Close()
+		return nil, err
+	}
+	r.put(c)
+	return v, err
+}
+
+func (r *Redis) conn() (*redisConn, error) {
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+	var nc net.Conn
+	var err error
+	if r.tls != nil {
+		nc, err = tls.DialWithDialer(&net.Dialer{Timeout: r.timeout}, "tcp", r.addr, r.tls)
+	} else {
+		nc, err = net.DialTimeout("tcp", r.addr, r.timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := &redisConn{Conn: nc, br: bufio.NewReader(nc)}
+	_ = c.SetDeadline(time.Now().Add(r.timeout))
+	if r.password != "" {
+		auth := []string{"AUTH", r.password}
+		if r.username != "" {
+			auth = []string{"AUTH", r.username, r.password}
+		}
+		if _, err = c.do(auth...); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, err = c.do("SELECT", strconv.Itoa(r.db)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (r *Redis) put(c *redisConn) {
+	select {
+	case r.idle <- c:
+	default:
+		c.Close()
+	}
+}
+
+type redisConn struct {
+	net.Conn
+	br *bufio.Reader
+}
+
+func (c *redisConn) do(args ...string) (any, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.Conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(c.br)
+}
+
+func readReply(br *bufio.Reader) (any, error) {
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, RedisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err // null bulk string.
+		}
+		data := make([]byte, n+2)
+		if _, err = io.ReadFull(br, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err // null array.
+		}
+		values := make([]any, n)
+		for i := range values {
+			if values[i], err = readReply(br); err != nil {
+				var re RedisError
+				if !errors.As(err, &re) {
+					return nil, err
+				}
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: invalid reply: %q", line)
+	}
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves GET, SET with NX and PX, DEL, and the unlock
+// and reserve scripts of the RESP protocol.
+type fakeRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+	expiry map[string]time.Time
+}
+
+func (f *fakeRedis) serve(c net.Conn) {
+	defer c.Close()
+	br := bufio.NewReader(c)
+	for {
+		args, err := f.readCommand(br)
+		if err != nil {
+			return
+		}
+		fmt.Fprint(c, f.exec(args))
+	}
+}
+
+func (f *fakeRedis) readCommand(br *bufio.Reader) ([]string, error) {
+	v, err := readReply(br)
+	if err != nil {
+		return nil, err
+	}
+	var args []string
+	for _, arg := range v.([]any) {
+		args = append(args, string(arg.([]byte)))
+	}
+	return args, nil
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		v, ok := f.values[args[1]]
+		if e, has := f.expiry[args[1]]; !ok || has && time.Now().After(e) {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case "SET":
+		if len(args) > 3 && strings.EqualFold(args[3], "NX") {
+			if _, ok := f.values[args[1]]; ok {
+				return "$-1\r\n"
+			}
+			args = append(args[:3], args[4:]...)
+		}
+		f.values[args[1]] = args[2]
+		delete(f.expiry, args[1])
+		if len(args) == 5 && strings.EqualFold(args[3], "PX") {
+			ms, _ := strconv.Atoi(args[4])
+			f.expiry[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "EVAL":
+		if args[1] == reserveScript {
+			now := time.Now().UnixMilli()
+			at, _ := strconv.ParseInt(f.values[args[3]], 10, 64)
+			at = max(at, now)
+			interval, _ := strconv.ParseInt(args[4], 10, 64)
+			f.values[args[3]] = strconv.FormatInt(at+interval, 10)
+			return fmt.Sprintf(":%d\r\n", at-now)
+		}
+		if f.values[args[3]] != args[4] {
+			return ":0\r\n"
+		}
+		delete(f.values, args[3])
+		return ":1\r\n"
+	case "DEL":
+		_, ok := f.values[args[1]]
+		delete(f.values, args[1])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+// serveFakeRedis serves a fake Redis on the listener until closed.
+func serveFakeRedis(ln net.Listener) {
+	f := &fakeRedis{values: make(map[string]string), expiry: make(map[string]time.Time)}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(c)
+		}
+	}()
+}
+
+func TestRedis(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	serveFakeRedis(ln)
+
+	s, err := NewRedis("redis://" + ln.Addr().String())
+	require.NoError(t, err)
+	defer s.Close()
+	testStore(t, s)
+	testLocker(t, s)
+	testReserver(t, s)
+
+	_, err = s.Do("UNKNOWN")
+	require.ErrorAs(t, err, new(RedisError))
+}
+
+func TestRedisTLS(t *testing.T) {
+	// borrow the self-signed certificate of the test server.
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", srv.TLS.Clone())
+	require.NoError(t, err)
+	defer ln.Close()
+	serveFakeRedis(ln)
+
+	s, err := NewRedis("rediss://" + ln.Addr().String())
+	require.NoError(t, err)
+	defer s.Close()
+	// untrusted certificates are rejected.
+	_, err = s.Get("key")
+	require.Error(t, err)
+
+	s.tls.RootCAs = x509.NewCertPool()
+	s.tls.RootCAs.AddCert(srv.Certificate())
+	testStore(t, s)
+}
+
+func TestNewRedis(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://localhost",
+		"redis:///0",
+		"redis://localhost/x",
+		"redis://localhost?dial_timeout=1s",
+		"redis-sentinel://localhost",
+	} {
+		_, err := NewRedis(rawURL)
+		require.Error(t, err, rawURL)
+	}
+}
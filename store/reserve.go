@@ -0,0 +1,35 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// Reserver is implemented by stores that can space out events, e.g.
+// requests of replicas to the same provider, by a minimum interval.
+type Reserver interface {
+	// Reserve reserves the next slot of the key, slots are spaced by
+	// interval, and returns how long to wait for it.
+	Reserve(key string, interval time.Duration) (time.Duration, error)
+}
+
+// reservations are the next free slots of keys, in-process.
+type reservations struct {
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func (r *reservations) reserve(key string, interval time.Duration) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.next == nil {
+		r.next = make(map[string]time.Time)
+	}
+	now := time.Now()
+	at := r.next[key]
+	if at.Before(now) {
+		at = now
+	}
+	r.next[key] = at.Add(interval)
+	return at.Sub(now)
+}
@@ -0,0 +1,69 @@
+// Package store provides key-value stores with expiration, so that
+// caches, e.g. of HTTP responses, translations and images, can share
+// one backend chosen by the deployment.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned by Get if the key is missing or expired.
+var ErrNotFound = errors.New("store: not found")
+
+// Store is a key-value store with expiration.
+type Store interface {
+	// Get returns the value of the key, or ErrNotFound.
+	Get(key string) ([]byte, error)
+	// Set sets the value of the key, it never expires if ttl <= 0.
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete deletes the key, missing keys are ignored.
+	Delete(key string) error
+}
+
+// Sweeper is implemented by stores whose expired entries are kept until
+// they are read, e.g. on disk, so that they have to be swept regularly.
+type Sweeper interface {
+	// DeleteExpired deletes all expired entries.
+	DeleteExpired() error
+}
+
+// Open opens the store of the DSN:
+//
+//	memory            in-memory store of the process
+//	db                the given database, i.e. GORM store
+//	redis://...       Redis store, shared by replicas, rediss:// for TLS
+//	file:///path      on-disk store in the directory
+//	bolt:///path      embedded BoltDB store in the file
+func Open(dsn string, db *gorm.DB) (Store, error) {
+	switch {
+	case dsn == "" || dsn == "memory":
+		return NewMemory(DefaultMemoryCapacity), nil
+	case dsn == "db":
+		if db == nil {
+			return nil, errors.New("store: no database")
+		}
+		return NewGORM(db)
+	case strings.HasPrefix(dsn, "redis://"), strings.HasPrefix(dsn, "rediss://"):
+		return NewRedis(dsn)
+	case strings.HasPrefix(dsn, "file://"):
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return NewFile(u.Host + u.Path)
+	case strings.HasPrefix(dsn, "bolt://"):
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return NewBolt(u.Host + u.Path)
+	default:
+		return nil, fmt.Errorf("store: unsupported dsn: %s", dsn)
+	}
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+
+	"github.com/metatube-community/metatube-sdk-go/database"
+)
+
+func testStore(t *testing.T, s Store) {
+	_, err := s.Get("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, s.Set("key", []byte("value"), 0))
+	v, err := s.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(v))
+
+	require.NoError(t, s.Set("key", []byte("updated"), time.Hour))
+	v, err = s.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(v))
+
+	require.NoError(t, s.Delete("key"))
+	_, err = s.Get("key")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, s.Delete("key"))
+
+	require.NoError(t, s.Set("expiring", []byte("value"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	_, err = s.Get("expiring")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func testLocker(t *testing.T, l Locker) {
+	unlock, ok, err := l.TryLock("lock", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, ok, err = l.TryLock("lock", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	unlock()
+	unlock, ok, err = l.TryLock("lock", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// waits until the holder is done.
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(2 * LockPollInterval)
+		close(done)
+	}()
+	assert.Nil(t, Acquire(context.Background(), l, "lock", time.Minute, func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}))
+	unlock()
+	unlock = Acquire(context.Background(), l, "lock", time.Minute, func() bool { return false })
+	require.NotNil(t, unlock)
+
+	// stops waiting once the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*LockPollInterval)
+	defer cancel()
+	var polls int
+	start := time.Now()
+	assert.Nil(t, Acquire(ctx, l, "lock", time.Minute, func() bool {
+		polls++
+		return false
+	}))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.LessOrEqual(t, polls, 2, "polls back off")
+	unlock()
+}
+
+func testReserver(t *testing.T, r Reserver) {
+	var waits []time.Duration
+	for i := 0; i < 3; i++ {
+		d, err := r.Reserve("slots", time.Second)
+		require.NoError(t, err)
+		waits = append(waits, d)
+	}
+	assert.Zero(t, waits[0])
+	assert.InDelta(t, time.Second, waits[1], float64(100*time.Millisecond))
+	assert.InDelta(t, 2*time.Second, waits[2], float64(100*time.Millisecond))
+}
+
+func TestMemory(t *testing.T) {
+	m := NewMemory(DefaultMemoryCapacity)
+	testStore(t, m)
+	testLocker(t, m)
+	testReserver(t, m)
+}
+
+func testSweeper(t *testing.T, s interface {
+	Store
+	Sweeper
+}) {
+	require.NoError(t, s.Set("kept", []byte("value"), time.Hour))
+	require.NoError(t, s.Set("forever", []byte("value"), 0))
+	require.NoError(t, s.Set("expired", []byte("value"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, s.DeleteExpired())
+	for _, key := range []string{"kept", "forever"} {
+		_, err := s.Get(key)
+		assert.NoError(t, err, key)
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFile(dir)
+	require.NoError(t, err)
+	testStore(t, s)
+	testSweeper(t, s)
+
+	// expired entries are deleted without being read.
+	require.NoError(t, s.Set("expired", []byte("value"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, s.DeleteExpired())
+	_, err = os.Stat(s.path("expired"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBolt(t *testing.T) {
+	s, err := NewBolt(filepath.Join(t.TempDir(), "cache", "cache.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	testStore(t, s)
+	testSweeper(t, s)
+
+	require.NoError(t, s.Set("expired", []byte("value"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, s.DeleteExpired())
+	require.NoError(t, s.db.View(func(tx *bbolt.Tx) error {
+		assert.Nil(t, tx.Bucket(boltBucket).Get([]byte("expired")))
+		return nil
+	}))
+}
+
+func TestGORM(t *testing.T) {
+	db, err := database.Open(&database.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	s, err := NewGORM(db)
+	require.NoError(t, err)
+	testStore(t, s)
+	testSweeper(t, s)
+}
+
+func TestOpen(t *testing.T) {
+	for _, dsn := range []string{"", "memory", "redis://:secret@localhost:6380/2", "file://" + t.TempDir(), "bolt://" + t.TempDir() + "/cache.db"} {
+		_, err := Open(dsn, nil)
+		assert.NoError(t, err, dsn)
+	}
+	for _, dsn := range []string{"db", "mysql://localhost", "redis://localhost/x"} {
+		_, err := Open(dsn, nil)
+		assert.Error(t, err, dsn)
+	}
+}
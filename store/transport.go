@@ -0,0 +1,118 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"time"
+)
+
+// DefaultMaxResponseSize is the default max size of cached responses.
+const DefaultMaxResponseSize = 8 << 20
+
+// Transport caches successful responses of GET requests in the store,
+// so that the same page is never fetched twice within the ttl.
+type Transport struct {
+	Base  http.RoundTripper
+	Store Store
+	TTL   time.Duration
+	// MaxSize is the max size of cached response bodies, larger ones
+	// are passed through, DefaultMaxResponseSize if zero.
+	MaxSize int64
+}
+
+// NewTransport returns a caching Transport of the base transport.
+func NewTransport(s Store, ttl time.Duration, base http.RoundTripper) *Transport {
+	return &Transport{Base: base, Store: s, TTL: ttl}
+}
+
+type noCacheKey struct{}
+
+// WithoutCache returns a copy of ctx, whose requests skip the cached
+// responses of Transport, e.g. to force refreshes, while fresh responses
+// still replace the cached ones.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+func cacheDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noCacheKey{}).(bool)
+	return disabled
+}
+
+// keyHeaders are the request headers that responses depend on, e.g.
+// age-check cookies, so that they are never served to other requests.
+var keyHeaders = []string{"Accept-Language", "Authorization", "Cookie"}
+
+// HTTPKey returns the store key of the request, i.e. the URL and the
+// hash of the headers that responses depend on, if any.
+func HTTPKey(req *http.Request) string {
+	key := "http:" + req.URL.String()
+	h := sha256.New()
+	var found bool
+	for _, name := range keyHeaders {
+		for _, value := range req.Header.Values(name) {
+			found = true
+			_, _ = fmt.Fprintf(h, "%s: %s\n", name, value)
+		}
+	}
+	if found {
+		key += "#" + hex.EncodeToString(h.Sum(nil))
+	}
+	return key
+}
+
+func (t *Transport) maxSize() int64 {
+	if t.MaxSize > 0 {
+		return t.MaxSize
+	}
+	return DefaultMaxResponseSize
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.Base.RoundTrip(req)
+	}
+	key := HTTPKey(req)
+	if cacheDisabled(req.Context()) {
+		// always fetched.
+	} else if data, err := t.Store.Get(key); err == nil {
+		if resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req); err == nil {
+			return resp, nil
+		}
+		// ignore broken entries.
+	}
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK || resp.ContentLength > t.maxSize() {
+		return resp, err
+	}
+	// bodies of unknown length are read up to the max size, larger ones
+	// are passed through as they are, without buffering all of them.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.maxSize()+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > t.maxSize() {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	data, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	_ = t.Store.Set(key, data, t.TTL) // ignore error.
+	return resp, nil
+}
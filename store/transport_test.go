@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-Page", r.URL.Path)
+		_, _ = io.WriteString(w, "page "+r.URL.Path)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(NewMemory(0), time.Hour, http.DefaultTransport)}
+	get := func(path string) (int, string, string) {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, resp.Header.Get("X-Page"), string(body)
+	}
+	for i := 0; i < 2; i++ {
+		code, page, body := get("/a")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "/a", page)
+		assert.Equal(t, "page /a", body)
+	}
+	assert.Equal(t, 1, hits)
+
+	for i := 0; i < 2; i++ {
+		code, _, _ := get("/missing")
+		assert.Equal(t, http.StatusNotFound, code)
+	}
+	assert.Equal(t, 3, hits)
+}
+
+func TestTransportWithoutCache(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = fmt.Fprintf(w, "page %d", hits)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(NewMemory(0), time.Hour, http.DefaultTransport)}
+	get := func(ctx context.Context) string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	assert.Equal(t, "page 1", get(context.Background()))
+	assert.Equal(t, "page 1", get(context.Background()))
+	// forced refreshes skip the cache, but update it.
+	assert.Equal(t, "page 2", get(WithoutCache(context.Background())))
+	assert.Equal(t, "page 2", get(context.Background()))
+	assert.Equal(t, 2, hits)
+}
+
+func TestTransportKeyHeaders(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if _, err := r.Cookie("age_check_done"); err == nil {
+			_, _ = io.WriteString(w, "adult")
+			return
+		}
+		_, _ = io.WriteString(w, "age check")
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(NewMemory(0), time.Hour, http.DefaultTransport)}
+	get := func(cookie string) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		if cookie != "" {
+			req.Header.Set("Cookie", cookie)
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	// responses of other cookies are never served.
+	assert.Equal(t, "age check", get(""))
+	assert.Equal(t, "adult", get("age_check_done=1"))
+	assert.Equal(t, "adult", get("age_check_done=1"))
+	assert.Equal(t, "age check", get(""))
+	assert.Equal(t, 2, hits)
+}
+
+func TestTransportMaxSize(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.(http.Flusher).Flush() // unknown length.
+		_, _ = io.WriteString(w, strings.Repeat("x", 16))
+	}))
+	defer srv.Close()
+
+	transport := NewTransport(NewMemory(0), time.Hour, http.DefaultTransport)
+	transport.MaxSize = 8
+	client := &http.Client{Transport: transport}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Len(t, body, 16)
+	}
+	// large responses are passed through without being cached.
+	assert.Equal(t, 2, hits)
+}
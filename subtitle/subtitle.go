@@ -0,0 +1,100 @@
+package subtitle
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrSourceNotFound = errors.New("subtitle: unknown source")
+
+// Subtitle is an available subtitle of a movie.
+type Subtitle struct {
+	Number   string `json:"number"`
+	Language string `json:"language"`
+	Name     string `json:"name,omitempty"`
+	URL      string `json:"url"`
+	Source   string `json:"source"`
+}
+
+// Source searches subtitles by movie number.
+type Source interface {
+	Name() string
+	Search(number string) ([]*Subtitle, error)
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]Source)
+)
+
+// Register registers a subtitle source to package.
+func Register(source Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[strings.ToLower(source.Name())] = source
+}
+
+// Sources returns names of all registered sources.
+func Sources() (names []string) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	for _, source := range sources {
+		names = append(names, source.Name())
+	}
+	sort.Strings(names)
+	return
+}
+
+// Lookup returns the registered source by name.
+func Lookup(name string) (Source, error) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	source, ok := sources[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrSourceNotFound
+	}
+	return source, nil
+}
+
+// Search searches the number from the named sources concurrently, all
+// registered sources are used if no names are given. Errors are only
+// returned if all sources failed.
+func Search(number string, names ...string) ([]*Subtitle, error) {
+	if len(names) == 0 {
+		names = Sources()
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []*Subtitle
+		errs    []error
+	)
+	for _, name := range names {
+		source, err := Lookup(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			subtitles, err := source.Search(number)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			results = append(results, subtitles...)
+		}()
+	}
+	wg.Wait()
+
+	if len(results) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return results, nil
+}
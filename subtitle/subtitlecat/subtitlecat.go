@@ -0,0 +1,125 @@
+package subtitlecat
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/antchfx/htmlquery"
+
+	"github.com/metatube-community/metatube-sdk-go/common/fetch"
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/subtitle"
+)
+
+const (
+	Name = "SubtitleCat"
+
+	baseURL   = "https://www.subtitlecat.com/"
+	searchURL = "https://www.subtitlecat.com/index.php?search=%s"
+)
+
+var _ subtitle.Source = (*SubtitleCat)(nil)
+
+type SubtitleCat struct {
+	fetcher *fetch.Fetcher
+}
+
+func New() *SubtitleCat {
+	return &SubtitleCat{
+		fetcher: fetch.Default(&fetch.Config{Referer: baseURL}),
+	}
+}
+
+func (sc *SubtitleCat) Name() string { return Name }
+
+func (sc *SubtitleCat) Search(num string) (results []*subtitle.Subtitle, err error) {
+	pages, err := sc.searchPages(num)
+	if err != nil {
+		return nil, err
+	}
+	for _, page := range pages {
+		subtitles, err := sc.parseSubtitlePage(num, page)
+		if err != nil {
+			continue // ignore bad pages.
+		}
+		results = append(results, subtitles...)
+	}
+	return
+}
+
+// searchPages returns the pages of subtitles that match the number.
+func (sc *SubtitleCat) searchPages(num string) (pages []string, err error) {
+	resp, err := sc.fetcher.Get(strings.Replace(searchURL, "%s", url.QueryEscape(num), 1))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	doc, err := htmlquery.Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range htmlquery.Find(doc, `//table[contains(@class,"sub-table")]//td/a[@href]`) {
+		if !matchNumber(num, htmlquery.InnerText(a)) {
+			continue
+		}
+		if u, err := url.Parse(baseURL); err == nil {
+			pages = append(pages, u.JoinPath(strings.TrimPrefix(htmlquery.SelectAttr(a, "href"), "/")).String())
+		}
+	}
+	return
+}
+
+// parseSubtitlePage returns all translated subtitles on the page.
+func (sc *SubtitleCat) parseSubtitlePage(num, page string) (results []*subtitle.Subtitle, err error) {
+	resp, err := sc.fetcher.Get(page)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	doc, err := htmlquery.Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range htmlquery.Find(doc, `//div[contains(@class,"sub-single")]//a[@href and contains(@href,".srt")]`) {
+		href := htmlquery.SelectAttr(a, "href")
+		u, err := url.Parse(page)
+		if err != nil {
+			continue
+		}
+		if u, err = u.Parse(href); err != nil {
+			continue
+		}
+		results = append(results, &subtitle.Subtitle{
+			Number:   num,
+			Language: parseLanguage(href),
+			Name:     path.Base(u.Path),
+			URL:      u.String(),
+			Source:   Name,
+		})
+	}
+	return
+}
+
+// parseLanguage parses language code from the srt filename,
+// e.g. ABP-123-zh-CN.srt -> zh-CN.
+func parseLanguage(href string) string {
+	if ss := regexp.MustCompile(`-([a-z]{2,3}(?:-[A-Za-z]{2,4})?)\.srt$`).FindStringSubmatch(href); len(ss) == 2 {
+		return ss[1]
+	}
+	return ""
+}
+
+func matchNumber(num, text string) bool {
+	normalize := func(s string) string {
+		return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToUpper(s))
+	}
+	return strings.HasPrefix(normalize(number.Trim(text)), normalize(num))
+}
+
+func init() {
+	subtitle.Register(New())
+}
@@ -0,0 +1,34 @@
+package subtitlecat
+
+import (
+	"testing"
+)
+
+func TestSubtitleCat_Search(t *testing.T) {
+	for _, unit := range []string{
+		"SSIS-001",
+		"ABP-123",
+	} {
+		results, err := New().Search(unit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, result := range results {
+			t.Logf("%+v", result)
+		}
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	for _, unit := range []struct {
+		href, want string
+	}{
+		{"/subs/1/SSIS-001-zh-CN.srt", "zh-CN"},
+		{"/subs/1/SSIS-001-en.srt", "en"},
+		{"/subs/1/SSIS-001.srt", ""},
+	} {
+		if got := parseLanguage(unit.href); got != unit.want {
+			t.Errorf("parseLanguage(%q) = %q, want %q", unit.href, got, unit.want)
+		}
+	}
+}
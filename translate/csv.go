@@ -0,0 +1,54 @@
+package translate
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+)
+
+var csvHeader = []string{"from", "to", "source", "target"}
+
+// WriteCSV writes the entries as CSV records of from, to, source and
+// target, with a header row.
+func WriteCSV(w io.Writer, entries []*Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := cw.Write([]string{entry.From, entry.To, entry.Source, entry.Target}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads entries from CSV records written by WriteCSV.
+func ReadCSV(r io.Reader) (entries []*Entry, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(header, csvHeader) {
+		return nil, fmt.Errorf("invalid CSV header: %v", header)
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &Entry{
+			From:   record[0],
+			To:     record[1],
+			Source: record[2],
+			Target: record[3],
+		})
+	}
+}
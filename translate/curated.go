@@ -0,0 +1,98 @@
+package translate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+)
+
+// CuratedTranslation is a human translation of a movie, empty fields
+// are left to machine translation.
+type CuratedTranslation struct {
+	Title   string `yaml:"title" json:"title,omitempty"`
+	Summary string `yaml:"summary" json:"summary,omitempty"`
+}
+
+// curatedFile is a community maintained translation file of a language.
+type curatedFile struct {
+	Lang   string                         `yaml:"lang"`
+	Movies map[string]*CuratedTranslation `yaml:"movies"`
+}
+
+// Curated is a set of curated translations keyed by language and then
+// normalized movie number, they take precedence over machine translation.
+type Curated struct {
+	movies map[string]map[string]*CuratedTranslation
+}
+
+// LoadCurated loads curated translation files in YAML format, e.g.
+//
+//	lang: zh-CN
+//	movies:
+//	  ABP-030:
+//	    title: ...
+//	    summary: ...
+//
+// Translations in later files take precedence.
+func LoadCurated(paths ...string) (*Curated, error) {
+	c := &Curated{movies: make(map[string]map[string]*CuratedTranslation)}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.parse(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *Curated) parse(data []byte) error {
+	f := &curatedFile{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(f); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if f.Lang == "" {
+		return errors.New("lang is required")
+	}
+	lang := strings.ToLower(f.Lang)
+	if c.movies[lang] == nil {
+		c.movies[lang] = make(map[string]*CuratedTranslation, len(f.Movies))
+	}
+	for num, t := range f.Movies {
+		if t != nil {
+			c.movies[lang][number.Normalize(num)] = t
+		}
+	}
+	return nil
+}
+
+// Lookup returns the curated translation of the movie number.
+func (c *Curated) Lookup(num, lang string) (*CuratedTranslation, bool) {
+	if c == nil {
+		return nil, false
+	}
+	t, ok := c.movies[strings.ToLower(lang)][number.Normalize(num)]
+	return t, ok
+}
+
+// Len returns the number of curated translations of all languages.
+func (c *Curated) Len() (n int) {
+	if c == nil {
+		return 0
+	}
+	for _, movies := range c.movies {
+		n += len(movies)
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package translate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCurated(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	a := write("a.yaml", `
+lang: zh-CN
+movies:
+  ABP-030: {title: A, summary: SA}
+  SSIS-001: {title: B}
+`)
+	b := write("b.yaml", `
+lang: zh-cn
+movies:
+  abp00030: {title: C}
+`)
+
+	c, err := LoadCurated(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Len())
+
+	got, ok := c.Lookup("ABP-030", "ZH-CN")
+	require.True(t, ok)
+	assert.Equal(t, &CuratedTranslation{Title: "C"}, got)
+	_, ok = c.Lookup("ABP-030", "en")
+	assert.False(t, ok)
+
+	_, err = LoadCurated(write("c.yaml", "movies: {}"))
+	assert.Error(t, err)
+	_, err = LoadCurated(write("d.yaml", "lang: en\nunknown: 1"))
+	assert.Error(t, err)
+
+	var nilCurated *Curated
+	_, ok = nilCurated.Lookup("ABP-030", "zh-CN")
+	assert.False(t, ok)
+}
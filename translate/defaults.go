@@ -0,0 +1,47 @@
+package translate
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	defaultsMu sync.RWMutex
+	defaults   = make(map[string]map[string]string)
+)
+
+// SetDefaults replaces the default parameters, e.g. API keys, of all
+// translators, keyed by translator name and then parameter name.
+func SetDefaults(params map[string]map[string]string) {
+	m := make(map[string]map[string]string, len(params))
+	for name, values := range params {
+		m[strings.ToLower(name)] = values
+	}
+	defaultsMu.Lock()
+	defaults = m
+	defaultsMu.Unlock()
+}
+
+// Defaults returns the default parameters of the named translator.
+func Defaults(name string) map[string]string {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return defaults[strings.ToLower(name)]
+}
+
+// WithDefaults returns values merged with the default parameters
+// of the named translator, values given explicitly take precedence.
+func WithDefaults(name string, values map[string][]string) map[string][]string {
+	params := Defaults(name)
+	if len(params) == 0 {
+		return values
+	}
+	merged := make(map[string][]string, len(values)+len(params))
+	for k, v := range params {
+		merged[k] = []string{v}
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	return merged
+}
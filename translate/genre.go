@@ -0,0 +1,44 @@
+package translate
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+	"sync"
+)
+
+//go:embed genres.csv
+var genresCSV string
+
+// genreDictionary maps Japanese genres of DMM/MGS to their Chinese and
+// English names, so that tags are named consistently.
+var genreDictionary = sync.OnceValue(func() map[string][2]string {
+	records, err := csv.NewReader(strings.NewReader(genresCSV)).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+	dict := make(map[string][2]string, len(records))
+	for _, record := range records[1:] /* skip header */ {
+		dict[record[0]] = [2]string{record[1], record[2]}
+	}
+	return dict
+})
+
+// TranslateGenre translates the Japanese genre with the embedded
+// dictionary, lang is Chinese (simplified) or English, e.g. zh-CN or en.
+func TranslateGenre(genre, lang string) (string, bool) {
+	var i int
+	switch base, region, _ := strings.Cut(strings.ToLower(lang), "-"); {
+	case base == "zh" && (region == "" || region == "cn" || region == "sg" || region == "hans"):
+		i = 0
+	case base == "en":
+		i = 1
+	default:
+		return "", false
+	}
+	names, ok := genreDictionary()[strings.TrimSpace(genre)]
+	if !ok {
+		return "", false
+	}
+	return names[i], true
+}
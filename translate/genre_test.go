@@ -0,0 +1,26 @@
+package translate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateGenre(t *testing.T) {
+	for _, unit := range []struct {
+		genre, lang, want string
+		ok                bool
+	}{
+		{"巨乳", "en", "Big Tits", true},
+		{"巨乳", "en-US", "Big Tits", true},
+		{"人妻・主婦", "zh-CN", "人妻", true},
+		{" 中出し ", "zh", "中出", true},
+		{"巨乳", "zh-TW", "", false},
+		{"巨乳", "ko", "", false},
+		{"unknown", "en", "", false},
+	} {
+		got, ok := TranslateGenre(unit.genre, unit.lang)
+		assert.Equal(t, unit.ok, ok, unit.genre, unit.lang)
+		assert.Equal(t, unit.want, got, unit.genre, unit.lang)
+	}
+}
@@ -0,0 +1,278 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+// Translation memory file formats.
+const (
+	FormatTMX = "tmx"
+	FormatCSV = "csv"
+)
+
+// DefaultMemoryCapacity is the max number of translations remembered
+// locally, the least recently used ones are evicted beyond it.
+const DefaultMemoryCapacity = 100000
+
+// DefaultMemory is the translation memory shared by the server.
+var DefaultMemory = NewMemory()
+
+// Entry is a translation of the source text.
+type Entry struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type memoryKey struct{ from, to, source string }
+
+// Memory is a translation memory, it remembers translations so that
+// the same text is never machine translated twice.
+type Memory struct {
+	entries *ttlcache.Cache[memoryKey, string]
+
+	mu sync.RWMutex
+	// shared store of translations, optional.
+	store store.Store
+	ttl   time.Duration
+}
+
+// NewMemory returns a translation memory of DefaultMemoryCapacity.
+func NewMemory() *Memory {
+	return newMemory(DefaultMemoryCapacity)
+}
+
+func newMemory(capacity uint64) *Memory {
+	return &Memory{
+		entries: ttlcache.New[memoryKey, string](
+			ttlcache.WithCapacity[memoryKey, string](capacity),
+			ttlcache.WithDisableTouchOnHit[memoryKey, string]()),
+	}
+}
+
+func (m *Memory) key(from, to, source string) memoryKey {
+	return memoryKey{strings.ToLower(from), strings.ToLower(to), source}
+}
+
+// SetStore shares translations through the store, e.g. among replicas,
+// they expire after ttl if ttl > 0, both in the store and locally. Nil
+// store disables sharing.
+func (m *Memory) SetStore(s store.Store, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store, m.ttl = s, ttl
+}
+
+func (k memoryKey) storeKey() string {
+	return "translate:" + k.from + ":" + k.to + ":" + k.source
+}
+
+// lockTTL is the max time a translation lock is held.
+const lockTTL = time.Minute
+
+// lock acquires the lock of the text if the store supports locking, so
+// that replicas sharing the store never translate the same text at once.
+// It returns nil if the text is translated by the holder meanwhile.
+func (m *Memory) lock(text, from, to string) (unlock func()) {
+	m.mu.RLock()
+	locker, ok := m.store.(store.Locker)
+	m.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	key := m.key(from, to, text)
+	return store.Acquire(context.Background(), locker, "lock:"+key.storeKey(), lockTTL, func() bool {
+		_, ok := m.Get(text, from, to)
+		return ok
+	})
+}
+
+// Get returns the remembered translation of the text, the store is
+// looked up if it's not remembered locally.
+func (m *Memory) Get(text, from, to string) (string, bool) {
+	key := m.key(from, to, text)
+	if item := m.entries.Get(key); item != nil && !item.IsExpired() {
+		return item.Value(), true
+	}
+	m.mu.RLock()
+	s, ttl := m.store, m.ttl
+	m.mu.RUnlock()
+	if s == nil {
+		return "", false
+	}
+	data, err := s.Get(key.storeKey())
+	if err != nil {
+		return "", false
+	}
+	m.entries.Set(key, string(data), entryTTL(ttl))
+	return string(data), true
+}
+
+// entryTTL returns the ttl of local entries.
+func entryTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return ttlcache.NoTTL
+	}
+	return ttl
+}
+
+// Set remembers the translation of the text, and saves it to the
+// store if any.
+func (m *Memory) Set(text, from, to, target string) {
+	key := m.key(from, to, text)
+	m.mu.RLock()
+	s, ttl := m.store, m.ttl
+	m.mu.RUnlock()
+	m.entries.Set(key, target, entryTTL(ttl))
+	if s != nil {
+		_ = s.Set(key.storeKey(), []byte(target), ttl) // ignore error.
+	}
+}
+
+// Len returns the number of remembered translations.
+func (m *Memory) Len() int {
+	m.entries.DeleteExpired()
+	return m.entries.Len()
+}
+
+// Entries returns all remembered translations in a stable order.
+func (m *Memory) Entries() []*Entry {
+	m.entries.DeleteExpired()
+	entries := make([]*Entry, 0, m.entries.Len())
+	m.entries.Range(func(item *ttlcache.Item[memoryKey, string]) bool {
+		k := item.Key()
+		entries = append(entries, &Entry{From: k.from, To: k.to, Source: k.source, Target: item.Value()})
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Source < b.Source
+	})
+	return entries
+}
+
+// Export writes all translations in the format, tmx or csv.
+func (m *Memory) Export(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case FormatTMX:
+		return WriteTMX(w, m.Entries())
+	case FormatCSV:
+		return WriteCSV(w, m.Entries())
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// Import reads translations in the format, tmx or csv, and returns the
+// number of them. Existing translations of the same text are replaced.
+func (m *Memory) Import(r io.Reader, format string) (int, error) {
+	var (
+		entries []*Entry
+		err     error
+	)
+	switch strings.ToLower(format) {
+	case FormatTMX:
+		entries, err = ReadTMX(r)
+	case FormatCSV:
+		entries, err = ReadCSV(r)
+	default:
+		return 0, fmt.Errorf("unsupported format: %s", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		m.Set(entry.Source, entry.From, entry.To, entry.Target)
+	}
+	return len(entries), nil
+}
+
+// FormatOf returns the format of the file by its extension.
+func FormatOf(path string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+}
+
+// Load imports translations from the file, a missing file is ignored.
+func (m *Memory) Load(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = m.Import(f, FormatOf(path))
+	return err
+}
+
+// Save exports all translations to the file atomically.
+func (m *Memory) Save(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err = m.Export(tmp, FormatOf(path)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var _ Translator = (*cachedTranslator)(nil)
+
+type cachedTranslator struct {
+	Translator
+	memory *Memory
+}
+
+// Cached returns a Translator that looks up the memory before the
+// translator, and remembers new translations.
+func Cached(t Translator, m *Memory) Translator {
+	if _, ok := t.(*errorTranslator); ok || m == nil {
+		return t
+	}
+	return &cachedTranslator{Translator: t, memory: m}
+}
+
+func (t *cachedTranslator) Translate(text, from, to string) (string, error) {
+	if target, ok := t.memory.Get(text, from, to); ok {
+		return target, nil
+	}
+	if unlock := t.memory.lock(text, from, to); unlock != nil {
+		defer unlock()
+	}
+	// translated by others while waiting for the lock.
+	if target, ok := t.memory.Get(text, from, to); ok {
+		return target, nil
+	}
+	target, err := t.Translator.Translate(text, from, to)
+	if err != nil {
+		return "", err
+	}
+	t.memory.Set(text, from, to, target)
+	return target, nil
+}
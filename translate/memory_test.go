@@ -0,0 +1,115 @@
+package translate
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/store"
+)
+
+type countingTranslator struct{ n int }
+
+func (t *countingTranslator) Translate(text, _, _ string) (string, error) {
+	t.n++
+	return strings.ToUpper(text), nil
+}
+
+func TestMemory_Cached(t *testing.T) {
+	m := NewMemory()
+	ct := &countingTranslator{}
+	tr := Cached(ct, m)
+	for i := 0; i < 2; i++ {
+		text, err := tr.Translate("abc", "ja", "zh-CN")
+		require.NoError(t, err)
+		assert.Equal(t, "ABC", text)
+	}
+	assert.Equal(t, 1, ct.n)
+	assert.Equal(t, ErrTranslator, Cached(ErrTranslator, m))
+}
+
+func TestMemory_ExportImport(t *testing.T) {
+	m := NewMemory()
+	m.Set("素人", "ja", "zh-CN", "素人")
+	m.Set("Tom & \"Jerry\", <3>", "en", "ja", "トムとジェリー")
+
+	for _, format := range []string{FormatTMX, FormatCSV} {
+		buf := &bytes.Buffer{}
+		require.NoError(t, m.Export(buf, format))
+
+		imported := NewMemory()
+		n, err := imported.Import(buf, format)
+		require.NoError(t, err, format)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, m.Entries(), imported.Entries(), format)
+	}
+
+	_, err := NewMemory().Import(strings.NewReader("a,b\n"), FormatCSV)
+	assert.Error(t, err)
+	assert.Error(t, m.Export(&bytes.Buffer{}, "xlsx"))
+}
+
+func TestMemory_LoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.tmx")
+	require.NoError(t, NewMemory().Load(path))
+
+	m := NewMemory()
+	m.Set("abc", "en", "ja", "ABC")
+	require.NoError(t, m.Save(path))
+
+	loaded := NewMemory()
+	require.NoError(t, loaded.Load(path))
+	text, ok := loaded.Get("abc", "EN", "ja")
+	assert.True(t, ok)
+	assert.Equal(t, "ABC", text)
+}
+
+func TestMemory_SetStore(t *testing.T) {
+	shared := store.NewMemory(0)
+	a, b := NewMemory(), NewMemory()
+	a.SetStore(shared, 0)
+	b.SetStore(shared, 0)
+
+	tr := &countingTranslator{}
+	_, err := Cached(tr, a).Translate("hello", "en", "ja")
+	require.NoError(t, err)
+	target, err := Cached(tr, b).Translate("hello", "EN", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO", target)
+	assert.Equal(t, 1, tr.n)
+	assert.Equal(t, 1, b.Len())
+}
+
+func TestMemory_Capacity(t *testing.T) {
+	m := newMemory(2)
+	m.Set("a", "en", "ja", "A")
+	m.Set("b", "en", "ja", "B")
+	_, ok := m.Get("a", "en", "ja") // recently used.
+	assert.True(t, ok)
+	m.Set("c", "en", "ja", "C")
+
+	assert.Equal(t, 2, m.Len())
+	_, ok = m.Get("b", "en", "ja")
+	assert.False(t, ok)
+	_, ok = m.Get("a", "en", "ja")
+	assert.True(t, ok)
+}
+
+func TestMemory_TTL(t *testing.T) {
+	m := NewMemory()
+	m.SetStore(store.NewMemory(0), 50*time.Millisecond)
+	m.Set("a", "en", "ja", "A")
+	_, ok := m.Get("a", "en", "ja")
+	assert.True(t, ok)
+
+	// local entries expire along with the store.
+	time.Sleep(100 * time.Millisecond)
+	_, ok = m.Get("a", "en", "ja")
+	assert.False(t, ok)
+	assert.Zero(t, m.Len())
+}
@@ -0,0 +1,96 @@
+package translate
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// tmx is a Translation Memory eXchange (TMX 1.4) document.
+type tmx struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool string `xml:"creationtool,attr"`
+	SegType      string `xml:"segtype,attr"`
+	AdminLang    string `xml:"adminlang,attr"`
+	SrcLang      string `xml:"srclang,attr"`
+	DataType     string `xml:"datatype,attr"`
+}
+
+type tmxUnit struct {
+	SrcLang  string       `xml:"srclang,attr,omitempty"`
+	Variants []tmxVariant `xml:"tuv"`
+}
+
+type tmxVariant struct {
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Segment string `xml:"seg"`
+}
+
+// WriteTMX writes the entries as a TMX document.
+func WriteTMX(w io.Writer, entries []*Entry) error {
+	doc := &tmx{
+		Version: "1.4",
+		Header: tmxHeader{
+			CreationTool: "metatube",
+			SegType:      "block",
+			AdminLang:    "en",
+			SrcLang:      "*all*",
+			DataType:     "plaintext",
+		},
+		Units: make([]tmxUnit, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		doc.Units = append(doc.Units, tmxUnit{
+			SrcLang: entry.From,
+			Variants: []tmxVariant{
+				{Lang: entry.From, Segment: entry.Source},
+				{Lang: entry.To, Segment: entry.Target},
+			},
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+// ReadTMX reads entries from a TMX document, the source of each unit
+// is the variant of its srclang, or the first variant if not set, and
+// each of the other variants is a translation.
+func ReadTMX(r io.Reader) (entries []*Entry, err error) {
+	doc := &tmx{}
+	if err = xml.NewDecoder(r).Decode(doc); err != nil {
+		return nil, err
+	}
+	for _, unit := range doc.Units {
+		if len(unit.Variants) < 2 {
+			continue
+		}
+		src := 0
+		for i, v := range unit.Variants {
+			if unit.SrcLang != "" && v.Lang == unit.SrcLang {
+				src = i
+				break
+			}
+		}
+		for i, v := range unit.Variants {
+			if i == src {
+				continue
+			}
+			entries = append(entries, &Entry{
+				From:   unit.Variants[src].Lang,
+				To:     v.Lang,
+				Source: unit.Variants[src].Segment,
+				Target: v.Segment,
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// DefaultTimeout is the default timeout of each webhook delivery.
+const DefaultTimeout = 10 * time.Second
+
+type EventType string
+
+const (
+	MovieScraped              EventType = "movie.scraped"
+	MovieRefreshed            EventType = "movie.refreshed"
+	ActorScraped              EventType = "actor.scraped"
+	ActorRefreshed            EventType = "actor.refreshed"
+	ProviderHealthCheckFailed EventType = "provider.health_check_failed"
+	ProviderRecovered         EventType = "provider.recovered"
+	FollowNewRelease          EventType = "follow.new_release"
+)
+
+// Event is the JSON payload posted to webhook URLs.
+type Event struct {
+	Type     EventType `json:"type"`
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source,omitempty"`
+	Provider string    `json:"provider,omitempty"`
+	ID       string    `json:"id,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Data     any       `json:"data,omitempty"`
+}
+
+// Notifier delivers events to all configured webhook URLs.
+type Notifier struct {
+	urls   []string
+	client *http.Client
+	logger *slog.Logger
+	// in-flight deliveries.
+	wg sync.WaitGroup
+}
+
+func New(urls []string, timeout time.Duration) *Notifier {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = timeout
+	return &Notifier{
+		urls:   urls,
+		client: client,
+		logger: slog.Default().With(slog.String("component", "webhook")),
+	}
+}
+
+// Notify posts the event to all webhook URLs asynchronously,
+// delivery failures are logged but never returned.
+func (n *Notifier) Notify(event *Event) {
+	if n == nil || len(n.urls) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		n.logger.Error("marshal event", slog.Any("event", event.Type), slog.Any("error", err))
+		return
+	}
+	for _, url := range n.urls {
+		n.wg.Add(1)
+		go func(url string) {
+			defer n.wg.Done()
+			if err := n.post(url, body); err != nil {
+				n.logger.Warn("deliver event", slog.Any("event", event.Type), slog.String("url", url), slog.Any("error", err))
+			}
+		}(url)
+	}
+}
+
+// Wait blocks until all in-flight deliveries are done.
+func (n *Notifier) Wait() {
+	if n == nil {
+		return
+	}
+	n.wg.Wait()
+}
+
+func (n *Notifier) post(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
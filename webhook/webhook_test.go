@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	received := make(chan *Event, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		event := &Event{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(event))
+		received <- event
+	}))
+	defer srv.Close()
+
+	n := New([]string{srv.URL, srv.URL}, time.Second)
+	n.Notify(&Event{Type: MovieScraped, Provider: "FANZA", ID: "abc123"})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-received:
+			assert.Equal(t, MovieScraped, event.Type)
+			assert.Equal(t, "FANZA", event.Provider)
+			assert.Equal(t, "abc123", event.ID)
+			assert.False(t, event.Time.IsZero())
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "webhook not delivered")
+		}
+	}
+}
+
+func TestNotifier_Nil(t *testing.T) {
+	var n *Notifier
+	n.Notify(&Event{Type: MovieScraped}) // should not panic.
+	n.Wait()
+}
+
+func TestNotifier_Wait(t *testing.T) {
+	var delivered atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		delivered.Add(1)
+	}))
+	defer srv.Close()
+
+	n := New([]string{srv.URL}, time.Second)
+	n.Notify(&Event{Type: ActorScraped})
+	n.Wait()
+	assert.Equal(t, int32(1), delivered.Load())
+}